@@ -0,0 +1,614 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v5.29.3
+// source: cfguardian/v1/config_service.proto
+
+package cfguardianv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConfigEventType int32
+
+const (
+	ConfigEventType_CONFIG_EVENT_TYPE_UNSPECIFIED ConfigEventType = 0
+	ConfigEventType_CONFIG_EVENT_TYPE_CREATED     ConfigEventType = 1
+	ConfigEventType_CONFIG_EVENT_TYPE_UPDATED     ConfigEventType = 2
+	ConfigEventType_CONFIG_EVENT_TYPE_DELETED     ConfigEventType = 3
+	ConfigEventType_CONFIG_EVENT_TYPE_ROLLED_BACK ConfigEventType = 4
+	// Events after last_event_id are no longer retained; refetch configs
+	ConfigEventType_CONFIG_EVENT_TYPE_RESYNC ConfigEventType = 5
+)
+
+// Enum value maps for ConfigEventType.
+var (
+	ConfigEventType_name = map[int32]string{
+		0: "CONFIG_EVENT_TYPE_UNSPECIFIED",
+		1: "CONFIG_EVENT_TYPE_CREATED",
+		2: "CONFIG_EVENT_TYPE_UPDATED",
+		3: "CONFIG_EVENT_TYPE_DELETED",
+		4: "CONFIG_EVENT_TYPE_ROLLED_BACK",
+		5: "CONFIG_EVENT_TYPE_RESYNC",
+	}
+	ConfigEventType_value = map[string]int32{
+		"CONFIG_EVENT_TYPE_UNSPECIFIED": 0,
+		"CONFIG_EVENT_TYPE_CREATED":     1,
+		"CONFIG_EVENT_TYPE_UPDATED":     2,
+		"CONFIG_EVENT_TYPE_DELETED":     3,
+		"CONFIG_EVENT_TYPE_ROLLED_BACK": 4,
+		"CONFIG_EVENT_TYPE_RESYNC":      5,
+	}
+)
+
+func (x ConfigEventType) Enum() *ConfigEventType {
+	p := new(ConfigEventType)
+	*p = x
+	return p
+}
+
+func (x ConfigEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_cfguardian_v1_config_service_proto_enumTypes[0].Descriptor()
+}
+
+func (ConfigEventType) Type() protoreflect.EnumType {
+	return &file_cfguardian_v1_config_service_proto_enumTypes[0]
+}
+
+func (x ConfigEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigEventType.Descriptor instead.
+func (ConfigEventType) EnumDescriptor() ([]byte, []int) {
+	return file_cfguardian_v1_config_service_proto_rawDescGZIP(), []int{0}
+}
+
+// Config is a config entry as seen by clients
+type Config struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Key     string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Version int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// JSON-encoded config content
+	Content       []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_cfguardian_v1_config_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Config) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Config) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Config) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type GetConfigRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required when authenticating with a JWT
+	ProjectId     string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_cfguardian_v1_config_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetConfigRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetConfigRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *Config                `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_cfguardian_v1_config_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetConfigResponse) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type BatchGetConfigsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required when authenticating with a JWT
+	ProjectId     string   `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Keys          []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetConfigsRequest) Reset() {
+	*x = BatchGetConfigsRequest{}
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetConfigsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetConfigsRequest) ProtoMessage() {}
+
+func (x *BatchGetConfigsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetConfigsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetConfigsRequest) Descriptor() ([]byte, []int) {
+	return file_cfguardian_v1_config_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetConfigsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *BatchGetConfigsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type BatchGetConfigsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Configs []*Config              `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"`
+	// Requested keys that do not exist
+	MissingKeys   []string `protobuf:"bytes,2,rep,name=missing_keys,json=missingKeys,proto3" json:"missing_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetConfigsResponse) Reset() {
+	*x = BatchGetConfigsResponse{}
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetConfigsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetConfigsResponse) ProtoMessage() {}
+
+func (x *BatchGetConfigsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetConfigsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetConfigsResponse) Descriptor() ([]byte, []int) {
+	return file_cfguardian_v1_config_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetConfigsResponse) GetConfigs() []*Config {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+func (x *BatchGetConfigsResponse) GetMissingKeys() []string {
+	if x != nil {
+		return x.MissingKeys
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required when authenticating with a JWT
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Only stream changes to these keys (empty = all keys)
+	Keys []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Resume after this event ID (0 = live events only)
+	LastEventId   uint64 `protobuf:"varint,3,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_cfguardian_v1_config_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetLastEventId() uint64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+type ConfigEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Stream position, usable as last_event_id when reconnecting: the Raft
+	// log index of the write. Set on the last event of each write only, as
+	// one write can change several configs.
+	Id   uint64          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type ConfigEventType `protobuf:"varint,2,opt,name=type,proto3,enum=cfguardian.v1.ConfigEventType" json:"type,omitempty"`
+	Key  string          `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// Config version after the change (last version for deletes)
+	Version int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	// JSON-encoded content after the change (empty for deletes)
+	Content       []byte                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigEvent) Reset() {
+	*x = ConfigEvent{}
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigEvent) ProtoMessage() {}
+
+func (x *ConfigEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_cfguardian_v1_config_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigEvent.ProtoReflect.Descriptor instead.
+func (*ConfigEvent) Descriptor() ([]byte, []int) {
+	return file_cfguardian_v1_config_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConfigEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConfigEvent) GetType() ConfigEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ConfigEventType_CONFIG_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ConfigEvent) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ConfigEvent) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ConfigEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_cfguardian_v1_config_service_proto protoreflect.FileDescriptor
+
+const file_cfguardian_v1_config_service_proto_rawDesc = "" +
+	"\n" +
+	"\"cfguardian/v1/config_service.proto\x12\rcfguardian.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"N\n" +
+	"\x06Config\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"C\n" +
+	"\x10GetConfigRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"B\n" +
+	"\x11GetConfigResponse\x12-\n" +
+	"\x06config\x18\x01 \x01(\v2\x15.cfguardian.v1.ConfigR\x06config\"K\n" +
+	"\x16BatchGetConfigsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\"m\n" +
+	"\x17BatchGetConfigsResponse\x12/\n" +
+	"\aconfigs\x18\x01 \x03(\v2\x15.cfguardian.v1.ConfigR\aconfigs\x12!\n" +
+	"\fmissing_keys\x18\x02 \x03(\tR\vmissingKeys\"e\n" +
+	"\fWatchRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\x12\"\n" +
+	"\rlast_event_id\x18\x03 \x01(\x04R\vlastEventId\"\xd4\x01\n" +
+	"\vConfigEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x122\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1e.cfguardian.v1.ConfigEventTypeR\x04type\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\fR\acontent\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt*\xd2\x01\n" +
+	"\x0fConfigEventType\x12!\n" +
+	"\x1dCONFIG_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19CONFIG_EVENT_TYPE_CREATED\x10\x01\x12\x1d\n" +
+	"\x19CONFIG_EVENT_TYPE_UPDATED\x10\x02\x12\x1d\n" +
+	"\x19CONFIG_EVENT_TYPE_DELETED\x10\x03\x12!\n" +
+	"\x1dCONFIG_EVENT_TYPE_ROLLED_BACK\x10\x04\x12\x1c\n" +
+	"\x18CONFIG_EVENT_TYPE_RESYNC\x10\x052\x85\x02\n" +
+	"\rConfigService\x12N\n" +
+	"\tGetConfig\x12\x1f.cfguardian.v1.GetConfigRequest\x1a .cfguardian.v1.GetConfigResponse\x12`\n" +
+	"\x0fBatchGetConfigs\x12%.cfguardian.v1.BatchGetConfigsRequest\x1a&.cfguardian.v1.BatchGetConfigsResponse\x12B\n" +
+	"\x05Watch\x12\x1b.cfguardian.v1.WatchRequest\x1a\x1a.cfguardian.v1.ConfigEvent0\x01BEZCgithub.com/vlone310/cfguardian/api/proto/cfguardian/v1;cfguardianv1b\x06proto3"
+
+var (
+	file_cfguardian_v1_config_service_proto_rawDescOnce sync.Once
+	file_cfguardian_v1_config_service_proto_rawDescData []byte
+)
+
+func file_cfguardian_v1_config_service_proto_rawDescGZIP() []byte {
+	file_cfguardian_v1_config_service_proto_rawDescOnce.Do(func() {
+		file_cfguardian_v1_config_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cfguardian_v1_config_service_proto_rawDesc), len(file_cfguardian_v1_config_service_proto_rawDesc)))
+	})
+	return file_cfguardian_v1_config_service_proto_rawDescData
+}
+
+var file_cfguardian_v1_config_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_cfguardian_v1_config_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_cfguardian_v1_config_service_proto_goTypes = []any{
+	(ConfigEventType)(0),            // 0: cfguardian.v1.ConfigEventType
+	(*Config)(nil),                  // 1: cfguardian.v1.Config
+	(*GetConfigRequest)(nil),        // 2: cfguardian.v1.GetConfigRequest
+	(*GetConfigResponse)(nil),       // 3: cfguardian.v1.GetConfigResponse
+	(*BatchGetConfigsRequest)(nil),  // 4: cfguardian.v1.BatchGetConfigsRequest
+	(*BatchGetConfigsResponse)(nil), // 5: cfguardian.v1.BatchGetConfigsResponse
+	(*WatchRequest)(nil),            // 6: cfguardian.v1.WatchRequest
+	(*ConfigEvent)(nil),             // 7: cfguardian.v1.ConfigEvent
+	(*timestamppb.Timestamp)(nil),   // 8: google.protobuf.Timestamp
+}
+var file_cfguardian_v1_config_service_proto_depIdxs = []int32{
+	1, // 0: cfguardian.v1.GetConfigResponse.config:type_name -> cfguardian.v1.Config
+	1, // 1: cfguardian.v1.BatchGetConfigsResponse.configs:type_name -> cfguardian.v1.Config
+	0, // 2: cfguardian.v1.ConfigEvent.type:type_name -> cfguardian.v1.ConfigEventType
+	8, // 3: cfguardian.v1.ConfigEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	2, // 4: cfguardian.v1.ConfigService.GetConfig:input_type -> cfguardian.v1.GetConfigRequest
+	4, // 5: cfguardian.v1.ConfigService.BatchGetConfigs:input_type -> cfguardian.v1.BatchGetConfigsRequest
+	6, // 6: cfguardian.v1.ConfigService.Watch:input_type -> cfguardian.v1.WatchRequest
+	3, // 7: cfguardian.v1.ConfigService.GetConfig:output_type -> cfguardian.v1.GetConfigResponse
+	5, // 8: cfguardian.v1.ConfigService.BatchGetConfigs:output_type -> cfguardian.v1.BatchGetConfigsResponse
+	7, // 9: cfguardian.v1.ConfigService.Watch:output_type -> cfguardian.v1.ConfigEvent
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_cfguardian_v1_config_service_proto_init() }
+func file_cfguardian_v1_config_service_proto_init() {
+	if File_cfguardian_v1_config_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cfguardian_v1_config_service_proto_rawDesc), len(file_cfguardian_v1_config_service_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cfguardian_v1_config_service_proto_goTypes,
+		DependencyIndexes: file_cfguardian_v1_config_service_proto_depIdxs,
+		EnumInfos:         file_cfguardian_v1_config_service_proto_enumTypes,
+		MessageInfos:      file_cfguardian_v1_config_service_proto_msgTypes,
+	}.Build()
+	File_cfguardian_v1_config_service_proto = out.File
+	file_cfguardian_v1_config_service_proto_goTypes = nil
+	file_cfguardian_v1_config_service_proto_depIdxs = nil
+}
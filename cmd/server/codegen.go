@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+)
+
+// codegenUsage is printed for -h and for invalid arguments
+const codegenUsage = `Usage:
+  cfguardian codegen -lang go|typescript -server URL -schema ID [-version N] [options]
+  cfguardian codegen -lang go|typescript -file schema.json [options]
+
+Generates Go structs or TypeScript interfaces from a schema stored in the
+registry, or from a local schema file. The API token for -server is read
+from CFGUARDIAN_TOKEN.
+
+Options:
+`
+
+// runCodegen runs "cfguardian codegen" and returns the process exit code
+func runCodegen(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("codegen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, codegenUsage)
+		flags.PrintDefaults()
+	}
+
+	lang := flags.String("lang", "", "language to generate: go or typescript")
+	server := flags.String("server", "", "cfguardian server URL, such as https://cfguardian.example.com")
+	schemaID := flags.String("schema", "", "ID of the stored schema (with -server)")
+	version := flags.Int("version", 0, "schema version (with -server); the latest when 0")
+	file := flags.String("file", "", "local JSON Schema file, instead of -server")
+	typeName := flags.String("type", "", "name of the root type; derived from the schema name by default")
+	packageName := flags.String("package", "config", "package of generated Go code")
+	output := flags.String("o", "", "file to write; standard output by default")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *lang == "" || (*file == "") == (*server == "") || (*server != "" && *schemaID == "") {
+		flags.Usage()
+		return 2
+	}
+
+	var code []byte
+	var err error
+	if *file != "" {
+		code, err = generateFromFile(*file, *lang, *typeName, *packageName)
+	} else {
+		query := url.Values{"lang": {*lang}, "package": {*packageName}}
+		if *typeName != "" {
+			query.Set("type", *typeName)
+		}
+		if *version != 0 {
+			query.Set("version", strconv.Itoa(*version))
+		}
+		code, err = fetchGeneratedCode(*server, *schemaID, query, os.Getenv("CFGUARDIAN_TOKEN"))
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "codegen: %v\n", err)
+		return 1
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(code)
+	} else {
+		err = os.WriteFile(*output, code, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "codegen: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// generateFromFile generates code from a local schema file. References to
+// registry schemas cannot be resolved offline.
+func generateFromFile(path, lang, typeName, packageName string) ([]byte, error) {
+	language, err := services.ParseCodegenLanguage(lang)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if typeName == "" {
+		typeName = services.CodegenTypeName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	}
+
+	return services.NewSchemaCodeGenerator(nil).Generate(context.Background(), string(content), services.CodegenOptions{
+		Language: language,
+		TypeName: typeName,
+		Package:  packageName,
+		Source:   filepath.Base(path),
+	})
+}
+
+// fetchGeneratedCode downloads code generated by the server from a stored schema
+func fetchGeneratedCode(server, schemaID string, query url.Values, token string) ([]byte, error) {
+	endpoint := strings.TrimRight(server, "/") + "/api/v1/schemas/" + url.PathEscape(schemaID) + "/codegen?" + query.Encode()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var problem struct {
+			Detail string `json:"detail"`
+		}
+		if json.Unmarshal(body, &problem) == nil && problem.Detail != "" {
+			return nil, fmt.Errorf("server answered %s: %s", resp.Status, problem.Detail)
+		}
+		return nil, fmt.Errorf("server answered %s", resp.Status)
+	}
+	return body, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCodegen_File(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "payments-api.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"required": ["currency"], "properties": {"currency": {"type": "string"}}}`), 0644))
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	code := runCodegen([]string{"-lang", "ts", "-file", path}, &stdout, &stderr)
+
+	// Assert
+	require.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, "// Code generated by cfguardian codegen. DO NOT EDIT.\n// Source: payments-api.json\n\n"+
+		"export interface PaymentsAPI {\n  currency: string;\n}\n", stdout.String())
+}
+
+func TestRunCodegen_Server(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status": 401, "detail": "missing token"}`))
+			return
+		}
+		assert.Equal(t, "/api/v1/schemas/schema-1/codegen", r.URL.Path)
+		assert.Equal(t, "go", r.URL.Query().Get("lang"))
+		assert.Equal(t, "settings", r.URL.Query().Get("package"))
+		assert.Equal(t, "3", r.URL.Query().Get("version"))
+		w.Write([]byte("package settings\n"))
+	}))
+	defer server.Close()
+	args := []string{"-lang", "go", "-server", server.URL, "-schema", "schema-1", "-version", "3", "-package", "settings"}
+
+	t.Run("writes the generated code", func(t *testing.T) {
+		t.Setenv("CFGUARDIAN_TOKEN", "secret")
+		output := filepath.Join(t.TempDir(), "settings.go")
+		var stdout, stderr bytes.Buffer
+
+		code := runCodegen(append(args, "-o", output), &stdout, &stderr)
+
+		require.Equal(t, 0, code, stderr.String())
+		written, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Equal(t, "package settings\n", string(written))
+	})
+
+	t.Run("reports the problem detail", func(t *testing.T) {
+		t.Setenv("CFGUARDIAN_TOKEN", "")
+		var stdout, stderr bytes.Buffer
+
+		code := runCodegen(args, &stdout, &stderr)
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr.String(), "401 Unauthorized: missing token")
+	})
+}
+
+func TestRunCodegen_Usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no language", []string{"-file", "schema.json"}},
+		{"no source", []string{"-lang", "go"}},
+		{"both sources", []string{"-lang", "go", "-file", "schema.json", "-server", "http://localhost", "-schema", "s"}},
+		{"server without schema", []string{"-lang", "go", "-server", "http://localhost"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := runCodegen(tt.args, &stdout, &stderr)
+
+			assert.Equal(t, 2, code)
+			assert.Contains(t, stderr.String(), "Usage:")
+		})
+	}
+}
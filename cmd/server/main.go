@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,21 +13,27 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	grpcAdapter "github.com/vlone310/cfguardian/internal/adapters/inbound/grpc"
 	httpAdapter "github.com/vlone310/cfguardian/internal/adapters/inbound/http"
 	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/handlers"
 	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/middleware"
+	"github.com/vlone310/cfguardian/internal/adapters/outbound/authcache"
+	"github.com/vlone310/cfguardian/internal/adapters/outbound/eventbus"
 	"github.com/vlone310/cfguardian/internal/adapters/outbound/postgres"
 	"github.com/vlone310/cfguardian/internal/adapters/outbound/raft"
 	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/infrastructure/config"
 	"github.com/vlone310/cfguardian/internal/infrastructure/secrets"
 	"github.com/vlone310/cfguardian/internal/infrastructure/telemetry"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+	"github.com/vlone310/cfguardian/internal/usecases/apikey"
 	"github.com/vlone310/cfguardian/internal/usecases/auth"
 	configUseCase "github.com/vlone310/cfguardian/internal/usecases/config"
 	"github.com/vlone310/cfguardian/internal/usecases/project"
 	"github.com/vlone310/cfguardian/internal/usecases/role"
 	"github.com/vlone310/cfguardian/internal/usecases/schema"
 	"github.com/vlone310/cfguardian/internal/usecases/user"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -35,6 +42,11 @@ const (
 )
 
 func main() {
+	// "cfguardian codegen" generates types from a schema instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "codegen" {
+		os.Exit(runCodegen(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	
 	// Display banner
 	displayBanner()
 	
@@ -93,13 +105,37 @@ func main() {
 
 	// Initialize repositories
 	userRepo := postgres.NewUserRepositoryAdapter(dbPool)
-	projectRepo := postgres.NewProjectRepositoryAdapter(dbPool)
+	
+	// Project and API key metadata is cached so that /read keeps authorizing
+	// API keys from the last known data while the database is unreachable
+	authCache := authcache.NewCache(
+		postgres.NewAPIKeyRepositoryAdapter(dbPool),
+		postgres.NewProjectRepositoryAdapter(dbPool),
+		authcache.CacheConfig{
+			RefreshInterval: cfg.AuthCache.RefreshInterval,
+			MaxStaleness:    cfg.AuthCache.MaxStaleness,
+		},
+	)
+	if err := authCache.Refresh(ctx); err != nil {
+		slog.Warn("Failed to load API key cache", "error", err)
+	}
+	go authCache.Run(ctx)
+	projectRepo := authCache.Projects()
+	apiKeyRepo := authCache.APIKeys()
 	roleRepo := postgres.NewRoleRepositoryAdapter(dbPool)
 	configSchemaRepo := postgres.NewConfigSchemaRepositoryAdapter(dbPool)
 	configRevisionRepo := postgres.NewConfigRevisionRepositoryAdapter(dbPool)
+	configMigrationRepo := postgres.NewConfigMigrationRepositoryAdapter(dbPool)
+	
+	// Initialize event broker for config change streams; every node
+	// publishes the changes it applies, so it must exist before Raft
+	eventBroker := eventbus.NewBroker(eventbus.BrokerConfig{
+		ReplaySize:           cfg.Stream.ReplayBufferSize,
+		SubscriberBufferSize: cfg.Stream.SubscriberBufferSize,
+	})
 	
 	// Initialize Raft consensus for config repository
-	raftStore, err := initRaft(cfg)
+	raftStore, err := initRaft(cfg, eventBroker)
 	if err != nil {
 		slog.Error("Failed to initialize Raft", "error", err)
 		os.Exit(1)
@@ -113,27 +149,43 @@ func main() {
 	// Initialize domain services
 	passwordHasher := services.NewPasswordHasher(12) // bcrypt cost
 	apiKeyGenerator := services.NewAPIKeyGenerator()
-	schemaValidator := services.NewSchemaValidator()
+	apiKeyHasher := services.NewAPIKeyHasher(cfg.Security.APIKeyPepper)
+	// $refs to cfguardian://schemas/<name> resolve against the schema registry
+	schemaResolver := schema.NewRegistrySchemaResolver(configSchemaRepo)
+	schemaValidator := services.NewSchemaValidatorWithResolver(schemaResolver, cfg.SchemaCache.Size)
+	schemaCodeGenerator := services.NewSchemaCodeGenerator(schemaResolver)
 	versionManager := services.NewVersionManager()
+	schemaCompatibilityChecker := services.NewSchemaCompatibilityChecker()
+	configMigrator := services.NewConfigMigrator()
 
 	// Initialize use cases
 	// Auth
 	loginUseCase := auth.NewLoginUserUseCase(userRepo, passwordHasher)
 	registerUseCase := auth.NewRegisterUserUseCase(userRepo, passwordHasher)
 	refreshTokenUseCase := auth.NewRefreshTokenUseCase(userRepo)
+	validateAPIKeyUseCase := auth.NewValidateAPIKeyUseCase(apiKeyRepo, projectRepo, apiKeyHasher)
 
 	// User
 	createUserUseCase := user.NewCreateUserUseCase(userRepo, passwordHasher)
 	listUsersUseCase := user.NewListUsersUseCase(userRepo)
 	getUserUseCase := user.NewGetUserUseCase(userRepo)
 	deleteUserUseCase := user.NewDeleteUserUseCase(userRepo, roleRepo)
+	checkPlatformAdminUseCase := user.NewCheckPlatformAdminUseCase(cfg.Security.PlatformAdminUserIDs)
 
 	// Project
-	createProjectUseCase := project.NewCreateProjectUseCase(projectRepo, userRepo, roleRepo, apiKeyGenerator)
+	createProjectUseCase := project.NewCreateProjectUseCase(projectRepo, apiKeyRepo, userRepo, roleRepo, apiKeyGenerator, apiKeyHasher)
 	listProjectsUseCase := project.NewListProjectsUseCase(projectRepo)
 	getProjectUseCase := project.NewGetProjectUseCase(projectRepo)
 	deleteProjectUseCase := project.NewDeleteProjectUseCase(projectRepo)
 
+	// API key
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(apiKeyRepo, projectRepo, apiKeyGenerator, apiKeyHasher)
+	listAPIKeysUseCase := apikey.NewListAPIKeysUseCase(apiKeyRepo)
+	getAPIKeyUseCase := apikey.NewGetAPIKeyUseCase(apiKeyRepo)
+	updateAPIKeyUseCase := apikey.NewUpdateAPIKeyUseCase(apiKeyRepo)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(apiKeyRepo)
+	rotateAPIKeyUseCase := apikey.NewRotateAPIKeyUseCase(apiKeyRepo, apiKeyGenerator, apiKeyHasher, cfg.Security.APIKeyRotationGracePeriod)
+
 	// Role
 	assignRoleUseCase := role.NewAssignRoleUseCase(roleRepo, userRepo, projectRepo)
 	revokeRoleUseCase := role.NewRevokeRoleUseCase(roleRepo)
@@ -141,9 +193,17 @@ func main() {
 
 	// Schema
 	createSchemaUseCase := schema.NewCreateSchemaUseCase(configSchemaRepo, schemaValidator)
-	listSchemasUseCase := schema.NewListSchemasUseCase(configSchemaRepo)
-	updateSchemaUseCase := schema.NewUpdateSchemaUseCase(configSchemaRepo, schemaValidator)
-	deleteSchemaUseCase := schema.NewDeleteSchemaUseCase(configSchemaRepo)
+	listSchemasUseCase := schema.NewListSchemasUseCase(configSchemaRepo, configRepo, roleRepo)
+	updateSchemaUseCase := schema.NewUpdateSchemaUseCase(configSchemaRepo, configRepo, schemaValidator, schemaCompatibilityChecker, configMigrator)
+	getSchemaUsageUseCase := schema.NewGetSchemaUsageUseCase(configSchemaRepo, configRepo, userRepo, schemaValidator, configMigrator)
+	deleteSchemaUseCase := schema.NewDeleteSchemaUseCase(configSchemaRepo, configRepo, schemaValidator, getSchemaUsageUseCase)
+	validateContentUseCase := schema.NewValidateContentUseCase(configSchemaRepo, schemaValidator)
+	publishSchemaVersionUseCase := schema.NewPublishSchemaVersionUseCase(configSchemaRepo, configRepo, schemaValidator, schemaCompatibilityChecker, configMigrator)
+	listSchemaVersionsUseCase := schema.NewListSchemaVersionsUseCase(configSchemaRepo)
+	getSchemaVersionUseCase := schema.NewGetSchemaVersionUseCase(configSchemaRepo)
+	previewSchemaChangeUseCase := schema.NewPreviewSchemaChangeUseCase(configSchemaRepo, configRepo, schemaValidator, schemaCompatibilityChecker, configMigrator)
+	getSchemaScopeUseCase := schema.NewGetSchemaScopeUseCase(configSchemaRepo)
+	generateSchemaCodeUseCase := schema.NewGenerateSchemaCodeUseCase(configSchemaRepo, schemaCodeGenerator)
 
 	// Config
 	createConfigUseCase := configUseCase.NewCreateConfigUseCase(
@@ -153,7 +213,7 @@ func main() {
 		projectRepo,
 		schemaValidator,
 	)
-	getConfigUseCase := configUseCase.NewGetConfigUseCase(configRepo)
+	getConfigUseCase := configUseCase.NewGetConfigUseCase(configRepo, configSchemaRepo, schemaValidator)
 	updateConfigUseCase := configUseCase.NewUpdateConfigUseCase(
 		configRepo,
 		configRevisionRepo,
@@ -169,13 +229,60 @@ func main() {
 		schemaValidator,
 		versionManager,
 	)
+	importConfigsUseCase := configUseCase.NewImportConfigsUseCase(
+		createConfigUseCase,
+		updateConfigUseCase,
+		configRepo,
+		configSchemaRepo,
+		projectRepo,
+		schemaValidator,
+	)
+	validateConfigUseCase := configUseCase.NewValidateConfigUseCase(
+		configRepo,
+		configSchemaRepo,
+		schemaValidator,
+		versionManager,
+	)
+	upgradeConfigSchemaUseCase := configUseCase.NewUpgradeConfigSchemaUseCase(
+		configRepo,
+		configRevisionRepo,
+		configSchemaRepo,
+		schemaValidator,
+		versionManager,
+	)
+	migrateConfigsUseCase := configUseCase.NewMigrateConfigsUseCase(
+		configRepo,
+		configRevisionRepo,
+		configSchemaRepo,
+		configMigrationRepo,
+		schemaValidator,
+		configMigrator,
+	)
+	listConfigMigrationsUseCase := configUseCase.NewListConfigMigrationsUseCase(configMigrationRepo)
+	getConfigMigrationUseCase := configUseCase.NewGetConfigMigrationUseCase(configMigrationRepo)
+	rollbackConfigMigrationUseCase := configUseCase.NewRollbackConfigMigrationUseCase(
+		configRepo,
+		configRevisionRepo,
+		configMigrationRepo,
+	)
 	readConfigByAPIKeyUseCase := configUseCase.NewReadConfigByAPIKeyUseCase(
 		projectRepo,
 		configRepo,
+		configSchemaRepo,
+		schemaValidator,
+	)
+	streamConfigChangesUseCase := configUseCase.NewStreamConfigChangesUseCase(
+		projectRepo,
+		configRepo,
+		eventBroker,
 	)
 
 	// Initialize Prometheus metrics
 	prometheusMetrics := telemetry.NewPrometheusMetrics(appName)
+	telemetry.RegisterSchemaCacheMetrics(appName, func() (uint64, uint64, int) {
+		stats := schemaValidator.CacheStats()
+		return stats.Hits, stats.Misses, stats.Entries
+	})
 	slog.Info("Prometheus metrics initialized")
 	
 	// Initialize HTTP handlers
@@ -184,33 +291,50 @@ func main() {
 	authHandler := handlers.NewAuthHandler(loginUseCase, registerUseCase, refreshTokenUseCase, cfg.JWT.Secret, cfg.JWT.Expiration, refreshTokenExpiration)
 	userHandler := handlers.NewUserHandler(createUserUseCase, listUsersUseCase, getUserUseCase, deleteUserUseCase)
 	projectHandler := handlers.NewProjectHandler(createProjectUseCase, listProjectsUseCase, getProjectUseCase, deleteProjectUseCase)
+	apiKeyHandler := handlers.NewAPIKeyHandler(createAPIKeyUseCase, listAPIKeysUseCase, getAPIKeyUseCase, updateAPIKeyUseCase, revokeAPIKeyUseCase, rotateAPIKeyUseCase)
 	roleHandler := handlers.NewRoleHandler(assignRoleUseCase, revokeRoleUseCase)
-	schemaHandler := handlers.NewSchemaHandler(createSchemaUseCase, listSchemasUseCase, updateSchemaUseCase, deleteSchemaUseCase)
-	configHandler := handlers.NewConfigHandler(createConfigUseCase, getConfigUseCase, updateConfigUseCase, deleteConfigUseCase, rollbackConfigUseCase)
+	schemaHandler := handlers.NewSchemaHandler(createSchemaUseCase, listSchemasUseCase, updateSchemaUseCase, deleteSchemaUseCase, validateContentUseCase, publishSchemaVersionUseCase, listSchemaVersionsUseCase, getSchemaVersionUseCase, previewSchemaChangeUseCase, generateSchemaCodeUseCase, getSchemaUsageUseCase, checkPlatformAdminUseCase)
+	migrationHandler := handlers.NewMigrationHandler(migrateConfigsUseCase, listConfigMigrationsUseCase, getConfigMigrationUseCase, rollbackConfigMigrationUseCase)
+	configHandler := handlers.NewConfigHandler(createConfigUseCase, getConfigUseCase, updateConfigUseCase, deleteConfigUseCase, rollbackConfigUseCase, importConfigsUseCase, validateConfigUseCase, upgradeConfigSchemaUseCase)
 	readHandler := handlers.NewReadHandler(readConfigByAPIKeyUseCase)
-	healthHandler := handlers.NewHealthHandler(dbPool)
+	streamHandler := handlers.NewStreamHandler(streamConfigChangesUseCase, cfg.Stream.HeartbeatInterval)
+	healthHandler := handlers.NewHealthHandler(dbPool, raftStore, authCache)
 	metricsHandler := handlers.NewMetricsHandler()
 
+	// Rate limiter shared by the HTTP and gRPC servers
+	rateLimiter := middleware.NewRateLimiter(100, 200)
+	authorizationConfig := middleware.AuthorizationConfig{
+		CheckPermission: checkPermissionUseCase,
+		PlatformAdmin:   checkPlatformAdminUseCase,
+		SchemaScope:     getSchemaScopeUseCase,
+	}
+
 	// Initialize router
 	router := httpAdapter.NewRouter(httpAdapter.RouterConfig{
 		JWTSecret:      cfg.JWT.Secret,
-		RateLimitRPS:   100,
-		RateLimitBurst: 200,
+		RateLimiter:    rateLimiter,
+		APIKeyAuthenticator: validateAPIKeyUseCase,
+		AllowAPIKeyInPath: cfg.Security.APIKeyInPathEnabled,
 		AuthHandler:    authHandler,
 		UserHandler:    userHandler,
 		ProjectHandler: projectHandler,
+		APIKeyHandler:  apiKeyHandler,
 		RoleHandler:    roleHandler,
 		SchemaHandler:  schemaHandler,
+		MigrationHandler: migrationHandler,
 		ConfigHandler:  configHandler,
 		ReadHandler:    readHandler,
+		StreamHandler:  streamHandler,
 		HealthHandler:  healthHandler,
 		MetricsHandler: metricsHandler,
 		PrometheusMetrics: prometheusMetrics,
-		AuthorizationConfig: middleware.AuthorizationConfig{
-			CheckPermission: checkPermissionUseCase,
-		},
+		AuthorizationConfig: authorizationConfig,
 	})
 
+	if cfg.Security.APIKeyInPathEnabled {
+		slog.Warn("API keys in URL paths are deprecated; send them in the X-API-Key header and set API_KEY_IN_PATH_ENABLED=false")
+	}
+
 	// Initialize HTTP server
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
@@ -229,6 +353,35 @@ func main() {
 		}
 	}()
 
+	// Initialize gRPC server
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Enabled {
+		grpcServer = grpcAdapter.NewServer(grpcAdapter.ServerConfig{
+			JWTSecret:             cfg.JWT.Secret,
+			Reflection:            cfg.GRPC.Reflection,
+			RateLimiter:           rateLimiter,
+			PrometheusMetrics:     prometheusMetrics,
+			CheckPermission:       authorizationConfig.CheckPermission,
+			ValidateAPIKeyUseCase: validateAPIKeyUseCase,
+			GetConfigUseCase:      getConfigUseCase,
+			StreamUseCase:         streamConfigChangesUseCase,
+		})
+
+		grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+		if err != nil {
+			slog.Error("Failed to listen for gRPC", "error", err)
+			os.Exit(1)
+		}
+
+		go func() {
+			slog.Info("gRPC server starting", "addr", grpcListener.Addr().String())
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				slog.Error("gRPC server failed", "error", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
 	slog.Info("Application initialized successfully")
 	slog.Info("GoConfig Guardian is ready to accept requests", "address", server.Addr)
 
@@ -245,6 +398,11 @@ func main() {
 		slog.Error("HTTP server shutdown failed", "error", err)
 	}
 
+	// Shutdown gRPC server (open Watch streams are closed)
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
+
 	// Close Raft node
 	if err := raftStore.Shutdown(); err != nil {
 		slog.Error("Raft shutdown failed", "error", err)
@@ -292,8 +450,9 @@ func initDatabase(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error
 	return pool, nil
 }
 
-// initRaft initializes the Raft consensus layer
-func initRaft(cfg *config.Config) (*raft.Store, error) {
+// initRaft initializes the Raft consensus layer, publishing the events of
+// applied changes to events
+func initRaft(cfg *config.Config, events outbound.EventPublisher) (*raft.Store, error) {
 	storeConfig := raft.StoreConfig{
 		NodeID:            cfg.Raft.NodeID,
 		BindAddr:          cfg.Raft.BindAddr,
@@ -303,6 +462,8 @@ func initRaft(cfg *config.Config) (*raft.Store, error) {
 		ElectionTimeout:   cfg.Raft.ElectionTimeout,
 		SnapshotInterval:  cfg.Raft.SnapshotInterval,
 		SnapshotThreshold: cfg.Raft.SnapshotThreshold,
+		MaxTombstones:     cfg.Raft.MaxTombstones,
+		Events:            events,
 	}
 
 	store, err := raft.NewStore(storeConfig)
@@ -0,0 +1,3 @@
+-- Drop api_keys table and its indexes
+DROP INDEX IF EXISTS idx_api_keys_project_id;
+DROP TABLE IF EXISTS api_keys CASCADE;
@@ -0,0 +1,42 @@
+-- Create api_keys table for multiple scoped client keys per project
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(255) PRIMARY KEY,
+    project_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    api_key VARCHAR(255) NOT NULL UNIQUE,
+    key_prefixes TEXT[] NOT NULL DEFAULT '{}',
+    created_by_user_id VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    CONSTRAINT fk_api_keys_project
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_api_keys_created_by_user
+        FOREIGN KEY (created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Create indexes for faster lookups
+CREATE INDEX IF NOT EXISTS idx_api_keys_project_id ON api_keys(project_id);
+
+-- Carry over each project's existing key so current clients keep working
+INSERT INTO api_keys (id, project_id, name, api_key, created_by_user_id, created_at)
+SELECT gen_random_uuid()::text, id, 'default', api_key, owner_user_id, created_at
+FROM projects
+ON CONFLICT (api_key) DO NOTHING;
+
+-- Add comments
+COMMENT ON TABLE api_keys IS 'Read-only client API keys, several per project';
+COMMENT ON COLUMN api_keys.id IS 'Unique API key identifier (UUID)';
+COMMENT ON COLUMN api_keys.project_id IS 'Project the key grants read access to';
+COMMENT ON COLUMN api_keys.name IS 'Display name, e.g. the client that uses the key';
+COMMENT ON COLUMN api_keys.api_key IS 'Secret key value (unique)';
+COMMENT ON COLUMN api_keys.key_prefixes IS 'Config key prefixes the key is limited to (empty = all configs)';
+COMMENT ON COLUMN api_keys.created_by_user_id IS 'User who created the key';
+COMMENT ON COLUMN api_keys.expires_at IS 'When the key stops working (NULL = never)';
+COMMENT ON COLUMN api_keys.last_used_at IS 'When the key last authenticated a request';
+COMMENT ON COLUMN api_keys.revoked_at IS 'When the key was revoked (NULL = active)';
@@ -0,0 +1,29 @@
+-- Restore plaintext API key columns.
+-- Hashes cannot be reversed, so every key is replaced by a new random one
+-- and existing clients must be given their project's new key.
+DROP INDEX IF EXISTS idx_api_keys_lookup_prefix;
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS api_key VARCHAR(255);
+UPDATE api_keys SET api_key = 'cfg_' || REPLACE(gen_random_uuid()::text, '-', '');
+ALTER TABLE api_keys ALTER COLUMN api_key SET NOT NULL;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_api_key_key UNIQUE (api_key);
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS api_key VARCHAR(255);
+UPDATE projects p
+SET api_key = COALESCE(
+    (SELECT k.api_key FROM api_keys k
+     WHERE k.project_id = p.id AND k.name = 'default'
+     ORDER BY k.created_at
+     LIMIT 1),
+    'cfg_' || REPLACE(gen_random_uuid()::text, '-', '')
+);
+ALTER TABLE projects ALTER COLUMN api_key SET NOT NULL;
+ALTER TABLE projects ADD CONSTRAINT projects_api_key_key UNIQUE (api_key);
+CREATE INDEX IF NOT EXISTS idx_projects_api_key ON projects(api_key);
+
+ALTER TABLE api_keys
+    DROP COLUMN IF EXISTS lookup_prefix,
+    DROP COLUMN IF EXISTS key_hash,
+    DROP COLUMN IF EXISTS key_salt,
+    DROP COLUMN IF EXISTS key_hint,
+    DROP COLUMN IF EXISTS hash_version;
@@ -0,0 +1,44 @@
+-- Store API keys as a lookup prefix plus a salted hash instead of plaintext
+ALTER TABLE api_keys
+    ADD COLUMN IF NOT EXISTS lookup_prefix VARCHAR(12),
+    ADD COLUMN IF NOT EXISTS key_hash BYTEA,
+    ADD COLUMN IF NOT EXISTS key_salt BYTEA,
+    ADD COLUMN IF NOT EXISTS key_hint VARCHAR(4),
+    ADD COLUMN IF NOT EXISTS hash_version SMALLINT;
+
+-- Convert existing keys. The server pepper is not available here, so keys
+-- get the legacy hash SHA-256(salt || key) (version 1); the server wraps it
+-- with the pepper the next time the key is used.
+UPDATE api_keys
+SET
+    lookup_prefix = LEFT(api_key, 12),
+    key_salt = uuid_send(gen_random_uuid()),
+    key_hint = RIGHT(api_key, 4),
+    hash_version = 1
+WHERE key_hash IS NULL;
+
+UPDATE api_keys
+SET key_hash = sha256(key_salt || convert_to(api_key, 'UTF8'))
+WHERE key_hash IS NULL;
+
+ALTER TABLE api_keys
+    ALTER COLUMN lookup_prefix SET NOT NULL,
+    ALTER COLUMN key_hash SET NOT NULL,
+    ALTER COLUMN key_salt SET NOT NULL,
+    ALTER COLUMN key_hint SET NOT NULL,
+    ALTER COLUMN hash_version SET NOT NULL;
+
+-- Drop the plaintext keys
+ALTER TABLE api_keys DROP COLUMN IF EXISTS api_key;
+DROP INDEX IF EXISTS idx_projects_api_key;
+ALTER TABLE projects DROP COLUMN IF EXISTS api_key;
+
+-- Create indexes for faster lookups
+CREATE INDEX IF NOT EXISTS idx_api_keys_lookup_prefix ON api_keys(lookup_prefix);
+
+-- Add comments
+COMMENT ON COLUMN api_keys.lookup_prefix IS 'Leading characters of the key, used to find its hash (not unique)';
+COMMENT ON COLUMN api_keys.key_hash IS 'Salted hash of the key (see hash_version)';
+COMMENT ON COLUMN api_keys.key_salt IS 'Random per-key salt';
+COMMENT ON COLUMN api_keys.key_hint IS 'Last characters of the key, for display';
+COMMENT ON COLUMN api_keys.hash_version IS 'Hashing scheme: 1 = SHA-256(salt || key), 2 = HMAC-SHA256(pepper, SHA-256(salt || key))';
@@ -0,0 +1,4 @@
+-- Drop schema versions; each schema keeps the content of its latest version
+ALTER TABLE config_schemas DROP COLUMN IF EXISTS latest_version;
+DROP TABLE IF EXISTS config_schema_versions;
+COMMENT ON COLUMN config_schemas.schema_content IS 'JSON Schema definition';
@@ -0,0 +1,34 @@
+-- Make schemas append-only: each content change publishes a new numbered
+-- version and configs pin the version they were validated against
+CREATE TABLE IF NOT EXISTS config_schema_versions (
+    schema_id VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL,
+    schema_content TEXT NOT NULL,
+    created_by_user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (schema_id, version),
+    CONSTRAINT fk_config_schema_versions_schema
+        FOREIGN KEY (schema_id)
+        REFERENCES config_schemas(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_config_schema_versions_created_by_user
+        FOREIGN KEY (created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+    CONSTRAINT chk_config_schema_versions_version CHECK (version >= 1)
+);
+
+ALTER TABLE config_schemas ADD COLUMN IF NOT EXISTS latest_version INTEGER NOT NULL DEFAULT 1;
+
+-- Existing content becomes version 1 of each schema
+INSERT INTO config_schema_versions (schema_id, version, schema_content, created_by_user_id, created_at)
+SELECT id, 1, schema_content, created_by_user_id, updated_at
+FROM config_schemas
+ON CONFLICT (schema_id, version) DO NOTHING;
+
+-- Add comments
+COMMENT ON TABLE config_schema_versions IS 'Immutable, numbered versions of each config schema';
+COMMENT ON COLUMN config_schema_versions.version IS 'Version number within the schema, starting at 1';
+COMMENT ON COLUMN config_schema_versions.schema_content IS 'JSON Schema definition of this version';
+COMMENT ON COLUMN config_schemas.latest_version IS 'Newest published version';
+COMMENT ON COLUMN config_schemas.schema_content IS 'JSON Schema definition of the latest version';
@@ -0,0 +1,4 @@
+-- Drop migration runs and the migrations attached to schema versions
+DROP TABLE IF EXISTS config_migration_items;
+DROP TABLE IF EXISTS config_migrations;
+ALTER TABLE config_schema_versions DROP COLUMN IF EXISTS migration;
@@ -0,0 +1,59 @@
+-- Attach declarative content migrations to schema versions and record each
+-- run, with the content it replaced, so that a run can be rolled back
+ALTER TABLE config_schema_versions ADD COLUMN IF NOT EXISTS migration JSONB;
+
+CREATE TABLE IF NOT EXISTS config_migrations (
+    id VARCHAR(255) PRIMARY KEY,
+    schema_id VARCHAR(255) NOT NULL,
+    target_version INTEGER NOT NULL,
+    created_by_user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rolled_back_by_user_id VARCHAR(255),
+    rolled_back_at TIMESTAMP,
+    CONSTRAINT fk_config_migrations_schema
+        FOREIGN KEY (schema_id)
+        REFERENCES config_schemas(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_config_migrations_created_by_user
+        FOREIGN KEY (created_by_user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_config_migrations_rolled_back_by_user
+        FOREIGN KEY (rolled_back_by_user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+-- Configs live in Raft, so items reference projects rather than configs
+CREATE TABLE IF NOT EXISTS config_migration_items (
+    migration_id VARCHAR(255) NOT NULL,
+    project_id VARCHAR(255) NOT NULL,
+    config_key VARCHAR(255) NOT NULL,
+    from_schema_version INTEGER NOT NULL,
+    from_version BIGINT NOT NULL,
+    to_version BIGINT NOT NULL,
+    previous_content JSONB NOT NULL,
+    PRIMARY KEY (migration_id, project_id, config_key),
+    CONSTRAINT fk_config_migration_items_migration
+        FOREIGN KEY (migration_id)
+        REFERENCES config_migrations(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_config_migration_items_project
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE
+);
+
+-- Create indexes for faster lookups
+CREATE INDEX IF NOT EXISTS idx_config_migrations_schema_id ON config_migrations(schema_id);
+CREATE INDEX IF NOT EXISTS idx_config_migrations_created_at ON config_migrations(created_at);
+
+-- Add comments
+COMMENT ON COLUMN config_schema_versions.migration IS 'Operations that rewrite content of the previous version for this one';
+COMMENT ON TABLE config_migrations IS 'Runs of schema migrations over the configs using a schema';
+COMMENT ON COLUMN config_migrations.target_version IS 'Schema version the configs were migrated to';
+COMMENT ON COLUMN config_migrations.rolled_back_at IS 'When the run was rolled back; NULL while it stands';
+COMMENT ON TABLE config_migration_items IS 'Configs rewritten by a migration run';
+COMMENT ON COLUMN config_migration_items.from_version IS 'Config version before the run';
+COMMENT ON COLUMN config_migration_items.to_version IS 'Config version written by the run';
+COMMENT ON COLUMN config_migration_items.previous_content IS 'Content before the run, restored on rollback';
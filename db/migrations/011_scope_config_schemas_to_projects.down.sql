@@ -0,0 +1,4 @@
+-- Make every schema global again
+DROP INDEX IF EXISTS idx_config_schemas_project_id;
+ALTER TABLE config_schemas DROP CONSTRAINT IF EXISTS fk_config_schemas_project;
+ALTER TABLE config_schemas DROP COLUMN IF EXISTS project_id;
@@ -0,0 +1,13 @@
+-- Let a schema belong to a project; schemas without a project stay global
+ALTER TABLE config_schemas ADD COLUMN IF NOT EXISTS project_id VARCHAR(255);
+
+ALTER TABLE config_schemas
+    ADD CONSTRAINT fk_config_schemas_project
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_config_schemas_project_id ON config_schemas(project_id);
+
+-- Add comments
+COMMENT ON COLUMN config_schemas.project_id IS 'Project owning this schema; NULL for a global schema';
@@ -0,0 +1,2 @@
+-- Drop schema references; referenced schemas can be deleted again
+DROP TABLE IF EXISTS config_schema_references;
@@ -0,0 +1,25 @@
+-- Record which registry schemas each schema version references through
+-- cfguardian://schemas/<name> so that a referenced schema cannot be deleted.
+-- NO ACTION is checked at the end of the statement, so deleting a project
+-- still removes its schemas that reference each other
+CREATE TABLE IF NOT EXISTS config_schema_references (
+    schema_id VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL,
+    referenced_schema_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (schema_id, version, referenced_schema_id),
+    CONSTRAINT fk_config_schema_references_version
+        FOREIGN KEY (schema_id, version)
+        REFERENCES config_schema_versions(schema_id, version)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_config_schema_references_referenced_schema
+        FOREIGN KEY (referenced_schema_id)
+        REFERENCES config_schemas(id)
+        ON DELETE NO ACTION
+);
+
+-- Create indexes for faster lookups
+CREATE INDEX IF NOT EXISTS idx_config_schema_references_referenced_schema_id ON config_schema_references(referenced_schema_id);
+
+-- Add comments
+COMMENT ON TABLE config_schema_references IS 'Registry schemas referenced by each schema version';
+COMMENT ON COLUMN config_schema_references.referenced_schema_id IS 'Schema named by a $ref; it cannot be deleted while referenced';
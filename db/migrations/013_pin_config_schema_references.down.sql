@@ -0,0 +1,6 @@
+-- Resolve references to the latest version of the referenced schema again.
+-- The references reached through other schemas are kept; they only stop
+-- those schemas from being deleted.
+ALTER TABLE config_schema_references DROP CONSTRAINT IF EXISTS fk_config_schema_references_referenced_version;
+ALTER TABLE config_schema_references DROP COLUMN IF EXISTS referenced_version;
+ALTER TABLE config_schema_references DROP COLUMN IF EXISTS referenced_name;
@@ -0,0 +1,46 @@
+-- Pin each schema reference to the version of the referenced schema it was
+-- published with, so publishing a schema does not change how the configs of
+-- the schemas referencing it validate. A schema version records every
+-- registry schema it reaches, through other schemas or not, under the name
+-- its $ref uses.
+ALTER TABLE config_schema_references
+    ADD COLUMN IF NOT EXISTS referenced_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS referenced_version INTEGER;
+
+-- Existing references resolved to the latest version of the referenced schema
+UPDATE config_schema_references r
+SET referenced_name = s.name,
+    referenced_version = s.latest_version
+FROM config_schemas s
+WHERE s.id = r.referenced_schema_id;
+
+-- Record the schemas they reach through other schemas as well
+WITH RECURSIVE reachable (schema_id, version, referenced_schema_id, referenced_version) AS (
+    SELECT schema_id, version, referenced_schema_id, referenced_version
+    FROM config_schema_references
+    UNION
+    SELECT reachable.schema_id, reachable.version, r.referenced_schema_id, r.referenced_version
+    FROM reachable
+    JOIN config_schema_references r
+        ON r.schema_id = reachable.referenced_schema_id
+        AND r.version = reachable.referenced_version
+)
+INSERT INTO config_schema_references (schema_id, version, referenced_schema_id, referenced_name, referenced_version)
+SELECT reachable.schema_id, reachable.version, reachable.referenced_schema_id, s.name, reachable.referenced_version
+FROM reachable
+JOIN config_schemas s ON s.id = reachable.referenced_schema_id
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE config_schema_references
+    ALTER COLUMN referenced_name SET NOT NULL,
+    ALTER COLUMN referenced_version SET NOT NULL;
+
+ALTER TABLE config_schema_references
+    ADD CONSTRAINT fk_config_schema_references_referenced_version
+        FOREIGN KEY (referenced_schema_id, referenced_version)
+        REFERENCES config_schema_versions(schema_id, version)
+        ON DELETE NO ACTION;
+
+-- Add comments
+COMMENT ON COLUMN config_schema_references.referenced_name IS 'Name the $ref used when the version was published';
+COMMENT ON COLUMN config_schema_references.referenced_version IS 'Version of the referenced schema the version validates with';
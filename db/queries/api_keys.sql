@@ -0,0 +1,70 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+    id,
+    project_id,
+    name,
+    lookup_prefix,
+    key_hash,
+    key_salt,
+    key_hint,
+    hash_version,
+    key_prefixes,
+    created_by_user_id,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+RETURNING *;
+
+-- name: GetAPIKey :one
+SELECT * FROM api_keys
+WHERE id = $1 AND project_id = $2
+LIMIT 1;
+
+-- name: ListActiveAPIKeys :many
+SELECT * FROM api_keys
+WHERE revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: ListAPIKeysByLookupPrefix :many
+SELECT * FROM api_keys
+WHERE lookup_prefix = $1;
+
+-- name: ListAPIKeysByProject :many
+SELECT * FROM api_keys
+WHERE project_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateAPIKey :one
+UPDATE api_keys
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    key_prefixes = COALESCE(sqlc.narg('key_prefixes'), key_prefixes),
+    expires_at = CASE
+        WHEN sqlc.arg('clear_expiry')::boolean THEN NULL
+        ELSE COALESCE(sqlc.narg('expires_at'), expires_at)
+    END
+WHERE id = sqlc.arg('id') AND project_id = sqlc.arg('project_id')
+RETURNING *;
+
+-- name: RevokeAPIKey :one
+UPDATE api_keys
+SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+WHERE id = $1 AND project_id = $2
+RETURNING *;
+
+-- name: SetAPIKeyExpiry :one
+UPDATE api_keys
+SET expires_at = $3
+WHERE id = $1 AND project_id = $2
+RETURNING *;
+
+-- name: UpdateAPIKeyHash :exec
+UPDATE api_keys
+SET key_hash = $2, hash_version = $3
+WHERE id = $1;
+
+-- name: TouchAPIKeyLastUsed :exec
+UPDATE api_keys
+SET last_used_at = $2
+WHERE id = $1;
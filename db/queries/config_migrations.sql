@@ -0,0 +1,46 @@
+-- name: CreateConfigMigration :one
+INSERT INTO config_migrations (
+    id,
+    schema_id,
+    target_version,
+    created_by_user_id
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: CreateConfigMigrationItem :exec
+INSERT INTO config_migration_items (
+    migration_id,
+    project_id,
+    config_key,
+    from_schema_version,
+    from_version,
+    to_version,
+    previous_content
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: GetConfigMigration :one
+SELECT * FROM config_migrations
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListConfigMigrationsBySchema :many
+SELECT * FROM config_migrations
+WHERE schema_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListConfigMigrationItems :many
+SELECT * FROM config_migration_items
+WHERE migration_id = $1
+ORDER BY project_id, config_key;
+
+-- name: MarkConfigMigrationRolledBack :one
+UPDATE config_migrations
+SET
+    rolled_back_by_user_id = $2,
+    rolled_back_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND rolled_back_at IS NULL
+RETURNING *;
@@ -3,9 +3,10 @@ INSERT INTO config_schemas (
     id,
     name,
     schema_content,
-    created_by_user_id
+    created_by_user_id,
+    project_id
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 )
 RETURNING *;
 
@@ -15,8 +16,11 @@ WHERE id = $1
 LIMIT 1;
 
 -- name: GetConfigSchemaByName :one
+-- A project sees its own schemas and the global ones; without a project,
+-- only the global ones
 SELECT * FROM config_schemas
-WHERE name = $1
+WHERE name = $1 AND (project_id IS NULL OR project_id = sqlc.narg('project_id'))
+ORDER BY project_id NULLS LAST
 LIMIT 1;
 
 -- name: ListConfigSchemas :many
@@ -28,15 +32,55 @@ SELECT * FROM config_schemas
 WHERE created_by_user_id = $1
 ORDER BY created_at DESC;
 
+-- name: ListConfigSchemasByProject :many
+SELECT * FROM config_schemas
+WHERE project_id IS NULL OR project_id = $1
+ORDER BY name;
+
+-- name: ListConfigSchemasForProjects :many
+SELECT * FROM config_schemas
+WHERE project_id IS NULL OR project_id = ANY(sqlc.arg('project_ids')::varchar[])
+ORDER BY name;
+
 -- name: UpdateConfigSchema :one
 UPDATE config_schemas
 SET
     name = COALESCE(sqlc.narg('name'), name),
-    schema_content = COALESCE(sqlc.narg('schema_content'), schema_content),
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
 
+-- name: BumpConfigSchemaVersion :one
+UPDATE config_schemas
+SET
+    latest_version = latest_version + 1,
+    schema_content = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateConfigSchemaVersion :one
+INSERT INTO config_schema_versions (
+    schema_id,
+    version,
+    schema_content,
+    created_by_user_id,
+    migration
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetConfigSchemaVersion :one
+SELECT * FROM config_schema_versions
+WHERE schema_id = $1 AND version = $2
+LIMIT 1;
+
+-- name: ListConfigSchemaVersions :many
+SELECT * FROM config_schema_versions
+WHERE schema_id = $1
+ORDER BY version DESC;
+
 -- name: DeleteConfigSchema :exec
 DELETE FROM config_schemas
 WHERE id = $1;
@@ -47,8 +91,12 @@ SELECT EXISTS(
 ) AS exists;
 
 -- name: ConfigSchemaExistsByName :one
+-- A project schema's name must be free among the project's and the global
+-- schemas; a global schema's name among all schemas, as every project sees it
 SELECT EXISTS(
-    SELECT 1 FROM config_schemas WHERE name = $1
+    SELECT 1 FROM config_schemas
+    WHERE name = $1
+        AND (sqlc.narg('project_id')::varchar IS NULL OR project_id IS NULL OR project_id = sqlc.narg('project_id'))
 ) AS exists;
 
 -- name: CountConfigSchemas :one
@@ -58,3 +106,32 @@ SELECT COUNT(*) FROM config_schemas;
 SELECT COUNT(*) FROM configs
 WHERE schema_id = $1;
 
+
+-- name: CreateConfigSchemaReference :exec
+INSERT INTO config_schema_references (
+    schema_id,
+    version,
+    referenced_schema_id,
+    referenced_name,
+    referenced_version
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT DO NOTHING;
+
+-- name: ListConfigSchemaVersionReferences :many
+SELECT r.referenced_name, r.referenced_schema_id, r.referenced_version, v.schema_content
+FROM config_schema_references r
+JOIN config_schema_versions v
+    ON v.schema_id = r.referenced_schema_id
+    AND v.version = r.referenced_version
+WHERE r.schema_id = $1 AND r.version = $2
+ORDER BY r.referenced_name;
+
+-- name: ListConfigSchemasReferencing :many
+SELECT * FROM config_schemas
+WHERE id IN (
+    SELECT DISTINCT schema_id FROM config_schema_references
+    WHERE referenced_schema_id = $1
+)
+ORDER BY name;
@@ -2,10 +2,9 @@
 INSERT INTO projects (
     id,
     name,
-    api_key,
     owner_user_id
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3
 )
 RETURNING *;
 
@@ -14,11 +13,6 @@ SELECT * FROM projects
 WHERE id = $1
 LIMIT 1;
 
--- name: GetProjectByAPIKey :one
-SELECT * FROM projects
-WHERE api_key = $1
-LIMIT 1;
-
 -- name: ListProjects :many
 SELECT * FROM projects
 ORDER BY created_at DESC;
@@ -32,7 +26,6 @@ ORDER BY created_at DESC;
 UPDATE projects
 SET
     name = COALESCE(sqlc.narg('name'), name),
-    api_key = COALESCE(sqlc.narg('api_key'), api_key),
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
@@ -46,11 +39,6 @@ SELECT EXISTS(
     SELECT 1 FROM projects WHERE id = $1
 ) AS exists;
 
--- name: ProjectExistsByAPIKey :one
-SELECT EXISTS(
-    SELECT 1 FROM projects WHERE api_key = $1
-) AS exists;
-
 -- name: ProjectExistsByName :one
 SELECT EXISTS(
     SELECT 1 FROM projects WHERE name = $1
@@ -0,0 +1,24 @@
+package authn
+
+import "strings"
+
+const (
+	// APIKeyHeader is the dedicated header (gRPC metadata key) for project API keys
+	APIKeyHeader = "X-API-Key"
+
+	// APIKeyScheme is the Authorization scheme for project API keys
+	APIKeyScheme = "ApiKey"
+
+	// BearerScheme is the Authorization scheme for JWT access tokens
+	BearerScheme = "Bearer"
+)
+
+// SplitAuthorization splits an Authorization value into its scheme and
+// credential. ok is false when the value has no scheme.
+func SplitAuthorization(value string) (scheme, credential string, ok bool) {
+	scheme, credential, ok = strings.Cut(value, " ")
+	if !ok {
+		return "", "", false
+	}
+	return scheme, strings.TrimSpace(credential), true
+}
@@ -0,0 +1,36 @@
+package authn
+
+import "context"
+
+// contextKey is a custom type for context keys to avoid collisions
+type contextKey string
+
+const (
+	// UserIDKey is the context key for user ID
+	UserIDKey contextKey = "user_id"
+	
+	// UserEmailKey is the context key for user email
+	UserEmailKey contextKey = "user_email"
+)
+
+// WithUser returns a context carrying the authenticated user's ID and email
+func WithUser(ctx context.Context, userID, email string) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, userID)
+	return context.WithValue(ctx, UserEmailKey, email)
+}
+
+// GetUserID retrieves the user ID from context
+func GetUserID(ctx context.Context) string {
+	if userID, ok := ctx.Value(UserIDKey).(string); ok {
+		return userID
+	}
+	return ""
+}
+
+// GetUserEmail retrieves the user email from context
+func GetUserEmail(ctx context.Context) string {
+	if email, ok := ctx.Value(UserEmailKey).(string); ok {
+		return email
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+package authn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserContext(t *testing.T) {
+	t.Run("GetUserID returns empty for missing context value", func(t *testing.T) {
+		ctx := context.Background()
+		userID := GetUserID(ctx)
+		assert.Empty(t, userID)
+	})
+
+	t.Run("GetUserEmail returns empty for missing context value", func(t *testing.T) {
+		ctx := context.Background()
+		email := GetUserEmail(ctx)
+		assert.Empty(t, email)
+	})
+
+	t.Run("GetUserID returns value from context", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		userID := GetUserID(ctx)
+		assert.Equal(t, "user123", userID)
+	})
+
+	t.Run("GetUserEmail returns value from context", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), UserEmailKey, "user@example.com")
+		email := GetUserEmail(ctx)
+		assert.Equal(t, "user@example.com", email)
+	})
+}
@@ -0,0 +1,180 @@
+package authn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateToken(t *testing.T) {
+	secret := "test-secret"
+	userID := "user123"
+	email := "user@example.com"
+
+	t.Run("generates valid access token", func(t *testing.T) {
+		// Act
+		token, err := GenerateToken(userID, email, secret, 1*time.Hour)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+
+		// Verify token is valid
+		claims := &Claims{}
+		parsedToken, parseErr := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte(secret), nil
+		})
+		require.NoError(t, parseErr)
+		assert.True(t, parsedToken.Valid)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, email, claims.Email)
+		assert.Equal(t, "access", claims.Type)
+		assert.Equal(t, "cfguardian", claims.Issuer)
+	})
+
+	t.Run("sets correct expiration", func(t *testing.T) {
+		// Act
+		token, err := GenerateToken(userID, email, secret, 30*time.Minute)
+		require.NoError(t, err)
+
+		// Verify expiration
+		claims := &Claims{}
+		_, parseErr := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte(secret), nil
+		})
+		require.NoError(t, parseErr)
+
+		// Check expiration is approximately 30 minutes in the future
+		expectedExpiry := time.Now().Add(30 * time.Minute)
+		actualExpiry := claims.ExpiresAt.Time
+		assert.WithinDuration(t, expectedExpiry, actualExpiry, 5*time.Second)
+	})
+
+	t.Run("generates unique tokens", func(t *testing.T) {
+		// Act
+		token1, err1 := GenerateToken(userID, email, secret, 1*time.Hour)
+		time.Sleep(1100 * time.Millisecond) // Ensure different IssuedAt (JWT timestamps are in seconds, not ms)
+		token2, err2 := GenerateToken(userID, email, secret, 1*time.Hour)
+
+		// Assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.NotEqual(t, token1, token2, "Tokens should be unique due to different IssuedAt")
+	})
+}
+
+func TestGenerateRefreshToken(t *testing.T) {
+	secret := "test-secret"
+	userID := "user123"
+	email := "user@example.com"
+
+	t.Run("generates valid refresh token", func(t *testing.T) {
+		// Act
+		token, err := GenerateRefreshToken(userID, email, secret, 7*24*time.Hour)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+
+		// Verify token is valid
+		claims := &Claims{}
+		parsedToken, parseErr := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte(secret), nil
+		})
+		require.NoError(t, parseErr)
+		assert.True(t, parsedToken.Valid)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, email, claims.Email)
+		assert.Equal(t, "refresh", claims.Type)
+	})
+
+	t.Run("sets longer expiration", func(t *testing.T) {
+		// Act
+		token, err := GenerateRefreshToken(userID, email, secret, 7*24*time.Hour)
+		require.NoError(t, err)
+
+		// Verify expiration is approximately 7 days
+		claims := &Claims{}
+		_, parseErr := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte(secret), nil
+		})
+		require.NoError(t, parseErr)
+
+		expectedExpiry := time.Now().Add(7 * 24 * time.Hour)
+		actualExpiry := claims.ExpiresAt.Time
+		assert.WithinDuration(t, expectedExpiry, actualExpiry, 5*time.Second)
+	})
+}
+
+func TestValidateRefreshToken(t *testing.T) {
+	secret := "test-secret"
+	userID := "user123"
+	email := "user@example.com"
+
+	t.Run("validates valid refresh token", func(t *testing.T) {
+		// Arrange
+		token, err := GenerateRefreshToken(userID, email, secret, 7*24*time.Hour)
+		require.NoError(t, err)
+
+		// Act
+		claims, validateErr := ValidateRefreshToken(token, secret)
+
+		// Assert
+		require.NoError(t, validateErr)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, email, claims.Email)
+		assert.Equal(t, "refresh", claims.Type)
+	})
+
+	t.Run("rejects access token", func(t *testing.T) {
+		// Arrange
+		token, err := GenerateToken(userID, email, secret, 1*time.Hour)
+		require.NoError(t, err)
+
+		// Act
+		claims, validateErr := ValidateRefreshToken(token, secret)
+
+		// Assert
+		assert.Error(t, validateErr)
+		assert.Nil(t, claims)
+		assert.Contains(t, validateErr.Error(), "not a refresh token")
+	})
+
+	t.Run("rejects expired refresh token", func(t *testing.T) {
+		// Arrange
+		token, err := GenerateRefreshToken(userID, email, secret, -1*time.Hour)
+		require.NoError(t, err)
+
+		// Act
+		claims, validateErr := ValidateRefreshToken(token, secret)
+
+		// Assert
+		assert.Error(t, validateErr)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("rejects token with wrong secret", func(t *testing.T) {
+		// Arrange
+		token, err := GenerateRefreshToken(userID, email, secret, 7*24*time.Hour)
+		require.NoError(t, err)
+
+		// Act
+		claims, validateErr := ValidateRefreshToken(token, "wrong-secret")
+
+		// Assert
+		assert.Error(t, validateErr)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("rejects malformed token", func(t *testing.T) {
+		// Act
+		claims, err := ValidateRefreshToken("not.a.valid.token", secret)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, claims)
+	})
+}
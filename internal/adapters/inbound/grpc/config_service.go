@@ -0,0 +1,245 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+
+	cfguardianv1 "github.com/vlone310/cfguardian/api/proto/cfguardian/v1"
+	"github.com/vlone310/cfguardian/internal/domain/events"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+	"github.com/vlone310/cfguardian/internal/usecases/role"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PermissionChecker checks a user's role in a project
+type PermissionChecker interface {
+	Execute(ctx context.Context, req role.CheckPermissionRequest) (*role.CheckPermissionResponse, error)
+}
+
+// ConfigService implements the cfguardian.v1.ConfigService gRPC API
+type ConfigService struct {
+	cfguardianv1.UnimplementedConfigServiceServer
+
+	getUseCase      *config.GetConfigUseCase
+	streamUseCase   *config.StreamConfigChangesUseCase
+	checkPermission PermissionChecker
+}
+
+// NewConfigService creates a new ConfigService
+func NewConfigService(
+	getUseCase *config.GetConfigUseCase,
+	streamUseCase *config.StreamConfigChangesUseCase,
+	checkPermission PermissionChecker,
+) *ConfigService {
+	return &ConfigService{
+		getUseCase:      getUseCase,
+		streamUseCase:   streamUseCase,
+		checkPermission: checkPermission,
+	}
+}
+
+// GetConfig returns a single config
+func (s *ConfigService) GetConfig(ctx context.Context, req *cfguardianv1.GetConfigRequest) (*cfguardianv1.GetConfigResponse, error) {
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	projectID, err := s.resolveProject(ctx, req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Configs outside an API key's scope are indistinguishable from missing ones
+	if !getPrincipal(ctx).Scope.AllowsConfigKey(req.GetKey()) {
+		return nil, status.Error(codes.NotFound, "config not found")
+	}
+
+	resp, err := s.getUseCase.Execute(ctx, config.GetConfigRequest{
+		ProjectID: projectID,
+		Key:       req.GetKey(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "config not found")
+	}
+
+	return &cfguardianv1.GetConfigResponse{
+		Config: &cfguardianv1.Config{
+			Key:     resp.Key,
+			Version: resp.Version,
+			Content: resp.Content,
+		},
+	}, nil
+}
+
+// BatchGetConfigs returns several configs in one call
+func (s *ConfigService) BatchGetConfigs(ctx context.Context, req *cfguardianv1.BatchGetConfigsRequest) (*cfguardianv1.BatchGetConfigsResponse, error) {
+	if len(req.GetKeys()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one key is required")
+	}
+	if len(req.GetKeys()) > config.MaxBatchKeys {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d keys may be requested", config.MaxBatchKeys)
+	}
+
+	projectID, err := s.resolveProject(ctx, req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+
+	scope := getPrincipal(ctx).Scope
+	result := &cfguardianv1.BatchGetConfigsResponse{}
+	for _, key := range req.GetKeys() {
+		if !scope.AllowsConfigKey(key) {
+			result.MissingKeys = append(result.MissingKeys, key)
+			continue
+		}
+		resp, err := s.getUseCase.Execute(ctx, config.GetConfigRequest{
+			ProjectID: projectID,
+			Key:       key,
+		})
+		if err != nil {
+			result.MissingKeys = append(result.MissingKeys, key)
+			continue
+		}
+		result.Configs = append(result.Configs, &cfguardianv1.Config{
+			Key:     resp.Key,
+			Version: resp.Version,
+			Content: resp.Content,
+		})
+	}
+
+	return result, nil
+}
+
+// Watch streams config changes for a project until the client disconnects
+func (s *ConfigService) Watch(req *cfguardianv1.WatchRequest, stream cfguardianv1.ConfigService_WatchServer) error {
+	ctx := stream.Context()
+
+	projectID, err := s.resolveProject(ctx, req.GetProjectId())
+	if err != nil {
+		return err
+	}
+
+	sub, err := s.streamUseCase.Execute(ctx, config.StreamConfigChangesRequest{
+		ProjectID:   projectID,
+		LastEventID: req.GetLastEventId(),
+	})
+	if err != nil {
+		return status.Error(codes.NotFound, "project not found")
+	}
+	defer sub.Close()
+
+	if sub.Gap() {
+		if err := stream.Send(&cfguardianv1.ConfigEvent{Type: cfguardianv1.ConfigEventType_CONFIG_EVENT_TYPE_RESYNC}); err != nil {
+			return err
+		}
+	}
+
+	scope := getPrincipal(ctx).Scope
+	keys := make(map[string]bool, len(req.GetKeys()))
+	for _, key := range req.GetKeys() {
+		keys[key] = true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-sub.Dropped():
+			return status.Error(codes.ResourceExhausted, "consumer too slow; reconnect with last_event_id")
+
+		case event := <-sub.Events():
+			if !scope.AllowsConfigKey(event.ConfigKey) {
+				continue
+			}
+			msg, err := toConfigEvent(event)
+			if err != nil {
+				return status.Error(codes.Internal, "failed to decode event")
+			}
+			if len(keys) > 0 && !keys[msg.Key] {
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resolveProject returns the project a request operates on.
+// API key callers are bound to their project; JWT callers must name one
+// and hold at least the viewer role in it.
+func (s *ConfigService) resolveProject(ctx context.Context, requestedProjectID string) (string, error) {
+	p := getPrincipal(ctx)
+	if p == nil {
+		return "", status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	if p.ProjectID != "" {
+		if requestedProjectID != "" && requestedProjectID != p.ProjectID {
+			return "", status.Error(codes.PermissionDenied, "API key does not grant access to this project")
+		}
+		return p.ProjectID, nil
+	}
+
+	if requestedProjectID == "" {
+		return "", status.Error(codes.InvalidArgument, "project_id is required")
+	}
+
+	resp, err := s.checkPermission.Execute(ctx, role.CheckPermissionRequest{
+		UserID:            p.UserID,
+		ProjectID:         requestedProjectID,
+		RequiredRoleLevel: "viewer",
+	})
+	if err != nil || !resp.Allowed {
+		return "", status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	return requestedProjectID, nil
+}
+
+// eventPayload holds the fields shared by the config domain events
+type eventPayload struct {
+	ConfigKey   string          `json:"config_key"`
+	Version     int64           `json:"version"`
+	NewVersion  int64           `json:"new_version"`
+	LastVersion int64           `json:"last_version"`
+	Content     json.RawMessage `json:"content"`
+}
+
+// toConfigEvent converts a published domain event into its gRPC message
+func toConfigEvent(event *outbound.StreamEvent) (*cfguardianv1.ConfigEvent, error) {
+	var payload eventPayload
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		return nil, err
+	}
+
+	msg := &cfguardianv1.ConfigEvent{
+		Key:        payload.ConfigKey,
+		Content:    payload.Content,
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+	if event.Last {
+		msg.Id = event.ID
+	}
+
+	switch event.Type {
+	case events.EventTypeConfigCreated:
+		msg.Type = cfguardianv1.ConfigEventType_CONFIG_EVENT_TYPE_CREATED
+		msg.Version = payload.Version
+	case events.EventTypeConfigUpdated:
+		msg.Type = cfguardianv1.ConfigEventType_CONFIG_EVENT_TYPE_UPDATED
+		msg.Version = payload.NewVersion
+	case events.EventTypeConfigRolledBack:
+		msg.Type = cfguardianv1.ConfigEventType_CONFIG_EVENT_TYPE_ROLLED_BACK
+		msg.Version = payload.NewVersion
+	case events.EventTypeConfigDeleted:
+		msg.Type = cfguardianv1.ConfigEventType_CONFIG_EVENT_TYPE_DELETED
+		msg.Version = payload.LastVersion
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,228 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/infrastructure/telemetry"
+	"github.com/vlone310/cfguardian/internal/usecases/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type principalKey struct{}
+
+// principal is the authenticated caller of an RPC.
+// API key callers are bound to a project; JWT callers are users.
+type principal struct {
+	ProjectID string
+	UserID    string
+	Scope     valueobjects.APIKeyScope // Configs an API key may read; unrestricted for users
+}
+
+// getPrincipal retrieves the authenticated caller from context
+func getPrincipal(ctx context.Context) *principal {
+	if p, ok := ctx.Value(principalKey{}).(*principal); ok {
+		return p
+	}
+	return nil
+}
+
+// authenticator resolves API keys and JWTs from request metadata
+type authenticator struct {
+	jwtSecret      string
+	validateAPIKey *auth.ValidateAPIKeyUseCase
+}
+
+// isPublicMethod reports whether a method is served without authentication
+func isPublicMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") ||
+		strings.HasPrefix(fullMethod, "/grpc.reflection.")
+}
+
+// authenticate resolves the caller from metadata:
+// "authorization: Bearer <jwt>", "authorization: ApiKey <key>" or "x-api-key: <key>"
+func (a *authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var scheme, credential string
+	if values := md.Get("authorization"); len(values) > 0 {
+		var ok bool
+		if scheme, credential, ok = authn.SplitAuthorization(values[0]); !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+		}
+	} else if values := md.Get(authn.APIKeyHeader); len(values) > 0 {
+		scheme, credential = authn.APIKeyScheme, values[0]
+	} else {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	switch {
+	case strings.EqualFold(scheme, authn.BearerScheme):
+		claims, err := authn.ParseAccessToken(credential, a.jwtSecret)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+		ctx = authn.WithUser(ctx, claims.UserID, claims.Email)
+		return context.WithValue(ctx, principalKey{}, &principal{UserID: claims.UserID}), nil
+
+	case strings.EqualFold(scheme, authn.APIKeyScheme):
+		if a.validateAPIKey == nil {
+			return nil, status.Error(codes.Unauthenticated, "API key authentication is not enabled")
+		}
+		apiKey, err := a.validateAPIKey.Authenticate(ctx, credential)
+		if err != nil {
+			if !apperrors.HasCode(err, apperrors.ErrCodeInvalidAPIKey) {
+				return nil, status.Error(codes.Unavailable, "failed to verify API key")
+			}
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+		return context.WithValue(ctx, principalKey{}, &principal{ProjectID: apiKey.ProjectID, Scope: apiKey.Scope}), nil
+
+	default:
+		return nil, status.Error(codes.Unauthenticated, "unsupported authorization scheme")
+	}
+}
+
+func (a *authenticator) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if isPublicMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isPublicMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	ctx, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// contextStream overrides the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// RateLimiter limits calls per client. The HTTP server's limiter is passed in
+// so that both transports share one budget per client.
+type RateLimiter interface {
+	Allow(remoteAddr string) bool
+}
+
+// rateLimitUnary applies the shared per-client rate limiter to unary calls
+func rateLimitUnary(limiter RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if limiter != nil && !limiter.Allow(peerAddr(ctx)) {
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitStream applies the shared per-client rate limiter to stream opens
+func rateLimitStream(limiter RateLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if limiter != nil && !limiter.Allow(peerAddr(ss.Context())) {
+			return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		return handler(srv, ss)
+	}
+}
+
+// peerAddr returns the address of the calling peer
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// metricsUnary records request counts and durations for unary calls
+func metricsUnary(metrics *telemetry.PrometheusMetrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if metrics == nil {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		metrics.GRPCRequestsTotal.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		metrics.GRPCRequestDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+
+		return resp, err
+	}
+}
+
+// metricsStream tracks open streams and records their final status
+func metricsStream(metrics *telemetry.PrometheusMetrics) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if metrics == nil {
+			return handler(srv, ss)
+		}
+
+		metrics.GRPCStreamsActive.Inc()
+		defer metrics.GRPCStreamsActive.Dec()
+
+		err := handler(srv, ss)
+		metrics.GRPCRequestsTotal.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+		return err
+	}
+}
+
+// recoveryUnary converts panics in unary handlers into Internal errors
+func recoveryUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logPanic(info.FullMethod, r)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// recoveryStream converts panics in stream handlers into Internal errors
+func recoveryStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logPanic(info.FullMethod, r)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func logPanic(method string, r interface{}) {
+	slog.Error("panic recovered",
+		slog.String("grpc_method", method),
+		slog.Any("error", r),
+		slog.String("stack", string(debug.Stack())),
+	)
+}
@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"time"
+
+	cfguardianv1 "github.com/vlone310/cfguardian/api/proto/cfguardian/v1"
+	"github.com/vlone310/cfguardian/internal/infrastructure/telemetry"
+	"github.com/vlone310/cfguardian/internal/usecases/auth"
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+// ServerConfig holds gRPC server configuration
+type ServerConfig struct {
+	JWTSecret             string
+	Reflection            bool
+	RateLimiter           RateLimiter
+	PrometheusMetrics     *telemetry.PrometheusMetrics
+	CheckPermission       PermissionChecker
+	ValidateAPIKeyUseCase *auth.ValidateAPIKeyUseCase
+	GetConfigUseCase      *config.GetConfigUseCase
+	StreamUseCase         *config.StreamConfigChangesUseCase
+}
+
+// NewServer creates a gRPC server with the config, health and (optionally)
+// reflection services registered. Auth, rate limiting and metrics share
+// their implementation and state with the HTTP adapter.
+func NewServer(cfg ServerConfig) *grpc.Server {
+	authn := &authenticator{
+		jwtSecret:      cfg.JWTSecret,
+		validateAPIKey: cfg.ValidateAPIKeyUseCase,
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			recoveryUnary,
+			metricsUnary(cfg.PrometheusMetrics),
+			rateLimitUnary(cfg.RateLimiter),
+			authn.unary,
+		),
+		grpc.ChainStreamInterceptor(
+			recoveryStream,
+			metricsStream(cfg.PrometheusMetrics),
+			rateLimitStream(cfg.RateLimiter),
+			authn.stream,
+		),
+		// Keep idle Watch streams alive through proxies
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+	)
+
+	cfguardianv1.RegisterConfigServiceServer(server, NewConfigService(
+		cfg.GetConfigUseCase,
+		cfg.StreamUseCase,
+		cfg.CheckPermission,
+	))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(cfguardianv1.ConfigService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	if cfg.Reflection {
+		reflection.Register(server)
+	}
+
+	return server
+}
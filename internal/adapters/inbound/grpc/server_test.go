@@ -0,0 +1,335 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	cfguardianv1 "github.com/vlone310/cfguardian/api/proto/cfguardian/v1"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/middleware"
+	"github.com/vlone310/cfguardian/internal/adapters/outbound/eventbus"
+	"github.com/vlone310/cfguardian/internal/domain/events"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+	"github.com/vlone310/cfguardian/internal/usecases/auth"
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+	"github.com/vlone310/cfguardian/internal/usecases/role"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testAPIKey    = "cfg_abcdefghijklmnopqrstuvwxyz012345"
+	testJWTSecret = "test-secret-key-that-is-long-enough"
+	testPepper    = "test-api-key-pepper"
+)
+
+// MockProjectRepository mocks the project lookups used by the gRPC adapter
+type MockProjectRepository struct {
+	mock.Mock
+	outbound.ProjectRepository
+}
+
+func (m *MockProjectRepository) Exists(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+// MockAPIKeyRepository mocks the API key lookups used for authentication
+type MockAPIKeyRepository struct {
+	mock.Mock
+	outbound.APIKeyRepository
+}
+
+func (m *MockAPIKeyRepository) ListByLookupPrefix(ctx context.Context, lookupPrefix string) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx, lookupPrefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	return nil
+}
+
+// MockConfigRepository mocks the config reads used by the gRPC adapter
+type MockConfigRepository struct {
+	mock.Mock
+	outbound.ConfigRepository
+}
+
+func (m *MockConfigRepository) Get(ctx context.Context, projectID, key string) (*outbound.Config, error) {
+	args := m.Called(ctx, projectID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.Config), args.Error(1)
+}
+
+// MockPermissionChecker mocks project role checks
+type MockPermissionChecker struct {
+	mock.Mock
+}
+
+func (m *MockPermissionChecker) Execute(ctx context.Context, req role.CheckPermissionRequest) (*role.CheckPermissionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*role.CheckPermissionResponse), args.Error(1)
+}
+
+type testEnv struct {
+	conn        *grpc.ClientConn
+	apiKeyRepo  *MockAPIKeyRepository
+	projectRepo *MockProjectRepository
+	configRepo  *MockConfigRepository
+	permissions *MockPermissionChecker
+	broker      *eventbus.Broker
+}
+
+func setupServer(t *testing.T, limiter RateLimiter) *testEnv {
+	env := &testEnv{
+		apiKeyRepo:  new(MockAPIKeyRepository),
+		projectRepo: new(MockProjectRepository),
+		configRepo:  new(MockConfigRepository),
+		permissions: new(MockPermissionChecker),
+		broker:      eventbus.NewBroker(eventbus.BrokerConfig{}),
+	}
+
+	server := NewServer(ServerConfig{
+		JWTSecret:             testJWTSecret,
+		Reflection:            true,
+		RateLimiter:           limiter,
+		CheckPermission:       env.permissions,
+		ValidateAPIKeyUseCase: auth.NewValidateAPIKeyUseCase(env.apiKeyRepo, env.projectRepo, services.NewAPIKeyHasher(testPepper)),
+		GetConfigUseCase:      config.NewGetConfigUseCase(env.configRepo, nil, nil),
+		StreamUseCase:         config.NewStreamConfigChangesUseCase(env.projectRepo, env.configRepo, env.broker),
+	})
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	env.conn = conn
+	return env
+}
+
+// storedTestKey returns the stored (hashed) form of testAPIKey as a key of project-1
+func storedTestKey(t *testing.T) *outbound.APIKey {
+	hashed, err := services.NewAPIKeyHasher(testPepper).Hash(valueobjects.MustNewAPIKey(testAPIKey))
+	require.NoError(t, err)
+
+	return &outbound.APIKey{
+		ID:           "key-1",
+		ProjectID:    "project-1",
+		LookupPrefix: hashed.LookupPrefix(),
+		KeyHash:      hashed.Hash(),
+		KeySalt:      hashed.Salt(),
+		KeyHint:      hashed.Hint(),
+		HashVersion:  hashed.Version(),
+	}
+}
+
+// allowAPIKey makes testAPIKey a key of project-1 limited to the given config key prefixes
+func (env *testEnv) allowAPIKey(t *testing.T, keyPrefixes ...string) {
+	stored := storedTestKey(t)
+	stored.KeyPrefixes = keyPrefixes
+	env.apiKeyRepo.On("ListByLookupPrefix", mock.Anything, stored.LookupPrefix).Return([]*outbound.APIKey{stored}, nil)
+}
+
+func withAPIKey(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "x-api-key", testAPIKey)
+}
+
+func TestConfigService_GetConfig(t *testing.T) {
+	t.Run("returns config for a valid API key", func(t *testing.T) {
+		// Arrange
+		env := setupServer(t, nil)
+		env.allowAPIKey(t)
+		env.configRepo.On("Get", mock.Anything, "project-1", "app").Return(&outbound.Config{
+			ProjectID: "project-1",
+			Key:       "app",
+			Version:   3,
+			Content:   []byte(`{"debug":true}`),
+		}, nil)
+		client := cfguardianv1.NewConfigServiceClient(env.conn)
+
+		// Act
+		resp, err := client.GetConfig(withAPIKey(context.Background()), &cfguardianv1.GetConfigRequest{Key: "app"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "app", resp.GetConfig().GetKey())
+		assert.Equal(t, int64(3), resp.GetConfig().GetVersion())
+		assert.JSONEq(t, `{"debug":true}`, string(resp.GetConfig().GetContent()))
+	})
+
+	t.Run("hides configs outside the API key's scope", func(t *testing.T) {
+		env := setupServer(t, nil)
+		env.allowAPIKey(t, "mobile.")
+		client := cfguardianv1.NewConfigServiceClient(env.conn)
+
+		_, err := client.GetConfig(withAPIKey(context.Background()), &cfguardianv1.GetConfigRequest{Key: "app"})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		env.configRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects revoked API keys", func(t *testing.T) {
+		env := setupServer(t, nil)
+		revokedAt := time.Now().Add(-time.Minute)
+		stored := storedTestKey(t)
+		stored.RevokedAt = &revokedAt
+		env.apiKeyRepo.On("ListByLookupPrefix", mock.Anything, stored.LookupPrefix).Return([]*outbound.APIKey{stored}, nil)
+		client := cfguardianv1.NewConfigServiceClient(env.conn)
+
+		_, err := client.GetConfig(withAPIKey(context.Background()), &cfguardianv1.GetConfigRequest{Key: "app"})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("rejects calls without credentials", func(t *testing.T) {
+		env := setupServer(t, nil)
+		client := cfguardianv1.NewConfigServiceClient(env.conn)
+
+		_, err := client.GetConfig(context.Background(), &cfguardianv1.GetConfigRequest{Key: "app"})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("requires project_id for JWT callers", func(t *testing.T) {
+		env := setupServer(t, nil)
+		token, err := authn.GenerateToken("user-1", "user@example.com", testJWTSecret, time.Hour)
+		require.NoError(t, err)
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+		client := cfguardianv1.NewConfigServiceClient(env.conn)
+
+		_, err = client.GetConfig(ctx, &cfguardianv1.GetConfigRequest{Key: "app"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("checks project role for JWT callers", func(t *testing.T) {
+		env := setupServer(t, nil)
+		token, err := authn.GenerateToken("user-1", "user@example.com", testJWTSecret, time.Hour)
+		require.NoError(t, err)
+		env.permissions.On("Execute", mock.Anything, role.CheckPermissionRequest{
+			UserID:            "user-1",
+			ProjectID:         "project-2",
+			RequiredRoleLevel: "viewer",
+		}).Return(&role.CheckPermissionResponse{Allowed: false}, nil)
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+		client := cfguardianv1.NewConfigServiceClient(env.conn)
+
+		_, err = client.GetConfig(ctx, &cfguardianv1.GetConfigRequest{ProjectId: "project-2", Key: "app"})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("applies the shared rate limiter", func(t *testing.T) {
+		env := setupServer(t, middleware.NewRateLimiter(1, 1))
+		env.allowAPIKey(t)
+		env.configRepo.On("Get", mock.Anything, "project-1", "app").Return(&outbound.Config{Key: "app", Version: 1}, nil)
+		client := cfguardianv1.NewConfigServiceClient(env.conn)
+
+		_, err := client.GetConfig(withAPIKey(context.Background()), &cfguardianv1.GetConfigRequest{Key: "app"})
+		require.NoError(t, err)
+		_, err = client.GetConfig(withAPIKey(context.Background()), &cfguardianv1.GetConfigRequest{Key: "app"})
+
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+}
+
+func TestConfigService_BatchGetConfigs(t *testing.T) {
+	t.Run("returns found configs and missing keys", func(t *testing.T) {
+		// Arrange
+		env := setupServer(t, nil)
+		env.allowAPIKey(t)
+		env.configRepo.On("Get", mock.Anything, "project-1", "app").Return(&outbound.Config{Key: "app", Version: 1, Content: []byte(`{}`)}, nil)
+		env.configRepo.On("Get", mock.Anything, "project-1", "missing").Return(nil, errors.New("config not found"))
+		client := cfguardianv1.NewConfigServiceClient(env.conn)
+
+		// Act
+		resp, err := client.BatchGetConfigs(withAPIKey(context.Background()), &cfguardianv1.BatchGetConfigsRequest{
+			Keys: []string{"app", "missing"},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, resp.GetConfigs(), 1)
+		assert.Equal(t, "app", resp.GetConfigs()[0].GetKey())
+		assert.Equal(t, []string{"missing"}, resp.GetMissingKeys())
+	})
+}
+
+func TestConfigService_Watch(t *testing.T) {
+	t.Run("streams events for watched keys", func(t *testing.T) {
+		// Arrange
+		env := setupServer(t, nil)
+		env.allowAPIKey(t)
+		env.projectRepo.On("Exists", mock.Anything, "project-1").Return(true, nil)
+		client := cfguardianv1.NewConfigServiceClient(env.conn)
+		ctx, cancel := context.WithTimeout(withAPIKey(context.Background()), 5*time.Second)
+		defer cancel()
+
+		publisher := env.broker
+		require.NoError(t, publisher.Publish(ctx, 1, events.NewConfigCreated("e1", "project-1", "app", "schema-1", valueobjects.MustNewVersion(1), []byte(`{}`), "user-1")))
+		require.NoError(t, publisher.Publish(ctx, 2, events.NewConfigCreated("e2", "project-1", "other", "schema-1", valueobjects.MustNewVersion(1), []byte(`{}`), "user-1")))
+		require.NoError(t, publisher.Publish(ctx, 3, events.NewConfigUpdated("e3", "project-1", "app", "schema-1", valueobjects.MustNewVersion(1), valueobjects.MustNewVersion(2), []byte(`{"a":1}`), "user-1")))
+
+		// Act (resume after the first event; the live publish may land before or after subscribing)
+		stream, err := client.Watch(ctx, &cfguardianv1.WatchRequest{Keys: []string{"app"}, LastEventId: 1})
+		require.NoError(t, err)
+		require.NoError(t, publisher.Publish(ctx, 4, events.NewConfigDeleted("e4", "project-1", "app", valueobjects.MustNewVersion(2), "user-1")))
+
+		// Assert
+		updated, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, cfguardianv1.ConfigEventType_CONFIG_EVENT_TYPE_UPDATED, updated.GetType())
+		assert.Equal(t, uint64(3), updated.GetId())
+		assert.Equal(t, int64(2), updated.GetVersion())
+		assert.JSONEq(t, `{"a":1}`, string(updated.GetContent()))
+
+		deleted, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, cfguardianv1.ConfigEventType_CONFIG_EVENT_TYPE_DELETED, deleted.GetType())
+		assert.Equal(t, "app", deleted.GetKey())
+		assert.Equal(t, uint64(4), deleted.GetId())
+	})
+}
+
+func TestServer_Health(t *testing.T) {
+	t.Run("serves health checks without credentials", func(t *testing.T) {
+		env := setupServer(t, nil)
+
+		resp, err := healthpb.NewHealthClient(env.conn).Check(context.Background(), &healthpb.HealthCheckRequest{
+			Service: cfguardianv1.ConfigService_ServiceDesc.ServiceName,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	})
+}
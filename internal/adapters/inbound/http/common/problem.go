@@ -0,0 +1,126 @@
+package common
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+)
+
+// ProblemContentType is the media type of error responses (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix namespaces problem types; the code is appended in kebab case
+const problemTypePrefix = "urn:cfguardian:problem:"
+
+// Problem is an RFC 7807 problem details response.
+// Extensions are written as top-level members next to the standard ones.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Code       string
+	RequestID  string
+	Extensions map[string]interface{}
+}
+
+// NewProblem creates a problem for a status and error code
+func NewProblem(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   ProblemType(code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// ProblemType returns the problem type URI for an error code,
+// e.g. CONFIG_NOT_FOUND becomes urn:cfguardian:problem:config-not-found
+func ProblemType(code string) string {
+	return problemTypePrefix + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
+
+// MarshalJSON writes the standard members followed by the extensions
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+6)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+	members["code"] = p.Code
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.RequestID != "" {
+		members["request_id"] = p.RequestID
+	}
+	return json.Marshal(members)
+}
+
+// RespondProblem writes a problem response. The request ID is taken from
+// the X-Request-ID response header when the problem does not carry one.
+func RespondProblem(w http.ResponseWriter, problem *Problem) {
+	if problem.RequestID == "" {
+		problem.RequestID = w.Header().Get("X-Request-ID")
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		slog.Error("failed to encode problem response", slog.Any("error", err))
+	}
+}
+
+// RespondAppError writes the problem response for an error returned by a
+// use case. Typed errors carry their status; anything else is a 500 whose
+// cause is logged rather than returned.
+func RespondAppError(w http.ResponseWriter, err error) {
+	RespondProblem(w, ProblemFromError(err))
+}
+
+// ProblemFromError maps an error to a problem
+func ProblemFromError(err error) *Problem {
+	if conflict, ok := services.AsVersionConflict(err); ok {
+		problem := NewProblem(http.StatusConflict, string(apperrors.ErrCodeConfigVersionMismatch), conflict.Error())
+		problem.Extensions = map[string]interface{}{
+			"key":              conflict.Key,
+			"expected_version": conflict.Expected.Value(),
+			"current_version":  conflict.Current.Value(),
+		}
+		return problem
+	}
+
+	if validationErr, ok := services.AsContentValidationError(err); ok {
+		problem := NewProblem(http.StatusBadRequest, "VALIDATION_FAILED", validationErr.Error())
+		problem.Extensions = map[string]interface{}{"errors": validationErr.Errors}
+		return problem
+	}
+
+	if appErr, ok := apperrors.GetAppError(err); ok {
+		status := appErr.StatusCode
+		if status == 0 {
+			status = apperrors.StatusCode(appErr.Code)
+		}
+		if status >= http.StatusInternalServerError {
+			slog.Error("request failed", slog.String("code", string(appErr.Code)), slog.Any("error", err))
+		}
+
+		detail := appErr.Message
+		if appErr.Details != "" {
+			detail += ": " + appErr.Details
+		}
+		problem := NewProblem(status, string(appErr.Code), detail)
+		problem.Extensions = appErr.Extensions
+		return problem
+	}
+
+	slog.Error("request failed", slog.Any("error", err))
+	return NewProblem(http.StatusInternalServerError, string(apperrors.ErrCodeInternal), "internal server error")
+}
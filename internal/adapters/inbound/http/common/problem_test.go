@@ -0,0 +1,146 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+)
+
+func TestRespondAppError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+		wantExtra  map[string]interface{}
+	}{
+		{
+			name:       "not found",
+			err:        apperrors.Wrap(errors.New("no rows"), apperrors.ErrCodeConfigNotFound, "config not found"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "CONFIG_NOT_FOUND",
+			wantDetail: "config not found",
+		},
+		{
+			name:       "bad request",
+			err:        apperrors.BadRequest("project ID is required"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+			wantDetail: "project ID is required",
+		},
+		{
+			name: "version conflict",
+			err: fmt.Errorf("failed to update config: %w", services.VersionConflictError{
+				Expected: valueobjects.MustNewVersion(3),
+				Current:  valueobjects.MustNewVersion(5),
+				Key:      "api",
+			}),
+			wantStatus: http.StatusConflict,
+			wantCode:   "CONFIG_VERSION_MISMATCH",
+			wantExtra: map[string]interface{}{
+				"key":              "api",
+				"expected_version": float64(3),
+				"current_version":  float64(5),
+			},
+		},
+		{
+			name:       "internal error hides its cause",
+			err:        apperrors.Internal(errors.New("connection refused"), "failed to list projects"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "INTERNAL_ERROR",
+			wantDetail: "failed to list projects",
+		},
+		{
+			name:       "untyped error",
+			err:        errors.New("dial tcp: connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "INTERNAL_ERROR",
+			wantDetail: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			rec := httptest.NewRecorder()
+			rec.Header().Set("X-Request-ID", "req-1")
+
+			// Act
+			RespondAppError(rec, tt.err)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, ProblemType(tt.wantCode), body["type"])
+			assert.Equal(t, http.StatusText(tt.wantStatus), body["title"])
+			assert.Equal(t, float64(tt.wantStatus), body["status"])
+			assert.Equal(t, tt.wantCode, body["code"])
+			assert.Equal(t, "req-1", body["request_id"])
+			if tt.wantDetail != "" {
+				assert.Equal(t, tt.wantDetail, body["detail"])
+			}
+			for key, value := range tt.wantExtra {
+				assert.Equal(t, value, body[key], key)
+			}
+		})
+	}
+}
+
+func TestRespondAppError_ValidationFailed(t *testing.T) {
+	// Arrange
+	rec := httptest.NewRecorder()
+	err := fmt.Errorf("content validation failed: %w", services.ContentValidationError{
+		Errors: []services.ValidationError{
+			{Pointer: "/port", Keyword: "type", Message: "Invalid type. Expected: integer, given: string", Value: "80"},
+		},
+	})
+
+	// Act
+	RespondAppError(rec, err)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body struct {
+		Code   string                     `json:"code"`
+		Errors []services.ValidationError `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "VALIDATION_FAILED", body.Code)
+	require.Len(t, body.Errors, 1)
+	assert.Equal(t, "/port", body.Errors[0].Pointer)
+	assert.Equal(t, "type", body.Errors[0].Keyword)
+	assert.Equal(t, "80", body.Errors[0].Value)
+}
+
+func TestRespondError_IsProblem(t *testing.T) {
+	// Arrange
+	rec := httptest.NewRecorder()
+
+	// Act
+	Forbidden(rec, "Insufficient permissions")
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "urn:cfguardian:problem:forbidden",
+		"title": "Forbidden",
+		"status": 403,
+		"detail": "Insufficient permissions",
+		"code": "FORBIDDEN"
+	}`, rec.Body.String())
+}
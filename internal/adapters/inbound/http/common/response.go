@@ -5,13 +5,6 @@ import (
 	"net/http"
 )
 
-// ErrorResponse represents an API error response
-type ErrorResponse struct {
-	Error   string                 `json:"error"`
-	Code    string                 `json:"code"`
-	Details map[string]interface{} `json:"details,omitempty"`
-}
-
 // SuccessResponse represents a generic success response
 type SuccessResponse struct {
 	Data    interface{} `json:"data,omitempty"`
@@ -30,21 +23,17 @@ func RespondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	}
 }
 
-// RespondError writes an error response
+// RespondError writes a problem response with the message as its detail
 func RespondError(w http.ResponseWriter, status int, message, code string) {
-	RespondJSON(w, status, ErrorResponse{
-		Error: message,
-		Code:  code,
-	})
+	RespondProblem(w, NewProblem(status, code, message))
 }
 
-// RespondErrorWithDetails writes an error response with additional details
+// RespondErrorWithDetails writes a problem response whose extension
+// members are the given details
 func RespondErrorWithDetails(w http.ResponseWriter, status int, message, code string, details map[string]interface{}) {
-	RespondJSON(w, status, ErrorResponse{
-		Error:   message,
-		Code:    code,
-		Details: details,
-	})
+	problem := NewProblem(status, code, message)
+	problem.Extensions = details
+	RespondProblem(w, problem)
 }
 
 // Common error responses
@@ -76,7 +65,7 @@ func Conflict(w http.ResponseWriter, message string) {
 
 // InternalServerError responds with 500 Internal Server Error
 func InternalServerError(w http.ResponseWriter, message string) {
-	RespondError(w, http.StatusInternalServerError, message, "INTERNAL_SERVER_ERROR")
+	RespondError(w, http.StatusInternalServerError, message, "INTERNAL_ERROR")
 }
 
 // Created responds with 201 Created
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
+	"github.com/vlone310/cfguardian/internal/usecases/apikey"
+)
+
+// APIKeyHandler handles project API key management endpoints
+type APIKeyHandler struct {
+	createUseCase *apikey.CreateAPIKeyUseCase
+	listUseCase   *apikey.ListAPIKeysUseCase
+	getUseCase    *apikey.GetAPIKeyUseCase
+	updateUseCase *apikey.UpdateAPIKeyUseCase
+	revokeUseCase *apikey.RevokeAPIKeyUseCase
+	rotateUseCase *apikey.RotateAPIKeyUseCase
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(
+	createUseCase *apikey.CreateAPIKeyUseCase,
+	listUseCase *apikey.ListAPIKeysUseCase,
+	getUseCase *apikey.GetAPIKeyUseCase,
+	updateUseCase *apikey.UpdateAPIKeyUseCase,
+	revokeUseCase *apikey.RevokeAPIKeyUseCase,
+	rotateUseCase *apikey.RotateAPIKeyUseCase,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		createUseCase: createUseCase,
+		listUseCase:   listUseCase,
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+		revokeUseCase: revokeUseCase,
+		rotateUseCase: rotateUseCase,
+	}
+}
+
+// Create handles API key creation. The full key is only returned here.
+// POST /api/v1/projects/{projectId}/api-keys
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req apikey.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+	req.ProjectID = chi.URLParam(r, "projectId")
+	req.CreatedByUserID = authn.GetUserID(r.Context())
+
+	resp, err := h.createUseCase.Execute(r.Context(), req)
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+
+	common.Created(w, resp)
+}
+
+// List handles listing a project's API keys
+// GET /api/v1/projects/{projectId}/api-keys
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.listUseCase.Execute(r.Context(), apikey.ListAPIKeysRequest{
+		ProjectID: chi.URLParam(r, "projectId"),
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+
+	common.OK(w, resp)
+}
+
+// Get handles getting an API key by ID
+// GET /api/v1/projects/{projectId}/api-keys/{keyId}
+func (h *APIKeyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.getUseCase.Execute(r.Context(), apikey.GetAPIKeyRequest{
+		ProjectID: chi.URLParam(r, "projectId"),
+		KeyID:     chi.URLParam(r, "keyId"),
+	})
+	if err != nil {
+		common.NotFound(w, "API key not found")
+		return
+	}
+
+	common.OK(w, resp)
+}
+
+// Update handles changing an API key's name, scope or expiry
+// PATCH /api/v1/projects/{projectId}/api-keys/{keyId}
+func (h *APIKeyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req apikey.UpdateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+	req.ProjectID = chi.URLParam(r, "projectId")
+	req.KeyID = chi.URLParam(r, "keyId")
+
+	resp, err := h.updateUseCase.Execute(r.Context(), req)
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+
+	common.OK(w, resp)
+}
+
+// Revoke handles immediately disabling an API key
+// DELETE /api/v1/projects/{projectId}/api-keys/{keyId}
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.revokeUseCase.Execute(r.Context(), apikey.RevokeAPIKeyRequest{
+		ProjectID: chi.URLParam(r, "projectId"),
+		KeyID:     chi.URLParam(r, "keyId"),
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+
+	common.OK(w, resp)
+}
+
+// Rotate handles replacing an API key; the old key keeps working for a grace period
+// POST /api/v1/projects/{projectId}/api-keys/{keyId}/rotate
+func (h *APIKeyHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		GracePeriod string     `json:"grace_period"` // Go duration, e.g. "24h"
+		ExpiresAt   *time.Time `json:"expires_at"`
+	}
+	// The body is optional
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil && !errors.Is(err, io.EOF) {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	req := apikey.RotateAPIKeyRequest{
+		ProjectID:       chi.URLParam(r, "projectId"),
+		KeyID:           chi.URLParam(r, "keyId"),
+		ExpiresAt:       reqBody.ExpiresAt,
+		RotatedByUserID: authn.GetUserID(r.Context()),
+	}
+	if reqBody.GracePeriod != "" {
+		gracePeriod, err := time.ParseDuration(reqBody.GracePeriod)
+		if err != nil {
+			common.BadRequest(w, "Invalid grace_period")
+			return
+		}
+		req.GracePeriod = &gracePeriod
+	}
+
+	resp, err := h.rotateUseCase.Execute(r.Context(), req)
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+
+	common.Created(w, resp)
+}
@@ -5,8 +5,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
 	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
-	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/middleware"
 	"github.com/vlone310/cfguardian/internal/usecases/auth"
 )
 
@@ -50,7 +50,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	
 	resp, err := h.registerUseCase.Execute(r.Context(), req)
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -68,12 +68,12 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	
 	resp, err := h.loginUseCase.Execute(r.Context(), req)
 	if err != nil {
-		common.Unauthorized(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
 	// Generate access token
-	accessToken, err := middleware.GenerateToken(
+	accessToken, err := authn.GenerateToken(
 		resp.UserID,
 		resp.Email,
 		h.jwtSecret,
@@ -85,7 +85,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	// Generate refresh token
-	refreshToken, err := middleware.GenerateRefreshToken(
+	refreshToken, err := authn.GenerateRefreshToken(
 		resp.UserID,
 		resp.Email,
 		h.jwtSecret,
@@ -117,14 +117,14 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	// Validate refresh token
-	claims, err := middleware.ValidateRefreshToken(req.RefreshToken, h.jwtSecret)
+	claims, err := authn.ValidateRefreshToken(req.RefreshToken, h.jwtSecret)
 	if err != nil {
 		common.Unauthorized(w, "Invalid or expired refresh token")
 		return
 	}
 	
 	// Generate new access token
-	newAccessToken, err := middleware.GenerateToken(
+	newAccessToken, err := authn.GenerateToken(
 		claims.UserID,
 		claims.Email,
 		h.jwtSecret,
@@ -136,7 +136,7 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	// Generate new refresh token (token rotation)
-	newRefreshToken, err := middleware.GenerateRefreshToken(
+	newRefreshToken, err := authn.GenerateRefreshToken(
 		claims.UserID,
 		claims.Email,
 		h.jwtSecret,
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+)
+
+const (
+	// bundleManifestName is the archive entry listing the bundle's configs
+	bundleManifestName = "bundle.json"
+
+	// bundleConfigDir is the archive directory holding one file per config
+	bundleConfigDir = "configs/"
+)
+
+// bundleArchiveModTime is the modification time of every archive entry.
+// A fixed time keeps the archive byte-for-byte identical for the same bundle
+// hash and cursor, so its ETag can be derived from them.
+var bundleArchiveModTime = time.Unix(0, 0).UTC()
+
+// bundleManifest describes the contents of a bundle archive
+type bundleManifest struct {
+	Hash    string                 `json:"hash"`
+	Cursor  int64                  `json:"cursor"`
+	Configs []bundleManifestConfig `json:"configs"`
+}
+
+// bundleManifestConfig locates one config in a bundle archive.
+// SchemaID is only read on import, where it picks the schema of a new config.
+type bundleManifestConfig struct {
+	Key      string `json:"key"`
+	Version  int64  `json:"version"`
+	File     string `json:"file"`
+	SchemaID string `json:"schema_id,omitempty"`
+}
+
+// bundleFileName returns the archive path of a config.
+// Keys are path-escaped so that none can leave the configs directory.
+func bundleFileName(key string) string {
+	return bundleConfigDir + url.PathEscape(key) + ".json"
+}
+
+// writeBundleArchive writes a bundle as a gzip-compressed tar archive holding
+// bundle.json and configs/<key>.json for each config
+func writeBundleArchive(w io.Writer, bundle *config.ReadConfigBundleResponse) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifest := bundleManifest{
+		Hash:    bundle.Hash,
+		Cursor:  bundle.Cursor,
+		Configs: make([]bundleManifestConfig, len(bundle.Configs)),
+	}
+	for i, cfg := range bundle.Configs {
+		manifest.Configs[i] = bundleManifestConfig{
+			Key:     cfg.Key,
+			Version: cfg.Version,
+			File:    bundleFileName(cfg.Key),
+		}
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle manifest: %w", err)
+	}
+	if err := writeArchiveFile(tw, bundleManifestName, manifestJSON); err != nil {
+		return err
+	}
+
+	for i, cfg := range bundle.Configs {
+		if err := writeArchiveFile(tw, manifest.Configs[i].File, cfg.Content); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to compress bundle archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeArchiveFile adds a regular file to a tar archive
+func writeArchiveFile(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  bundleArchiveModTime,
+		Format:   tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write archive header for %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+)
+
+func testBundle() *config.ReadConfigBundleResponse {
+	return &config.ReadConfigBundleResponse{
+		Hash: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		Configs: []*config.ReadConfigByAPIKeyResponse{
+			{Key: "../etc/passwd", Version: 1, Content: json.RawMessage(`{"a":1}`)},
+			{Key: "mobile.flags", Version: 4, Content: json.RawMessage(`{"beta":true}`)},
+		},
+	}
+}
+
+// readArchive returns the files of a tar.gz archive by name
+func readArchive(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = content
+	}
+	return files
+}
+
+func TestWriteBundleArchive(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := writeBundleArchive(&buf, testBundle())
+
+	// Assert
+	require.NoError(t, err)
+	files := readArchive(t, buf.Bytes())
+	assert.Len(t, files, 3)
+	assert.JSONEq(t, `{"beta":true}`, string(files["configs/mobile.flags.json"]))
+	assert.JSONEq(t, `{"a":1}`, string(files["configs/..%2Fetc%2Fpasswd.json"]))
+
+	var manifest bundleManifest
+	require.NoError(t, json.Unmarshal(files[bundleManifestName], &manifest))
+	assert.Equal(t, testBundle().Hash, manifest.Hash)
+	assert.Equal(t, bundleManifestConfig{Key: "mobile.flags", Version: 4, File: "configs/mobile.flags.json"}, manifest.Configs[1])
+
+	t.Run("is reproducible", func(t *testing.T) {
+		var again bytes.Buffer
+		require.NoError(t, writeBundleArchive(&again, testBundle()))
+		assert.Equal(t, buf.Bytes(), again.Bytes())
+	})
+}
+
+func TestWantsBundleArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		accept  string
+		want    bool
+		wantErr bool
+	}{
+		{"default", "/read/bundle", "", false, false},
+		{"json accept", "/read/bundle", "application/json", false, false},
+		{"format query", "/read/bundle?format=tar.gz", "", true, false},
+		{"gzip accept", "/read/bundle", "application/json;q=0.5, application/gzip", true, false},
+		{"format overrides accept", "/read/bundle?format=json", "application/gzip", false, false},
+		{"unknown format", "/read/bundle?format=zip", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			got, err := wantsBundleArchive(r)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,21 +2,28 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
 	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
-	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/middleware"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
 	"github.com/vlone310/cfguardian/internal/usecases/config"
 )
 
 // ConfigHandler handles configuration management endpoints
 type ConfigHandler struct {
-	createUseCase   *config.CreateConfigUseCase
-	getUseCase      *config.GetConfigUseCase
-	updateUseCase   *config.UpdateConfigUseCase
-	deleteUseCase   *config.DeleteConfigUseCase
-	rollbackUseCase *config.RollbackConfigUseCase
+	createUseCase        *config.CreateConfigUseCase
+	getUseCase           *config.GetConfigUseCase
+	updateUseCase        *config.UpdateConfigUseCase
+	deleteUseCase        *config.DeleteConfigUseCase
+	rollbackUseCase      *config.RollbackConfigUseCase
+	importUseCase        *config.ImportConfigsUseCase
+	validateUseCase      *config.ValidateConfigUseCase
+	upgradeSchemaUseCase *config.UpgradeConfigSchemaUseCase
 }
 
 // NewConfigHandler creates a new ConfigHandler
@@ -26,25 +33,34 @@ func NewConfigHandler(
 	updateUseCase *config.UpdateConfigUseCase,
 	deleteUseCase *config.DeleteConfigUseCase,
 	rollbackUseCase *config.RollbackConfigUseCase,
+	importUseCase *config.ImportConfigsUseCase,
+	validateUseCase *config.ValidateConfigUseCase,
+	upgradeSchemaUseCase *config.UpgradeConfigSchemaUseCase,
 ) *ConfigHandler {
 	return &ConfigHandler{
-		createUseCase:   createUseCase,
-		getUseCase:      getUseCase,
-		updateUseCase:   updateUseCase,
-		deleteUseCase:   deleteUseCase,
-		rollbackUseCase: rollbackUseCase,
+		createUseCase:        createUseCase,
+		getUseCase:           getUseCase,
+		updateUseCase:        updateUseCase,
+		deleteUseCase:        deleteUseCase,
+		rollbackUseCase:      rollbackUseCase,
+		importUseCase:        importUseCase,
+		validateUseCase:      validateUseCase,
+		upgradeSchemaUseCase: upgradeSchemaUseCase,
 	}
 }
 
-// Create handles config creation
+// Create handles config creation. With "format" set, content is a string
+// holding a YAML, TOML, dotenv or properties file.
 // POST /api/v1/projects/{projectId}/configs
 func (h *ConfigHandler) Create(w http.ResponseWriter, r *http.Request) {
 	projectID := chi.URLParam(r, "projectId")
 	
 	var reqBody struct {
-		Key      string          `json:"key"`
-		SchemaID string          `json:"schema_id"`
-		Content  json.RawMessage `json:"content"`
+		Key           string          `json:"key"`
+		SchemaID      string          `json:"schema_id"`
+		Content       json.RawMessage `json:"content"`
+		Format        string          `json:"format"`
+		ApplyDefaults bool            `json:"apply_defaults"`
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
@@ -52,8 +68,14 @@ func (h *ConfigHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
+	format, err := parseContentFormat(reqBody.Format)
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return
+	}
+	
 	// Get user ID from auth context
-	userID := middleware.GetUserID(r.Context())
+	userID := authn.GetUserID(r.Context())
 	if userID == "" {
 		common.Unauthorized(w, "User not authenticated")
 		return
@@ -64,35 +86,61 @@ func (h *ConfigHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Key:             reqBody.Key,
 		SchemaID:        reqBody.SchemaID,
 		Content:         reqBody.Content,
+		Format:          format,
+		ApplyDefaults:   reqBody.ApplyDefaults,
 		UpdatedByUserID: userID,
 	})
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
 	common.Created(w, resp)
 }
 
-// Get handles getting a config
+// Get handles getting a config, or the fragment at ?path= of it.
+// ?with_defaults=true fills in schema defaults the stored content leaves out.
 // GET /api/v1/projects/{projectId}/configs/{configKey}
 func (h *ConfigHandler) Get(w http.ResponseWriter, r *http.Request) {
 	projectID := chi.URLParam(r, "projectId")
 	configKey := chi.URLParam(r, "configKey")
 	
+	path, err := valueobjects.NewConfigPath(r.URL.Query().Get("path"))
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return
+	}
+	
+	withDefaults, err := parseWithDefaults(r)
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return
+	}
+	
 	resp, err := h.getUseCase.Execute(r.Context(), config.GetConfigRequest{
-		ProjectID: projectID,
-		Key:       configKey,
+		ProjectID:    projectID,
+		Key:          configKey,
+		Path:         path,
+		WithDefaults: withDefaults,
 	})
 	if err != nil {
-		common.NotFound(w, err.Error())
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	etag := configETag(resp.Version, resp.Content)
+	if !path.IsRoot() {
+		etag = fragmentETag(resp.Path, resp.Content)
+	}
+	if notModified(w, r, etag) {
 		return
 	}
 	
 	common.OK(w, resp)
 }
 
-// Update handles config update with optimistic locking
+// Update handles config update with optimistic locking. Content may be
+// given in another format as in Create.
 // PUT /api/v1/projects/{projectId}/configs/{configKey}
 func (h *ConfigHandler) Update(w http.ResponseWriter, r *http.Request) {
 	projectID := chi.URLParam(r, "projectId")
@@ -101,6 +149,8 @@ func (h *ConfigHandler) Update(w http.ResponseWriter, r *http.Request) {
 	var reqBody struct {
 		ExpectedVersion int64           `json:"expected_version"`
 		Content         json.RawMessage `json:"content"`
+		Format          string          `json:"format"`
+		ApplyDefaults   bool            `json:"apply_defaults"`
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
@@ -108,8 +158,14 @@ func (h *ConfigHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
+	format, err := parseContentFormat(reqBody.Format)
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return
+	}
+	
 	// Get user ID from auth context
-	userID := middleware.GetUserID(r.Context())
+	userID := authn.GetUserID(r.Context())
 	if userID == "" {
 		common.Unauthorized(w, "User not authenticated")
 		return
@@ -120,18 +176,57 @@ func (h *ConfigHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Key:             configKey,
 		ExpectedVersion: reqBody.ExpectedVersion,
 		Content:         reqBody.Content,
+		Format:          format,
+		ApplyDefaults:   reqBody.ApplyDefaults,
 		UpdatedByUserID: userID,
 	})
 	if err != nil {
-		// Check if it's a version conflict
-		if isVersionConflict(err) {
-			common.Conflict(w, err.Error())
-			return
-		}
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	common.OK(w, resp)
+}
+
+// Validate handles a dry run of a config write: the candidate is checked
+// against the schema and the expected version, and diffed against the
+// current content. Nothing is written.
+// POST /api/v1/projects/{projectId}/configs/{configKey}:validate
+func (h *ConfigHandler) Validate(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "projectId")
+	configKey := chi.URLParam(r, "configKey")
+	
+	var reqBody struct {
+		SchemaID        string          `json:"schema_id"`
+		ExpectedVersion int64           `json:"expected_version"`
+		Content         json.RawMessage `json:"content"`
+		Format          string          `json:"format"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+	
+	format, err := parseContentFormat(reqBody.Format)
+	if err != nil {
 		common.BadRequest(w, err.Error())
 		return
 	}
 	
+	resp, err := h.validateUseCase.Execute(r.Context(), config.ValidateConfigRequest{
+		ProjectID:       projectID,
+		Key:             configKey,
+		SchemaID:        reqBody.SchemaID,
+		ExpectedVersion: reqBody.ExpectedVersion,
+		Content:         reqBody.Content,
+		Format:          format,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
 	common.OK(w, resp)
 }
 
@@ -142,7 +237,7 @@ func (h *ConfigHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	configKey := chi.URLParam(r, "configKey")
 	
 	// Get user ID from auth context
-	userID := middleware.GetUserID(r.Context())
+	userID := authn.GetUserID(r.Context())
 	if userID == "" {
 		common.Unauthorized(w, "User not authenticated")
 		return
@@ -154,7 +249,7 @@ func (h *ConfigHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		DeletedByUserID: userID,
 	})
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -178,7 +273,7 @@ func (h *ConfigHandler) Rollback(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	// Get user ID from auth context
-	userID := middleware.GetUserID(r.Context())
+	userID := authn.GetUserID(r.Context())
 	if userID == "" {
 		common.Unauthorized(w, "User not authenticated")
 		return
@@ -192,40 +287,136 @@ func (h *ConfigHandler) Rollback(w http.ResponseWriter, r *http.Request) {
 		RolledBackByUserID: userID,
 	})
 	if err != nil {
-		// Check if it's a version conflict
-		if isVersionConflict(err) {
-			common.Conflict(w, err.Error())
-			return
-		}
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
 	common.OK(w, resp)
 }
 
-// isVersionConflict checks if an error is a version conflict
-func isVersionConflict(err error) bool {
-	if err == nil {
-		return false
-	}
-	// TODO: Use proper error type checking with domain/services.VersionConflictError
-	errMsg := err.Error()
-	return errMsg == "version mismatch" || 
-		   stringContains(errMsg, "version conflict") ||
-		   stringContains(errMsg, "concurrent modification")
+// UpgradeSchema handles pinning a config to another version of its schema.
+// The config is re-validated against that version; schema_version 0 means latest.
+// POST /api/v1/projects/{projectId}/configs/{configKey}/upgrade-schema
+func (h *ConfigHandler) UpgradeSchema(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "projectId")
+	configKey := chi.URLParam(r, "configKey")
+	
+	var reqBody struct {
+		ExpectedVersion int64 `json:"expected_version"`
+		SchemaVersion   int32 `json:"schema_version"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+	
+	// Get user ID from auth context
+	userID := authn.GetUserID(r.Context())
+	if userID == "" {
+		common.Unauthorized(w, "User not authenticated")
+		return
+	}
+	
+	resp, err := h.upgradeSchemaUseCase.Execute(r.Context(), config.UpgradeConfigSchemaRequest{
+		ProjectID:       projectID,
+		Key:             configKey,
+		ExpectedVersion: reqBody.ExpectedVersion,
+		SchemaVersion:   reqBody.SchemaVersion,
+		UpdatedByUserID: userID,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	common.OK(w, resp)
 }
 
-// stringContains checks if a string contains a substring
-func stringContains(s, substr string) bool {
-	if len(substr) > len(s) {
-		return false
-	}
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+// Import handles bulk import of a tar, tar.gz or zip archive of config files.
+// Nothing is stored unless every file is valid; ?dry_run=true only reports.
+// POST /api/v1/projects/{projectId}/configs/import
+func (h *ConfigHandler) Import(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "projectId")
+	query := r.URL.Query()
+	
+	dryRun := false
+	if raw := query.Get("dry_run"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			common.BadRequest(w, "dry_run must be true or false")
+			return
+		}
+		dryRun = parsed
+	}
+	
+	// Get user ID from auth context
+	userID := authn.GetUserID(r.Context())
+	if userID == "" {
+		common.Unauthorized(w, "User not authenticated")
+		return
+	}
+	
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			common.RespondError(w, http.StatusRequestEntityTooLarge, errImportArchiveTooLarge.Error(), "PAYLOAD_TOO_LARGE")
+			return
+		}
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+	
+	files, err := readImportArchive(data)
+	if err != nil {
+		if errors.Is(err, errImportArchiveTooLarge) {
+			common.RespondError(w, http.StatusRequestEntityTooLarge, err.Error(), "PAYLOAD_TOO_LARGE")
+			return
 		}
+		common.BadRequest(w, err.Error())
+		return
 	}
-	return false
+	
+	resp, err := h.importUseCase.Execute(r.Context(), config.ImportConfigsRequest{
+		ProjectID:       projectID,
+		SchemaID:        query.Get("schema_id"),
+		Files:           files,
+		DryRun:          dryRun,
+		UpdatedByUserID: userID,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	switch {
+	case !resp.Valid:
+		common.RespondJSON(w, http.StatusUnprocessableEntity, resp)
+	case resp.Failed > 0:
+		common.RespondJSON(w, http.StatusConflict, resp)
+	default:
+		common.OK(w, resp)
+	}
+}
+
+// parseContentFormat parses the optional format of request content
+func parseContentFormat(format string) (valueobjects.ConfigFormat, error) {
+	if format == "" {
+		return "", nil
+	}
+	return valueobjects.NewConfigFormat(format)
 }
 
+// parseWithDefaults parses the optional ?with_defaults= flag of config reads
+func parseWithDefaults(r *http.Request) (bool, error) {
+	raw := r.URL.Query().Get("with_defaults")
+	if raw == "" {
+		return false, nil
+	}
+	withDefaults, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New("with_defaults must be true or false")
+	}
+	return withDefaults, nil
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+)
+
+// configETag builds a strong ETag from a config's version and content.
+// The content hash keeps it unique across delete/recreate cycles.
+func configETag(version int64, content []byte) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf(`"v%d-%s"`, version, hex.EncodeToString(sum[:8]))
+}
+
+// fragmentETag builds a strong ETag for a fragment of a config from its path
+// and canonical content. It leaves out the config version, so the ETag only
+// changes when the fragment does.
+func fragmentETag(path string, fragment []byte) string {
+	h := sha256.New()
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(fragment)
+	return fmt.Sprintf(`"p-%s"`, hex.EncodeToString(h.Sum(nil)[:8]))
+}
+
+// formatETag derives the ETag of another representation of the same
+// content, such as a config rendered as YAML
+func formatETag(etag string, format valueobjects.ConfigFormat) string {
+	return strings.TrimSuffix(etag, `"`) + "-" + format.String() + `"`
+}
+
+// notModified sets the ETag header and reports whether the request's
+// If-None-Match matches it, in which case a 304 has been written
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+
+	match := r.Header.Get("If-None-Match")
+	if match == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(match, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
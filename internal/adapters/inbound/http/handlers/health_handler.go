@@ -17,14 +17,19 @@ type HealthChecker interface {
 
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
-	dbPool *pgxpool.Pool
-	// raftStore could be added here for Raft health checks
+	dbPool   *pgxpool.Pool
+	raft     HealthChecker
+	readPath HealthChecker
 }
 
-// NewHealthHandler creates a new HealthHandler
-func NewHealthHandler(dbPool *pgxpool.Pool) *HealthHandler {
+// NewHealthHandler creates a new HealthHandler.
+// readPath reports whether API key reads can still be authorized from cached
+// metadata while the database is down; nil means they cannot.
+func NewHealthHandler(dbPool *pgxpool.Pool, raft HealthChecker, readPath HealthChecker) *HealthHandler {
 	return &HealthHandler{
-		dbPool: dbPool,
+		dbPool:   dbPool,
+		raft:     raft,
+		readPath: readPath,
 	}
 }
 
@@ -67,32 +72,30 @@ func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 
 // Readiness handles readiness probe (K8s-style)
 // GET /ready
-// Returns 200 if service is ready to accept traffic, 503 otherwise
+// Returns 200 if service is ready to accept traffic, 503 otherwise.
+// While the database is down but Raft and the cached API key metadata can
+// still serve /read, the status is "degraded" and 200 is returned so that
+// config delivery keeps receiving traffic.
 func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
 	defer cancel()
 	
 	checks := make(map[string]CheckResult)
-	allHealthy := true
 	
 	// Check database connection
-	if err := h.checkDatabase(ctx); err != nil {
-		checks["database"] = CheckResult{
-			Status:  "unhealthy",
-			Message: err.Error(),
-		}
-		allHealthy = false
-	} else {
-		checks["database"] = CheckResult{
-			Status: "healthy",
-		}
-	}
+	databaseErr := h.checkDatabase(ctx)
+	checks["database"] = checkResult(databaseErr)
 	
-	// TODO: Add Raft health check
-	// For now, assume Raft is healthy
-	checks["raft"] = CheckResult{
-		Status: "healthy",
+	// Check Raft, which holds the config data
+	raftErr := h.checkRaft(ctx)
+	checks["raft"] = checkResult(raftErr)
+	
+	// Check whether /read can still authorize API keys
+	readPathErr := raftErr
+	if readPathErr == nil && databaseErr != nil {
+		readPathErr = h.checkReadPath(ctx)
 	}
+	checks["read_path"] = checkResult(readPathErr)
 	
 	response := ReadinessResponse{
 		Status:    "healthy",
@@ -101,9 +104,16 @@ func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	statusCode := http.StatusOK
-	if !allHealthy {
+	switch {
+	case readPathErr != nil:
 		response.Status = "unhealthy"
 		statusCode = http.StatusServiceUnavailable
+	case databaseErr != nil:
+		response.Status = "degraded"
+		checks["read_path"] = CheckResult{
+			Status:  "degraded",
+			Message: "serving reads with cached API key metadata; management API unavailable",
+		}
 	}
 	
 	w.Header().Set("Content-Type", "application/json")
@@ -141,12 +151,33 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) error {
 	return h.dbPool.Ping(ctx)
 }
 
-// checkRaft verifies Raft cluster health
-// TODO: Implement this when raftStore is added to handler
+// checkRaft verifies this node can serve reads from Raft
 func (h *HealthHandler) checkRaft(ctx context.Context) error {
-	// Check if Raft is running
-	// Check if node has a leader
-	// Check if cluster has quorum
-	return nil
+	if h.raft == nil {
+		return errors.New("raft not initialized")
+	}
+	
+	return h.raft.Check(ctx)
 }
 
+// checkReadPath verifies API keys can be authorized without the database
+func (h *HealthHandler) checkReadPath(ctx context.Context) error {
+	if h.readPath == nil {
+		return errors.New("database unavailable and no API key cache configured")
+	}
+	
+	return h.readPath.Check(ctx)
+}
+
+// checkResult converts a check error to its result
+func checkResult(err error) CheckResult {
+	if err != nil {
+		return CheckResult{
+			Status:  "unhealthy",
+			Message: err.Error(),
+		}
+	}
+	return CheckResult{
+		Status: "healthy",
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+)
+
+// The upload itself is bounded by the router's request size limit; these
+// bound what it expands to
+const (
+	// maxImportFiles caps the number of files in an import archive
+	maxImportFiles = 1000
+
+	// maxImportFileSize caps the uncompressed size of one imported file
+	maxImportFileSize = 4 << 20
+
+	// maxImportTotalSize caps the uncompressed size of all imported files,
+	// so that a small compressed archive cannot expand without bound
+	maxImportTotalSize = 32 << 20
+
+	// maxImportTarSize caps the uncompressed tar stream, which also holds
+	// entry headers, padding and the skipped entries that are never imported
+	maxImportTarSize = maxImportTotalSize + 8<<20
+)
+
+// errImportArchiveTooLarge is returned when an upload exceeds the limits
+var errImportArchiveTooLarge = errors.New("import archive is too large")
+
+// readImportArchive returns the files of an uploaded tar, tar.gz or zip
+// archive, detected from its first bytes. Directories, links and macOS
+// metadata are left out. A bundle.json manifest, as written by the bundle
+// download, maps files to config keys and schemas; other files get keys
+// derived from their names.
+func readImportArchive(data []byte) ([]config.ImportFile, error) {
+	var files []config.ImportFile
+	var err error
+
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")), bytes.HasPrefix(data, []byte("PK\x05\x06")):
+		files, err = readZipArchive(data)
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gz, gzErr := gzip.NewReader(bytes.NewReader(data))
+		if gzErr != nil {
+			return nil, fmt.Errorf("invalid gzip archive: %w", gzErr)
+		}
+		files, err = readTarArchive(gz)
+	default:
+		files, err = readTarArchive(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return applyImportManifest(files)
+}
+
+// readTarArchive returns the regular files of a tar archive
+func readTarArchive(r io.Reader) ([]config.ImportFile, error) {
+	stream := &io.LimitedReader{R: r, N: maxImportTarSize + 1}
+	tr := tar.NewReader(stream)
+	var files importFiles
+	for {
+		header, err := tr.Next()
+		if stream.N <= 0 {
+			return nil, errImportArchiveTooLarge
+		}
+		if err == io.EOF {
+			return files.list, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: expected tar, tar.gz or zip: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name, ok := importFileName(header.Name)
+		if !ok {
+			continue
+		}
+		data, err := readImportFile(tr)
+		if stream.N <= 0 {
+			return nil, errImportArchiveTooLarge
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := files.add(name, data); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readZipArchive returns the regular files of a zip archive.
+// Skipped entries are never opened, so they are not decompressed.
+func readZipArchive(data []byte) ([]config.ImportFile, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	var files importFiles
+	for _, entry := range zr.File {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		name, ok := importFileName(entry.Name)
+		if !ok {
+			continue
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		content, err := readImportFile(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := files.add(name, content); err != nil {
+			return nil, err
+		}
+	}
+	return files.list, nil
+}
+
+// importFileName cleans an archive entry name and reports whether the
+// entry should be imported
+func importFileName(name string) (string, bool) {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" || strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(path.Base(name), "._") {
+		return "", false
+	}
+	return name, true
+}
+
+// readImportFile reads one archive entry within maxImportFileSize
+func readImportFile(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxImportFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImportFileSize {
+		return nil, errImportArchiveTooLarge
+	}
+	return data, nil
+}
+
+// importFiles collects the files of an archive, enforcing maxImportFiles
+// and maxImportTotalSize
+type importFiles struct {
+	list []config.ImportFile
+	size int
+}
+
+// add appends a file
+func (f *importFiles) add(name string, data []byte) error {
+	if len(f.list) >= maxImportFiles || f.size+len(data) > maxImportTotalSize {
+		return errImportArchiveTooLarge
+	}
+	f.list = append(f.list, config.ImportFile{Name: name, Data: data})
+	f.size += len(data)
+	return nil
+}
+
+// applyImportManifest removes bundle.json from the files and applies its
+// key and schema mappings
+func applyImportManifest(files []config.ImportFile) ([]config.ImportFile, error) {
+	manifestIndex := -1
+	for i, file := range files {
+		if file.Name == bundleManifestName {
+			manifestIndex = i
+			break
+		}
+	}
+	if manifestIndex < 0 {
+		return files, nil
+	}
+
+	var manifest bundleManifest
+	if err := json.Unmarshal(files[manifestIndex].Data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", bundleManifestName, err)
+	}
+	files = append(files[:manifestIndex], files[manifestIndex+1:]...)
+
+	byName := make(map[string]int, len(files))
+	for i, file := range files {
+		byName[file.Name] = i
+	}
+
+	var missing []string
+	for _, entry := range manifest.Configs {
+		i, ok := byName[strings.TrimPrefix(path.Clean("/"+entry.File), "/")]
+		if !ok {
+			missing = append(missing, entry.File)
+			continue
+		}
+		files[i].Key = entry.Key
+		files[i].SchemaID = entry.SchemaID
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%s lists files missing from the archive: %s", bundleManifestName, strings.Join(missing, ", "))
+	}
+
+	return files, nil
+}
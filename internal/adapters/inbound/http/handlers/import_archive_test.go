@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+)
+
+// zipArchive builds a zip archive from files by name
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		fw, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// filesByName indexes imported files by name
+func filesByName(files []config.ImportFile) map[string]config.ImportFile {
+	byName := make(map[string]config.ImportFile, len(files))
+	for _, file := range files {
+		byName[file.Name] = file
+	}
+	return byName
+}
+
+func TestReadImportArchive_Zip(t *testing.T) {
+	// Arrange
+	data := zipArchive(t, map[string]string{
+		"./mobile/api.yaml":       "port: 8080\n",
+		"web.env":                 "PORT=80\n",
+		"mobile/":                 "",
+		"__MACOSX/mobile/._a.yml": "junk",
+		"mobile/._api.yaml":       "junk",
+	})
+
+	// Act
+	files, err := readImportArchive(data)
+
+	// Assert
+	require.NoError(t, err)
+	byName := filesByName(files)
+	assert.Len(t, byName, 2)
+	assert.Equal(t, "port: 8080\n", string(byName["mobile/api.yaml"].Data))
+	assert.Equal(t, "PORT=80\n", string(byName["web.env"].Data))
+	assert.Empty(t, byName["web.env"].Key, "keys without a manifest are derived by the use case")
+}
+
+func TestReadImportArchive_BundleManifest(t *testing.T) {
+	// Arrange: a bundle downloaded from the read API imports as is
+	var buf bytes.Buffer
+	require.NoError(t, writeBundleArchive(&buf, testBundle()))
+
+	// Act
+	files, err := readImportArchive(buf.Bytes())
+
+	// Assert
+	require.NoError(t, err)
+	byName := filesByName(files)
+	assert.Len(t, byName, 2)
+	assert.Equal(t, "../etc/passwd", byName[bundleFileName("../etc/passwd")].Key)
+	assert.Equal(t, "mobile.flags", byName[bundleFileName("mobile.flags")].Key)
+	assert.Equal(t, `{"beta":true}`, string(byName[bundleFileName("mobile.flags")].Data))
+}
+
+func TestReadImportArchive_Tar(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, writeArchiveFile(tw, "app.toml", []byte("port = 1\n")))
+	require.NoError(t, writeArchiveFile(tw, bundleManifestName, []byte(`{"configs":[{"key":"app","file":"app.toml","schema_id":"schema-1"},{"key":"gone","file":"gone.yaml"}]}`)))
+	require.NoError(t, tw.Close())
+
+	// Act
+	_, err := readImportArchive(buf.Bytes())
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing from the archive: gone.yaml")
+}
+
+func TestReadImportArchive_FileTooLarge(t *testing.T) {
+	data := zipArchive(t, map[string]string{
+		"big.json": string(bytes.Repeat([]byte(" "), maxImportFileSize+1)),
+	})
+
+	_, err := readImportArchive(data)
+
+	assert.ErrorIs(t, err, errImportArchiveTooLarge)
+}
+
+func TestReadImportArchive_TotalTooLarge(t *testing.T) {
+	// Arrange: files within maxImportFileSize that together exceed
+	// maxImportTotalSize, compressing to a small gzip
+	var tarball bytes.Buffer
+	gz := gzip.NewWriter(&tarball)
+	tw := tar.NewWriter(gz)
+	content := bytes.Repeat([]byte(" "), maxImportFileSize)
+	for i := 0; i <= maxImportTotalSize/maxImportFileSize; i++ {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: fmt.Sprintf("config-%d.json", i), Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	// Act
+	_, err := readImportArchive(tarball.Bytes())
+
+	// Assert
+	assert.Less(t, tarball.Len(), 1<<20)
+	assert.ErrorIs(t, err, errImportArchiveTooLarge)
+}
+
+func TestReadImportArchive_SkippedEntriesTooLarge(t *testing.T) {
+	// Arrange: macOS metadata entries are never imported, but they are
+	// still decompressed while walking the archive
+	var tarball bytes.Buffer
+	gz := gzip.NewWriter(&tarball)
+	tw := tar.NewWriter(gz)
+	content := bytes.Repeat([]byte(" "), 16<<20)
+	for i := 0; i <= maxImportTarSize/len(content); i++ {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: fmt.Sprintf("__MACOSX/._config-%d.json", i), Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	// Act
+	_, err := readImportArchive(tarball.Bytes())
+
+	// Assert
+	assert.ErrorIs(t, err, errImportArchiveTooLarge)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+)
+
+// MigrationHandler handles schema migration runs over configs
+type MigrationHandler struct {
+	migrateUseCase  *config.MigrateConfigsUseCase
+	listUseCase     *config.ListConfigMigrationsUseCase
+	getUseCase      *config.GetConfigMigrationUseCase
+	rollbackUseCase *config.RollbackConfigMigrationUseCase
+}
+
+// NewMigrationHandler creates a new MigrationHandler
+func NewMigrationHandler(
+	migrateUseCase *config.MigrateConfigsUseCase,
+	listUseCase *config.ListConfigMigrationsUseCase,
+	getUseCase *config.GetConfigMigrationUseCase,
+	rollbackUseCase *config.RollbackConfigMigrationUseCase,
+) *MigrationHandler {
+	return &MigrationHandler{
+		migrateUseCase:  migrateUseCase,
+		listUseCase:     listUseCase,
+		getUseCase:      getUseCase,
+		rollbackUseCase: rollbackUseCase,
+	}
+}
+
+// Migrate handles migrating the configs using a schema to one of its
+// versions; target_version 0 means latest and dry_run only reports
+// POST /api/v1/schemas/{schemaId}/migrations
+func (h *MigrationHandler) Migrate(w http.ResponseWriter, r *http.Request) {
+	schemaID := chi.URLParam(r, "schemaId")
+
+	var reqBody struct {
+		TargetVersion int32 `json:"target_version"`
+		DryRun        bool  `json:"dry_run"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	// Get user ID from auth context
+	userID := authn.GetUserID(r.Context())
+	if userID == "" {
+		common.Unauthorized(w, "User not authenticated")
+		return
+	}
+
+	resp, err := h.migrateUseCase.Execute(r.Context(), config.MigrateConfigsRequest{
+		SchemaID:        schemaID,
+		TargetVersion:   reqBody.TargetVersion,
+		DryRun:          reqBody.DryRun,
+		UpdatedByUserID: userID,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+
+	if resp.MigrationID != "" {
+		common.Created(w, resp)
+		return
+	}
+	common.OK(w, resp)
+}
+
+// List handles listing the migration runs of a schema
+// GET /api/v1/schemas/{schemaId}/migrations
+func (h *MigrationHandler) List(w http.ResponseWriter, r *http.Request) {
+	schemaID := chi.URLParam(r, "schemaId")
+
+	resp, err := h.listUseCase.Execute(r.Context(), schemaID)
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+
+	common.OK(w, resp)
+}
+
+// Get handles retrieving one migration run
+// GET /api/v1/schemas/{schemaId}/migrations/{migrationId}
+func (h *MigrationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	schemaID := chi.URLParam(r, "schemaId")
+	migrationID := chi.URLParam(r, "migrationId")
+
+	resp, err := h.getUseCase.Execute(r.Context(), schemaID, migrationID)
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+
+	common.OK(w, resp)
+}
+
+// Rollback handles restoring the configs a migration run rewrote
+// POST /api/v1/schemas/{schemaId}/migrations/{migrationId}/rollback
+func (h *MigrationHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from auth context
+	userID := authn.GetUserID(r.Context())
+	if userID == "" {
+		common.Unauthorized(w, "User not authenticated")
+		return
+	}
+
+	resp, err := h.rollbackUseCase.Execute(r.Context(), config.RollbackConfigMigrationRequest{
+		SchemaID:        chi.URLParam(r, "schemaId"),
+		MigrationID:     chi.URLParam(r, "migrationId"),
+		UpdatedByUserID: userID,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+
+	common.OK(w, resp)
+}
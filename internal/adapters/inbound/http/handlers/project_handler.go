@@ -43,7 +43,7 @@ func (h *ProjectHandler) Create(w http.ResponseWriter, r *http.Request) {
 	
 	resp, err := h.createUseCase.Execute(r.Context(), req)
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -62,7 +62,7 @@ func (h *ProjectHandler) List(w http.ResponseWriter, r *http.Request) {
 	
 	resp, err := h.listUseCase.Execute(r.Context(), req)
 	if err != nil {
-		common.InternalServerError(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -78,7 +78,7 @@ func (h *ProjectHandler) Get(w http.ResponseWriter, r *http.Request) {
 		ProjectID: projectID,
 	})
 	if err != nil {
-		common.NotFound(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -94,7 +94,7 @@ func (h *ProjectHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		ProjectID: projectID,
 	})
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -1,40 +1,309 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/middleware"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
 	"github.com/vlone310/cfguardian/internal/usecases/config"
 )
 
+// bundleArchiveContentType is the media type of the tar.gz bundle
+const bundleArchiveContentType = "application/gzip"
+
 // ReadHandler handles public client read API endpoints
 type ReadHandler struct {
 	readUseCase *config.ReadConfigByAPIKeyUseCase
+	renderer    *services.ConfigRenderer
 }
 
 // NewReadHandler creates a new ReadHandler
 func NewReadHandler(readUseCase *config.ReadConfigByAPIKeyUseCase) *ReadHandler {
 	return &ReadHandler{
 		readUseCase: readUseCase,
+		renderer:    services.NewConfigRenderer(),
 	}
 }
 
-// Read handles reading a config by API key
-// GET /api/v1/read/{apiKey}/{configKey}
+// Read handles reading a config by API key.
+// The project and scope come from the API key middleware, which also
+// handles the key in the path on the deprecated route.
+// JSON responses carry the config with its metadata; with ?format= or a
+// matching Accept header the body is the config content alone, rendered as
+// YAML, TOML, dotenv or properties. ?path= (a JSON Pointer or JSONPath)
+// reads only that fragment, with an ETag that changes only with the fragment.
+// ?with_defaults=true fills in schema defaults the stored content leaves out.
+// GET /api/v1/read/{configKey}
+// GET /api/v1/read/{apiKey}/{configKey} (deprecated)
 func (h *ReadHandler) Read(w http.ResponseWriter, r *http.Request) {
-	apiKey := chi.URLParam(r, "apiKey")
 	configKey := chi.URLParam(r, "configKey")
 	
+	format, err := negotiateConfigFormat(r)
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return
+	}
+	
+	path, err := valueobjects.NewConfigPath(r.URL.Query().Get("path"))
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return
+	}
+	
+	withDefaults, err := parseWithDefaults(r)
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return
+	}
+	
 	resp, err := h.readUseCase.Execute(r.Context(), config.ReadConfigByAPIKeyRequest{
-		APIKey: apiKey,
-		Key:    configKey,
+		ProjectID:    middleware.GetProjectID(r.Context()),
+		Key:          configKey,
+		Path:         path,
+		Scope:        middleware.GetAPIKeyScope(r.Context()),
+		WithDefaults: withDefaults,
 	})
 	if err != nil {
-		common.NotFound(w, "Config not found")
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	// Support conditional requests so clients can revalidate cached configs
+	etag := configETag(resp.Version, resp.Content)
+	if !path.IsRoot() {
+		etag = fragmentETag(resp.Path, resp.Content)
+	}
+	
+	w.Header().Set("Vary", "Accept")
+	if format == valueobjects.ConfigFormatJSON {
+		if notModified(w, r, etag) {
+			return
+		}
+		common.OK(w, resp)
+		return
+	}
+	
+	rendered, err := h.renderer.Render(resp.Content, format)
+	if err != nil {
+		common.RespondError(w, http.StatusNotAcceptable, err.Error(), "NOT_ACCEPTABLE")
 		return
 	}
 	
+	if notModified(w, r, formatETag(etag, format)) {
+		return
+	}
+	w.Header().Set("Content-Type", format.MediaType()+"; charset=utf-8")
+	w.Header().Set("X-Config-Key", resp.Key)
+	w.Header().Set("X-Config-Version", strconv.FormatInt(resp.Version, 10))
+	if resp.Path != "" {
+		w.Header().Set("X-Config-Path", resp.Path)
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(rendered)
+}
+
+// batchConfig is one config of a batch read, with the ETag a later
+// single-config read can be revalidated with
+type batchConfig struct {
+	*config.ReadConfigByAPIKeyResponse
+	ETag string `json:"etag"`
+}
+
+// ReadBatchResponse holds the result of a batch read.
+// Missing lists requested keys that do not exist or are outside the key's scope.
+type ReadBatchResponse struct {
+	Configs map[string]batchConfig `json:"configs"`
+	Missing []string               `json:"missing"`
+}
+
+// ReadBatch handles reading several configs in one request
+// POST /api/v1/read/batch
+func (h *ReadHandler) ReadBatch(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		Keys []string `json:"keys"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+	
+	configs, err := h.readUseCase.ExecuteMultiple(
+		r.Context(),
+		middleware.GetProjectID(r.Context()),
+		middleware.GetAPIKeyScope(r.Context()),
+		reqBody.Keys,
+	)
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	resp := ReadBatchResponse{
+		Configs: make(map[string]batchConfig, len(configs)),
+		Missing: []string{},
+	}
+	for _, key := range reqBody.Keys {
+		cfg, found := configs[key]
+		if !found {
+			resp.Missing = append(resp.Missing, key)
+			continue
+		}
+		resp.Configs[key] = batchConfig{
+			ReadConfigByAPIKeyResponse: cfg,
+			ETag:                       configETag(cfg.Version, cfg.Content),
+		}
+	}
+	
 	common.OK(w, resp)
 }
 
+// ReadBundle handles downloading every config the API key can read.
+// The bundle is JSON by default, or a tar.gz archive of bundle.json plus
+// configs/<key>.json with ?format=tar.gz or Accept: application/gzip.
+// GET /api/v1/read/bundle
+func (h *ReadHandler) ReadBundle(w http.ResponseWriter, r *http.Request) {
+	archive, err := wantsBundleArchive(r)
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return
+	}
+	
+	bundle, err := h.readUseCase.ExecuteBundle(
+		r.Context(),
+		middleware.GetProjectID(r.Context()),
+		middleware.GetAPIKeyScope(r.Context()),
+	)
+	if err != nil {
+		common.InternalServerError(w, "Failed to read config bundle")
+		return
+	}
+	
+	w.Header().Set("Vary", "Accept")
+	if !archive {
+		if notModified(w, r, bundleETag(bundle, "")) {
+			return
+		}
+		common.OK(w, bundle)
+		return
+	}
+	
+	// Build the archive before writing headers so a failure can still be reported
+	var buf bytes.Buffer
+	if err := writeBundleArchive(&buf, bundle); err != nil {
+		common.InternalServerError(w, "Failed to build config bundle archive")
+		return
+	}
+	
+	if notModified(w, r, bundleETag(bundle, "-tgz")) {
+		return
+	}
+	w.Header().Set("Content-Type", bundleArchiveContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="cfguardian-bundle-%s.tar.gz"`, bundleHashHex(bundle.Hash)[:12]))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// ReadChanges handles reading the configs changed since a cursor.
+// The cursor comes from a bundle or an earlier call; a cursor the server can
+// no longer serve answers 410 Gone with code RESYNC_REQUIRED, after which the
+// client must download the bundle again.
+// GET /api/v1/read/changes?since={cursor}
+func (h *ReadHandler) ReadChanges(w http.ResponseWriter, r *http.Request) {
+	since, err := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+	if err != nil {
+		common.BadRequest(w, "since must be a cursor returned by the bundle or changes endpoint")
+		return
+	}
+	
+	resp, err := h.readUseCase.ExecuteChanges(
+		r.Context(),
+		middleware.GetProjectID(r.Context()),
+		middleware.GetAPIKeyScope(r.Context()),
+		since,
+	)
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	w.Header().Set("Cache-Control", "no-store")
+	common.OK(w, resp)
+}
+
+// wantsBundleArchive reports whether the tar.gz form of the bundle was requested
+func wantsBundleArchive(r *http.Request) (bool, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "":
+	case "json":
+		return false, nil
+	case "tar.gz", "tgz":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported bundle format: %s", format)
+	}
+	
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case bundleArchiveContentType, "application/x-gzip", "application/x-tar+gzip":
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// negotiateConfigFormat returns the format a config read asked for.
+// ?format= wins over Accept; among the Accept media types cfguardian can
+// render, the one with the highest q-value is used. Anything else,
+// including */*, gets JSON.
+func negotiateConfigFormat(r *http.Request) (valueobjects.ConfigFormat, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return valueobjects.NewConfigFormat(format)
+	}
+	
+	best, bestQ := valueobjects.ConfigFormatJSON, 0.0
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		format, ok := valueobjects.ConfigFormatForMediaType(mediaType)
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+	return best, nil
+}
+
+// bundleETag builds a strong ETag for one representation of a bundle.
+// The cursor is part of the body, so it is part of the ETag too.
+func bundleETag(bundle *config.ReadConfigBundleResponse, suffix string) string {
+	return fmt.Sprintf(`"bundle-%s-%d%s"`, bundleHashHex(bundle.Hash)[:32], bundle.Cursor, suffix)
+}
+
+// bundleHashHex strips the algorithm from a bundle hash
+func bundleHashHex(hash string) string {
+	return strings.TrimPrefix(hash, "sha256:")
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+)
+
+func TestNegotiateConfigFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		accept  string
+		want    valueobjects.ConfigFormat
+		wantErr bool
+	}{
+		{"default", "/read/app", "", valueobjects.ConfigFormatJSON, false},
+		{"any media type", "/read/app", "*/*", valueobjects.ConfigFormatJSON, false},
+		{"unsupported accept", "/read/app", "text/html", valueobjects.ConfigFormatJSON, false},
+		{"yaml accept", "/read/app", "application/yaml", valueobjects.ConfigFormatYAML, false},
+		{"highest q wins", "/read/app", "application/json;q=0.5, text/x-java-properties;q=0.8, application/toml;q=0.1", valueobjects.ConfigFormatProperties, false},
+		{"first of equal q wins", "/read/app", "application/toml, application/json", valueobjects.ConfigFormatTOML, false},
+		{"format query", "/read/app?format=env", "", valueobjects.ConfigFormatDotenv, false},
+		{"format overrides accept", "/read/app?format=json", "application/yaml", valueobjects.ConfigFormatJSON, false},
+		{"unknown format", "/read/app?format=xml", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			got, err := negotiateConfigFormat(r)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -47,7 +47,7 @@ func (h *RoleHandler) Assign(w http.ResponseWriter, r *http.Request) {
 		RoleLevel: reqBody.RoleLevel,
 	})
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -65,7 +65,7 @@ func (h *RoleHandler) Revoke(w http.ResponseWriter, r *http.Request) {
 		ProjectID: projectID,
 	})
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -2,20 +2,31 @@ package handlers
 
 import (
 	"encoding/json"
+	"mime"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
 	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
-	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/middleware"
 	"github.com/vlone310/cfguardian/internal/usecases/schema"
+	"github.com/vlone310/cfguardian/internal/usecases/user"
 )
 
 // SchemaHandler handles config schema endpoints
 type SchemaHandler struct {
-	createUseCase *schema.CreateSchemaUseCase
-	listUseCase   *schema.ListSchemasUseCase
-	updateUseCase *schema.UpdateSchemaUseCase
-	deleteUseCase *schema.DeleteSchemaUseCase
+	createUseCase         *schema.CreateSchemaUseCase
+	listUseCase           *schema.ListSchemasUseCase
+	updateUseCase         *schema.UpdateSchemaUseCase
+	deleteUseCase         *schema.DeleteSchemaUseCase
+	validateUseCase       *schema.ValidateContentUseCase
+	publishVersionUseCase *schema.PublishSchemaVersionUseCase
+	listVersionsUseCase   *schema.ListSchemaVersionsUseCase
+	getVersionUseCase     *schema.GetSchemaVersionUseCase
+	previewUseCase        *schema.PreviewSchemaChangeUseCase
+	codegenUseCase        *schema.GenerateSchemaCodeUseCase
+	usageUseCase          *schema.GetSchemaUsageUseCase
+	platformAdminUseCase  *user.CheckPlatformAdminUseCase
 }
 
 // NewSchemaHandler creates a new SchemaHandler
@@ -24,17 +35,35 @@ func NewSchemaHandler(
 	listUseCase *schema.ListSchemasUseCase,
 	updateUseCase *schema.UpdateSchemaUseCase,
 	deleteUseCase *schema.DeleteSchemaUseCase,
+	validateUseCase *schema.ValidateContentUseCase,
+	publishVersionUseCase *schema.PublishSchemaVersionUseCase,
+	listVersionsUseCase *schema.ListSchemaVersionsUseCase,
+	getVersionUseCase *schema.GetSchemaVersionUseCase,
+	previewUseCase *schema.PreviewSchemaChangeUseCase,
+	codegenUseCase *schema.GenerateSchemaCodeUseCase,
+	usageUseCase *schema.GetSchemaUsageUseCase,
+	platformAdminUseCase *user.CheckPlatformAdminUseCase,
 ) *SchemaHandler {
 	return &SchemaHandler{
-		createUseCase: createUseCase,
-		listUseCase:   listUseCase,
-		updateUseCase: updateUseCase,
-		deleteUseCase: deleteUseCase,
+		createUseCase:         createUseCase,
+		listUseCase:           listUseCase,
+		updateUseCase:         updateUseCase,
+		deleteUseCase:         deleteUseCase,
+		validateUseCase:       validateUseCase,
+		publishVersionUseCase: publishVersionUseCase,
+		listVersionsUseCase:   listVersionsUseCase,
+		getVersionUseCase:     getVersionUseCase,
+		previewUseCase:        previewUseCase,
+		codegenUseCase:        codegenUseCase,
+		usageUseCase:          usageUseCase,
+		platformAdminUseCase:  platformAdminUseCase,
 	}
 }
 
-// Create handles schema creation
+// Create handles schema creation. Under a project the schema belongs to
+// that project; otherwise it is global.
 // POST /api/v1/schemas
+// POST /api/v1/projects/{projectId}/schemas
 func (h *SchemaHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var reqBody struct {
 		Name          string `json:"name"`
@@ -47,7 +76,7 @@ func (h *SchemaHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	// Get user ID from auth context
-	userID := middleware.GetUserID(r.Context())
+	userID := authn.GetUserID(r.Context())
 	if userID == "" {
 		common.Unauthorized(w, "User not authenticated")
 		return
@@ -56,29 +85,54 @@ func (h *SchemaHandler) Create(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.createUseCase.Execute(r.Context(), schema.CreateSchemaRequest{
 		Name:            reqBody.Name,
 		SchemaContent:   reqBody.SchemaContent,
+		ProjectID:       chi.URLParam(r, "projectId"),
 		CreatedByUserID: userID,
 	})
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
 	common.Created(w, resp)
 }
 
-// List handles listing schemas
+// List handles listing the schemas usable by the caller: under a project,
+// the global schemas and the project's own
 // GET /api/v1/schemas
+// GET /api/v1/projects/{projectId}/schemas
 func (h *SchemaHandler) List(w http.ResponseWriter, r *http.Request) {
-	resp, err := h.listUseCase.Execute(r.Context())
+	// Get user ID from auth context
+	userID := authn.GetUserID(r.Context())
+	if userID == "" {
+		common.Unauthorized(w, "User not authenticated")
+		return
+	}
+	
+	projectID := chi.URLParam(r, "projectId")
+	platformAdmin := false
+	if projectID == "" {
+		var err error
+		platformAdmin, err = h.platformAdminUseCase.Execute(r.Context(), userID)
+		if err != nil {
+			common.RespondAppError(w, err)
+			return
+		}
+	}
+	
+	resp, err := h.listUseCase.Execute(r.Context(), schema.ListSchemasRequest{
+		UserID:        userID,
+		ProjectID:     projectID,
+		PlatformAdmin: platformAdmin,
+	})
 	if err != nil {
-		common.InternalServerError(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
 	common.OK(w, resp)
 }
 
-// Update handles schema update
+// Update handles schema update. Changed content is published as a new version.
 // PUT /api/v1/schemas/{schemaId}
 func (h *SchemaHandler) Update(w http.ResponseWriter, r *http.Request) {
 	schemaID := chi.URLParam(r, "schemaId")
@@ -86,6 +140,7 @@ func (h *SchemaHandler) Update(w http.ResponseWriter, r *http.Request) {
 	var reqBody struct {
 		Name          *string `json:"name,omitempty"`
 		SchemaContent *string `json:"schema_content,omitempty"`
+		Force         bool    `json:"force,omitempty"`
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
@@ -93,13 +148,22 @@ func (h *SchemaHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
+	// Get user ID from auth context
+	userID := authn.GetUserID(r.Context())
+	if userID == "" {
+		common.Unauthorized(w, "User not authenticated")
+		return
+	}
+	
 	resp, err := h.updateUseCase.Execute(r.Context(), schema.UpdateSchemaRequest{
-		SchemaID:      schemaID,
-		Name:          reqBody.Name,
-		SchemaContent: reqBody.SchemaContent,
+		SchemaID:        schemaID,
+		Name:            reqBody.Name,
+		SchemaContent:   reqBody.SchemaContent,
+		Force:           reqBody.Force,
+		UpdatedByUserID: userID,
 	})
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -115,10 +179,199 @@ func (h *SchemaHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		SchemaID: schemaID,
 	})
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
 	common.NoContent(w)
 }
 
+// Validate handles checking candidate config content against a schema.
+// Nothing is stored; the result lists every validation error.
+// POST /api/v1/schemas/{schemaId}:validate
+func (h *SchemaHandler) Validate(w http.ResponseWriter, r *http.Request) {
+	schemaID := chi.URLParam(r, "schemaId")
+	
+	var reqBody struct {
+		Version int32           `json:"version"`
+		Content json.RawMessage `json:"content"`
+		Format  string          `json:"format"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+	
+	format, err := parseContentFormat(reqBody.Format)
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return
+	}
+	
+	resp, err := h.validateUseCase.Execute(r.Context(), schema.ValidateContentRequest{
+		SchemaID: schemaID,
+		Version:  reqBody.Version,
+		Content:  reqBody.Content,
+		Format:   format,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	common.OK(w, resp)
+}
+
+// Preview handles reporting the impact of new schema content: how it
+// compares to the latest version and which configs would fail it.
+// Nothing is published.
+// POST /api/v1/schemas/{schemaId}:preview
+func (h *SchemaHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	schemaID := chi.URLParam(r, "schemaId")
+	
+	var reqBody struct {
+		SchemaContent string          `json:"schema_content"`
+		Migration     json.RawMessage `json:"migration,omitempty"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+	
+	resp, err := h.previewUseCase.Execute(r.Context(), schema.PreviewSchemaChangeRequest{
+		SchemaID:      schemaID,
+		SchemaContent: reqBody.SchemaContent,
+		Migration:     reqBody.Migration,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	common.OK(w, resp)
+}
+
+// PublishVersion handles publishing a new immutable version of a schema
+// POST /api/v1/schemas/{schemaId}/versions
+func (h *SchemaHandler) PublishVersion(w http.ResponseWriter, r *http.Request) {
+	schemaID := chi.URLParam(r, "schemaId")
+	
+	var reqBody struct {
+		SchemaContent string          `json:"schema_content"`
+		Migration     json.RawMessage `json:"migration,omitempty"`
+		Force         bool            `json:"force,omitempty"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		common.BadRequest(w, "Invalid request body")
+		return
+	}
+	
+	// Get user ID from auth context
+	userID := authn.GetUserID(r.Context())
+	if userID == "" {
+		common.Unauthorized(w, "User not authenticated")
+		return
+	}
+	
+	resp, err := h.publishVersionUseCase.Execute(r.Context(), schema.PublishSchemaVersionRequest{
+		SchemaID:        schemaID,
+		SchemaContent:   reqBody.SchemaContent,
+		Migration:       reqBody.Migration,
+		Force:           reqBody.Force,
+		CreatedByUserID: userID,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	common.Created(w, resp)
+}
+
+// ListVersions handles listing the versions of a schema
+// GET /api/v1/schemas/{schemaId}/versions
+func (h *SchemaHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	schemaID := chi.URLParam(r, "schemaId")
+	
+	resp, err := h.listVersionsUseCase.Execute(r.Context(), schemaID)
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	common.OK(w, resp)
+}
+
+// GetVersion handles retrieving one version of a schema
+// GET /api/v1/schemas/{schemaId}/versions/{version}
+func (h *SchemaHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	schemaID := chi.URLParam(r, "schemaId")
+	
+	version, err := strconv.ParseInt(chi.URLParam(r, "version"), 10, 32)
+	if err != nil {
+		common.BadRequest(w, "Invalid schema version")
+		return
+	}
+	
+	resp, err := h.getVersionUseCase.Execute(r.Context(), schema.GetSchemaVersionRequest{
+		SchemaID: schemaID,
+		Version:  int32(version),
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	common.OK(w, resp)
+}
+
+// Usage handles reporting the configs using a schema and whether they pass
+// its latest version
+// GET /api/v1/schemas/{schemaId}/usage
+func (h *SchemaHandler) Usage(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.usageUseCase.Execute(r.Context(), chi.URLParam(r, "schemaId"))
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	common.OK(w, resp)
+}
+
+// Codegen handles generating Go structs or TypeScript interfaces from a
+// schema version. The body is the source file itself.
+// GET /api/v1/schemas/{schemaId}/codegen?lang=go|typescript&version=&type=&package=
+func (h *SchemaHandler) Codegen(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	
+	var version int64
+	if raw := query.Get("version"); raw != "" {
+		var err error
+		version, err = strconv.ParseInt(raw, 10, 32)
+		if err != nil || version < 1 {
+			common.BadRequest(w, "Invalid schema version")
+			return
+		}
+	}
+	
+	resp, err := h.codegenUseCase.Execute(r.Context(), schema.GenerateSchemaCodeRequest{
+		SchemaID: chi.URLParam(r, "schemaId"),
+		Version:  int32(version),
+		Language: query.Get("lang"),
+		TypeName: query.Get("type"),
+		Package:  query.Get("package"),
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": resp.FileName}))
+	w.Header().Set("X-Schema-Version", strconv.FormatInt(int64(resp.Version), 10))
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp.Code)
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/middleware"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+)
+
+const (
+	defaultHeartbeatInterval = 15 * time.Second
+	streamRetryMillis        = 3000
+)
+
+// StreamHandler handles Server-Sent Events streams of config changes
+type StreamHandler struct {
+	streamUseCase     *config.StreamConfigChangesUseCase
+	heartbeatInterval time.Duration
+}
+
+// NewStreamHandler creates a new StreamHandler
+func NewStreamHandler(streamUseCase *config.StreamConfigChangesUseCase, heartbeatInterval time.Duration) *StreamHandler {
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultHeartbeatInterval
+	}
+
+	return &StreamHandler{
+		streamUseCase:     streamUseCase,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// StreamByAPIKey streams a project's config changes using its API key.
+// The project and scope come from the API key middleware, which also
+// handles the key in the path on the deprecated route.
+// ?key= watches one config, and ?key=&path= one fragment of it.
+// GET /api/v1/read/stream
+// GET /api/v1/read/{apiKey}/stream (deprecated)
+func (h *StreamHandler) StreamByAPIKey(w http.ResponseWriter, r *http.Request) {
+	lastEventID, ok := parseLastEventID(w, r)
+	if !ok {
+		return
+	}
+	key, path, ok := parseWatchTarget(w, r)
+	if !ok {
+		return
+	}
+
+	sub, err := h.streamUseCase.Execute(r.Context(), config.StreamConfigChangesRequest{
+		ProjectID:   middleware.GetProjectID(r.Context()),
+		LastEventID: lastEventID,
+		Key:         key,
+		Path:        path,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	defer sub.Close()
+
+	h.serve(w, r, sub, middleware.GetAPIKeyScope(r.Context()))
+}
+
+// StreamByProject streams a project's config changes for authenticated users.
+// ?key= watches one config, and ?key=&path= one fragment of it.
+// GET /api/v1/projects/{projectId}/stream
+func (h *StreamHandler) StreamByProject(w http.ResponseWriter, r *http.Request) {
+	lastEventID, ok := parseLastEventID(w, r)
+	if !ok {
+		return
+	}
+	key, path, ok := parseWatchTarget(w, r)
+	if !ok {
+		return
+	}
+
+	sub, err := h.streamUseCase.Execute(r.Context(), config.StreamConfigChangesRequest{
+		ProjectID:   chi.URLParam(r, "projectId"),
+		LastEventID: lastEventID,
+		Key:         key,
+		Path:        path,
+	})
+	if err != nil {
+		common.RespondAppError(w, err)
+		return
+	}
+	defer sub.Close()
+
+	h.serve(w, r, sub, valueobjects.APIKeyScope{})
+}
+
+// serve writes events within scope to the client until it disconnects or falls behind
+func (h *StreamHandler) serve(w http.ResponseWriter, r *http.Request, sub outbound.EventSubscription, scope valueobjects.APIKeyScope) {
+	rc := http.NewResponseController(w)
+
+	// Streams outlive the server's write timeout
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMillis)
+
+	// Tell the client that part of the history it asked for is gone
+	if sub.Gap() {
+		fmt.Fprint(w, "event: resync\ndata: {\"reason\":\"events_expired\"}\n\n")
+	}
+
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-sub.Dropped():
+			// Client is too slow; it can reconnect with Last-Event-ID to catch up
+			fmt.Fprint(w, "event: overflow\ndata: {\"reason\":\"consumer_too_slow\"}\n\n")
+			_ = rc.Flush()
+			return
+
+		case event := <-sub.Events():
+			if !scope.AllowsConfigKey(event.ConfigKey) {
+				continue
+			}
+			// The events of one write share an ID, which is only a
+			// complete resume position after the last of them
+			if event.Last {
+				fmt.Fprintf(w, "id: %d\n", event.ID)
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, event.Data)
+			if err := rc.Flush(); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseLastEventID reads the resume position from the Last-Event-ID header
+// or the last_event_id query parameter (for clients that cannot set headers)
+func parseLastEventID(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, true
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		common.BadRequest(w, "Invalid Last-Event-ID")
+		return 0, false
+	}
+
+	return id, true
+}
+
+// parseWatchTarget reads the optional config key and fragment path to watch
+func parseWatchTarget(w http.ResponseWriter, r *http.Request) (string, valueobjects.ConfigPath, bool) {
+	key := r.URL.Query().Get("key")
+
+	path, err := valueobjects.NewConfigPath(r.URL.Query().Get("path"))
+	if err != nil {
+		common.BadRequest(w, err.Error())
+		return "", valueobjects.ConfigPath{}, false
+	}
+	if key == "" && !path.IsRoot() {
+		common.BadRequest(w, "key is required to watch a path")
+		return "", valueobjects.ConfigPath{}, false
+	}
+
+	return key, path, true
+}
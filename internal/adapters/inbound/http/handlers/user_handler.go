@@ -43,7 +43,7 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 	
 	resp, err := h.createUseCase.Execute(r.Context(), req)
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -55,7 +55,7 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.listUseCase.Execute(r.Context())
 	if err != nil {
-		common.InternalServerError(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -71,7 +71,7 @@ func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
 		UserID: userID,
 	})
 	if err != nil {
-		common.NotFound(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
@@ -87,7 +87,7 @@ func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		UserID: userID,
 	})
 	if err != nil {
-		common.BadRequest(w, err.Error())
+		common.RespondAppError(w, err)
 		return
 	}
 	
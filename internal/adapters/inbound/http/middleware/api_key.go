@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/usecases/auth"
+)
+
+const (
+	// APIKeyPrincipalKey is the context key for the client authenticated by an API key
+	APIKeyPrincipalKey contextKey = "api_key_principal"
+)
+
+// APIKeyAuthenticator resolves an API key to the project and scope it grants
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, apiKey string) (*auth.APIKeyPrincipal, error)
+}
+
+// APIKeyAuth middleware authenticates clients by project API key.
+// The key is read from "Authorization: ApiKey <key>" or the X-API-Key header.
+func APIKeyAuth(authenticator APIKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey := ExtractAPIKey(r)
+			if apiKey == "" {
+				common.RespondError(w, http.StatusUnauthorized, "Missing API key", "UNAUTHORIZED")
+				return
+			}
+
+			principal, err := authenticator.Authenticate(r.Context(), apiKey)
+			if err != nil {
+				respondAuthenticateError(w, err)
+				return
+			}
+
+			ctx := WithAPIKeyPrincipal(r.Context(), principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// APIKeyInPathAuth middleware authenticates the legacy read routes that carry
+// the API key in the URL path, and marks their responses as deprecated
+func APIKeyInPathAuth(authenticator APIKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", `</api/v1/read>; rel="successor-version"`)
+
+			principal, err := authenticator.Authenticate(r.Context(), chi.URLParam(r, "apiKey"))
+			if err != nil {
+				respondAuthenticateError(w, err)
+				return
+			}
+
+			ctx := WithAPIKeyPrincipal(r.Context(), principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// respondAuthenticateError answers 401 for a rejected key and passes any
+// other failure, such as an unreachable key store, through with its own status
+func respondAuthenticateError(w http.ResponseWriter, err error) {
+	if apperrors.HasCode(err, apperrors.ErrCodeInvalidAPIKey) {
+		common.RespondError(w, http.StatusUnauthorized, "Invalid API key", "UNAUTHORIZED")
+		return
+	}
+	common.RespondAppError(w, err)
+}
+
+// ExtractAPIKey returns the API key from the Authorization or X-API-Key header
+func ExtractAPIKey(r *http.Request) string {
+	if scheme, key, ok := authn.SplitAuthorization(r.Header.Get("Authorization")); ok && strings.EqualFold(scheme, authn.APIKeyScheme) {
+		return key
+	}
+	return strings.TrimSpace(r.Header.Get(authn.APIKeyHeader))
+}
+
+// WithAPIKeyPrincipal returns a context carrying the client authenticated by an API key
+func WithAPIKeyPrincipal(ctx context.Context, principal *auth.APIKeyPrincipal) context.Context {
+	return context.WithValue(ctx, APIKeyPrincipalKey, principal)
+}
+
+// GetAPIKeyPrincipal retrieves the client authenticated by an API key from context
+func GetAPIKeyPrincipal(ctx context.Context) *auth.APIKeyPrincipal {
+	if principal, ok := ctx.Value(APIKeyPrincipalKey).(*auth.APIKeyPrincipal); ok {
+		return principal
+	}
+	return nil
+}
+
+// GetProjectID retrieves the API key's project ID from context
+func GetProjectID(ctx context.Context) string {
+	if principal := GetAPIKeyPrincipal(ctx); principal != nil {
+		return principal.ProjectID
+	}
+	return ""
+}
+
+// GetAPIKeyScope retrieves the configs the API key may read from context.
+// Requests without an API key get an unrestricted scope.
+func GetAPIKeyScope(ctx context.Context) valueobjects.APIKeyScope {
+	if principal := GetAPIKeyPrincipal(ctx); principal != nil {
+		return principal.Scope
+	}
+	return valueobjects.APIKeyScope{}
+}
+
+// redactedPath returns the request path with any API keys masked
+func redactedPath(r *http.Request) string {
+	return valueobjects.MaskAPIKeys(r.URL.Path)
+}
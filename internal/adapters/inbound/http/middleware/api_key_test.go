@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/usecases/auth"
+)
+
+const (
+	testAPIKey = "cfg_abcdefghijklmnopqrstuvwxyz012345"
+
+	// unverifiableAPIKey simulates a key that can't be checked because the key store is down
+	unverifiableAPIKey = "cfg_unverifiableunverifiableunveri"
+)
+
+// fakeAPIKeyAuthenticator accepts only testAPIKey, scoped to "mobile."
+type fakeAPIKeyAuthenticator struct{}
+
+func (fakeAPIKeyAuthenticator) Authenticate(ctx context.Context, apiKey string) (*auth.APIKeyPrincipal, error) {
+	if apiKey == unverifiableAPIKey {
+		return nil, apperrors.Internal(fmt.Errorf("connection refused"), "failed to look up API key")
+	}
+	if apiKey != testAPIKey {
+		return nil, apperrors.New(apperrors.ErrCodeInvalidAPIKey, "invalid API key")
+	}
+	scope, err := valueobjects.NewAPIKeyScope([]string{"mobile."})
+	if err != nil {
+		return nil, err
+	}
+	return &auth.APIKeyPrincipal{KeyID: "key-1", ProjectID: "project-1", Scope: scope}, nil
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	newHandler := func(projectID *string) http.Handler {
+		return APIKeyAuth(fakeAPIKeyAuthenticator{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*projectID = GetProjectID(r.Context())
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	t.Run("accepts valid keys from supported headers", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			header string
+			value  string
+		}{
+			{"authorization ApiKey scheme", "Authorization", "ApiKey " + testAPIKey},
+			{"case-insensitive scheme", "Authorization", "apikey " + testAPIKey},
+			{"X-API-Key header", "X-API-Key", testAPIKey},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Arrange
+				var projectID string
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/read/app-config", nil)
+				req.Header.Set(tc.header, tc.value)
+				rec := httptest.NewRecorder()
+
+				// Act
+				newHandler(&projectID).ServeHTTP(rec, req)
+
+				// Assert
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "project-1", projectID)
+			})
+		}
+	})
+
+	t.Run("rejects missing key", func(t *testing.T) {
+		var projectID string
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/read/app-config", nil)
+		req.Header.Set("Authorization", "Bearer some-jwt")
+		rec := httptest.NewRecorder()
+
+		newHandler(&projectID).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Missing API key")
+		assert.Empty(t, projectID)
+	})
+
+	t.Run("rejects unknown key", func(t *testing.T) {
+		var projectID string
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/read/app-config", nil)
+		req.Header.Set("X-API-Key", "cfg_00000000000000000000000000000000")
+		rec := httptest.NewRecorder()
+
+		newHandler(&projectID).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Invalid API key")
+	})
+
+	t.Run("does not reject keys it could not verify", func(t *testing.T) {
+		var projectID string
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/read/app-config", nil)
+		req.Header.Set("X-API-Key", unverifiableAPIKey)
+		rec := httptest.NewRecorder()
+
+		newHandler(&projectID).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Empty(t, projectID)
+	})
+}
+
+func TestAPIKeyAuth_CarriesScope(t *testing.T) {
+	var scope valueobjects.APIKeyScope
+	handler := APIKeyAuth(fakeAPIKeyAuthenticator{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope = GetAPIKeyScope(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/read/mobile.ios", nil)
+	req.Header.Set("X-API-Key", testAPIKey)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, scope.AllowsConfigKey("mobile.ios"))
+	assert.False(t, scope.AllowsConfigKey("web.app"))
+}
+
+func TestAPIKeyInPathAuth(t *testing.T) {
+	var projectID string
+	r := chi.NewRouter()
+	r.With(APIKeyInPathAuth(fakeAPIKeyAuthenticator{})).Get("/read/{apiKey}/{configKey}", func(w http.ResponseWriter, r *http.Request) {
+		projectID = GetProjectID(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("authenticates key from path and marks deprecation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/read/"+testAPIKey+"/app-config", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "project-1", projectID)
+		assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+		assert.Contains(t, rec.Header().Get("Link"), "successor-version")
+	})
+
+	t.Run("rejects unknown key", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/read/cfg_00000000000000000000000000000000/app-config", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+	})
+}
+
+func TestLogging_RedactsAPIKeys(t *testing.T) {
+	// Arrange
+	var logBuf bytes.Buffer
+	oldLogger := slog.Default()
+	defer slog.SetDefault(oldLogger)
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logBuf, nil)))
+
+	handler := Logging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/read/"+testAPIKey+"/app-config", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	logOutput := logBuf.String()
+	assert.NotContains(t, logOutput, testAPIKey)
+	assert.Contains(t, logOutput, "/api/v1/read/cfg_...2345/app-config")
+}
@@ -1,31 +1,13 @@
 package middleware
 
 import (
-	"context"
-	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
 )
 
-const (
-	// UserIDKey is the context key for user ID
-	UserIDKey contextKey = "user_id"
-	
-	// UserEmailKey is the context key for user email
-	UserEmailKey contextKey = "user_email"
-)
-
-// Claims represents JWT claims
-type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Type   string `json:"type"` // "access" or "refresh"
-	jwt.RegisteredClaims
-}
-
 // AuthConfig holds authentication configuration
 type AuthConfig struct {
 	JWTSecret string
@@ -38,123 +20,31 @@ func Auth(cfg AuthConfig) func(http.Handler) http.Handler {
 			// Extract token from Authorization header
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte(`{"error":"Missing authorization header","code":"UNAUTHORIZED"}`))
+				common.RespondError(w, http.StatusUnauthorized, "Missing authorization header", "UNAUTHORIZED")
 				return
 			}
 			
 			// Check Bearer prefix
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte(`{"error":"Invalid authorization header format","code":"UNAUTHORIZED"}`))
+				common.RespondError(w, http.StatusUnauthorized, "Invalid authorization header format", "UNAUTHORIZED")
 				return
 			}
 			
 			tokenString := parts[1]
 			
 			// Parse and validate token
-			claims := &Claims{}
-			token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-				// Validate signing method
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-				}
-				return []byte(cfg.JWTSecret), nil
-			})
-			
-			if err != nil || !token.Valid {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte(`{"error":"Invalid or expired token","code":"UNAUTHORIZED"}`))
+			claims, err := authn.ParseAccessToken(tokenString, cfg.JWTSecret)
+			if err != nil {
+				common.RespondError(w, http.StatusUnauthorized, "Invalid or expired token", "UNAUTHORIZED")
 				return
 			}
 			
 			// Add user info to context
-			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-			ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
+			ctx := authn.WithUser(r.Context(), claims.UserID, claims.Email)
 			
 			// Call next handler
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
-
-// GetUserID retrieves the user ID from context
-func GetUserID(ctx context.Context) string {
-	if userID, ok := ctx.Value(UserIDKey).(string); ok {
-		return userID
-	}
-	return ""
-}
-
-// GetUserEmail retrieves the user email from context
-func GetUserEmail(ctx context.Context) string {
-	if email, ok := ctx.Value(UserEmailKey).(string); ok {
-		return email
-	}
-	return ""
-}
-
-// GenerateToken generates a JWT access token for a user
-func GenerateToken(userID, email, secret string, expiration time.Duration) (string, error) {
-	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Type:   "access",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "cfguardian",
-		},
-	}
-	
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
-}
-
-// GenerateRefreshToken generates a JWT refresh token for a user
-func GenerateRefreshToken(userID, email, secret string, expiration time.Duration) (string, error) {
-	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Type:   "refresh",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "cfguardian",
-		},
-	}
-	
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
-}
-
-// ValidateRefreshToken validates a refresh token and returns claims
-func ValidateRefreshToken(tokenString, secret string) (*Claims, error) {
-	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
-	
-	if err != nil {
-		return nil, fmt.Errorf("invalid refresh token: %w", err)
-	}
-	
-	if !token.Valid {
-		return nil, fmt.Errorf("refresh token is not valid")
-	}
-	
-	// Verify it's a refresh token
-	if claims.Type != "refresh" {
-		return nil, fmt.Errorf("token is not a refresh token")
-	}
-	
-	return claims, nil
-}
-
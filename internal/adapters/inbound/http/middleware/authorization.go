@@ -5,7 +5,10 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
 	"github.com/vlone310/cfguardian/internal/usecases/role"
+	"github.com/vlone310/cfguardian/internal/usecases/schema"
 )
 
 // PermissionChecker defines the interface for checking permissions
@@ -13,9 +16,21 @@ type PermissionChecker interface {
 	Execute(ctx context.Context, req role.CheckPermissionRequest) (*role.CheckPermissionResponse, error)
 }
 
+// PlatformAdminChecker defines the interface for recognising platform admins
+type PlatformAdminChecker interface {
+	Execute(ctx context.Context, userID string) (bool, error)
+}
+
+// SchemaScopeResolver defines the interface for finding the project that governs a schema
+type SchemaScopeResolver interface {
+	Execute(ctx context.Context, schemaID string) (*schema.SchemaScope, error)
+}
+
 // AuthorizationConfig holds authorization configuration
 type AuthorizationConfig struct {
 	CheckPermission PermissionChecker
+	PlatformAdmin   PlatformAdminChecker
+	SchemaScope     SchemaScopeResolver
 }
 
 // RequireRole middleware requires a specific role level for the project
@@ -23,11 +38,9 @@ func RequireRole(cfg AuthorizationConfig, requiredRole string) func(http.Handler
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Get user ID from context (set by Auth middleware)
-			userID := GetUserID(r.Context())
+			userID := authn.GetUserID(r.Context())
 			if userID == "" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusUnauthorized)
-				w.Write([]byte(`{"error":"User not authenticated","code":"UNAUTHORIZED"}`))
+				common.RespondError(w, http.StatusUnauthorized, "User not authenticated", "UNAUTHORIZED")
 				return
 			}
 
@@ -47,9 +60,7 @@ func RequireRole(cfg AuthorizationConfig, requiredRole string) func(http.Handler
 			})
 
 			if err != nil || !resp.Allowed {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusForbidden)
-				w.Write([]byte(`{"error":"Insufficient permissions","code":"FORBIDDEN"}`))
+				common.RespondError(w, http.StatusForbidden, "Insufficient permissions", "FORBIDDEN")
 				return
 			}
 
@@ -72,3 +83,86 @@ func RequireEditor(cfg AuthorizationConfig) func(http.Handler) http.Handler {
 func RequireViewer(cfg AuthorizationConfig) func(http.Handler) http.Handler {
 	return RequireRole(cfg, "viewer")
 }
+
+// RequirePlatformAdmin middleware requires the user to be a platform admin
+func RequirePlatformAdmin(cfg AuthorizationConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := authn.GetUserID(r.Context())
+			if userID == "" {
+				common.RespondError(w, http.StatusUnauthorized, "User not authenticated", "UNAUTHORIZED")
+				return
+			}
+
+			if !isPlatformAdmin(r.Context(), cfg, userID) {
+				common.RespondError(w, http.StatusForbidden, "Platform admin required", "FORBIDDEN")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSchemaRole middleware requires a role on the schema in the
+// schemaId URL param. A project schema needs the role in its project; any
+// user may view a global schema, but only platform admins may do more.
+// Platform admins pass for every schema.
+func RequireSchemaRole(cfg AuthorizationConfig, requiredRole string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := authn.GetUserID(r.Context())
+			if userID == "" {
+				common.RespondError(w, http.StatusUnauthorized, "User not authenticated", "UNAUTHORIZED")
+				return
+			}
+
+			scope, err := cfg.SchemaScope.Execute(r.Context(), chi.URLParam(r, "schemaId"))
+			if err != nil {
+				common.RespondAppError(w, err)
+				return
+			}
+
+			if scope.IsGlobal() {
+				if requiredRole == "viewer" || isPlatformAdmin(r.Context(), cfg, userID) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				common.RespondError(w, http.StatusForbidden, "Global schemas are managed by platform admins", "FORBIDDEN")
+				return
+			}
+
+			resp, err := cfg.CheckPermission.Execute(r.Context(), role.CheckPermissionRequest{
+				UserID:            userID,
+				ProjectID:         scope.ProjectID,
+				RequiredRoleLevel: requiredRole,
+			})
+			if (err != nil || !resp.Allowed) && !isPlatformAdmin(r.Context(), cfg, userID) {
+				common.RespondError(w, http.StatusForbidden, "Insufficient permissions", "FORBIDDEN")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSchemaAdmin middleware requires admin role on the schema
+func RequireSchemaAdmin(cfg AuthorizationConfig) func(http.Handler) http.Handler {
+	return RequireSchemaRole(cfg, "admin")
+}
+
+// RequireSchemaViewer middleware requires at least viewer role on the schema
+func RequireSchemaViewer(cfg AuthorizationConfig) func(http.Handler) http.Handler {
+	return RequireSchemaRole(cfg, "viewer")
+}
+
+// isPlatformAdmin reports whether the user is a platform admin; a failed
+// check counts as not
+func isPlatformAdmin(ctx context.Context, cfg AuthorizationConfig, userID string) bool {
+	if cfg.PlatformAdmin == nil {
+		return false
+	}
+	admin, err := cfg.PlatformAdmin.Execute(ctx, userID)
+	return err == nil && admin
+}
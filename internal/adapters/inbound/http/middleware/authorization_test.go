@@ -12,7 +12,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/authn"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/usecases/role"
+	"github.com/vlone310/cfguardian/internal/usecases/schema"
 )
 
 // MockPermissionChecker is a mock for PermissionChecker
@@ -28,6 +31,29 @@ func (m *MockPermissionChecker) Execute(ctx context.Context, req role.CheckPermi
 	return args.Get(0).(*role.CheckPermissionResponse), args.Error(1)
 }
 
+// MockPlatformAdminChecker is a mock for PlatformAdminChecker
+type MockPlatformAdminChecker struct {
+	mock.Mock
+}
+
+func (m *MockPlatformAdminChecker) Execute(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockSchemaScopeResolver is a mock for SchemaScopeResolver
+type MockSchemaScopeResolver struct {
+	mock.Mock
+}
+
+func (m *MockSchemaScopeResolver) Execute(ctx context.Context, schemaID string) (*schema.SchemaScope, error) {
+	args := m.Called(ctx, schemaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.SchemaScope), args.Error(1)
+}
+
 func TestRequireRole(t *testing.T) {
 	t.Run("missing user ID in context", func(t *testing.T) {
 		// Arrange
@@ -61,7 +87,7 @@ func TestRequireRole(t *testing.T) {
 		}))
 
 		// Add user ID to context
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodGet, "/test", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -97,7 +123,7 @@ func TestRequireRole(t *testing.T) {
 		})
 
 		// Add user ID to context
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodGet, "/projects/project456/configs", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -133,7 +159,7 @@ func TestRequireRole(t *testing.T) {
 		})
 
 		// Add user ID to context
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodGet, "/projects/project456/configs", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -165,7 +191,7 @@ func TestRequireRole(t *testing.T) {
 		})
 
 		// Add user ID to context
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodGet, "/projects/project456/configs", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -202,7 +228,7 @@ func TestRequireAdmin(t *testing.T) {
 		})
 
 		// Add user ID to context
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodDelete, "/projects/project456", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -231,7 +257,7 @@ func TestRequireAdmin(t *testing.T) {
 			w.WriteHeader(http.StatusNoContent)
 		})
 
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodDelete, "/projects/project456", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -265,7 +291,7 @@ func TestRequireEditor(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		})
 
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodPut, "/projects/project456/configs/app-config", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -294,7 +320,7 @@ func TestRequireEditor(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		})
 
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodPut, "/projects/project456/configs/app-config", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -328,7 +354,7 @@ func TestRequireViewer(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		})
 
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodGet, "/projects/project456/configs", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -356,7 +382,7 @@ func TestAuthorization_Integration(t *testing.T) {
 		authzCfg := AuthorizationConfig{CheckPermission: mockUseCase}
 
 		// Generate valid token
-		token, err := GenerateToken("user123", "user@example.com", secret, 1*time.Hour)
+		token, err := authn.GenerateToken("user123", "user@example.com", secret, 1*time.Hour)
 		require.NoError(t, err)
 
 		// Create router with auth chain
@@ -429,7 +455,7 @@ func TestAuthorization_Integration(t *testing.T) {
 
 		authzCfg := AuthorizationConfig{CheckPermission: mockUseCase}
 
-		token, err := GenerateToken("user123", "user@example.com", secret, 1*time.Hour)
+		token, err := authn.GenerateToken("user123", "user@example.com", secret, 1*time.Hour)
 		require.NoError(t, err)
 
 		router := chi.NewRouter()
@@ -476,7 +502,7 @@ func TestAuthorization_RoleHierarchy(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		})
 
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodPut, "/projects/project456/configs/app-config", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -504,7 +530,7 @@ func TestAuthorization_RoleHierarchy(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		})
 
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodGet, "/projects/project456/configs", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -532,7 +558,7 @@ func TestAuthorization_RoleHierarchy(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		})
 
-		ctx := context.WithValue(context.Background(), UserIDKey, "user123")
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
 		req := httptest.NewRequest(http.MethodPut, "/projects/project456/configs/app-config", nil).WithContext(ctx)
 		rec := httptest.NewRecorder()
 
@@ -543,3 +569,121 @@ func TestAuthorization_RoleHierarchy(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, rec.Code)
 	})
 }
+
+func TestRequirePlatformAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		admin      bool
+		err        error
+		wantStatus int
+	}{
+		{name: "allows platform admin", admin: true, wantStatus: http.StatusCreated},
+		{name: "rejects other users", wantStatus: http.StatusForbidden},
+		{name: "rejects when the check fails", err: errors.New("database error"), wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			adminChecker := new(MockPlatformAdminChecker)
+			adminChecker.On("Execute", mock.Anything, "user123").Return(tt.admin, tt.err)
+			cfg := AuthorizationConfig{PlatformAdmin: adminChecker}
+
+			handler := RequirePlatformAdmin(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			}))
+
+			ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
+			req := httptest.NewRequest(http.MethodPost, "/schemas", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			adminChecker.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRequireSchemaRole(t *testing.T) {
+	tests := []struct {
+		name          string
+		requiredRole  string
+		projectID     string
+		roleAllowed   bool
+		platformAdmin bool
+		wantStatus    int
+	}{
+		{name: "anyone may view a global schema", requiredRole: "viewer", wantStatus: http.StatusOK},
+		{name: "platform admin manages a global schema", requiredRole: "admin", platformAdmin: true, wantStatus: http.StatusOK},
+		{name: "others may not manage a global schema", requiredRole: "admin", wantStatus: http.StatusForbidden},
+		{name: "project admin manages a project schema", requiredRole: "admin", projectID: "project456", roleAllowed: true, wantStatus: http.StatusOK},
+		{name: "project role is required on a project schema", requiredRole: "viewer", projectID: "project456", wantStatus: http.StatusForbidden},
+		{name: "platform admin manages a project schema", requiredRole: "admin", projectID: "project456", platformAdmin: true, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			scopeResolver := new(MockSchemaScopeResolver)
+			scopeResolver.On("Execute", mock.Anything, "schema789").Return(&schema.SchemaScope{SchemaID: "schema789", ProjectID: tt.projectID}, nil)
+			permissionChecker := new(MockPermissionChecker)
+			permissionChecker.On("Execute", mock.Anything, role.CheckPermissionRequest{
+				UserID:            "user123",
+				ProjectID:         tt.projectID,
+				RequiredRoleLevel: tt.requiredRole,
+			}).Return(&role.CheckPermissionResponse{Allowed: tt.roleAllowed}, nil)
+			adminChecker := new(MockPlatformAdminChecker)
+			adminChecker.On("Execute", mock.Anything, "user123").Return(tt.platformAdmin, nil)
+
+			cfg := AuthorizationConfig{
+				CheckPermission: permissionChecker,
+				PlatformAdmin:   adminChecker,
+				SchemaScope:     scopeResolver,
+			}
+
+			router := chi.NewRouter()
+			router.With(RequireSchemaRole(cfg, tt.requiredRole)).Put("/schemas/{schemaId}", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
+			req := httptest.NewRequest(http.MethodPut, "/schemas/schema789", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.projectID == "" {
+				permissionChecker.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+			}
+		})
+	}
+
+	t.Run("unknown schema", func(t *testing.T) {
+		// Arrange
+		scopeResolver := new(MockSchemaScopeResolver)
+		scopeResolver.On("Execute", mock.Anything, "missing").Return(nil, apperrors.New(apperrors.ErrCodeSchemaNotFound, "schema not found"))
+		cfg := AuthorizationConfig{SchemaScope: scopeResolver}
+
+		router := chi.NewRouter()
+		router.With(RequireSchemaViewer(cfg)).Get("/schemas/{schemaId}/versions", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		ctx := context.WithValue(context.Background(), authn.UserIDKey, "user123")
+		req := httptest.NewRequest(http.MethodGet, "/schemas/missing/versions", nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "SCHEMA_NOT_FOUND")
+	})
+}
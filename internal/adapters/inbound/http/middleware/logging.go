@@ -25,6 +25,18 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return size, err
 }
 
+// Flush forwards to the underlying writer so streaming responses work
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // Logging middleware logs HTTP requests with structured logging
 func Logging(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -43,7 +55,7 @@ func Logging(next http.Handler) http.Handler {
 		slog.Info("http request started",
 			slog.String("request_id", requestID),
 			slog.String("method", r.Method),
-			slog.String("path", r.URL.Path),
+			slog.String("path", redactedPath(r)),
 			slog.String("remote_addr", r.RemoteAddr),
 			slog.String("user_agent", r.UserAgent()),
 		)
@@ -57,7 +69,7 @@ func Logging(next http.Handler) http.Handler {
 		slog.Info("http request completed",
 			slog.String("request_id", requestID),
 			slog.String("method", r.Method),
-			slog.String("path", r.URL.Path),
+			slog.String("path", redactedPath(r)),
 			slog.Int("status", wrapped.status),
 			slog.Int("size", wrapped.size),
 			slog.Duration("duration", duration),
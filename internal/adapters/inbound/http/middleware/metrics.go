@@ -42,14 +42,14 @@ func Metrics(metrics *telemetry.PrometheusMetrics) func(http.Handler) http.Handl
 			// Record request count
 			metrics.HTTPRequestsTotal.WithLabelValues(
 				r.Method,
-				r.URL.Path,
+				redactedPath(r),
 				status,
 			).Inc()
 			
 			// Record request duration
 			metrics.HTTPRequestDuration.WithLabelValues(
 				r.Method,
-				r.URL.Path,
+				redactedPath(r),
 			).Observe(duration)
 		})
 	}
@@ -1,10 +1,12 @@
 package middleware
 
 import (
+	"net"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
 	"golang.org/x/time/rate"
 )
 
@@ -55,6 +57,22 @@ func (rl *RateLimiter) getLimiter(key string) *rate.Limiter {
 	return limiter
 }
 
+// Allow reports whether a request from the given remote address may proceed.
+// The same limiter can be shared by the HTTP and gRPC servers.
+func (rl *RateLimiter) Allow(remoteAddr string) bool {
+	return rl.getLimiter(rateLimitKey(remoteAddr)).Allow()
+}
+
+// rateLimitKey returns the limiter key for a remote address (its host part),
+// so HTTP and gRPC connections from the same client share a budget
+func rateLimitKey(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
 // cleanupStaleEntries removes inactive limiters periodically
 func (rl *RateLimiter) cleanupStaleEntries() {
 	ticker := time.NewTicker(1 * time.Minute)
@@ -77,16 +95,14 @@ func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Use IP address as key
-			ip := r.RemoteAddr
+			ip := rateLimitKey(r.RemoteAddr)
 			
 			// Get limiter for this IP
 			ipLimiter := limiter.getLimiter(ip)
 			
 			// Check if request is allowed
 			if !ipLimiter.Allow() {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusTooManyRequests)
-				w.Write([]byte(`{"error":"Rate limit exceeded","code":"RATE_LIMIT_EXCEEDED"}`))
+				common.RespondError(w, http.StatusTooManyRequests, "Rate limit exceeded", "RATE_LIMIT_EXCEEDED")
 				return
 			}
 			
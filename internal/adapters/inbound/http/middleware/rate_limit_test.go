@@ -240,7 +240,7 @@ func TestRateLimit(t *testing.T) {
 
 		// Assert
 		assert.Equal(t, http.StatusTooManyRequests, rec2.Code)
-		assert.Equal(t, "application/json", rec2.Header().Get("Content-Type"))
+		assert.Equal(t, "application/problem+json", rec2.Header().Get("Content-Type"))
 		assert.Contains(t, rec2.Body.String(), "Rate limit exceeded")
 		assert.Contains(t, rec2.Body.String(), "RATE_LIMIT_EXCEEDED")
 	})
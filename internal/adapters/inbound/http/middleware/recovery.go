@@ -1,10 +1,11 @@
 package middleware
 
 import (
-	"fmt"
 	"log/slog"
 	"net/http"
 	"runtime/debug"
+
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/common"
 )
 
 // Recovery middleware recovers from panics and returns a 500 error
@@ -19,15 +20,15 @@ func Recovery(next http.Handler) http.Handler {
 				slog.Error("panic recovered",
 					slog.String("request_id", requestID),
 					slog.String("method", r.Method),
-					slog.String("path", r.URL.Path),
+					slog.String("path", redactedPath(r)),
 					slog.Any("error", err),
 					slog.String("stack", string(debug.Stack())),
 				)
 				
 				// Return 500 error
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusInternalServerError)
-				fmt.Fprintf(w, `{"error":"Internal server error","code":"INTERNAL_SERVER_ERROR","request_id":"%s"}`, requestID)
+				problem := common.NewProblem(http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+				problem.RequestID = requestID
+				common.RespondProblem(w, problem)
 			}
 		}()
 		
@@ -29,18 +29,18 @@ func TestRecovery(t *testing.T) {
 		// Assert
 		assert.Equal(t, http.StatusInternalServerError, rec.Code,
 			"Should return 500 status code")
-		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"),
-			"Should set JSON content type")
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"),
+			"Should set problem JSON content type")
 
 		// Parse response body
 		var response map[string]interface{}
 		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		require.NoError(t, err, "Should return valid JSON")
 
-		assert.Equal(t, "Internal server error", response["error"])
-		assert.Equal(t, "INTERNAL_SERVER_ERROR", response["code"])
-		assert.Contains(t, response, "request_id", "Should include request_id field")
-		// Note: request_id will be empty if RequestID middleware is not used
+		assert.Equal(t, "Internal server error", response["detail"])
+		assert.Equal(t, "INTERNAL_ERROR", response["code"])
+		assert.Equal(t, float64(http.StatusInternalServerError), response["status"])
+		assert.NotContains(t, response, "request_id", "request_id is omitted without the RequestID middleware")
 	})
 
 	t.Run("recovers from panic with request ID in context", func(t *testing.T) {
@@ -128,8 +128,8 @@ func TestRecovery(t *testing.T) {
 				err := json.Unmarshal(rec.Body.Bytes(), &response)
 				require.NoError(t, err, "Should return valid JSON")
 
-				assert.Equal(t, "Internal server error", response["error"])
-				assert.Equal(t, "INTERNAL_SERVER_ERROR", response["code"])
+				assert.Equal(t, "Internal server error", response["detail"])
+				assert.Equal(t, "INTERNAL_ERROR", response["code"])
 			})
 		}
 	})
@@ -208,18 +208,19 @@ func TestRecovery_ErrorResponse(t *testing.T) {
 		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		// Verify all required fields are present
-		assert.Contains(t, response, "error", "Should contain error field")
+		// Verify the RFC 7807 members are present
+		assert.Contains(t, response, "type", "Should contain type field")
+		assert.Contains(t, response, "title", "Should contain title field")
+		assert.Contains(t, response, "status", "Should contain status field")
+		assert.Contains(t, response, "detail", "Should contain detail field")
 		assert.Contains(t, response, "code", "Should contain code field")
-		assert.Contains(t, response, "request_id", "Should contain request_id field")
 
 		// Verify field types
-		assert.IsType(t, "", response["error"], "error should be string")
+		assert.IsType(t, "", response["type"], "type should be string")
+		assert.IsType(t, "", response["title"], "title should be string")
+		assert.IsType(t, float64(0), response["status"], "status should be a number")
+		assert.IsType(t, "", response["detail"], "detail should be string")
 		assert.IsType(t, "", response["code"], "code should be string")
-		assert.IsType(t, "", response["request_id"], "request_id should be string")
-
-		// Note: request_id will be empty if RequestID middleware is not used
-		// This is expected behavior - the field is present but may be empty
 	})
 
 	t.Run("error message is user-friendly", func(t *testing.T) {
@@ -240,7 +241,7 @@ func TestRecovery_ErrorResponse(t *testing.T) {
 		require.NoError(t, err)
 
 		// Should return generic error message, not internal details
-		assert.Equal(t, "Internal server error", response["error"],
+		assert.Equal(t, "Internal server error", response["detail"],
 			"Should not expose internal error details")
 	})
 }
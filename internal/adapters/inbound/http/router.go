@@ -1,7 +1,9 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -17,13 +19,19 @@ type RouterConfig struct {
 	JWTSecret          string
 	RateLimitRPS       float64
 	RateLimitBurst     int
+	RateLimiter        *middleware.RateLimiter // Shared limiter; built from RPS/Burst if nil
+	APIKeyAuthenticator middleware.APIKeyAuthenticator
+	AllowAPIKeyInPath  bool // Serve the deprecated read routes that carry the API key in the URL
 	AuthHandler        *handlers.AuthHandler
 	UserHandler        *handlers.UserHandler
 	ProjectHandler     *handlers.ProjectHandler
+	APIKeyHandler      *handlers.APIKeyHandler
 	RoleHandler        *handlers.RoleHandler
 	SchemaHandler      *handlers.SchemaHandler
+	MigrationHandler   *handlers.MigrationHandler
 	ConfigHandler      *handlers.ConfigHandler
 	ReadHandler        *handlers.ReadHandler
+	StreamHandler      *handlers.StreamHandler
 	HealthHandler      *handlers.HealthHandler
 	MetricsHandler     *handlers.MetricsHandler
 	PrometheusMetrics  *telemetry.PrometheusMetrics
@@ -42,7 +50,10 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 	r.Use(middleware.Logging)
 	r.Use(middleware.CORS())
 	r.Use(chiMiddleware.Compress(5))
-	r.Use(chiMiddleware.Timeout(60 * time.Second))
+	// Long-lived event streams are exempt from the request timeout
+	r.Use(chiMiddleware.Maybe(chiMiddleware.Timeout(60*time.Second), func(r *http.Request) bool {
+		return !isStreamRequest(r)
+	}))
 	
 	// Metrics middleware (if enabled)
 	if cfg.PrometheusMetrics != nil {
@@ -50,11 +61,21 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 	}
 	
 	// Rate limiting (if configured)
-	if cfg.RateLimitRPS > 0 {
+	if cfg.RateLimiter != nil {
+		r.Use(middleware.RateLimit(cfg.RateLimiter))
+	} else if cfg.RateLimitRPS > 0 {
 		rateLimiter := middleware.NewRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst)
 		r.Use(middleware.RateLimit(rateLimiter))
 	}
 	
+	// Unknown routes and methods answer with problem responses too
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		common.NotFound(w, "Route not found")
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		common.RespondError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed", r.Method), "METHOD_NOT_ALLOWED")
+	})
+	
 	// Health check endpoints (no auth required)
 	if cfg.HealthHandler != nil {
 		r.Get("/health", cfg.HealthHandler.Health)
@@ -84,8 +105,23 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 			r.Post("/auth/login", cfg.AuthHandler.Login)
 			r.Post("/auth/refresh", cfg.AuthHandler.RefreshToken)
 			
-			// Public read API (API key in URL path)
-			r.Get("/read/{apiKey}/{configKey}", cfg.ReadHandler.Read)
+			// Deprecated read API (API key in URL path, exposed in access logs)
+			if cfg.AllowAPIKeyInPath {
+				r.With(middleware.APIKeyInPathAuth(cfg.APIKeyAuthenticator)).Get("/read/{apiKey}/stream", cfg.StreamHandler.StreamByAPIKey)
+				r.With(middleware.APIKeyInPathAuth(cfg.APIKeyAuthenticator)).Get("/read/{apiKey}/{configKey}", cfg.ReadHandler.Read)
+			}
+		})
+		
+		// Client read API (API key in Authorization or X-API-Key header)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.APIKeyAuth(cfg.APIKeyAuthenticator))
+			
+			// These names are reserved config keys so they never shadow /read/{configKey}
+			r.Get("/read/stream", cfg.StreamHandler.StreamByAPIKey)
+			r.Post("/read/batch", cfg.ReadHandler.ReadBatch)
+			r.Get("/read/bundle", cfg.ReadHandler.ReadBundle)
+			r.Get("/read/changes", cfg.ReadHandler.ReadChanges)
+			r.Get("/read/{configKey}", cfg.ReadHandler.Read)
 		})
 		
 		// Protected routes (JWT authentication required)
@@ -113,6 +149,27 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 					r.Get("/", cfg.ProjectHandler.Get)
 					r.Delete("/", cfg.ProjectHandler.Delete)
 					
+					// Schemas owned by the project, listed with the global ones
+					r.Route("/schemas", func(r chi.Router) {
+						r.With(middleware.RequireViewer(cfg.AuthorizationConfig)).Get("/", cfg.SchemaHandler.List)
+						r.With(middleware.RequireAdmin(cfg.AuthorizationConfig)).Post("/", cfg.SchemaHandler.Create)
+					})
+					
+					// Config change stream (Server-Sent Events)
+					r.With(middleware.RequireViewer(cfg.AuthorizationConfig)).Get("/stream", cfg.StreamHandler.StreamByProject)
+					
+					// API keys (admin only)
+					r.Route("/api-keys", func(r chi.Router) {
+						r.Use(middleware.RequireAdmin(cfg.AuthorizationConfig))
+						
+						r.Get("/", cfg.APIKeyHandler.List)
+						r.Post("/", cfg.APIKeyHandler.Create)
+						r.Get("/{keyId}", cfg.APIKeyHandler.Get)
+						r.Patch("/{keyId}", cfg.APIKeyHandler.Update)
+						r.Delete("/{keyId}", cfg.APIKeyHandler.Revoke)
+						r.Post("/{keyId}/rotate", cfg.APIKeyHandler.Rotate)
+					})
+					
 					// Roles (require at least viewer to list, admin to modify)
 					r.Route("/roles", func(r chi.Router) {
 						r.With(middleware.RequireViewer(cfg.AuthorizationConfig)).Get("/", func(w http.ResponseWriter, r *http.Request) {
@@ -132,6 +189,12 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 						})
 						r.With(middleware.RequireEditor(cfg.AuthorizationConfig)).Post("/", cfg.ConfigHandler.Create)
 						
+						// Bulk import of an archive of config files
+						r.With(middleware.RequireEditor(cfg.AuthorizationConfig)).Post("/import", cfg.ConfigHandler.Import)
+						
+						// Dry run of a write: schema, optimistic lock and diff
+						r.With(middleware.RequireViewer(cfg.AuthorizationConfig)).Post("/{configKey}:validate", cfg.ConfigHandler.Validate)
+						
 						// Individual config operations
 						r.Route("/{configKey}", func(r chi.Router) {
 							r.With(middleware.RequireViewer(cfg.AuthorizationConfig)).Get("/", cfg.ConfigHandler.Get)
@@ -140,17 +203,44 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 							
 							// Rollback (admin only)
 							r.With(middleware.RequireAdmin(cfg.AuthorizationConfig)).Post("/rollback", cfg.ConfigHandler.Rollback)
+							
+							// Pin to another schema version, re-validating the content
+							r.With(middleware.RequireEditor(cfg.AuthorizationConfig)).Post("/upgrade-schema", cfg.ConfigHandler.UpgradeSchema)
 						})
 					})
 				})
 			})
 			
-			// Schemas (global resource)
+			// Schemas: global schemas are managed by platform admins, project
+			// schemas by the admins of their project
 			r.Route("/schemas", func(r chi.Router) {
 				r.Get("/", cfg.SchemaHandler.List)
-				r.Post("/", cfg.SchemaHandler.Create)
-				r.Put("/{schemaId}", cfg.SchemaHandler.Update)
-				r.Delete("/{schemaId}", cfg.SchemaHandler.Delete)
+				r.With(middleware.RequirePlatformAdmin(cfg.AuthorizationConfig)).Post("/", cfg.SchemaHandler.Create)
+				r.With(middleware.RequireSchemaAdmin(cfg.AuthorizationConfig)).Put("/{schemaId}", cfg.SchemaHandler.Update)
+				r.With(middleware.RequireSchemaAdmin(cfg.AuthorizationConfig)).Delete("/{schemaId}", cfg.SchemaHandler.Delete)
+				r.With(middleware.RequireSchemaViewer(cfg.AuthorizationConfig)).Post("/{schemaId}:validate", cfg.SchemaHandler.Validate)
+				
+				// The impact report names the configs using the schema
+				r.With(middleware.RequireSchemaAdmin(cfg.AuthorizationConfig)).Post("/{schemaId}:preview", cfg.SchemaHandler.Preview)
+				r.With(middleware.RequireSchemaAdmin(cfg.AuthorizationConfig)).Get("/{schemaId}/usage", cfg.SchemaHandler.Usage)
+				
+				// Immutable schema versions
+				r.With(middleware.RequireSchemaViewer(cfg.AuthorizationConfig)).Get("/{schemaId}/versions", cfg.SchemaHandler.ListVersions)
+				r.With(middleware.RequireSchemaAdmin(cfg.AuthorizationConfig)).Post("/{schemaId}/versions", cfg.SchemaHandler.PublishVersion)
+				r.With(middleware.RequireSchemaViewer(cfg.AuthorizationConfig)).Get("/{schemaId}/versions/{version}", cfg.SchemaHandler.GetVersion)
+				
+				// Go or TypeScript types generated from a schema version
+				r.With(middleware.RequireSchemaViewer(cfg.AuthorizationConfig)).Get("/{schemaId}/codegen", cfg.SchemaHandler.Codegen)
+				
+				// Migration runs over the configs using a schema (admin only)
+				r.Route("/{schemaId}/migrations", func(r chi.Router) {
+					r.Use(middleware.RequireSchemaAdmin(cfg.AuthorizationConfig))
+					
+					r.Get("/", cfg.MigrationHandler.List)
+					r.Post("/", cfg.MigrationHandler.Migrate)
+					r.Get("/{migrationId}", cfg.MigrationHandler.Get)
+					r.Post("/{migrationId}/rollback", cfg.MigrationHandler.Rollback)
+				})
 			})
 		})
 	})
@@ -158,3 +248,7 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 	return r
 }
 
+// isStreamRequest reports whether the request targets a Server-Sent Events stream
+func isStreamRequest(r *http.Request) bool {
+	return strings.HasSuffix(r.URL.Path, "/stream")
+}
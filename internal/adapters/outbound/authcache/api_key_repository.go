@@ -0,0 +1,119 @@
+package authcache
+
+import (
+	"context"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// apiKeyRepository reads API keys through the cache and keeps it in step
+// with writes. Methods not overridden go straight to the database.
+type apiKeyRepository struct {
+	outbound.APIKeyRepository
+	cache *Cache
+}
+
+// Create creates a new API key and caches it
+func (r *apiKeyRepository) Create(ctx context.Context, params outbound.CreateAPIKeyParams) (*outbound.APIKey, error) {
+	key, err := r.APIKeyRepository.Create(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.putKey(key)
+	return key, nil
+}
+
+// ListByLookupPrefix retrieves the API keys with the given lookup prefix.
+// While the database is unreachable the cached keys are returned instead.
+func (r *apiKeyRepository) ListByLookupPrefix(ctx context.Context, lookupPrefix string) ([]*outbound.APIKey, error) {
+	if r.cache.degraded() {
+		return r.cache.keysByLookupPrefix(lookupPrefix)
+	}
+
+	keys, err := r.APIKeyRepository.ListByLookupPrefix(ctx, lookupPrefix)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		r.cache.markUnavailable(ctx, err)
+		return r.cache.keysByLookupPrefix(lookupPrefix)
+	}
+
+	r.cache.markAvailable()
+	r.cache.replaceLookupPrefix(lookupPrefix, keys)
+	return keys, nil
+}
+
+// Update updates an API key and caches the result
+func (r *apiKeyRepository) Update(ctx context.Context, params outbound.UpdateAPIKeyParams) (*outbound.APIKey, error) {
+	key, err := r.APIKeyRepository.Update(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.putKey(key)
+	return key, nil
+}
+
+// Revoke revokes an API key and caches the result
+func (r *apiKeyRepository) Revoke(ctx context.Context, projectID, id string) (*outbound.APIKey, error) {
+	key, err := r.APIKeyRepository.Revoke(ctx, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.putKey(key)
+	return key, nil
+}
+
+// SetExpiry sets an API key's expiry and caches the result
+func (r *apiKeyRepository) SetExpiry(ctx context.Context, projectID, id string, expiresAt time.Time) (*outbound.APIKey, error) {
+	key, err := r.APIKeyRepository.SetExpiry(ctx, projectID, id, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.putKey(key)
+	return key, nil
+}
+
+// UpdateHash replaces an API key's stored hash.
+// It is skipped while the database is unreachable.
+func (r *apiKeyRepository) UpdateHash(ctx context.Context, id string, keyHash []byte, hashVersion int) error {
+	if r.cache.degraded() {
+		return errDatabaseUnavailable
+	}
+
+	if err := r.APIKeyRepository.UpdateHash(ctx, id, keyHash, hashVersion); err != nil {
+		r.cache.markUnavailable(ctx, err)
+		return err
+	}
+
+	r.cache.updateKey(id, func(key *outbound.APIKey) {
+		key.KeyHash = append([]byte(nil), keyHash...)
+		key.HashVersion = hashVersion
+	})
+	return nil
+}
+
+// TouchLastUsed records when an API key was last used.
+// The cached timestamp is always updated so that callers throttling on it
+// do not retry the write on every request while the database is unreachable.
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	r.cache.updateKey(id, func(key *outbound.APIKey) {
+		key.LastUsedAt = &usedAt
+	})
+
+	if r.cache.degraded() {
+		return errDatabaseUnavailable
+	}
+
+	if err := r.APIKeyRepository.TouchLastUsed(ctx, id, usedAt); err != nil {
+		r.cache.markUnavailable(ctx, err)
+		return err
+	}
+
+	return nil
+}
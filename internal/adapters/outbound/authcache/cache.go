@@ -0,0 +1,303 @@
+package authcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+const (
+	defaultRefreshInterval = 30 * time.Second
+	defaultMaxStaleness    = 24 * time.Hour
+)
+
+// errDatabaseUnavailable is returned for writes skipped while the database is unreachable
+var errDatabaseUnavailable = errors.New("database unavailable")
+
+// CacheConfig holds authorization metadata cache configuration
+type CacheConfig struct {
+	// RefreshInterval is how often the active API keys are reloaded, and how
+	// often the database is retried while it is unreachable
+	RefreshInterval time.Duration
+	// MaxStaleness is how long cached metadata keeps authorizing requests
+	// after the last successful load; negative means no limit
+	MaxStaleness time.Duration
+}
+
+// Cache keeps the project and API key metadata the public read path needs
+// to authorize a request. Lookups go to the database while it is reachable
+// and refresh the cache; once the database fails they are answered from the
+// last data loaded, so config delivery from Raft survives a database outage.
+//
+// Writes made through the repositories returned by APIKeys and Projects
+// update the cache immediately. Changes made by other nodes are picked up by
+// the next lookup or refresh while the database is reachable.
+type Cache struct {
+	apiKeyRepo      outbound.APIKeyRepository
+	projectRepo     outbound.ProjectRepository
+	refreshInterval time.Duration
+	maxStaleness    time.Duration
+	now             func() time.Time
+
+	mu               sync.RWMutex
+	keys             map[string]*outbound.APIKey // key: API key ID
+	projects         map[string]bool             // key: project ID, value: exists
+	loadedAt         time.Time
+	unavailableSince time.Time
+	lastErr          error
+}
+
+// Status describes the cache and the database behind it
+type Status struct {
+	Degraded         bool      // The database is unreachable and lookups use cached data
+	UnavailableSince time.Time // When the database became unreachable
+	LastError        string    // Last database error while degraded
+	LoadedAt         time.Time // Last successful load of the active API keys
+	Keys             int       // Number of cached API keys
+}
+
+// NewCache creates a new Cache in front of the given repositories
+func NewCache(apiKeyRepo outbound.APIKeyRepository, projectRepo outbound.ProjectRepository, cfg CacheConfig) *Cache {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = defaultRefreshInterval
+	}
+	if cfg.MaxStaleness == 0 {
+		cfg.MaxStaleness = defaultMaxStaleness
+	}
+
+	return &Cache{
+		apiKeyRepo:      apiKeyRepo,
+		projectRepo:     projectRepo,
+		refreshInterval: cfg.RefreshInterval,
+		maxStaleness:    cfg.MaxStaleness,
+		now:             time.Now,
+		keys:            make(map[string]*outbound.APIKey),
+		projects:        make(map[string]bool),
+	}
+}
+
+// APIKeys returns an API key repository that reads through the cache
+func (c *Cache) APIKeys() outbound.APIKeyRepository {
+	return &apiKeyRepository{APIKeyRepository: c.apiKeyRepo, cache: c}
+}
+
+// Projects returns a project repository that reads through the cache
+func (c *Cache) Projects() outbound.ProjectRepository {
+	return &projectRepository{ProjectRepository: c.projectRepo, cache: c}
+}
+
+// Refresh reloads all active API keys from the database
+func (c *Cache) Refresh(ctx context.Context) error {
+	keys, err := c.apiKeyRepo.ListActive(ctx)
+	if err != nil {
+		c.markUnavailable(ctx, err)
+		return fmt.Errorf("failed to refresh API key cache: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keys = make(map[string]*outbound.APIKey, len(keys))
+	for _, key := range keys {
+		c.keys[key.ID] = key
+		c.projects[key.ProjectID] = true
+	}
+	c.loadedAt = c.now()
+	c.unavailableSince = time.Time{}
+	c.lastErr = nil
+
+	return nil
+}
+
+// Run refreshes the cache every RefreshInterval until ctx is done
+func (c *Cache) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.Refresh(ctx)
+		}
+	}
+}
+
+// Status returns the current state of the cache
+func (c *Cache) Status() Status {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	status := Status{
+		Degraded:         !c.unavailableSince.IsZero(),
+		UnavailableSince: c.unavailableSince,
+		LoadedAt:         c.loadedAt,
+		Keys:             len(c.keys),
+	}
+	if c.lastErr != nil {
+		status.LastError = c.lastErr.Error()
+	}
+
+	return status
+}
+
+// Check reports whether cached metadata can still authorize requests.
+// It only fails while the database is unreachable and the cache is empty or
+// older than MaxStaleness.
+func (c *Cache) Check(ctx context.Context) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.unavailableSince.IsZero() {
+		return nil
+	}
+	return c.usableLocked()
+}
+
+// degraded checks if the database is known to be unreachable
+func (c *Cache) degraded() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return !c.unavailableSince.IsZero()
+}
+
+// usableLocked checks if cached data may be served. Callers hold mu.
+func (c *Cache) usableLocked() error {
+	if c.loadedAt.IsZero() {
+		return fmt.Errorf("authorization metadata has not been loaded")
+	}
+	if c.maxStaleness > 0 && c.now().Sub(c.loadedAt) > c.maxStaleness {
+		return fmt.Errorf("authorization metadata is older than %s", c.maxStaleness)
+	}
+	return nil
+}
+
+// markUnavailable records a database failure.
+// Failures caused by the caller's context ending say nothing about the database.
+func (c *Cache) markUnavailable(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.unavailableSince.IsZero() {
+		c.unavailableSince = c.now()
+	}
+	c.lastErr = err
+}
+
+// markAvailable records a successful database call
+func (c *Cache) markAvailable() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.unavailableSince = time.Time{}
+	c.lastErr = nil
+}
+
+// keysByLookupPrefix returns the cached keys with the given lookup prefix
+func (c *Cache) keysByLookupPrefix(lookupPrefix string) ([]*outbound.APIKey, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := c.usableLocked(); err != nil {
+		return nil, fmt.Errorf("database unavailable and %w", err)
+	}
+
+	var keys []*outbound.APIKey
+	for _, key := range c.keys {
+		if key.LookupPrefix == lookupPrefix {
+			keys = append(keys, copyKey(key))
+		}
+	}
+
+	return keys, nil
+}
+
+// replaceLookupPrefix replaces the cached keys with the given lookup prefix
+func (c *Cache) replaceLookupPrefix(lookupPrefix string, keys []*outbound.APIKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, key := range c.keys {
+		if key.LookupPrefix == lookupPrefix {
+			delete(c.keys, id)
+		}
+	}
+	for _, key := range keys {
+		c.keys[key.ID] = copyKey(key)
+		c.projects[key.ProjectID] = true
+	}
+}
+
+// putKey stores a key returned by a write
+func (c *Cache) putKey(key *outbound.APIKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keys[key.ID] = copyKey(key)
+	c.projects[key.ProjectID] = true
+}
+
+// updateKey applies fn to a cached key, if present
+func (c *Cache) updateKey(id string, fn func(key *outbound.APIKey)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.keys[id]; ok {
+		updated := copyKey(key)
+		fn(updated)
+		c.keys[id] = updated
+	}
+}
+
+// projectExists returns whether a project is known to exist
+func (c *Cache) projectExists(id string) (exists bool, known bool, err error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if err := c.usableLocked(); err != nil {
+		return false, false, fmt.Errorf("database unavailable and %w", err)
+	}
+
+	exists, known = c.projects[id]
+	return exists, known, nil
+}
+
+// setProject records whether a project exists
+func (c *Cache) setProject(id string, exists bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.projects[id] = exists
+}
+
+// deleteProject forgets a deleted project and its keys
+func (c *Cache) deleteProject(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.projects[id] = false
+	for keyID, key := range c.keys {
+		if key.ProjectID == id {
+			delete(c.keys, keyID)
+		}
+	}
+}
+
+// copyKey copies a key so cached entries are never shared with callers
+func copyKey(key *outbound.APIKey) *outbound.APIKey {
+	clone := *key
+	clone.KeyHash = append([]byte(nil), key.KeyHash...)
+	clone.KeySalt = append([]byte(nil), key.KeySalt...)
+	clone.KeyPrefixes = append([]string(nil), key.KeyPrefixes...)
+	return &clone
+}
@@ -0,0 +1,227 @@
+package authcache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+var errConnRefused = errors.New("dial tcp 127.0.0.1:5432: connect: connection refused")
+
+// MockAPIKeyRepository mocks the API key database
+type MockAPIKeyRepository struct {
+	mock.Mock
+	outbound.APIKeyRepository
+}
+
+func (m *MockAPIKeyRepository) ListActive(ctx context.Context) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByLookupPrefix(ctx context.Context, lookupPrefix string) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx, lookupPrefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, projectID, id string) (*outbound.APIKey, error) {
+	args := m.Called(ctx, projectID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
+// MockProjectRepository mocks the project database
+type MockProjectRepository struct {
+	mock.Mock
+	outbound.ProjectRepository
+}
+
+func (m *MockProjectRepository) Exists(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func testKey(id, lookupPrefix string) *outbound.APIKey {
+	return &outbound.APIKey{
+		ID:           id,
+		ProjectID:    "proj-1",
+		LookupPrefix: lookupPrefix,
+		KeyHash:      []byte("hash"),
+		KeySalt:      []byte("salt"),
+		KeyHint:      "wxyz",
+		HashVersion:  2,
+	}
+}
+
+// newLoadedCache returns a cache loaded with the given active keys
+func newLoadedCache(t *testing.T, cfg CacheConfig, keys ...*outbound.APIKey) (*Cache, *MockAPIKeyRepository, *MockProjectRepository) {
+	t.Helper()
+
+	apiKeyRepo := new(MockAPIKeyRepository)
+	projectRepo := new(MockProjectRepository)
+	cache := NewCache(apiKeyRepo, projectRepo, cfg)
+
+	apiKeyRepo.On("ListActive", mock.Anything).Return(keys, nil).Once()
+	require.NoError(t, cache.Refresh(context.Background()))
+
+	return cache, apiKeyRepo, projectRepo
+}
+
+func TestCache_ListByLookupPrefix_ReadsThroughWhileAvailable(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	cache, apiKeyRepo, _ := newLoadedCache(t, CacheConfig{})
+	fresh := testKey("key-2", "cfg_abcdefgh")
+	apiKeyRepo.On("ListByLookupPrefix", ctx, "cfg_abcdefgh").Return([]*outbound.APIKey{fresh}, nil).Once()
+
+	// Act
+	keys, err := cache.APIKeys().ListByLookupPrefix(ctx, "cfg_abcdefgh")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []*outbound.APIKey{fresh}, keys)
+	assert.False(t, cache.Status().Degraded)
+	assert.Equal(t, 1, cache.Status().Keys)
+}
+
+func TestCache_ListByLookupPrefix_FallsBackWhenDatabaseFails(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	cache, apiKeyRepo, _ := newLoadedCache(t, CacheConfig{},
+		testKey("key-1", "cfg_abcdefgh"),
+		testKey("key-2", "cfg_zzzzzzzz"),
+	)
+	apiKeyRepo.On("ListByLookupPrefix", ctx, "cfg_abcdefgh").Return(nil, errConnRefused).Once()
+
+	// Act
+	keys, err := cache.APIKeys().ListByLookupPrefix(ctx, "cfg_abcdefgh")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "key-1", keys[0].ID)
+
+	status := cache.Status()
+	assert.True(t, status.Degraded)
+	assert.Contains(t, status.LastError, "connection refused")
+	assert.NoError(t, cache.Check(ctx))
+
+	t.Run("skips the database until a refresh succeeds", func(t *testing.T) {
+		keys, err := cache.APIKeys().ListByLookupPrefix(ctx, "cfg_abcdefgh")
+		require.NoError(t, err)
+		assert.Len(t, keys, 1)
+		apiKeyRepo.AssertNumberOfCalls(t, "ListByLookupPrefix", 1)
+
+		apiKeyRepo.On("ListActive", ctx).Return([]*outbound.APIKey{}, nil).Once()
+		require.NoError(t, cache.Refresh(ctx))
+		assert.False(t, cache.Status().Degraded)
+	})
+}
+
+func TestCache_ListByLookupPrefix_CanceledRequestDoesNotDegrade(t *testing.T) {
+	cache, apiKeyRepo, _ := newLoadedCache(t, CacheConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	apiKeyRepo.On("ListByLookupPrefix", ctx, "cfg_abcdefgh").Return(nil, context.Canceled).Once()
+
+	_, err := cache.APIKeys().ListByLookupPrefix(ctx, "cfg_abcdefgh")
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, cache.Status().Degraded)
+}
+
+func TestCache_Revoke_UpdatesCachedKey(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	cache, apiKeyRepo, _ := newLoadedCache(t, CacheConfig{}, testKey("key-1", "cfg_abcdefgh"))
+
+	revoked := testKey("key-1", "cfg_abcdefgh")
+	revokedAt := time.Now()
+	revoked.RevokedAt = &revokedAt
+	apiKeyRepo.On("Revoke", ctx, "proj-1", "key-1").Return(revoked, nil).Once()
+
+	// Act
+	_, err := cache.APIKeys().Revoke(ctx, "proj-1", "key-1")
+	require.NoError(t, err)
+
+	// Assert: the revocation holds once the database goes away
+	apiKeyRepo.On("ListByLookupPrefix", ctx, "cfg_abcdefgh").Return(nil, errConnRefused).Once()
+	keys, err := cache.APIKeys().ListByLookupPrefix(ctx, "cfg_abcdefgh")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].RevokedAt)
+}
+
+func TestCache_TouchLastUsed_SkippedWhileDegraded(t *testing.T) {
+	ctx := context.Background()
+	cache, apiKeyRepo, _ := newLoadedCache(t, CacheConfig{}, testKey("key-1", "cfg_abcdefgh"))
+	apiKeyRepo.On("ListActive", ctx).Return(nil, errConnRefused).Once()
+	require.Error(t, cache.Refresh(ctx))
+
+	usedAt := time.Now()
+	err := cache.APIKeys().TouchLastUsed(ctx, "key-1", usedAt)
+
+	assert.Error(t, err)
+	apiKeyRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+
+	keys, err := cache.APIKeys().ListByLookupPrefix(ctx, "cfg_abcdefgh")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, usedAt, *keys[0].LastUsedAt)
+}
+
+func TestCache_StaleMetadataIsNotServed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	cache, apiKeyRepo, _ := newLoadedCache(t, CacheConfig{MaxStaleness: time.Hour}, testKey("key-1", "cfg_abcdefgh"))
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	apiKeyRepo.On("ListByLookupPrefix", ctx, "cfg_abcdefgh").Return(nil, errConnRefused).Once()
+
+	// Act
+	_, err := cache.APIKeys().ListByLookupPrefix(ctx, "cfg_abcdefgh")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "older than")
+	assert.Error(t, cache.Check(ctx))
+}
+
+func TestCache_ProjectExists(t *testing.T) {
+	ctx := context.Background()
+	cache, _, projectRepo := newLoadedCache(t, CacheConfig{}, testKey("key-1", "cfg_abcdefgh"))
+	projectRepo.On("Exists", ctx, mock.Anything).Return(false, errConnRefused)
+
+	t.Run("project with a cached key", func(t *testing.T) {
+		exists, err := cache.Projects().Exists(ctx, "proj-1")
+
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.True(t, cache.Status().Degraded)
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		_, err := cache.Projects().Exists(ctx, "proj-2")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not cached")
+	})
+}
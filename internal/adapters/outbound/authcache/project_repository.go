@@ -0,0 +1,63 @@
+package authcache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// projectRepository answers project existence checks through the cache and
+// keeps it in step with writes. Methods not overridden go straight to the database.
+type projectRepository struct {
+	outbound.ProjectRepository
+	cache *Cache
+}
+
+// Create creates a new project and caches that it exists
+func (r *projectRepository) Create(ctx context.Context, params outbound.CreateProjectParams) (*outbound.Project, error) {
+	project, err := r.ProjectRepository.Create(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.setProject(project.ID, true)
+	return project, nil
+}
+
+// Delete deletes a project and drops it and its API keys from the cache
+func (r *projectRepository) Delete(ctx context.Context, id string) error {
+	if err := r.ProjectRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	r.cache.deleteProject(id)
+	return nil
+}
+
+// Exists checks if a project exists by ID.
+// While the database is unreachable only projects seen before are known.
+func (r *projectRepository) Exists(ctx context.Context, id string) (bool, error) {
+	if !r.cache.degraded() {
+		exists, err := r.ProjectRepository.Exists(ctx, id)
+		if err == nil {
+			r.cache.markAvailable()
+			r.cache.setProject(id, exists)
+			return exists, nil
+		}
+		if ctx.Err() != nil {
+			return false, err
+		}
+		r.cache.markUnavailable(ctx, err)
+	}
+
+	exists, known, err := r.cache.projectExists(id)
+	if err != nil {
+		return false, err
+	}
+	if !known {
+		return false, fmt.Errorf("database unavailable and project %s is not cached", id)
+	}
+
+	return exists, nil
+}
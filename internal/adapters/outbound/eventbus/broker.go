@@ -0,0 +1,226 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/vlone310/cfguardian/internal/domain/events"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+const (
+	defaultReplaySize     = 1024
+	defaultSubscriberSize = 64
+)
+
+// BrokerConfig holds in-memory broker configuration
+type BrokerConfig struct {
+	// ReplaySize is the number of recent events retained for Last-Event-ID resume
+	ReplaySize int
+	// SubscriberBufferSize is the number of undelivered events a subscriber may
+	// hold before it is considered too slow and disconnected
+	SubscriberBufferSize int
+}
+
+// Broker is an in-memory event bus that fans out project events to subscribers.
+// Every node publishes the events of each committed write as it applies it,
+// with the write's Raft log index as ID, so IDs are the same on every node
+// and a subscriber can resume on any of them. A bounded ring of recent events
+// allows subscribers to resume after a reconnect.
+type Broker struct {
+	mu             sync.Mutex
+	lastID         uint64
+	floor          uint64 // Events up to this ID are no longer retained
+	ring           []*outbound.StreamEvent
+	ringStart      int
+	ringLen        int
+	subscriberSize int
+	subscribers    map[string]map[*subscription]struct{}
+}
+
+// NewBroker creates a new in-memory Broker
+func NewBroker(cfg BrokerConfig) *Broker {
+	if cfg.ReplaySize <= 0 {
+		cfg.ReplaySize = defaultReplaySize
+	}
+	if cfg.SubscriberBufferSize <= 0 {
+		cfg.SubscriberBufferSize = defaultSubscriberSize
+	}
+
+	return &Broker{
+		ring:           make([]*outbound.StreamEvent, cfg.ReplaySize),
+		subscriberSize: cfg.SubscriberBufferSize,
+		subscribers:    make(map[string]map[*subscription]struct{}),
+	}
+}
+
+// Publish stamps the events of one write with its ID, retains them for
+// replay and delivers them to the projects' subscribers without blocking.
+// Subscribers whose buffer is full are dropped so they can resume from
+// their last ID.
+func (b *Broker) Publish(ctx context.Context, id uint64, published ...events.ProjectEvent) error {
+	streamEvents := make([]*outbound.StreamEvent, len(published))
+	for i, event := range published {
+		data, err := event.ToJSON()
+		if err != nil {
+			return fmt.Errorf("failed to serialize event: %w", err)
+		}
+
+		streamEvents[i] = &outbound.StreamEvent{
+			ID:         id,
+			Last:       i == len(published)-1,
+			ProjectID:  event.GetProjectID(),
+			Type:       event.GetEventType(),
+			Data:       data,
+			OccurredAt: event.GetOccurredAt(),
+		}
+		if configEvent, ok := event.(events.ConfigEvent); ok {
+			streamEvents[i].ConfigKey = configEvent.GetConfigKey()
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if id <= b.lastID {
+		return fmt.Errorf("event ID %d does not follow %d", id, b.lastID)
+	}
+	b.lastID = id
+
+	for _, streamEvent := range streamEvents {
+		b.retain(streamEvent)
+
+		for sub := range b.subscribers[streamEvent.ProjectID] {
+			if streamEvent.ID <= sub.afterID {
+				continue
+			}
+			select {
+			case sub.events <- streamEvent:
+			default:
+				b.removeLocked(sub)
+				close(sub.dropped)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reset discards the retained events and disconnects every subscriber. The
+// publisher calls it when it skipped events, as when a node restores a
+// snapshot; only events after id can be replayed.
+func (b *Broker) Reset(id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := range b.ring {
+		b.ring[i] = nil
+	}
+	b.ringStart, b.ringLen = 0, 0
+	b.lastID, b.floor = id, id
+
+	for _, subs := range b.subscribers {
+		for sub := range subs {
+			b.removeLocked(sub)
+			close(sub.dropped)
+		}
+	}
+}
+
+// Subscribe registers a subscriber for a project. Retained events with an
+// ID greater than afterID are queued ahead of live events. The subscription
+// reports a gap when events after afterID are no longer retained, as after
+// eviction or when the node restored a snapshot.
+func (b *Broker) Subscribe(ctx context.Context, projectID string, afterID uint64) (outbound.EventSubscription, error) {
+	if projectID == "" {
+		return nil, fmt.Errorf("project ID is required")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []*outbound.StreamEvent
+	gap := false
+	if afterID > 0 {
+		gap = afterID < b.floor
+		for i := 0; i < b.ringLen; i++ {
+			e := b.ring[(b.ringStart+i)%len(b.ring)]
+			if e.ID > afterID && e.ProjectID == projectID {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	sub := &subscription{
+		broker:    b,
+		projectID: projectID,
+		afterID:   afterID,
+		events:    make(chan *outbound.StreamEvent, len(replay)+b.subscriberSize),
+		dropped:   make(chan struct{}),
+		gap:       gap,
+	}
+	for _, e := range replay {
+		sub.events <- e
+	}
+
+	if b.subscribers[projectID] == nil {
+		b.subscribers[projectID] = make(map[*subscription]struct{})
+	}
+	b.subscribers[projectID][sub] = struct{}{}
+
+	return sub, nil
+}
+
+// retain appends an event to the replay ring, evicting the oldest when full
+func (b *Broker) retain(e *outbound.StreamEvent) {
+	if b.ringLen < len(b.ring) {
+		b.ring[(b.ringStart+b.ringLen)%len(b.ring)] = e
+		b.ringLen++
+		return
+	}
+
+	b.floor = b.ring[b.ringStart].ID
+	b.ring[b.ringStart] = e
+	b.ringStart = (b.ringStart + 1) % len(b.ring)
+}
+
+func (b *Broker) removeLocked(sub *subscription) {
+	subs := b.subscribers[sub.projectID]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subscribers, sub.projectID)
+	}
+}
+
+// subscription implements outbound.EventSubscription
+type subscription struct {
+	broker    *Broker
+	projectID string
+	afterID   uint64 // Live events up to this ID were already seen
+	events    chan *outbound.StreamEvent
+	dropped   chan struct{}
+	gap       bool
+	closeOnce sync.Once
+}
+
+func (s *subscription) Events() <-chan *outbound.StreamEvent {
+	return s.events
+}
+
+func (s *subscription) Dropped() <-chan struct{} {
+	return s.dropped
+}
+
+func (s *subscription) Gap() bool {
+	return s.gap
+}
+
+func (s *subscription) Close() {
+	s.closeOnce.Do(func() {
+		s.broker.mu.Lock()
+		defer s.broker.mu.Unlock()
+
+		s.broker.removeLocked(s)
+	})
+}
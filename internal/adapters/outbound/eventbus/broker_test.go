@@ -0,0 +1,216 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/events"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+func newDeletedEvent(projectID, key string) events.ProjectEvent {
+	return events.NewConfigDeleted("evt", projectID, key, valueobjects.MustNewVersion(1), "user-1")
+}
+
+func drain(sub outbound.EventSubscription) []*outbound.StreamEvent {
+	var out []*outbound.StreamEvent
+	for {
+		select {
+		case e := <-sub.Events():
+			out = append(out, e)
+		default:
+			return out
+		}
+	}
+}
+
+func TestBroker_Publish(t *testing.T) {
+	t.Run("delivers events only to subscribers of the project", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		broker := NewBroker(BrokerConfig{})
+		subA, err := broker.Subscribe(ctx, "project-a", 0)
+		require.NoError(t, err)
+		defer subA.Close()
+		subB, err := broker.Subscribe(ctx, "project-b", 0)
+		require.NoError(t, err)
+		defer subB.Close()
+
+		// Act
+		require.NoError(t, broker.Publish(ctx, 7, newDeletedEvent("project-a", "db")))
+
+		// Assert
+		received := drain(subA)
+		require.Len(t, received, 1)
+		assert.Equal(t, uint64(7), received[0].ID)
+		assert.True(t, received[0].Last)
+		assert.Equal(t, events.EventTypeConfigDeleted, received[0].Type)
+		assert.Equal(t, "project-a", received[0].ProjectID)
+		assert.Equal(t, "db", received[0].ConfigKey)
+		assert.Contains(t, string(received[0].Data), `"config_key":"db"`)
+		assert.Empty(t, drain(subB))
+	})
+
+	t.Run("marks the last event of a write", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		broker := NewBroker(BrokerConfig{})
+		sub, err := broker.Subscribe(ctx, "project-a", 0)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		// Act
+		require.NoError(t, broker.Publish(ctx, 3, newDeletedEvent("project-a", "db"), newDeletedEvent("project-a", "cache")))
+
+		// Assert
+		received := drain(sub)
+		require.Len(t, received, 2)
+		assert.Equal(t, uint64(3), received[0].ID)
+		assert.False(t, received[0].Last)
+		assert.Equal(t, uint64(3), received[1].ID)
+		assert.True(t, received[1].Last)
+	})
+
+	t.Run("rejects IDs that do not increase", func(t *testing.T) {
+		ctx := context.Background()
+		broker := NewBroker(BrokerConfig{})
+		require.NoError(t, broker.Publish(ctx, 5, newDeletedEvent("project-a", "db")))
+
+		err := broker.Publish(ctx, 5, newDeletedEvent("project-a", "db"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("drops subscribers whose buffer is full", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		broker := NewBroker(BrokerConfig{SubscriberBufferSize: 2})
+		sub, err := broker.Subscribe(ctx, "project-a", 0)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		// Act
+		for i := uint64(1); i <= 3; i++ {
+			require.NoError(t, broker.Publish(ctx, i, newDeletedEvent("project-a", "db")))
+		}
+
+		// Assert
+		select {
+		case <-sub.Dropped():
+		default:
+			t.Fatal("expected slow subscriber to be dropped")
+		}
+		assert.Len(t, drain(sub), 2)
+		assert.Empty(t, broker.subscribers)
+	})
+}
+
+func TestBroker_Subscribe(t *testing.T) {
+	t.Run("replays retained events after the given ID", func(t *testing.T) {
+		// Arrange: IDs are log indexes, so they need not be contiguous
+		ctx := context.Background()
+		broker := NewBroker(BrokerConfig{})
+		for i, p := range []string{"project-a", "project-b", "project-a", "project-a"} {
+			require.NoError(t, broker.Publish(ctx, uint64(10*(i+1)), newDeletedEvent(p, "db")))
+		}
+
+		// Act
+		sub, err := broker.Subscribe(ctx, "project-a", 10)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		// Assert
+		received := drain(sub)
+		require.Len(t, received, 2)
+		assert.Equal(t, uint64(30), received[0].ID)
+		assert.Equal(t, uint64(40), received[1].ID)
+		assert.False(t, sub.Gap())
+	})
+
+	t.Run("reports a gap when requested events were evicted", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		broker := NewBroker(BrokerConfig{ReplaySize: 2})
+		for i := uint64(1); i <= 5; i++ {
+			require.NoError(t, broker.Publish(ctx, i, newDeletedEvent("project-a", "db")))
+		}
+
+		// Act
+		gapped, err := broker.Subscribe(ctx, "project-a", 1)
+		require.NoError(t, err)
+		defer gapped.Close()
+		complete, err := broker.Subscribe(ctx, "project-a", 3)
+		require.NoError(t, err)
+		defer complete.Close()
+
+		// Assert
+		assert.True(t, gapped.Gap())
+		received := drain(gapped)
+		require.Len(t, received, 2)
+		assert.Equal(t, uint64(4), received[0].ID)
+		assert.False(t, complete.Gap(), "the newest evicted event was already seen")
+	})
+
+	t.Run("skips live events a client ahead of this node has seen", func(t *testing.T) {
+		// Arrange: the client resumes from a node that applied more writes
+		ctx := context.Background()
+		broker := NewBroker(BrokerConfig{})
+		require.NoError(t, broker.Publish(ctx, 4, newDeletedEvent("project-a", "db")))
+		sub, err := broker.Subscribe(ctx, "project-a", 6)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		// Act
+		require.NoError(t, broker.Publish(ctx, 6, newDeletedEvent("project-a", "db")))
+		require.NoError(t, broker.Publish(ctx, 7, newDeletedEvent("project-a", "db")))
+
+		// Assert
+		assert.False(t, sub.Gap())
+		received := drain(sub)
+		require.Len(t, received, 1)
+		assert.Equal(t, uint64(7), received[0].ID)
+	})
+
+	t.Run("requires a project ID", func(t *testing.T) {
+		broker := NewBroker(BrokerConfig{})
+
+		_, err := broker.Subscribe(context.Background(), "", 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBroker_Reset(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	broker := NewBroker(BrokerConfig{})
+	require.NoError(t, broker.Publish(ctx, 2, newDeletedEvent("project-a", "db")))
+	live, err := broker.Subscribe(ctx, "project-a", 0)
+	require.NoError(t, err)
+	defer live.Close()
+
+	// Act: the node restored a snapshot taken at revision 9
+	broker.Reset(9)
+
+	// Assert
+	select {
+	case <-live.Dropped():
+	default:
+		t.Fatal("expected open subscriptions to be dropped")
+	}
+
+	stale, err := broker.Subscribe(ctx, "project-a", 2)
+	require.NoError(t, err)
+	defer stale.Close()
+	assert.True(t, stale.Gap())
+	assert.Empty(t, drain(stale))
+
+	current, err := broker.Subscribe(ctx, "project-a", 9)
+	require.NoError(t, err)
+	defer current.Close()
+	assert.False(t, current.Gap())
+	assert.Error(t, broker.Publish(ctx, 9, newDeletedEvent("project-a", "db")))
+}
@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vlone310/cfguardian/internal/adapters/outbound/postgres/sqlc"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// APIKeyRepositoryAdapter implements outbound.APIKeyRepository using PostgreSQL
+type APIKeyRepositoryAdapter struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewAPIKeyRepositoryAdapter creates a new PostgreSQL API key repository
+func NewAPIKeyRepositoryAdapter(pool *pgxpool.Pool) *APIKeyRepositoryAdapter {
+	return &APIKeyRepositoryAdapter{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+// Create creates a new API key
+func (r *APIKeyRepositoryAdapter) Create(ctx context.Context, params outbound.CreateAPIKeyParams) (*outbound.APIKey, error) {
+	apiKey, err := r.queries.CreateAPIKey(ctx, sqlc.CreateAPIKeyParams{
+		ID:              params.ID,
+		ProjectID:       params.ProjectID,
+		Name:            params.Name,
+		LookupPrefix:    params.LookupPrefix,
+		KeyHash:         params.KeyHash,
+		KeySalt:         params.KeySalt,
+		KeyHint:         params.KeyHint,
+		HashVersion:     int16(params.HashVersion),
+		KeyPrefixes:     nonNilPrefixes(params.KeyPrefixes),
+		CreatedByUserID: pgtype.Text{String: params.CreatedByUserID, Valid: params.CreatedByUserID != ""},
+		ExpiresAt:       toTimestamp(params.ExpiresAt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return r.modelToOutbound(&apiKey), nil
+}
+
+// GetByID retrieves a project's API key by ID
+func (r *APIKeyRepositoryAdapter) GetByID(ctx context.Context, projectID, id string) (*outbound.APIKey, error) {
+	apiKey, err := r.queries.GetAPIKey(ctx, id, projectID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, apperrors.New(apperrors.ErrCodeAPIKeyNotFound, "API key not found")
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return r.modelToOutbound(&apiKey), nil
+}
+
+// ListByLookupPrefix retrieves the API keys whose secret starts with lookupPrefix
+func (r *APIKeyRepositoryAdapter) ListByLookupPrefix(ctx context.Context, lookupPrefix string) ([]*outbound.APIKey, error) {
+	apiKeys, err := r.queries.ListAPIKeysByLookupPrefix(ctx, lookupPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys by lookup prefix: %w", err)
+	}
+
+	result := make([]*outbound.APIKey, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		result[i] = r.modelToOutbound(&apiKey)
+	}
+
+	return result, nil
+}
+
+// ListActive retrieves every API key that is neither revoked nor expired
+func (r *APIKeyRepositoryAdapter) ListActive(ctx context.Context) ([]*outbound.APIKey, error) {
+	apiKeys, err := r.queries.ListActiveAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active API keys: %w", err)
+	}
+
+	result := make([]*outbound.APIKey, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		result[i] = r.modelToOutbound(&apiKey)
+	}
+
+	return result, nil
+}
+
+// ListByProject retrieves all API keys of a project, including revoked ones
+func (r *APIKeyRepositoryAdapter) ListByProject(ctx context.Context, projectID string) ([]*outbound.APIKey, error) {
+	apiKeys, err := r.queries.ListAPIKeysByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	result := make([]*outbound.APIKey, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		result[i] = r.modelToOutbound(&apiKey)
+	}
+
+	return result, nil
+}
+
+// Update updates an API key's name, scope or expiry
+func (r *APIKeyRepositoryAdapter) Update(ctx context.Context, params outbound.UpdateAPIKeyParams) (*outbound.APIKey, error) {
+	var name pgtype.Text
+	if params.Name != nil {
+		name = pgtype.Text{String: *params.Name, Valid: true}
+	}
+
+	// nil leaves the prefixes unchanged; an empty slice clears them
+	var keyPrefixes []string
+	if params.KeyPrefixes != nil {
+		keyPrefixes = nonNilPrefixes(params.KeyPrefixes)
+	}
+
+	apiKey, err := r.queries.UpdateAPIKey(ctx, sqlc.UpdateAPIKeyParams{
+		Name:        name,
+		KeyPrefixes: keyPrefixes,
+		ClearExpiry: params.ClearExpiry,
+		ExpiresAt:   toTimestamp(params.ExpiresAt),
+		ID:          params.ID,
+		ProjectID:   params.ProjectID,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, apperrors.New(apperrors.ErrCodeAPIKeyNotFound, "API key not found")
+		}
+		return nil, fmt.Errorf("failed to update API key: %w", err)
+	}
+
+	return r.modelToOutbound(&apiKey), nil
+}
+
+// Revoke marks an API key as revoked
+func (r *APIKeyRepositoryAdapter) Revoke(ctx context.Context, projectID, id string) (*outbound.APIKey, error) {
+	apiKey, err := r.queries.RevokeAPIKey(ctx, id, projectID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, apperrors.New(apperrors.ErrCodeAPIKeyNotFound, "API key not found")
+		}
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return r.modelToOutbound(&apiKey), nil
+}
+
+// SetExpiry sets when an API key stops working
+func (r *APIKeyRepositoryAdapter) SetExpiry(ctx context.Context, projectID, id string, expiresAt time.Time) (*outbound.APIKey, error) {
+	apiKey, err := r.queries.SetAPIKeyExpiry(ctx, id, projectID, toTimestamp(&expiresAt))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, apperrors.New(apperrors.ErrCodeAPIKeyNotFound, "API key not found")
+		}
+		return nil, fmt.Errorf("failed to set API key expiry: %w", err)
+	}
+
+	return r.modelToOutbound(&apiKey), nil
+}
+
+// UpdateHash replaces an API key's stored hash
+func (r *APIKeyRepositoryAdapter) UpdateHash(ctx context.Context, id string, keyHash []byte, hashVersion int) error {
+	if err := r.queries.UpdateAPIKeyHash(ctx, id, keyHash, int16(hashVersion)); err != nil {
+		return fmt.Errorf("failed to update API key hash: %w", err)
+	}
+	return nil
+}
+
+// TouchLastUsed records when an API key last authenticated a request
+func (r *APIKeyRepositoryAdapter) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	if err := r.queries.TouchAPIKeyLastUsed(ctx, id, toTimestamp(&usedAt)); err != nil {
+		return fmt.Errorf("failed to update API key last used: %w", err)
+	}
+	return nil
+}
+
+// modelToOutbound converts SQLC model to outbound model
+func (r *APIKeyRepositoryAdapter) modelToOutbound(apiKey *sqlc.ApiKey) *outbound.APIKey {
+	return &outbound.APIKey{
+		ID:              apiKey.ID,
+		ProjectID:       apiKey.ProjectID,
+		Name:            apiKey.Name,
+		LookupPrefix:    apiKey.LookupPrefix,
+		KeyHash:         apiKey.KeyHash,
+		KeySalt:         apiKey.KeySalt,
+		KeyHint:         apiKey.KeyHint,
+		HashVersion:     int(apiKey.HashVersion),
+		KeyPrefixes:     apiKey.KeyPrefixes,
+		CreatedByUserID: apiKey.CreatedByUserID.String,
+		CreatedAt:       apiKey.CreatedAt.Time,
+		ExpiresAt:       fromTimestamp(apiKey.ExpiresAt),
+		LastUsedAt:      fromTimestamp(apiKey.LastUsedAt),
+		RevokedAt:       fromTimestamp(apiKey.RevokedAt),
+	}
+}
+
+// nonNilPrefixes stores "no prefixes" as an empty array rather than NULL
+func nonNilPrefixes(prefixes []string) []string {
+	if prefixes == nil {
+		return []string{}
+	}
+	return prefixes
+}
+
+// toTimestamp converts an optional time to a nullable timestamp (stored in UTC)
+func toTimestamp(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: t.UTC(), Valid: true}
+}
+
+// fromTimestamp converts a nullable timestamp to an optional time
+func fromTimestamp(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/vlone310/cfguardian/internal/adapters/outbound/postgres/sqlc"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// ConfigMigrationRepositoryAdapter implements outbound.ConfigMigrationRepository using PostgreSQL
+type ConfigMigrationRepositoryAdapter struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewConfigMigrationRepositoryAdapter creates a new PostgreSQL migration run repository
+func NewConfigMigrationRepositoryAdapter(pool *pgxpool.Pool) *ConfigMigrationRepositoryAdapter {
+	return &ConfigMigrationRepositoryAdapter{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+// Create records a migration run and its items in one transaction
+func (r *ConfigMigrationRepositoryAdapter) Create(ctx context.Context, params outbound.CreateConfigMigrationParams) (*outbound.ConfigMigration, error) {
+	var migration sqlc.ConfigMigration
+	err := WithTransaction(ctx, r.pool, func(tx pgx.Tx) error {
+		queries := r.queries.WithTx(tx)
+
+		var err error
+		migration, err = queries.CreateConfigMigration(ctx, sqlc.CreateConfigMigrationParams{
+			ID:              params.ID,
+			SchemaID:        params.SchemaID,
+			TargetVersion:   params.TargetVersion,
+			CreatedByUserID: params.CreatedByUserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range params.Items {
+			err = queries.CreateConfigMigrationItem(ctx, sqlc.CreateConfigMigrationItemParams{
+				MigrationID:       migration.ID,
+				ProjectID:         item.ProjectID,
+				ConfigKey:         item.ConfigKey,
+				FromSchemaVersion: item.FromSchemaVersion,
+				FromVersion:       item.FromVersion,
+				ToVersion:         item.ToVersion,
+				PreviousContent:   item.PreviousContent,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record config migration: %w", err)
+	}
+
+	result := r.modelToOutbound(&migration)
+	result.Items = params.Items
+	return result, nil
+}
+
+// GetByID retrieves a migration run with its items
+func (r *ConfigMigrationRepositoryAdapter) GetByID(ctx context.Context, id string) (*outbound.ConfigMigration, error) {
+	migration, err := r.queries.GetConfigMigration(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, apperrors.New(apperrors.ErrCodeMigrationNotFound, "config migration not found")
+		}
+		return nil, fmt.Errorf("failed to get config migration: %w", err)
+	}
+
+	items, err := r.queries.ListConfigMigrationItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config migration items: %w", err)
+	}
+
+	result := r.modelToOutbound(&migration)
+	result.Items = make([]outbound.ConfigMigrationItem, len(items))
+	for i, item := range items {
+		result.Items[i] = outbound.ConfigMigrationItem{
+			ProjectID:         item.ProjectID,
+			ConfigKey:         item.ConfigKey,
+			FromSchemaVersion: item.FromSchemaVersion,
+			FromVersion:       item.FromVersion,
+			ToVersion:         item.ToVersion,
+			PreviousContent:   item.PreviousContent,
+		}
+	}
+	return result, nil
+}
+
+// ListBySchema retrieves the migration runs of a schema, newest first
+func (r *ConfigMigrationRepositoryAdapter) ListBySchema(ctx context.Context, schemaID string) ([]*outbound.ConfigMigration, error) {
+	migrations, err := r.queries.ListConfigMigrationsBySchema(ctx, schemaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config migrations: %w", err)
+	}
+
+	result := make([]*outbound.ConfigMigration, len(migrations))
+	for i := range migrations {
+		result[i] = r.modelToOutbound(&migrations[i])
+	}
+	return result, nil
+}
+
+// MarkRolledBack records that a run was rolled back. The update only matches
+// runs that still stand, so two concurrent rollbacks cannot both succeed.
+func (r *ConfigMigrationRepositoryAdapter) MarkRolledBack(ctx context.Context, id, userID string) (*outbound.ConfigMigration, error) {
+	migration, err := r.queries.MarkConfigMigrationRolledBack(ctx, id, pgtype.Text{String: userID, Valid: userID != ""})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, apperrors.New(apperrors.ErrCodeConflict, "config migration not found or already rolled back")
+		}
+		return nil, fmt.Errorf("failed to mark config migration rolled back: %w", err)
+	}
+
+	return r.modelToOutbound(&migration), nil
+}
+
+// modelToOutbound converts a SQLC migration run to the outbound model
+func (r *ConfigMigrationRepositoryAdapter) modelToOutbound(migration *sqlc.ConfigMigration) *outbound.ConfigMigration {
+	return &outbound.ConfigMigration{
+		ID:                 migration.ID,
+		SchemaID:           migration.SchemaID,
+		TargetVersion:      migration.TargetVersion,
+		CreatedByUserID:    migration.CreatedByUserID,
+		CreatedAt:          migration.CreatedAt.Time,
+		RolledBackByUserID: migration.RolledBackByUserID.String,
+		RolledBackAt:       fromTimestamp(migration.RolledBackAt),
+	}
+}
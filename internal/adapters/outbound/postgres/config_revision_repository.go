@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vlone310/cfguardian/internal/adapters/outbound/postgres/sqlc"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -48,7 +49,7 @@ func (r *ConfigRevisionRepositoryAdapter) GetByID(ctx context.Context, id string
 	revision, err := r.queries.GetConfigRevision(ctx, id)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("config revision not found")
+			return nil, apperrors.New(apperrors.ErrCodeConfigNotFound, "config revision not found")
 		}
 		return nil, fmt.Errorf("failed to get config revision: %w", err)
 	}
@@ -61,7 +62,7 @@ func (r *ConfigRevisionRepositoryAdapter) GetByVersion(ctx context.Context, proj
 	revision, err := r.queries.GetConfigRevisionByVersion(ctx, projectID, configKey, version)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("config revision not found for version %d", version)
+			return nil, apperrors.New(apperrors.ErrCodeConfigNotFound, fmt.Sprintf("config revision not found for version %d", version))
 		}
 		return nil, fmt.Errorf("failed to get config revision: %w", err)
 	}
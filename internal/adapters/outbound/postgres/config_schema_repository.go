@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vlone310/cfguardian/internal/adapters/outbound/postgres/sqlc"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -25,13 +26,35 @@ func NewConfigSchemaRepositoryAdapter(pool *pgxpool.Pool) *ConfigSchemaRepositor
 	}
 }
 
-// Create creates a new config schema
+// Create creates a new config schema and stores its content as version 1
 func (r *ConfigSchemaRepositoryAdapter) Create(ctx context.Context, params outbound.CreateConfigSchemaParams) (*outbound.ConfigSchema, error) {
-	schema, err := r.queries.CreateConfigSchema(ctx, sqlc.CreateConfigSchemaParams{
-		ID:              params.ID,
-		Name:            params.Name,
-		SchemaContent:   params.SchemaContent,
-		CreatedByUserID: params.CreatedByUserID,
+	var schema sqlc.ConfigSchema
+	err := WithTransaction(ctx, r.pool, func(tx pgx.Tx) error {
+		queries := r.queries.WithTx(tx)
+		
+		var err error
+		schema, err = queries.CreateConfigSchema(ctx, sqlc.CreateConfigSchemaParams{
+			ID:              params.ID,
+			Name:            params.Name,
+			SchemaContent:   params.SchemaContent,
+			CreatedByUserID: params.CreatedByUserID,
+			ProjectID:       pgtype.Text{String: params.ProjectID, Valid: params.ProjectID != ""},
+		})
+		if err != nil {
+			return err
+		}
+		
+		_, err = queries.CreateConfigSchemaVersion(ctx, sqlc.CreateConfigSchemaVersionParams{
+			SchemaID:        schema.ID,
+			Version:         schema.LatestVersion,
+			SchemaContent:   schema.SchemaContent,
+			CreatedByUserID: schema.CreatedByUserID,
+		})
+		if err != nil {
+			return err
+		}
+		
+		return createReferences(ctx, queries, schema.ID, schema.LatestVersion, params.References)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create config schema: %w", err)
@@ -45,7 +68,7 @@ func (r *ConfigSchemaRepositoryAdapter) GetByID(ctx context.Context, id string)
 	schema, err := r.queries.GetConfigSchemaByID(ctx, id)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("config schema not found")
+			return nil, apperrors.New(apperrors.ErrCodeSchemaNotFound, "config schema not found")
 		}
 		return nil, fmt.Errorf("failed to get config schema: %w", err)
 	}
@@ -53,12 +76,12 @@ func (r *ConfigSchemaRepositoryAdapter) GetByID(ctx context.Context, id string)
 	return r.modelToOutbound(&schema), nil
 }
 
-// GetByName retrieves a config schema by name
-func (r *ConfigSchemaRepositoryAdapter) GetByName(ctx context.Context, name string) (*outbound.ConfigSchema, error) {
-	schema, err := r.queries.GetConfigSchemaByName(ctx, name)
+// GetByName retrieves a config schema by name among the schemas a project sees
+func (r *ConfigSchemaRepositoryAdapter) GetByName(ctx context.Context, projectID, name string) (*outbound.ConfigSchema, error) {
+	schema, err := r.queries.GetConfigSchemaByName(ctx, name, pgtype.Text{String: projectID, Valid: projectID != ""})
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("config schema not found")
+			return nil, apperrors.New(apperrors.ErrCodeSchemaNotFound, "config schema not found")
 		}
 		return nil, fmt.Errorf("failed to get config schema: %w", err)
 	}
@@ -81,6 +104,54 @@ func (r *ConfigSchemaRepositoryAdapter) List(ctx context.Context) ([]*outbound.C
 	return result, nil
 }
 
+// ListByProject retrieves the global schemas and the schemas of a project
+func (r *ConfigSchemaRepositoryAdapter) ListByProject(ctx context.Context, projectID string) ([]*outbound.ConfigSchema, error) {
+	schemas, err := r.queries.ListConfigSchemasByProject(ctx, pgtype.Text{String: projectID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config schemas by project: %w", err)
+	}
+	
+	result := make([]*outbound.ConfigSchema, len(schemas))
+	for i, schema := range schemas {
+		result[i] = r.modelToOutbound(&schema)
+	}
+	
+	return result, nil
+}
+
+// ListForProjects retrieves the global schemas and the schemas of any of the given projects
+func (r *ConfigSchemaRepositoryAdapter) ListForProjects(ctx context.Context, projectIDs []string) ([]*outbound.ConfigSchema, error) {
+	if projectIDs == nil {
+		projectIDs = []string{}
+	}
+	schemas, err := r.queries.ListConfigSchemasForProjects(ctx, projectIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config schemas for projects: %w", err)
+	}
+	
+	result := make([]*outbound.ConfigSchema, len(schemas))
+	for i, schema := range schemas {
+		result[i] = r.modelToOutbound(&schema)
+	}
+	
+	return result, nil
+}
+
+// ListReferencing retrieves the schemas with any version referencing a schema
+func (r *ConfigSchemaRepositoryAdapter) ListReferencing(ctx context.Context, schemaID string) ([]*outbound.ConfigSchema, error) {
+	schemas, err := r.queries.ListConfigSchemasReferencing(ctx, schemaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config schemas referencing schema: %w", err)
+	}
+	
+	result := make([]*outbound.ConfigSchema, len(schemas))
+	for i, schema := range schemas {
+		result[i] = r.modelToOutbound(&schema)
+	}
+	
+	return result, nil
+}
+
 // ListByCreator retrieves config schemas created by a specific user
 func (r *ConfigSchemaRepositoryAdapter) ListByCreator(ctx context.Context, creatorUserID string) ([]*outbound.ConfigSchema, error) {
 	schemas, err := r.queries.ListConfigSchemasByCreator(ctx, creatorUserID)
@@ -99,24 +170,107 @@ func (r *ConfigSchemaRepositoryAdapter) ListByCreator(ctx context.Context, creat
 // Update updates a config schema
 func (r *ConfigSchemaRepositoryAdapter) Update(ctx context.Context, params outbound.UpdateConfigSchemaParams) (*outbound.ConfigSchema, error) {
 	var name pgtype.Text
-	var schemaContent pgtype.Text
 	
 	// Only update fields that are provided
 	if params.Name != nil {
 		name = pgtype.Text{String: *params.Name, Valid: true}
 	}
-	if params.SchemaContent != nil {
-		schemaContent = pgtype.Text{String: *params.SchemaContent, Valid: true}
-	}
 	
-	schema, err := r.queries.UpdateConfigSchema(ctx, params.ID, name, schemaContent)
+	schema, err := r.queries.UpdateConfigSchema(ctx, params.ID, name)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, apperrors.New(apperrors.ErrCodeSchemaNotFound, "config schema not found")
+		}
 		return nil, fmt.Errorf("failed to update config schema: %w", err)
 	}
 	
 	return r.modelToOutbound(&schema), nil
 }
 
+// PublishVersion appends the next version of a schema. Bumping the latest
+// version locks the schema row, so concurrent publishes get distinct numbers.
+func (r *ConfigSchemaRepositoryAdapter) PublishVersion(ctx context.Context, params outbound.PublishConfigSchemaVersionParams) (*outbound.ConfigSchemaVersion, error) {
+	var version sqlc.ConfigSchemaVersion
+	err := WithTransaction(ctx, r.pool, func(tx pgx.Tx) error {
+		queries := r.queries.WithTx(tx)
+		
+		schema, err := queries.BumpConfigSchemaVersion(ctx, params.SchemaID, params.SchemaContent)
+		if err != nil {
+			return err
+		}
+		
+		version, err = queries.CreateConfigSchemaVersion(ctx, sqlc.CreateConfigSchemaVersionParams{
+			SchemaID:        schema.ID,
+			Version:         schema.LatestVersion,
+			SchemaContent:   params.SchemaContent,
+			CreatedByUserID: params.CreatedByUserID,
+			Migration:       params.Migration,
+		})
+		if err != nil {
+			return err
+		}
+		
+		return createReferences(ctx, queries, version.SchemaID, version.Version, params.References)
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, apperrors.New(apperrors.ErrCodeSchemaNotFound, "config schema not found")
+		}
+		return nil, fmt.Errorf("failed to publish config schema version: %w", err)
+	}
+	
+	return r.versionToOutbound(&version), nil
+}
+
+// GetVersion retrieves one version of a schema
+func (r *ConfigSchemaRepositoryAdapter) GetVersion(ctx context.Context, schemaID string, version int32) (*outbound.ConfigSchemaVersion, error) {
+	schemaVersion, err := r.queries.GetConfigSchemaVersion(ctx, schemaID, version)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, apperrors.New(apperrors.ErrCodeSchemaVersionNotFound, "config schema version not found")
+		}
+		return nil, fmt.Errorf("failed to get config schema version: %w", err)
+	}
+	
+	return r.versionToOutbound(&schemaVersion), nil
+}
+
+// ListVersions retrieves every version of a schema, newest first
+func (r *ConfigSchemaRepositoryAdapter) ListVersions(ctx context.Context, schemaID string) ([]*outbound.ConfigSchemaVersion, error) {
+	versions, err := r.queries.ListConfigSchemaVersions(ctx, schemaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config schema versions: %w", err)
+	}
+	
+	result := make([]*outbound.ConfigSchemaVersion, len(versions))
+	for i, version := range versions {
+		result[i] = r.versionToOutbound(&version)
+	}
+	
+	return result, nil
+}
+
+// ListVersionReferences retrieves the schemas a version references,
+// directly or not, at the versions pinned when it was published
+func (r *ConfigSchemaRepositoryAdapter) ListVersionReferences(ctx context.Context, schemaID string, version int32) ([]*outbound.ConfigSchemaReference, error) {
+	references, err := r.queries.ListConfigSchemaVersionReferences(ctx, schemaID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config schema references: %w", err)
+	}
+	
+	result := make([]*outbound.ConfigSchemaReference, len(references))
+	for i, reference := range references {
+		result[i] = &outbound.ConfigSchemaReference{
+			Name:          reference.ReferencedName,
+			SchemaID:      reference.ReferencedSchemaID,
+			Version:       reference.ReferencedVersion,
+			SchemaContent: reference.SchemaContent,
+		}
+	}
+	
+	return result, nil
+}
+
 // Delete deletes a config schema
 func (r *ConfigSchemaRepositoryAdapter) Delete(ctx context.Context, id string) error {
 	err := r.queries.DeleteConfigSchema(ctx, id)
@@ -135,9 +289,9 @@ func (r *ConfigSchemaRepositoryAdapter) Exists(ctx context.Context, id string) (
 	return exists, nil
 }
 
-// ExistsByName checks if a config schema exists by name
-func (r *ConfigSchemaRepositoryAdapter) ExistsByName(ctx context.Context, name string) (bool, error) {
-	exists, err := r.queries.ConfigSchemaExistsByName(ctx, name)
+// ExistsByName checks if a name is taken for a schema of a project
+func (r *ConfigSchemaRepositoryAdapter) ExistsByName(ctx context.Context, projectID, name string) (bool, error) {
+	exists, err := r.queries.ConfigSchemaExistsByName(ctx, name, pgtype.Text{String: projectID, Valid: projectID != ""})
 	if err != nil {
 		return false, fmt.Errorf("failed to check config schema existence by name: %w", err)
 	}
@@ -162,15 +316,46 @@ func (r *ConfigSchemaRepositoryAdapter) CountConfigsUsing(ctx context.Context, s
 	return count, nil
 }
 
+// createReferences records the schema versions a schema version references
+func createReferences(ctx context.Context, queries *sqlc.Queries, schemaID string, version int32, references []outbound.ConfigSchemaReference) error {
+	for _, reference := range references {
+		err := queries.CreateConfigSchemaReference(ctx, sqlc.CreateConfigSchemaReferenceParams{
+			SchemaID:           schemaID,
+			Version:            version,
+			ReferencedSchemaID: reference.SchemaID,
+			ReferencedName:     reference.Name,
+			ReferencedVersion:  reference.Version,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // modelToOutbound converts SQLC model to outbound model
 func (r *ConfigSchemaRepositoryAdapter) modelToOutbound(schema *sqlc.ConfigSchema) *outbound.ConfigSchema {
 	return &outbound.ConfigSchema{
 		ID:              schema.ID,
 		Name:            schema.Name,
 		SchemaContent:   schema.SchemaContent,
+		LatestVersion:   schema.LatestVersion,
+		ProjectID:       schema.ProjectID.String,
 		CreatedByUserID: schema.CreatedByUserID,
 		CreatedAt:       schema.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:       schema.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
+// versionToOutbound converts a SQLC schema version to the outbound model
+func (r *ConfigSchemaRepositoryAdapter) versionToOutbound(version *sqlc.ConfigSchemaVersion) *outbound.ConfigSchemaVersion {
+	return &outbound.ConfigSchemaVersion{
+		SchemaID:        version.SchemaID,
+		Version:         version.Version,
+		SchemaContent:   version.SchemaContent,
+		Migration:       version.Migration,
+		CreatedByUserID: version.CreatedByUserID,
+		CreatedAt:       version.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
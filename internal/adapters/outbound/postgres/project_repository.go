@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vlone310/cfguardian/internal/adapters/outbound/postgres/sqlc"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -30,7 +31,6 @@ func (r *ProjectRepositoryAdapter) Create(ctx context.Context, params outbound.C
 	project, err := r.queries.CreateProject(ctx, sqlc.CreateProjectParams{
 		ID:          params.ID,
 		Name:        params.Name,
-		ApiKey:      params.APIKey,
 		OwnerUserID: params.OwnerUserID,
 	})
 	if err != nil {
@@ -45,20 +45,7 @@ func (r *ProjectRepositoryAdapter) GetByID(ctx context.Context, id string) (*out
 	project, err := r.queries.GetProjectByID(ctx, id)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("project not found")
-		}
-		return nil, fmt.Errorf("failed to get project: %w", err)
-	}
-	
-	return r.modelToOutbound(&project), nil
-}
-
-// GetByAPIKey retrieves a project by API key
-func (r *ProjectRepositoryAdapter) GetByAPIKey(ctx context.Context, apiKey string) (*outbound.Project, error) {
-	project, err := r.queries.GetProjectByAPIKey(ctx, apiKey)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("project not found")
+			return nil, apperrors.New(apperrors.ErrCodeProjectNotFound, "project not found")
 		}
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
@@ -99,17 +86,13 @@ func (r *ProjectRepositoryAdapter) ListByOwner(ctx context.Context, ownerUserID
 // Update updates a project
 func (r *ProjectRepositoryAdapter) Update(ctx context.Context, params outbound.UpdateProjectParams) (*outbound.Project, error) {
 	var name pgtype.Text
-	var apiKey pgtype.Text
 	
 	// Only update fields that are provided
 	if params.Name != nil {
 		name = pgtype.Text{String: *params.Name, Valid: true}
 	}
-	if params.APIKey != nil {
-		apiKey = pgtype.Text{String: *params.APIKey, Valid: true}
-	}
 	
-	project, err := r.queries.UpdateProject(ctx, params.ID, name, apiKey)
+	project, err := r.queries.UpdateProject(ctx, params.ID, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update project: %w", err)
 	}
@@ -135,15 +118,6 @@ func (r *ProjectRepositoryAdapter) Exists(ctx context.Context, id string) (bool,
 	return exists, nil
 }
 
-// ExistsByAPIKey checks if a project exists by API key
-func (r *ProjectRepositoryAdapter) ExistsByAPIKey(ctx context.Context, apiKey string) (bool, error) {
-	exists, err := r.queries.ProjectExistsByAPIKey(ctx, apiKey)
-	if err != nil {
-		return false, fmt.Errorf("failed to check project existence by API key: %w", err)
-	}
-	return exists, nil
-}
-
 // ExistsByName checks if a project exists by name
 func (r *ProjectRepositoryAdapter) ExistsByName(ctx context.Context, name string) (bool, error) {
 	// Note: We don't have a specific query for this in SQLC yet
@@ -184,7 +158,6 @@ func (r *ProjectRepositoryAdapter) modelToOutbound(project *sqlc.Project) *outbo
 	return &outbound.Project{
 		ID:          project.ID,
 		Name:        project.Name,
-		APIKey:      project.ApiKey,
 		OwnerUserID: project.OwnerUserID,
 		CreatedAt:   project.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   project.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
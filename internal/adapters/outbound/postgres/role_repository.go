@@ -7,6 +7,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vlone310/cfguardian/internal/adapters/outbound/postgres/sqlc"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -39,7 +40,7 @@ func (r *RoleRepositoryAdapter) Get(ctx context.Context, userID, projectID strin
 	role, err := r.queries.GetRole(ctx, userID, projectID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("role not found")
+			return nil, apperrors.New(apperrors.ErrCodeRoleNotFound, "role not found")
 		}
 		return nil, fmt.Errorf("failed to get role: %w", err)
 	}
@@ -52,7 +53,7 @@ func (r *RoleRepositoryAdapter) GetUserRole(ctx context.Context, userID, project
 	roleLevel, err := r.queries.GetUserRole(ctx, userID, projectID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return "", fmt.Errorf("role not found")
+			return "", apperrors.New(apperrors.ErrCodeRoleNotFound, "role not found")
 		}
 		return "", fmt.Errorf("failed to get user role: %w", err)
 	}
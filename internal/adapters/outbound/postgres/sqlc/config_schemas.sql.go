@@ -11,6 +11,32 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const bumpConfigSchemaVersion = `-- name: BumpConfigSchemaVersion :one
+UPDATE config_schemas
+SET
+    latest_version = latest_version + 1,
+    schema_content = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id
+`
+
+func (q *Queries) BumpConfigSchemaVersion(ctx context.Context, iD string, schemaContent string) (ConfigSchema, error) {
+	row := q.db.QueryRow(ctx, bumpConfigSchemaVersion, iD, schemaContent)
+	var i ConfigSchema
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.SchemaContent,
+		&i.CreatedByUserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LatestVersion,
+		&i.ProjectID,
+	)
+	return i, err
+}
+
 const configSchemaExists = `-- name: ConfigSchemaExists :one
 SELECT EXISTS(
     SELECT 1 FROM config_schemas WHERE id = $1
@@ -26,12 +52,16 @@ func (q *Queries) ConfigSchemaExists(ctx context.Context, id string) (bool, erro
 
 const configSchemaExistsByName = `-- name: ConfigSchemaExistsByName :one
 SELECT EXISTS(
-    SELECT 1 FROM config_schemas WHERE name = $1
+    SELECT 1 FROM config_schemas
+    WHERE name = $1
+        AND ($2::varchar IS NULL OR project_id IS NULL OR project_id = $2)
 ) AS exists
 `
 
-func (q *Queries) ConfigSchemaExistsByName(ctx context.Context, name string) (bool, error) {
-	row := q.db.QueryRow(ctx, configSchemaExistsByName, name)
+// A project schema's name must be free among the project's and the global
+// schemas; a global schema's name among all schemas, as every project sees it
+func (q *Queries) ConfigSchemaExistsByName(ctx context.Context, name string, projectID pgtype.Text) (bool, error) {
+	row := q.db.QueryRow(ctx, configSchemaExistsByName, name, projectID)
 	var exists bool
 	err := row.Scan(&exists)
 	return exists, err
@@ -65,18 +95,20 @@ INSERT INTO config_schemas (
     id,
     name,
     schema_content,
-    created_by_user_id
+    created_by_user_id,
+    project_id
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 )
-RETURNING id, name, schema_content, created_by_user_id, created_at, updated_at
+RETURNING id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id
 `
 
 type CreateConfigSchemaParams struct {
-	ID              string `db:"id" json:"id"`
-	Name            string `db:"name" json:"name"`
-	SchemaContent   string `db:"schema_content" json:"schema_content"`
-	CreatedByUserID string `db:"created_by_user_id" json:"created_by_user_id"`
+	ID              string      `db:"id" json:"id"`
+	Name            string      `db:"name" json:"name"`
+	SchemaContent   string      `db:"schema_content" json:"schema_content"`
+	CreatedByUserID string      `db:"created_by_user_id" json:"created_by_user_id"`
+	ProjectID       pgtype.Text `db:"project_id" json:"project_id"`
 }
 
 func (q *Queries) CreateConfigSchema(ctx context.Context, arg CreateConfigSchemaParams) (ConfigSchema, error) {
@@ -85,6 +117,7 @@ func (q *Queries) CreateConfigSchema(ctx context.Context, arg CreateConfigSchema
 		arg.Name,
 		arg.SchemaContent,
 		arg.CreatedByUserID,
+		arg.ProjectID,
 	)
 	var i ConfigSchema
 	err := row.Scan(
@@ -94,6 +127,81 @@ func (q *Queries) CreateConfigSchema(ctx context.Context, arg CreateConfigSchema
 		&i.CreatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LatestVersion,
+		&i.ProjectID,
+	)
+	return i, err
+}
+
+const createConfigSchemaReference = `-- name: CreateConfigSchemaReference :exec
+INSERT INTO config_schema_references (
+    schema_id,
+    version,
+    referenced_schema_id,
+    referenced_name,
+    referenced_version
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT DO NOTHING
+`
+
+type CreateConfigSchemaReferenceParams struct {
+	SchemaID           string `db:"schema_id" json:"schema_id"`
+	Version            int32  `db:"version" json:"version"`
+	ReferencedSchemaID string `db:"referenced_schema_id" json:"referenced_schema_id"`
+	ReferencedName     string `db:"referenced_name" json:"referenced_name"`
+	ReferencedVersion  int32  `db:"referenced_version" json:"referenced_version"`
+}
+
+func (q *Queries) CreateConfigSchemaReference(ctx context.Context, arg CreateConfigSchemaReferenceParams) error {
+	_, err := q.db.Exec(ctx, createConfigSchemaReference,
+		arg.SchemaID,
+		arg.Version,
+		arg.ReferencedSchemaID,
+		arg.ReferencedName,
+		arg.ReferencedVersion,
+	)
+	return err
+}
+
+const createConfigSchemaVersion = `-- name: CreateConfigSchemaVersion :one
+INSERT INTO config_schema_versions (
+    schema_id,
+    version,
+    schema_content,
+    created_by_user_id,
+    migration
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING schema_id, version, schema_content, created_by_user_id, created_at, migration
+`
+
+type CreateConfigSchemaVersionParams struct {
+	SchemaID        string `db:"schema_id" json:"schema_id"`
+	Version         int32  `db:"version" json:"version"`
+	SchemaContent   string `db:"schema_content" json:"schema_content"`
+	CreatedByUserID string `db:"created_by_user_id" json:"created_by_user_id"`
+	Migration       []byte `db:"migration" json:"migration"`
+}
+
+func (q *Queries) CreateConfigSchemaVersion(ctx context.Context, arg CreateConfigSchemaVersionParams) (ConfigSchemaVersion, error) {
+	row := q.db.QueryRow(ctx, createConfigSchemaVersion,
+		arg.SchemaID,
+		arg.Version,
+		arg.SchemaContent,
+		arg.CreatedByUserID,
+		arg.Migration,
+	)
+	var i ConfigSchemaVersion
+	err := row.Scan(
+		&i.SchemaID,
+		&i.Version,
+		&i.SchemaContent,
+		&i.CreatedByUserID,
+		&i.CreatedAt,
+		&i.Migration,
 	)
 	return i, err
 }
@@ -109,7 +217,7 @@ func (q *Queries) DeleteConfigSchema(ctx context.Context, id string) error {
 }
 
 const getConfigSchemaByID = `-- name: GetConfigSchemaByID :one
-SELECT id, name, schema_content, created_by_user_id, created_at, updated_at FROM config_schemas
+SELECT id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id FROM config_schemas
 WHERE id = $1
 LIMIT 1
 `
@@ -124,18 +232,23 @@ func (q *Queries) GetConfigSchemaByID(ctx context.Context, id string) (ConfigSch
 		&i.CreatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LatestVersion,
+		&i.ProjectID,
 	)
 	return i, err
 }
 
 const getConfigSchemaByName = `-- name: GetConfigSchemaByName :one
-SELECT id, name, schema_content, created_by_user_id, created_at, updated_at FROM config_schemas
-WHERE name = $1
+SELECT id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id FROM config_schemas
+WHERE name = $1 AND (project_id IS NULL OR project_id = $2)
+ORDER BY project_id NULLS LAST
 LIMIT 1
 `
 
-func (q *Queries) GetConfigSchemaByName(ctx context.Context, name string) (ConfigSchema, error) {
-	row := q.db.QueryRow(ctx, getConfigSchemaByName, name)
+// A project sees its own schemas and the global ones; without a project,
+// only the global ones
+func (q *Queries) GetConfigSchemaByName(ctx context.Context, name string, projectID pgtype.Text) (ConfigSchema, error) {
+	row := q.db.QueryRow(ctx, getConfigSchemaByName, name, projectID)
 	var i ConfigSchema
 	err := row.Scan(
 		&i.ID,
@@ -144,12 +257,109 @@ func (q *Queries) GetConfigSchemaByName(ctx context.Context, name string) (Confi
 		&i.CreatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LatestVersion,
+		&i.ProjectID,
+	)
+	return i, err
+}
+
+const getConfigSchemaVersion = `-- name: GetConfigSchemaVersion :one
+SELECT schema_id, version, schema_content, created_by_user_id, created_at, migration FROM config_schema_versions
+WHERE schema_id = $1 AND version = $2
+LIMIT 1
+`
+
+func (q *Queries) GetConfigSchemaVersion(ctx context.Context, schemaID string, version int32) (ConfigSchemaVersion, error) {
+	row := q.db.QueryRow(ctx, getConfigSchemaVersion, schemaID, version)
+	var i ConfigSchemaVersion
+	err := row.Scan(
+		&i.SchemaID,
+		&i.Version,
+		&i.SchemaContent,
+		&i.CreatedByUserID,
+		&i.CreatedAt,
+		&i.Migration,
 	)
 	return i, err
 }
 
+const listConfigSchemaVersionReferences = `-- name: ListConfigSchemaVersionReferences :many
+SELECT r.referenced_name, r.referenced_schema_id, r.referenced_version, v.schema_content
+FROM config_schema_references r
+JOIN config_schema_versions v
+    ON v.schema_id = r.referenced_schema_id
+    AND v.version = r.referenced_version
+WHERE r.schema_id = $1 AND r.version = $2
+ORDER BY r.referenced_name
+`
+
+type ListConfigSchemaVersionReferencesRow struct {
+	ReferencedName     string `db:"referenced_name" json:"referenced_name"`
+	ReferencedSchemaID string `db:"referenced_schema_id" json:"referenced_schema_id"`
+	ReferencedVersion  int32  `db:"referenced_version" json:"referenced_version"`
+	SchemaContent      string `db:"schema_content" json:"schema_content"`
+}
+
+func (q *Queries) ListConfigSchemaVersionReferences(ctx context.Context, schemaID string, version int32) ([]ListConfigSchemaVersionReferencesRow, error) {
+	rows, err := q.db.Query(ctx, listConfigSchemaVersionReferences, schemaID, version)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListConfigSchemaVersionReferencesRow{}
+	for rows.Next() {
+		var i ListConfigSchemaVersionReferencesRow
+		if err := rows.Scan(
+			&i.ReferencedName,
+			&i.ReferencedSchemaID,
+			&i.ReferencedVersion,
+			&i.SchemaContent,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listConfigSchemaVersions = `-- name: ListConfigSchemaVersions :many
+SELECT schema_id, version, schema_content, created_by_user_id, created_at, migration FROM config_schema_versions
+WHERE schema_id = $1
+ORDER BY version DESC
+`
+
+func (q *Queries) ListConfigSchemaVersions(ctx context.Context, schemaID string) ([]ConfigSchemaVersion, error) {
+	rows, err := q.db.Query(ctx, listConfigSchemaVersions, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConfigSchemaVersion{}
+	for rows.Next() {
+		var i ConfigSchemaVersion
+		if err := rows.Scan(
+			&i.SchemaID,
+			&i.Version,
+			&i.SchemaContent,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.Migration,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listConfigSchemas = `-- name: ListConfigSchemas :many
-SELECT id, name, schema_content, created_by_user_id, created_at, updated_at FROM config_schemas
+SELECT id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id FROM config_schemas
 ORDER BY name
 `
 
@@ -169,6 +379,8 @@ func (q *Queries) ListConfigSchemas(ctx context.Context) ([]ConfigSchema, error)
 			&i.CreatedByUserID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LatestVersion,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -181,7 +393,7 @@ func (q *Queries) ListConfigSchemas(ctx context.Context) ([]ConfigSchema, error)
 }
 
 const listConfigSchemasByCreator = `-- name: ListConfigSchemasByCreator :many
-SELECT id, name, schema_content, created_by_user_id, created_at, updated_at FROM config_schemas
+SELECT id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id FROM config_schemas
 WHERE created_by_user_id = $1
 ORDER BY created_at DESC
 `
@@ -202,6 +414,116 @@ func (q *Queries) ListConfigSchemasByCreator(ctx context.Context, createdByUserI
 			&i.CreatedByUserID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LatestVersion,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listConfigSchemasByProject = `-- name: ListConfigSchemasByProject :many
+SELECT id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id FROM config_schemas
+WHERE project_id IS NULL OR project_id = $1
+ORDER BY name
+`
+
+func (q *Queries) ListConfigSchemasByProject(ctx context.Context, projectID pgtype.Text) ([]ConfigSchema, error) {
+	rows, err := q.db.Query(ctx, listConfigSchemasByProject, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConfigSchema{}
+	for rows.Next() {
+		var i ConfigSchema
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.SchemaContent,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LatestVersion,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listConfigSchemasForProjects = `-- name: ListConfigSchemasForProjects :many
+SELECT id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id FROM config_schemas
+WHERE project_id IS NULL OR project_id = ANY($1::varchar[])
+ORDER BY name
+`
+
+func (q *Queries) ListConfigSchemasForProjects(ctx context.Context, projectIds []string) ([]ConfigSchema, error) {
+	rows, err := q.db.Query(ctx, listConfigSchemasForProjects, projectIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConfigSchema{}
+	for rows.Next() {
+		var i ConfigSchema
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.SchemaContent,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LatestVersion,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listConfigSchemasReferencing = `-- name: ListConfigSchemasReferencing :many
+SELECT id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id FROM config_schemas
+WHERE id IN (
+    SELECT DISTINCT schema_id FROM config_schema_references
+    WHERE referenced_schema_id = $1
+)
+ORDER BY name
+`
+
+func (q *Queries) ListConfigSchemasReferencing(ctx context.Context, referencedSchemaID string) ([]ConfigSchema, error) {
+	rows, err := q.db.Query(ctx, listConfigSchemasReferencing, referencedSchemaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConfigSchema{}
+	for rows.Next() {
+		var i ConfigSchema
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.SchemaContent,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LatestVersion,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -217,14 +539,13 @@ const updateConfigSchema = `-- name: UpdateConfigSchema :one
 UPDATE config_schemas
 SET
     name = COALESCE($2, name),
-    schema_content = COALESCE($3, schema_content),
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, schema_content, created_by_user_id, created_at, updated_at
+RETURNING id, name, schema_content, created_by_user_id, created_at, updated_at, latest_version, project_id
 `
 
-func (q *Queries) UpdateConfigSchema(ctx context.Context, iD string, name pgtype.Text, schemaContent pgtype.Text) (ConfigSchema, error) {
-	row := q.db.QueryRow(ctx, updateConfigSchema, iD, name, schemaContent)
+func (q *Queries) UpdateConfigSchema(ctx context.Context, iD string, name pgtype.Text) (ConfigSchema, error) {
+	row := q.db.QueryRow(ctx, updateConfigSchema, iD, name)
 	var i ConfigSchema
 	err := row.Scan(
 		&i.ID,
@@ -233,6 +554,8 @@ func (q *Queries) UpdateConfigSchema(ctx context.Context, iD string, name pgtype
 		&i.CreatedByUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LatestVersion,
+		&i.ProjectID,
 	)
 	return i, err
 }
@@ -72,6 +72,37 @@ func AllRoleLevelValues() []RoleLevel {
 	}
 }
 
+// Read-only client API keys, several per project
+type ApiKey struct {
+	// Unique API key identifier (UUID)
+	ID string `db:"id" json:"id"`
+	// Project the key grants read access to
+	ProjectID string `db:"project_id" json:"project_id"`
+	// Display name, e.g. the client that uses the key
+	Name string `db:"name" json:"name"`
+	// Config key prefixes the key is limited to (empty = all configs)
+	KeyPrefixes []string `db:"key_prefixes" json:"key_prefixes"`
+	// User who created the key
+	CreatedByUserID pgtype.Text      `db:"created_by_user_id" json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
+	// When the key stops working (NULL = never)
+	ExpiresAt pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	// When the key last authenticated a request
+	LastUsedAt pgtype.Timestamp `db:"last_used_at" json:"last_used_at"`
+	// When the key was revoked (NULL = active)
+	RevokedAt pgtype.Timestamp `db:"revoked_at" json:"revoked_at"`
+	// Leading characters of the key, used to find its hash (not unique)
+	LookupPrefix string `db:"lookup_prefix" json:"lookup_prefix"`
+	// Salted hash of the key (see hash_version)
+	KeyHash []byte `db:"key_hash" json:"key_hash"`
+	// Random per-key salt
+	KeySalt []byte `db:"key_salt" json:"key_salt"`
+	// Last characters of the key, for display
+	KeyHint string `db:"key_hint" json:"key_hint"`
+	// Hashing scheme: 1 = SHA-256(salt || key), 2 = HMAC-SHA256(pepper, SHA-256(salt || key))
+	HashVersion int16 `db:"hash_version" json:"hash_version"`
+}
+
 // Current authoritative configuration state (requires Raft consensus)
 type Config struct {
 	// Reference to project (part of composite PK)
@@ -90,6 +121,33 @@ type Config struct {
 	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
 }
 
+// Runs of schema migrations over the configs using a schema
+type ConfigMigration struct {
+	ID       string `db:"id" json:"id"`
+	SchemaID string `db:"schema_id" json:"schema_id"`
+	// Schema version the configs were migrated to
+	TargetVersion      int32            `db:"target_version" json:"target_version"`
+	CreatedByUserID    string           `db:"created_by_user_id" json:"created_by_user_id"`
+	CreatedAt          pgtype.Timestamp `db:"created_at" json:"created_at"`
+	RolledBackByUserID pgtype.Text      `db:"rolled_back_by_user_id" json:"rolled_back_by_user_id"`
+	// When the run was rolled back; NULL while it stands
+	RolledBackAt pgtype.Timestamp `db:"rolled_back_at" json:"rolled_back_at"`
+}
+
+// Configs rewritten by a migration run
+type ConfigMigrationItem struct {
+	MigrationID       string `db:"migration_id" json:"migration_id"`
+	ProjectID         string `db:"project_id" json:"project_id"`
+	ConfigKey         string `db:"config_key" json:"config_key"`
+	FromSchemaVersion int32  `db:"from_schema_version" json:"from_schema_version"`
+	// Config version before the run
+	FromVersion int64 `db:"from_version" json:"from_version"`
+	// Config version written by the run
+	ToVersion int64 `db:"to_version" json:"to_version"`
+	// Content before the run, restored on rollback
+	PreviousContent []byte `db:"previous_content" json:"previous_content"`
+}
+
 // Immutable audit log of all configuration changes
 type ConfigRevision struct {
 	// Unique revision identifier (UUID)
@@ -113,12 +171,41 @@ type ConfigSchema struct {
 	ID string `db:"id" json:"id"`
 	// Schema name for reference
 	Name string `db:"name" json:"name"`
-	// JSON Schema definition
+	// JSON Schema definition of the latest version
 	SchemaContent string `db:"schema_content" json:"schema_content"`
 	// User who created this schema
 	CreatedByUserID string           `db:"created_by_user_id" json:"created_by_user_id"`
 	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
 	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	// Newest published version
+	LatestVersion int32 `db:"latest_version" json:"latest_version"`
+	// Project owning this schema; NULL for a global schema
+	ProjectID pgtype.Text `db:"project_id" json:"project_id"`
+}
+
+// Registry schemas referenced by each schema version
+type ConfigSchemaReference struct {
+	SchemaID string `db:"schema_id" json:"schema_id"`
+	Version  int32  `db:"version" json:"version"`
+	// Schema named by a $ref; it cannot be deleted while referenced
+	ReferencedSchemaID string `db:"referenced_schema_id" json:"referenced_schema_id"`
+	// Name the $ref used when the version was published
+	ReferencedName string `db:"referenced_name" json:"referenced_name"`
+	// Version of the referenced schema the version validates with
+	ReferencedVersion int32 `db:"referenced_version" json:"referenced_version"`
+}
+
+// Immutable, numbered versions of each config schema
+type ConfigSchemaVersion struct {
+	SchemaID string `db:"schema_id" json:"schema_id"`
+	// Version number within the schema, starting at 1
+	Version int32 `db:"version" json:"version"`
+	// JSON Schema definition of this version
+	SchemaContent   string           `db:"schema_content" json:"schema_content"`
+	CreatedByUserID string           `db:"created_by_user_id" json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
+	// Operations that rewrite content of the previous version for this one
+	Migration []byte `db:"migration" json:"migration"`
 }
 
 // Projects for multi-tenancy scoping
@@ -127,8 +214,6 @@ type Project struct {
 	ID string `db:"id" json:"id"`
 	// Project name
 	Name string `db:"name" json:"name"`
-	// API key for client access (unique)
-	ApiKey string `db:"api_key" json:"api_key"`
 	// Reference to the project owner
 	OwnerUserID string           `db:"owner_user_id" json:"owner_user_id"`
 	CreatedAt   pgtype.Timestamp `db:"created_at" json:"created_at"`
@@ -38,18 +38,16 @@ const createProject = `-- name: CreateProject :one
 INSERT INTO projects (
     id,
     name,
-    api_key,
     owner_user_id
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3
 )
-RETURNING id, name, api_key, owner_user_id, created_at, updated_at
+RETURNING id, name, owner_user_id, created_at, updated_at
 `
 
 type CreateProjectParams struct {
 	ID          string `db:"id" json:"id"`
 	Name        string `db:"name" json:"name"`
-	ApiKey      string `db:"api_key" json:"api_key"`
 	OwnerUserID string `db:"owner_user_id" json:"owner_user_id"`
 }
 
@@ -57,14 +55,12 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 	row := q.db.QueryRow(ctx, createProject,
 		arg.ID,
 		arg.Name,
-		arg.ApiKey,
 		arg.OwnerUserID,
 	)
 	var i Project
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.ApiKey,
 		&i.OwnerUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -82,28 +78,8 @@ func (q *Queries) DeleteProject(ctx context.Context, id string) error {
 	return err
 }
 
-const getProjectByAPIKey = `-- name: GetProjectByAPIKey :one
-SELECT id, name, api_key, owner_user_id, created_at, updated_at FROM projects
-WHERE api_key = $1
-LIMIT 1
-`
-
-func (q *Queries) GetProjectByAPIKey(ctx context.Context, apiKey string) (Project, error) {
-	row := q.db.QueryRow(ctx, getProjectByAPIKey, apiKey)
-	var i Project
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.ApiKey,
-		&i.OwnerUserID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
-}
-
 const getProjectByID = `-- name: GetProjectByID :one
-SELECT id, name, api_key, owner_user_id, created_at, updated_at FROM projects
+SELECT id, name, owner_user_id, created_at, updated_at FROM projects
 WHERE id = $1
 LIMIT 1
 `
@@ -114,7 +90,6 @@ func (q *Queries) GetProjectByID(ctx context.Context, id string) (Project, error
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.ApiKey,
 		&i.OwnerUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -123,7 +98,7 @@ func (q *Queries) GetProjectByID(ctx context.Context, id string) (Project, error
 }
 
 const listProjects = `-- name: ListProjects :many
-SELECT id, name, api_key, owner_user_id, created_at, updated_at FROM projects
+SELECT id, name, owner_user_id, created_at, updated_at FROM projects
 ORDER BY created_at DESC
 `
 
@@ -139,7 +114,6 @@ func (q *Queries) ListProjects(ctx context.Context) ([]Project, error) {
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
-			&i.ApiKey,
 			&i.OwnerUserID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -155,7 +129,7 @@ func (q *Queries) ListProjects(ctx context.Context) ([]Project, error) {
 }
 
 const listProjectsByOwner = `-- name: ListProjectsByOwner :many
-SELECT id, name, api_key, owner_user_id, created_at, updated_at FROM projects
+SELECT id, name, owner_user_id, created_at, updated_at FROM projects
 WHERE owner_user_id = $1
 ORDER BY created_at DESC
 `
@@ -172,7 +146,6 @@ func (q *Queries) ListProjectsByOwner(ctx context.Context, ownerUserID string) (
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
-			&i.ApiKey,
 			&i.OwnerUserID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -200,19 +173,6 @@ func (q *Queries) ProjectExists(ctx context.Context, id string) (bool, error) {
 	return exists, err
 }
 
-const projectExistsByAPIKey = `-- name: ProjectExistsByAPIKey :one
-SELECT EXISTS(
-    SELECT 1 FROM projects WHERE api_key = $1
-) AS exists
-`
-
-func (q *Queries) ProjectExistsByAPIKey(ctx context.Context, apiKey string) (bool, error) {
-	row := q.db.QueryRow(ctx, projectExistsByAPIKey, apiKey)
-	var exists bool
-	err := row.Scan(&exists)
-	return exists, err
-}
-
 const projectExistsByName = `-- name: ProjectExistsByName :one
 SELECT EXISTS(
     SELECT 1 FROM projects WHERE name = $1
@@ -230,19 +190,17 @@ const updateProject = `-- name: UpdateProject :one
 UPDATE projects
 SET
     name = COALESCE($2, name),
-    api_key = COALESCE($3, api_key),
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, api_key, owner_user_id, created_at, updated_at
+RETURNING id, name, owner_user_id, created_at, updated_at
 `
 
-func (q *Queries) UpdateProject(ctx context.Context, iD string, name pgtype.Text, apiKey pgtype.Text) (Project, error) {
-	row := q.db.QueryRow(ctx, updateProject, iD, name, apiKey)
+func (q *Queries) UpdateProject(ctx context.Context, iD string, name pgtype.Text) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProject, iD, name)
 	var i Project
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.ApiKey,
 		&i.OwnerUserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
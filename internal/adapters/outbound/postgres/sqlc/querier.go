@@ -11,11 +11,15 @@ import (
 )
 
 type Querier interface {
+	// Optimistic locking helper - get current version for update
 	AssignRole(ctx context.Context, userID string, projectID string, roleLevel RoleLevel) (Role, error)
+	BumpConfigSchemaVersion(ctx context.Context, iD string, schemaContent string) (ConfigSchema, error)
 	ChangeConfigSchema(ctx context.Context, arg ChangeConfigSchemaParams) (Config, error)
 	ConfigExists(ctx context.Context, projectID string, key string) (bool, error)
 	ConfigSchemaExists(ctx context.Context, id string) (bool, error)
-	ConfigSchemaExistsByName(ctx context.Context, name string) (bool, error)
+	// A project schema's name must be free among the project's and the global
+	// schemas; a global schema's name among all schemas, as every project sees it
+	ConfigSchemaExistsByName(ctx context.Context, name string, projectID pgtype.Text) (bool, error)
 	CountConfigSchemas(ctx context.Context) (int64, error)
 	CountConfigsByProject(ctx context.Context, projectID string) (int64, error)
 	CountConfigsBySchema(ctx context.Context, schemaID string) (int64, error)
@@ -27,9 +31,14 @@ type Querier interface {
 	CountRolesByProject(ctx context.Context, projectID string) (int64, error)
 	CountRolesByUser(ctx context.Context, userID string) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
 	CreateConfig(ctx context.Context, arg CreateConfigParams) (Config, error)
+	CreateConfigMigration(ctx context.Context, arg CreateConfigMigrationParams) (ConfigMigration, error)
+	CreateConfigMigrationItem(ctx context.Context, arg CreateConfigMigrationItemParams) error
 	CreateConfigRevision(ctx context.Context, arg CreateConfigRevisionParams) (ConfigRevision, error)
 	CreateConfigSchema(ctx context.Context, arg CreateConfigSchemaParams) (ConfigSchema, error)
+	CreateConfigSchemaReference(ctx context.Context, arg CreateConfigSchemaReferenceParams) error
+	CreateConfigSchemaVersion(ctx context.Context, arg CreateConfigSchemaVersionParams) (ConfigSchemaVersion, error)
 	CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error)
 	CreateUser(ctx context.Context, iD string, email string, passwordHash string) (User, error)
 	DeleteConfig(ctx context.Context, projectID string, key string) error
@@ -37,18 +46,22 @@ type Querier interface {
 	DeleteOldRevisions(ctx context.Context, projectID string, configKey string, version int64) error
 	DeleteProject(ctx context.Context, id string) error
 	DeleteUser(ctx context.Context, id string) error
+	GetAPIKey(ctx context.Context, iD string, projectID string) (ApiKey, error)
 	GetConfig(ctx context.Context, projectID string, key string) (Config, error)
+	GetConfigMigration(ctx context.Context, id string) (ConfigMigration, error)
 	GetConfigRevision(ctx context.Context, id string) (ConfigRevision, error)
 	GetConfigRevisionByVersion(ctx context.Context, projectID string, configKey string, version int64) (ConfigRevision, error)
 	GetConfigSchemaByID(ctx context.Context, id string) (ConfigSchema, error)
-	GetConfigSchemaByName(ctx context.Context, name string) (ConfigSchema, error)
+	// A project sees its own schemas and the global ones; without a project,
+	// only the global ones
+	GetConfigSchemaByName(ctx context.Context, name string, projectID pgtype.Text) (ConfigSchema, error)
+	GetConfigSchemaVersion(ctx context.Context, schemaID string, version int32) (ConfigSchemaVersion, error)
 	GetConfigVersion(ctx context.Context, projectID string, key string) (int64, error)
 	GetConfigWithVersion(ctx context.Context, projectID string, key string, version int64) (Config, error)
 	GetConfigsUpdatedAfter(ctx context.Context, projectID string, updatedAt pgtype.Timestamp) ([]Config, error)
 	GetConfigsUpdatedByUser(ctx context.Context, updatedByUserID string, limit int32) ([]Config, error)
 	GetLatestNRevisions(ctx context.Context, projectID string, configKey string, limit int32) ([]ConfigRevision, error)
 	GetLatestRevision(ctx context.Context, projectID string, configKey string) (ConfigRevision, error)
-	GetProjectByAPIKey(ctx context.Context, apiKey string) (Project, error)
 	GetProjectByID(ctx context.Context, id string) (Project, error)
 	GetRevisionHistory(ctx context.Context, projectID string, configKey string, limit int32) ([]GetRevisionHistoryRow, error)
 	GetRevisionsCreatedAfter(ctx context.Context, projectID string, configKey string, createdAt pgtype.Timestamp) ([]ConfigRevision, error)
@@ -57,11 +70,21 @@ type Querier interface {
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id string) (User, error)
 	GetUserRole(ctx context.Context, userID string, projectID string) (RoleLevel, error)
+	ListAPIKeysByLookupPrefix(ctx context.Context, lookupPrefix string) ([]ApiKey, error)
+	ListAPIKeysByProject(ctx context.Context, projectID string) ([]ApiKey, error)
+	ListActiveAPIKeys(ctx context.Context) ([]ApiKey, error)
 	ListAllRevisionsByProject(ctx context.Context, projectID string) ([]ConfigRevision, error)
+	ListConfigMigrationItems(ctx context.Context, migrationID string) ([]ConfigMigrationItem, error)
+	ListConfigMigrationsBySchema(ctx context.Context, schemaID string) ([]ConfigMigration, error)
 	ListConfigRevisions(ctx context.Context, projectID string, configKey string) ([]ConfigRevision, error)
 	ListConfigRevisionsPaginated(ctx context.Context, arg ListConfigRevisionsPaginatedParams) ([]ConfigRevision, error)
+	ListConfigSchemaVersionReferences(ctx context.Context, schemaID string, version int32) ([]ListConfigSchemaVersionReferencesRow, error)
+	ListConfigSchemaVersions(ctx context.Context, schemaID string) ([]ConfigSchemaVersion, error)
 	ListConfigSchemas(ctx context.Context) ([]ConfigSchema, error)
 	ListConfigSchemasByCreator(ctx context.Context, createdByUserID string) ([]ConfigSchema, error)
+	ListConfigSchemasByProject(ctx context.Context, projectID pgtype.Text) ([]ConfigSchema, error)
+	ListConfigSchemasForProjects(ctx context.Context, projectIds []string) ([]ConfigSchema, error)
+	ListConfigSchemasReferencing(ctx context.Context, referencedSchemaID string) ([]ConfigSchema, error)
 	ListConfigsByProject(ctx context.Context, projectID string) ([]Config, error)
 	ListConfigsBySchema(ctx context.Context, schemaID string) ([]Config, error)
 	ListProjectRoles(ctx context.Context, projectID string) ([]ListProjectRolesRow, error)
@@ -71,19 +94,23 @@ type Querier interface {
 	ListRolesByLevel(ctx context.Context, roleLevel RoleLevel) ([]ListRolesByLevelRow, error)
 	ListUserRoles(ctx context.Context, userID string) ([]ListUserRolesRow, error)
 	ListUsers(ctx context.Context) ([]User, error)
-	// Optimistic locking helper - get current version for update
 	LockConfigForUpdate(ctx context.Context, projectID string, key string) (int64, error)
+	MarkConfigMigrationRolledBack(ctx context.Context, iD string, rolledBackByUserID pgtype.Text) (ConfigMigration, error)
 	ProjectExists(ctx context.Context, id string) (bool, error)
-	ProjectExistsByAPIKey(ctx context.Context, apiKey string) (bool, error)
 	ProjectExistsByName(ctx context.Context, name string) (bool, error)
+	RevokeAPIKey(ctx context.Context, iD string, projectID string) (ApiKey, error)
 	RevokeAllProjectRoles(ctx context.Context, projectID string) error
 	RevokeAllUserRoles(ctx context.Context, userID string) error
 	RevokeRole(ctx context.Context, userID string, projectID string) error
 	RoleExists(ctx context.Context, userID string, projectID string) (bool, error)
 	SearchConfigsByKey(ctx context.Context, projectID string, key string, limit int32) ([]Config, error)
+	SetAPIKeyExpiry(ctx context.Context, iD string, projectID string, expiresAt pgtype.Timestamp) (ApiKey, error)
+	TouchAPIKeyLastUsed(ctx context.Context, iD string, lastUsedAt pgtype.Timestamp) error
+	UpdateAPIKey(ctx context.Context, arg UpdateAPIKeyParams) (ApiKey, error)
+	UpdateAPIKeyHash(ctx context.Context, iD string, keyHash []byte, hashVersion int16) error
 	UpdateConfig(ctx context.Context, arg UpdateConfigParams) (Config, error)
-	UpdateConfigSchema(ctx context.Context, iD string, name pgtype.Text, schemaContent pgtype.Text) (ConfigSchema, error)
-	UpdateProject(ctx context.Context, iD string, name pgtype.Text, apiKey pgtype.Text) (Project, error)
+	UpdateConfigSchema(ctx context.Context, iD string, name pgtype.Text) (ConfigSchema, error)
+	UpdateProject(ctx context.Context, iD string, name pgtype.Text) (Project, error)
 	UpdateRole(ctx context.Context, userID string, projectID string, roleLevel RoleLevel) (Role, error)
 	UpdateUser(ctx context.Context, iD string, email pgtype.Text, passwordHash pgtype.Text) (User, error)
 	UserExists(ctx context.Context, id string) (bool, error)
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vlone310/cfguardian/internal/adapters/outbound/postgres/sqlc"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -40,7 +41,7 @@ func (r *UserRepositoryAdapter) GetByID(ctx context.Context, id string) (*outbou
 	user, err := r.queries.GetUserByID(ctx, id)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, apperrors.New(apperrors.ErrCodeUserNotFound, "user not found")
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -53,7 +54,7 @@ func (r *UserRepositoryAdapter) GetByEmail(ctx context.Context, email string) (*
 	user, err := r.queries.GetUserByEmail(ctx, email)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, apperrors.New(apperrors.ErrCodeUserNotFound, "user not found")
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -0,0 +1,108 @@
+package raft
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/domain/events"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+)
+
+// changeEvents returns the domain events of a command applied at revision,
+// built from the FSM response. Failed commands change nothing and have no
+// events. Callers hold mu, since the response holds live config state.
+func changeEvents(cmd Command, result interface{}, revision int64, occurredAt time.Time) []events.ProjectEvent {
+	var changed []events.ProjectEvent
+	eventID := func() string {
+		return fmt.Sprintf("%d-%d", revision, len(changed))
+	}
+
+	switch cmd.Type {
+	case CommandTypeCreateConfig:
+		if config, ok := result.(*ConfigState); ok {
+			event := events.NewConfigCreated(
+				eventID(),
+				config.ProjectID,
+				config.Key,
+				config.SchemaID,
+				valueobjects.MustNewVersion(config.Version),
+				config.Content,
+				cmd.UpdatedByUserID,
+			)
+			event.OccurredAt = occurredAt
+			changed = append(changed, event)
+		}
+
+	case CommandTypeUpdateConfig, CommandTypeChangeSchema:
+		config, ok := result.(*ConfigState)
+		if !ok {
+			break
+		}
+		if cmd.RollbackToVersion > 0 {
+			event := events.NewConfigRolledBack(
+				eventID(),
+				config.ProjectID,
+				config.Key,
+				valueobjects.MustNewVersion(config.Version-1),
+				valueobjects.MustNewVersion(cmd.RollbackToVersion),
+				valueobjects.MustNewVersion(config.Version),
+				config.Content,
+				cmd.UpdatedByUserID,
+			)
+			event.OccurredAt = occurredAt
+			changed = append(changed, event)
+			break
+		}
+		changed = append(changed, configUpdated(eventID(), config, occurredAt))
+
+	case CommandTypeApplyBatch:
+		configs, _ := result.([]*ConfigState)
+		for _, config := range configs {
+			changed = append(changed, configUpdated(eventID(), config, occurredAt))
+		}
+
+	case CommandTypeDeleteConfig:
+		if config, ok := result.(*ConfigState); ok {
+			event := events.NewConfigDeleted(
+				eventID(),
+				config.ProjectID,
+				config.Key,
+				valueobjects.MustNewVersion(config.Version),
+				cmd.UpdatedByUserID,
+			)
+			event.OccurredAt = occurredAt
+			changed = append(changed, event)
+		}
+	}
+
+	return changed
+}
+
+// configUpdated returns the event of a config whose version was bumped
+func configUpdated(eventID string, config *ConfigState, occurredAt time.Time) *events.ConfigUpdated {
+	event := events.NewConfigUpdated(
+		eventID,
+		config.ProjectID,
+		config.Key,
+		config.SchemaID,
+		valueobjects.MustNewVersion(config.Version-1),
+		valueobjects.MustNewVersion(config.Version),
+		config.Content,
+		config.UpdatedByUserID,
+	)
+	event.OccurredAt = occurredAt
+	return event
+}
+
+// publish publishes the events of the log entry at index. The change is
+// committed either way, so failures are logged, not returned.
+func (f *FSM) publish(index uint64, changed []events.ProjectEvent) {
+	if f.publisher == nil || len(changed) == 0 {
+		return
+	}
+
+	if err := f.publisher.Publish(context.Background(), index, changed...); err != nil {
+		fmt.Printf("Warning: failed to publish events of log entry %d: %v\n", index, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -29,6 +30,7 @@ func (r *ConfigRepository) Create(ctx context.Context, params outbound.CreateCon
 		params.ProjectID,
 		params.Key,
 		params.SchemaID,
+		params.SchemaVersion,
 		params.Content,
 		params.UpdatedByUserID,
 	)
@@ -44,7 +46,7 @@ func (r *ConfigRepository) Create(ctx context.Context, params outbound.CreateCon
 func (r *ConfigRepository) Get(ctx context.Context, projectID, key string) (*outbound.Config, error) {
 	state, err := r.store.GetConfig(projectID, key)
 	if err != nil {
-		return nil, fmt.Errorf("config not found")
+		return nil, apperrors.New(apperrors.ErrCodeConfigNotFound, "config not found")
 	}
 	
 	return r.stateToConfig(state), nil
@@ -54,7 +56,7 @@ func (r *ConfigRepository) Get(ctx context.Context, projectID, key string) (*out
 func (r *ConfigRepository) GetWithVersion(ctx context.Context, projectID, key string, version int64) (*outbound.Config, error) {
 	state, err := r.store.GetConfig(projectID, key)
 	if err != nil {
-		return nil, fmt.Errorf("config not found")
+		return nil, apperrors.New(apperrors.ErrCodeConfigNotFound, "config not found")
 	}
 	
 	if state.Version != version {
@@ -76,9 +78,39 @@ func (r *ConfigRepository) ListByProject(ctx context.Context, projectID string)
 	return configs, nil
 }
 
-// ListBySchema lists all configs using a specific schema (not supported in Raft - use PostgreSQL fallback)
+// ListChanges lists a project's configs changed and deleted after a revision (read from FSM)
+func (r *ConfigRepository) ListChanges(ctx context.Context, projectID string, since int64) (*outbound.ConfigChanges, error) {
+	changes := r.store.Changes(projectID, since)
+	
+	result := &outbound.ConfigChanges{
+		Revision:          changes.Revision,
+		CompactedRevision: changes.CompactedRevision,
+		Changed:           make([]*outbound.Config, len(changes.Changed)),
+		Deleted:           make([]*outbound.DeletedConfig, len(changes.Deleted)),
+	}
+	for i, state := range changes.Changed {
+		result.Changed[i] = r.stateToConfig(state)
+	}
+	for i, tombstone := range changes.Deleted {
+		result.Deleted[i] = &outbound.DeletedConfig{
+			Key:      tombstone.Key,
+			Revision: tombstone.Revision,
+		}
+	}
+	
+	return result, nil
+}
+
+// ListBySchema lists the configs of every project using a schema (read from FSM)
 func (r *ConfigRepository) ListBySchema(ctx context.Context, schemaID string) ([]*outbound.Config, error) {
-	return nil, fmt.Errorf("ListBySchema not supported in Raft store - use PostgreSQL repository")
+	states := r.store.ListConfigsBySchema(schemaID)
+	
+	configs := make([]*outbound.Config, len(states))
+	for i, state := range states {
+		configs[i] = r.stateToConfig(state)
+	}
+	
+	return configs, nil
 }
 
 // Update updates a config through Raft consensus with optimistic locking
@@ -91,6 +123,7 @@ func (r *ConfigRepository) Update(ctx context.Context, params outbound.UpdateCon
 		params.ExpectedVersion,
 		params.Content,
 		params.UpdatedByUserID,
+		params.RollbackToVersion,
 	)
 	if err != nil {
 		return nil, err
@@ -99,40 +132,54 @@ func (r *ConfigRepository) Update(ctx context.Context, params outbound.UpdateCon
 	return r.stateToConfig(state), nil
 }
 
-// ChangeSchema changes the schema ID for a config (not fully supported in current FSM)
+// ChangeSchema pins a config to a schema version through Raft consensus with optimistic locking
 func (r *ConfigRepository) ChangeSchema(ctx context.Context, params outbound.ChangeSchemaParams) (*outbound.Config, error) {
-	// For now, we'll need to implement this as an update
-	// Get current config
-	current, err := r.Get(ctx, params.ProjectID, params.Key)
-	if err != nil {
-		return nil, err
-	}
-	
-	// Update with same content but new schema (this is a simplification)
-	// In a full implementation, we'd add a CHANGE_SCHEMA command to the FSM
-	state, err := r.store.UpdateConfig(
+	state, err := r.store.ChangeSchema(
 		ctx,
 		params.ProjectID,
 		params.Key,
-		current.Version,
-		current.Content,
+		params.ExpectedVersion,
+		params.SchemaID,
+		params.SchemaVersion,
 		params.UpdatedByUserID,
 	)
 	if err != nil {
 		return nil, err
 	}
 	
-	// Manually update schema ID (not persisted in Raft currently)
-	config := r.stateToConfig(state)
-	config.SchemaID = params.SchemaID
+	return r.stateToConfig(state), nil
+}
+
+// UpdateBatch rewrites several configs in one Raft log entry with optimistic locking
+func (r *ConfigRepository) UpdateBatch(ctx context.Context, params outbound.UpdateBatchParams) ([]*outbound.Config, error) {
+	entries := make([]BatchEntry, len(params.Items))
+	for i, item := range params.Items {
+		entries[i] = BatchEntry{
+			ProjectID:       item.ProjectID,
+			Key:             item.Key,
+			ExpectedVersion: item.ExpectedVersion,
+			SchemaID:        item.SchemaID,
+			SchemaVersion:   item.SchemaVersion,
+			Content:         item.Content,
+		}
+	}
+	
+	states, err := r.store.ApplyBatch(ctx, entries, params.UpdatedByUserID)
+	if err != nil {
+		return nil, err
+	}
+	
+	configs := make([]*outbound.Config, len(states))
+	for i, state := range states {
+		configs[i] = r.stateToConfig(state)
+	}
 	
-	return config, nil
+	return configs, nil
 }
 
 // Delete deletes a config through Raft consensus
-func (r *ConfigRepository) Delete(ctx context.Context, projectID, key string) error {
-	// For delete, we need a user ID - use "system" as default
-	return r.store.DeleteConfig(ctx, projectID, key, "system")
+func (r *ConfigRepository) Delete(ctx context.Context, projectID, key, deletedByUserID string) error {
+	return r.store.DeleteConfig(ctx, projectID, key, deletedByUserID)
 }
 
 // Exists checks if a config exists
@@ -175,22 +222,31 @@ func (r *ConfigRepository) CountByProject(ctx context.Context, projectID string)
 	return int64(len(configs)), nil
 }
 
-// CountBySchema returns the number of configs using a schema (not supported in Raft)
+// CountBySchema returns the number of configs of every project using a schema (read from FSM)
 func (r *ConfigRepository) CountBySchema(ctx context.Context, schemaID string) (int64, error) {
-	return 0, fmt.Errorf("CountBySchema not supported in Raft store - use PostgreSQL repository")
+	return int64(r.store.CountConfigsBySchema(schemaID)), nil
 }
 
 // stateToConfig converts FSM ConfigState to outbound.Config
 func (r *ConfigRepository) stateToConfig(state *ConfigState) *outbound.Config {
 	now := time.Now().Format(time.RFC3339)
 	
+	// Configs written before schemas were versioned were validated against
+	// what migration 009 stored as version 1
+	schemaVersion := state.SchemaVersion
+	if schemaVersion == 0 {
+		schemaVersion = 1
+	}
+	
 	return &outbound.Config{
 		ProjectID:       state.ProjectID,
 		Key:             state.Key,
 		SchemaID:        state.SchemaID,
+		SchemaVersion:   schemaVersion,
 		Version:         state.Version,
 		Content:         state.Content,
 		UpdatedByUserID: state.UpdatedByUserID,
+		Revision:        state.Revision,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
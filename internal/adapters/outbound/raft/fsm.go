@@ -4,10 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/raft"
+	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
 // CommandType represents the type of Raft command
@@ -17,17 +22,40 @@ const (
 	CommandTypeCreateConfig CommandType = "CREATE_CONFIG"
 	CommandTypeUpdateConfig CommandType = "UPDATE_CONFIG"
 	CommandTypeDeleteConfig CommandType = "DELETE_CONFIG"
+	CommandTypeChangeSchema CommandType = "CHANGE_SCHEMA"
+	CommandTypeApplyBatch   CommandType = "APPLY_BATCH"
 )
 
+// defaultMaxTombstones is how many deleted configs are remembered for delta sync
+const defaultMaxTombstones = 10000
+
+// snapshotFormat identifies the snapshot layout; snapshots without it are a
+// bare map of configs written before revisions were tracked
+const snapshotFormat = 2
+
 // Command represents a Raft log command
 type Command struct {
 	Type            CommandType     `json:"type"`
 	ProjectID       string          `json:"project_id"`
 	Key             string          `json:"key"`
 	SchemaID        string          `json:"schema_id,omitempty"`
+	SchemaVersion   int32           `json:"schema_version,omitempty"`
 	Content         json.RawMessage `json:"content,omitempty"`
 	ExpectedVersion int64           `json:"expected_version,omitempty"`
 	UpdatedByUserID string          `json:"updated_by_user_id"`
+	Batch           []BatchEntry    `json:"batch,omitempty"` // APPLY_BATCH only
+	// RollbackToVersion is the version whose content an UPDATE_CONFIG restores
+	RollbackToVersion int64 `json:"rollback_to_version,omitempty"`
+}
+
+// BatchEntry is one config rewritten by an APPLY_BATCH command
+type BatchEntry struct {
+	ProjectID       string          `json:"project_id"`
+	Key             string          `json:"key"`
+	ExpectedVersion int64           `json:"expected_version"`
+	SchemaID        string          `json:"schema_id"`
+	SchemaVersion   int32           `json:"schema_version"`
+	Content         json.RawMessage `json:"content"`
 }
 
 // ConfigState represents the in-memory state of a config
@@ -35,22 +63,61 @@ type ConfigState struct {
 	ProjectID       string          `json:"project_id"`
 	Key             string          `json:"key"`
 	SchemaID        string          `json:"schema_id"`
+	SchemaVersion   int32           `json:"schema_version,omitempty"` // 0 for configs written before schemas were versioned
 	Version         int64           `json:"version"`
 	Content         json.RawMessage `json:"content"`
 	UpdatedByUserID string          `json:"updated_by_user_id"`
+	Revision        int64           `json:"revision"` // Raft log index of the last change
+}
+
+// Tombstone records a deleted config so delta sync can report the delete
+type Tombstone struct {
+	ProjectID string `json:"project_id"`
+	Key       string `json:"key"`
+	Revision  int64  `json:"revision"` // Raft log index of the delete
+}
+
+// ConfigChanges holds a project's changes after a revision
+type ConfigChanges struct {
+	Revision          int64
+	CompactedRevision int64
+	Changed           []*ConfigState
+	Deleted           []*Tombstone
 }
 
 // FSM implements the Raft Finite State Machine
 // This is where all state changes happen
+//
+// Every change is stamped with its Raft log index, which serves as a global,
+// monotonically increasing revision. Deletes leave a tombstone so that
+// clients can sync deltas; only the newest maxTombstones are kept, and
+// compactedRevision is the newest revision whose tombstone was dropped.
+//
+// Every node applies every committed change, so each node publishes the
+// change events itself, identified by the revision.
 type FSM struct {
-	mu      sync.RWMutex
-	configs map[string]*ConfigState // key: "projectID:configKey"
+	mu                sync.RWMutex
+	configs           map[string]*ConfigState // key: "projectID:configKey"
+	tombstones        map[string]*Tombstone   // key: "projectID:configKey"
+	revision          int64
+	compactedRevision int64
+	maxTombstones     int
+	publisher         outbound.EventPublisher
 }
 
-// NewFSM creates a new FSM
-func NewFSM() *FSM {
+// NewFSM creates a new FSM that keeps up to maxTombstones deletes
+// (0 = defaultMaxTombstones) and publishes change events to publisher
+// (nil = no events)
+func NewFSM(maxTombstones int, publisher outbound.EventPublisher) *FSM {
+	if maxTombstones <= 0 {
+		maxTombstones = defaultMaxTombstones
+	}
+	
 	return &FSM{
-		configs: make(map[string]*ConfigState),
+		configs:       make(map[string]*ConfigState),
+		tombstones:    make(map[string]*Tombstone),
+		maxTombstones: maxTombstones,
+		publisher:     publisher,
 	}
 }
 
@@ -62,23 +129,43 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 		return fmt.Errorf("failed to unmarshal command: %w", err)
 	}
 
+	// The leader stamps the entry, so every node reports the same time
+	occurredAt := log.AppendedAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
 	f.mu.Lock()
-	defer f.mu.Unlock()
+	revision := int64(log.Index)
+	result := f.apply(cmd, revision)
+	changed := changeEvents(cmd, result, revision, occurredAt)
+	f.mu.Unlock()
+
+	f.publish(log.Index, changed)
+	return result
+}
 
+// apply applies a command at revision and returns the FSM response.
+// Callers hold mu.
+func (f *FSM) apply(cmd Command, revision int64) interface{} {
 	switch cmd.Type {
 	case CommandTypeCreateConfig:
-		return f.applyCreateConfig(cmd)
+		return f.applyCreateConfig(cmd, revision)
 	case CommandTypeUpdateConfig:
-		return f.applyUpdateConfig(cmd)
+		return f.applyUpdateConfig(cmd, revision)
 	case CommandTypeDeleteConfig:
-		return f.applyDeleteConfig(cmd)
+		return f.applyDeleteConfig(cmd, revision)
+	case CommandTypeChangeSchema:
+		return f.applyChangeSchema(cmd, revision)
+	case CommandTypeApplyBatch:
+		return f.applyBatch(cmd, revision)
 	default:
 		return fmt.Errorf("unknown command type: %s", cmd.Type)
 	}
 }
 
 // applyCreateConfig creates a new config in the FSM
-func (f *FSM) applyCreateConfig(cmd Command) interface{} {
+func (f *FSM) applyCreateConfig(cmd Command, revision int64) interface{} {
 	key := makeKey(cmd.ProjectID, cmd.Key)
 	
 	// Check if config already exists
@@ -91,50 +178,164 @@ func (f *FSM) applyCreateConfig(cmd Command) interface{} {
 		ProjectID:       cmd.ProjectID,
 		Key:             cmd.Key,
 		SchemaID:        cmd.SchemaID,
+		SchemaVersion:   cmd.SchemaVersion,
 		Version:         1,
 		Content:         cmd.Content,
 		UpdatedByUserID: cmd.UpdatedByUserID,
+		Revision:        revision,
 	}
 	
 	f.configs[key] = config
+	delete(f.tombstones, key)
+	f.revision = revision
 	return config
 }
 
 // applyUpdateConfig updates an existing config with optimistic locking
-func (f *FSM) applyUpdateConfig(cmd Command) interface{} {
+func (f *FSM) applyUpdateConfig(cmd Command, revision int64) interface{} {
 	key := makeKey(cmd.ProjectID, cmd.Key)
 	
 	// Get existing config
 	config, exists := f.configs[key]
 	if !exists {
-		return fmt.Errorf("config not found: %s", key)
+		return apperrors.New(apperrors.ErrCodeConfigNotFound, fmt.Sprintf("config not found: %s", key))
 	}
 	
 	// Optimistic locking check
 	if config.Version != cmd.ExpectedVersion {
-		return fmt.Errorf("version mismatch: expected %d, got %d", cmd.ExpectedVersion, config.Version)
+		return versionMismatch(cmd.Key, cmd.ExpectedVersion, config.Version)
 	}
 	
 	// Update config
 	config.Content = cmd.Content
 	config.Version++
 	config.UpdatedByUserID = cmd.UpdatedByUserID
+	config.Revision = revision
+	f.revision = revision
+	
+	return config
+}
+
+// applyChangeSchema pins a config to a schema version with optimistic
+// locking. The content is kept; the config version is bumped so clients
+// and delta sync see the change.
+func (f *FSM) applyChangeSchema(cmd Command, revision int64) interface{} {
+	key := makeKey(cmd.ProjectID, cmd.Key)
+	
+	config, exists := f.configs[key]
+	if !exists {
+		return apperrors.New(apperrors.ErrCodeConfigNotFound, fmt.Sprintf("config not found: %s", key))
+	}
+	
+	if config.Version != cmd.ExpectedVersion {
+		return versionMismatch(cmd.Key, cmd.ExpectedVersion, config.Version)
+	}
+	
+	config.SchemaID = cmd.SchemaID
+	config.SchemaVersion = cmd.SchemaVersion
+	config.Version++
+	config.UpdatedByUserID = cmd.UpdatedByUserID
+	config.Revision = revision
+	f.revision = revision
 	
 	return config
 }
 
-// applyDeleteConfig deletes a config from the FSM
-func (f *FSM) applyDeleteConfig(cmd Command) interface{} {
+// applyBatch rewrites the content and schema version of several configs
+// at one revision. Every config is checked before any is changed, so the
+// batch applies completely or not at all.
+func (f *FSM) applyBatch(cmd Command, revision int64) interface{} {
+	seen := make(map[string]bool, len(cmd.Batch))
+	for _, entry := range cmd.Batch {
+		key := makeKey(entry.ProjectID, entry.Key)
+		if seen[key] {
+			return fmt.Errorf("config appears twice in batch: %s", key)
+		}
+		seen[key] = true
+		
+		config, exists := f.configs[key]
+		if !exists {
+			return apperrors.New(apperrors.ErrCodeConfigNotFound, fmt.Sprintf("config not found: %s", key))
+		}
+		if config.Version != entry.ExpectedVersion {
+			return versionMismatch(entry.Key, entry.ExpectedVersion, config.Version)
+		}
+	}
+	
+	updated := make([]*ConfigState, len(cmd.Batch))
+	for i, entry := range cmd.Batch {
+		config := f.configs[makeKey(entry.ProjectID, entry.Key)]
+		config.SchemaID = entry.SchemaID
+		config.SchemaVersion = entry.SchemaVersion
+		config.Content = entry.Content
+		config.Version++
+		config.UpdatedByUserID = cmd.UpdatedByUserID
+		config.Revision = revision
+		updated[i] = copyConfigState(config)
+	}
+	if len(cmd.Batch) > 0 {
+		f.revision = revision
+	}
+	
+	return updated
+}
+
+// versionMismatch reports a failed optimistic lock as a VersionConflictError
+func versionMismatch(key string, expected, current int64) error {
+	expectedVersion, err := valueobjects.NewVersion(expected)
+	if err != nil {
+		return fmt.Errorf("version mismatch: expected %d, got %d", expected, current)
+	}
+	currentVersion, err := valueobjects.NewVersion(current)
+	if err != nil {
+		return fmt.Errorf("version mismatch: expected %d, got %d", expected, current)
+	}
+	return services.VersionConflictError{
+		Expected: expectedVersion,
+		Current:  currentVersion,
+		Key:      key,
+	}
+}
+
+// applyDeleteConfig deletes a config from the FSM and returns it
+func (f *FSM) applyDeleteConfig(cmd Command, revision int64) interface{} {
 	key := makeKey(cmd.ProjectID, cmd.Key)
 	
 	// Check if config exists
-	if _, exists := f.configs[key]; !exists {
-		return fmt.Errorf("config not found: %s", key)
+	config, exists := f.configs[key]
+	if !exists {
+		return apperrors.New(apperrors.ErrCodeConfigNotFound, fmt.Sprintf("config not found: %s", key))
 	}
 	
-	// Delete config
+	// Delete config and remember the delete for delta sync
 	delete(f.configs, key)
-	return nil
+	f.tombstones[key] = &Tombstone{
+		ProjectID: cmd.ProjectID,
+		Key:       cmd.Key,
+		Revision:  revision,
+	}
+	f.compactTombstones()
+	f.revision = revision
+	return config
+}
+
+// compactTombstones drops the oldest tombstones beyond maxTombstones and
+// advances compactedRevision past them. Callers hold mu.
+func (f *FSM) compactTombstones() {
+	for len(f.tombstones) > f.maxTombstones {
+		var oldestKey string
+		var oldest *Tombstone
+		for key, tombstone := range f.tombstones {
+			if oldest == nil || tombstone.Revision < oldest.Revision {
+				oldestKey, oldest = key, tombstone
+			}
+		}
+		
+		delete(f.tombstones, oldestKey)
+		if oldest.Revision > f.compactedRevision {
+			f.compactedRevision = oldest.Revision
+		}
+	}
 }
 
 // Snapshot returns a snapshot of the FSM state
@@ -146,18 +347,22 @@ func (f *FSM) Snapshot() (raft.FSMSnapshot, error) {
 	// Clone the configs map
 	clone := make(map[string]*ConfigState, len(f.configs))
 	for k, v := range f.configs {
-		// Deep copy
-		clone[k] = &ConfigState{
-			ProjectID:       v.ProjectID,
-			Key:             v.Key,
-			SchemaID:        v.SchemaID,
-			Version:         v.Version,
-			Content:         append(json.RawMessage(nil), v.Content...),
-			UpdatedByUserID: v.UpdatedByUserID,
-		}
+		clone[k] = copyConfigState(v)
+	}
+	
+	tombstones := make(map[string]*Tombstone, len(f.tombstones))
+	for k, v := range f.tombstones {
+		tombstone := *v
+		tombstones[k] = &tombstone
 	}
 	
-	return &FSMSnapshot{configs: clone}, nil
+	return &FSMSnapshot{state: snapshotState{
+		Format:            snapshotFormat,
+		Configs:           clone,
+		Tombstones:        tombstones,
+		Revision:          f.revision,
+		CompactedRevision: f.compactedRevision,
+	}}, nil
 }
 
 // Restore restores the FSM state from a snapshot
@@ -165,15 +370,44 @@ func (f *FSM) Snapshot() (raft.FSMSnapshot, error) {
 func (f *FSM) Restore(rc io.ReadCloser) error {
 	defer rc.Close()
 	
-	var configs map[string]*ConfigState
-	if err := json.NewDecoder(rc).Decode(&configs); err != nil {
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	
+	var state snapshotState
+	if err := json.Unmarshal(data, &state); err != nil {
 		return fmt.Errorf("failed to decode snapshot: %w", err)
 	}
 	
+	// Snapshots taken before revisions were tracked hold only the configs
+	if state.Format == 0 {
+		state = snapshotState{}
+		if err := json.Unmarshal(data, &state.Configs); err != nil {
+			return fmt.Errorf("failed to decode snapshot: %w", err)
+		}
+	}
+	if state.Configs == nil {
+		state.Configs = make(map[string]*ConfigState)
+	}
+	if state.Tombstones == nil {
+		state.Tombstones = make(map[string]*Tombstone)
+	}
+	
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	
-	f.configs = configs
+	f.configs = state.Configs
+	f.tombstones = state.Tombstones
+	f.revision = state.Revision
+	f.compactedRevision = state.CompactedRevision
+	f.compactTombstones()
+	
+	// Changes up to the snapshot were not applied here, so their events
+	// cannot be replayed
+	if f.publisher != nil {
+		f.publisher.Reset(uint64(state.Revision))
+	}
 	return nil
 }
 
@@ -185,7 +419,7 @@ func (f *FSM) GetConfig(projectID, key string) (*ConfigState, error) {
 	configKey := makeKey(projectID, key)
 	config, exists := f.configs[configKey]
 	if !exists {
-		return nil, fmt.Errorf("config not found")
+		return nil, apperrors.New(apperrors.ErrCodeConfigNotFound, "config not found")
 	}
 	
 	return config, nil
@@ -206,6 +440,45 @@ func (f *FSM) ListConfigs(projectID string) []*ConfigState {
 	return configs
 }
 
+// ListConfigsBySchema lists the configs of every project that use a schema,
+// sorted by project and key (read-only)
+func (f *FSM) ListConfigsBySchema(schemaID string) []*ConfigState {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	
+	var configs []*ConfigState
+	for _, config := range f.configs {
+		if config.SchemaID == schemaID {
+			configs = append(configs, copyConfigState(config))
+		}
+	}
+	
+	sort.Slice(configs, func(i, j int) bool {
+		if configs[i].ProjectID != configs[j].ProjectID {
+			return configs[i].ProjectID < configs[j].ProjectID
+		}
+		return configs[i].Key < configs[j].Key
+	})
+	
+	return configs
+}
+
+// CountConfigsBySchema returns the number of configs of every project that
+// use a schema (read-only)
+func (f *FSM) CountConfigsBySchema(schemaID string) int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	
+	count := 0
+	for _, config := range f.configs {
+		if config.SchemaID == schemaID {
+			count++
+		}
+	}
+	
+	return count
+}
+
 // ConfigExists checks if a config exists
 func (f *FSM) ConfigExists(projectID, key string) bool {
 	f.mu.RLock()
@@ -224,27 +497,93 @@ func (f *FSM) GetVersion(projectID, key string) (valueobjects.Version, error) {
 	configKey := makeKey(projectID, key)
 	config, exists := f.configs[configKey]
 	if !exists {
-		return valueobjects.Version{}, fmt.Errorf("config not found")
+		return valueobjects.Version{}, apperrors.New(apperrors.ErrCodeConfigNotFound, "config not found")
 	}
 	
 	return valueobjects.MustNewVersion(config.Version), nil
 }
 
+// Revision returns the revision of the last change
+func (f *FSM) Revision() int64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	
+	return f.revision
+}
+
+// Changes returns a project's configs changed and deleted after since,
+// sorted by revision. since = 0 returns every config and no deletes.
+func (f *FSM) Changes(projectID string, since int64) *ConfigChanges {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	
+	changes := &ConfigChanges{
+		Revision:          f.revision,
+		CompactedRevision: f.compactedRevision,
+	}
+	
+	for _, config := range f.configs {
+		if config.ProjectID == projectID && (since == 0 || config.Revision > since) {
+			changes.Changed = append(changes.Changed, copyConfigState(config))
+		}
+	}
+	if since > 0 {
+		for _, tombstone := range f.tombstones {
+			if tombstone.ProjectID == projectID && tombstone.Revision > since {
+				deleted := *tombstone
+				changes.Deleted = append(changes.Deleted, &deleted)
+			}
+		}
+	}
+	
+	sort.Slice(changes.Changed, func(i, j int) bool {
+		return changes.Changed[i].Revision < changes.Changed[j].Revision
+	})
+	sort.Slice(changes.Deleted, func(i, j int) bool {
+		return changes.Deleted[i].Revision < changes.Deleted[j].Revision
+	})
+	
+	return changes
+}
+
+// copyConfigState returns a deep copy of a config
+func copyConfigState(state *ConfigState) *ConfigState {
+	return &ConfigState{
+		ProjectID:       state.ProjectID,
+		Key:             state.Key,
+		SchemaID:        state.SchemaID,
+		SchemaVersion:   state.SchemaVersion,
+		Version:         state.Version,
+		Content:         append(json.RawMessage(nil), state.Content...),
+		UpdatedByUserID: state.UpdatedByUserID,
+		Revision:        state.Revision,
+	}
+}
+
 // makeKey creates a composite key from projectID and configKey
 func makeKey(projectID, configKey string) string {
 	return projectID + ":" + configKey
 }
 
+// snapshotState is the persisted form of the FSM
+type snapshotState struct {
+	Format            int                     `json:"format"`
+	Configs           map[string]*ConfigState `json:"configs"`
+	Tombstones        map[string]*Tombstone   `json:"tombstones"`
+	Revision          int64                   `json:"revision"`
+	CompactedRevision int64                   `json:"compacted_revision"`
+}
+
 // FSMSnapshot implements raft.FSMSnapshot
 type FSMSnapshot struct {
-	configs map[string]*ConfigState
+	state snapshotState
 }
 
 // Persist writes the snapshot to the given sink
 func (s *FSMSnapshot) Persist(sink raft.SnapshotSink) error {
 	err := func() error {
 		// Encode the snapshot
-		if err := json.NewEncoder(sink).Encode(s.configs); err != nil {
+		if err := json.NewEncoder(sink).Encode(s.state); err != nil {
 			return fmt.Errorf("failed to encode snapshot: %w", err)
 		}
 		return nil
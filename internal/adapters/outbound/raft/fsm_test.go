@@ -0,0 +1,309 @@
+package raft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/events"
+)
+
+// apply applies a command to the FSM at the given log index
+func apply(t *testing.T, fsm *FSM, index uint64, cmd Command) interface{} {
+	t.Helper()
+
+	data, err := json.Marshal(cmd)
+	require.NoError(t, err)
+	return fsm.Apply(&raft.Log{Index: index, Data: data})
+}
+
+func createCmd(key string) Command {
+	return Command{Type: CommandTypeCreateConfig, ProjectID: "proj-1", Key: key, Content: json.RawMessage(`{}`)}
+}
+
+func deleteCmd(key string) Command {
+	return Command{Type: CommandTypeDeleteConfig, ProjectID: "proj-1", Key: key}
+}
+
+// memorySink is an in-memory raft.SnapshotSink
+type memorySink struct {
+	bytes.Buffer
+}
+
+func (s *memorySink) ID() string    { return "test" }
+func (s *memorySink) Cancel() error { return nil }
+func (s *memorySink) Close() error  { return nil }
+
+// publishedWrite is the events of one log entry
+type publishedWrite struct {
+	id     uint64
+	events []events.ProjectEvent
+}
+
+// recordingPublisher records what the FSM publishes
+type recordingPublisher struct {
+	writes []publishedWrite
+	resets []uint64
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, id uint64, published ...events.ProjectEvent) error {
+	p.writes = append(p.writes, publishedWrite{id: id, events: published})
+	return nil
+}
+
+func (p *recordingPublisher) Reset(id uint64) {
+	p.resets = append(p.resets, id)
+}
+
+func TestFSM_Revisions(t *testing.T) {
+	// Arrange
+	fsm := NewFSM(0, nil)
+
+	// Act
+	apply(t, fsm, 3, createCmd("a"))
+	apply(t, fsm, 4, createCmd("b"))
+	apply(t, fsm, 7, Command{Type: CommandTypeUpdateConfig, ProjectID: "proj-1", Key: "a", ExpectedVersion: 1, Content: json.RawMessage(`{"x":1}`)})
+	apply(t, fsm, 8, deleteCmd("b"))
+	result := apply(t, fsm, 9, Command{Type: CommandTypeUpdateConfig, ProjectID: "proj-1", Key: "a", ExpectedVersion: 1})
+
+	// Assert
+	assert.Error(t, result.(error), "failed commands do not advance the revision")
+	assert.Equal(t, int64(8), fsm.Revision())
+
+	changes := fsm.Changes("proj-1", 4)
+	assert.Equal(t, int64(8), changes.Revision)
+	require.Len(t, changes.Changed, 1)
+	assert.Equal(t, "a", changes.Changed[0].Key)
+	assert.Equal(t, int64(7), changes.Changed[0].Revision)
+	require.Len(t, changes.Deleted, 1)
+	assert.Equal(t, "b", changes.Deleted[0].Key)
+
+	t.Run("since zero returns every config without deletes", func(t *testing.T) {
+		changes := fsm.Changes("proj-1", 0)
+		assert.Len(t, changes.Changed, 1)
+		assert.Empty(t, changes.Deleted)
+	})
+
+	t.Run("recreating a key clears its tombstone", func(t *testing.T) {
+		apply(t, fsm, 10, createCmd("b"))
+
+		changes := fsm.Changes("proj-1", 4)
+		assert.Len(t, changes.Changed, 2)
+		assert.Empty(t, changes.Deleted)
+	})
+}
+
+func TestFSM_ChangeSchema(t *testing.T) {
+	// Arrange
+	fsm := NewFSM(0, nil)
+	create := createCmd("a")
+	create.SchemaID = "schema-1"
+	create.SchemaVersion = 1
+	create.Content = json.RawMessage(`{"port":80}`)
+	apply(t, fsm, 3, create)
+
+	// Act
+	result := apply(t, fsm, 4, Command{Type: CommandTypeChangeSchema, ProjectID: "proj-1", Key: "a", ExpectedVersion: 1, SchemaID: "schema-1", SchemaVersion: 2})
+	stale := apply(t, fsm, 5, Command{Type: CommandTypeChangeSchema, ProjectID: "proj-1", Key: "a", ExpectedVersion: 1, SchemaID: "schema-1", SchemaVersion: 3})
+
+	// Assert
+	state, ok := result.(*ConfigState)
+	require.True(t, ok, "unexpected result: %v", result)
+	assert.Equal(t, int32(2), state.SchemaVersion)
+	assert.Equal(t, int64(2), state.Version)
+	assert.Equal(t, int64(4), state.Revision)
+	assert.JSONEq(t, `{"port":80}`, string(state.Content), "content is kept")
+
+	assert.Error(t, stale.(error), "a stale expected version is rejected")
+	current, err := fsm.GetConfig("proj-1", "a")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), current.SchemaVersion)
+}
+
+func TestFSM_ApplyBatch(t *testing.T) {
+	// Arrange
+	fsm := NewFSM(0, nil)
+	apply(t, fsm, 1, createCmd("a"))
+	apply(t, fsm, 2, createCmd("b"))
+	apply(t, fsm, 3, Command{Type: CommandTypeUpdateConfig, ProjectID: "proj-1", Key: "b", ExpectedVersion: 1, Content: json.RawMessage(`{"x":1}`)})
+	entry := func(key string, expected int64) BatchEntry {
+		return BatchEntry{ProjectID: "proj-1", Key: key, ExpectedVersion: expected, SchemaID: "schema-1", SchemaVersion: 2, Content: json.RawMessage(`{"y":2}`)}
+	}
+
+	// Act
+	stale := apply(t, fsm, 4, Command{Type: CommandTypeApplyBatch, Batch: []BatchEntry{entry("a", 1), entry("b", 1)}, UpdatedByUserID: "user-1"})
+	result := apply(t, fsm, 5, Command{Type: CommandTypeApplyBatch, Batch: []BatchEntry{entry("a", 1), entry("b", 2)}, UpdatedByUserID: "user-1"})
+
+	// Assert
+	assert.Error(t, stale.(error), "a stale entry rejects the whole batch")
+
+	states, ok := result.([]*ConfigState)
+	require.True(t, ok, "unexpected result: %v", result)
+	require.Len(t, states, 2)
+	for _, state := range states {
+		assert.Equal(t, int32(2), state.SchemaVersion)
+		assert.Equal(t, int64(5), state.Revision, "the batch shares one revision")
+		assert.Equal(t, "user-1", state.UpdatedByUserID)
+		assert.JSONEq(t, `{"y":2}`, string(state.Content))
+	}
+	assert.Equal(t, int64(2), states[0].Version)
+	assert.Equal(t, int64(3), states[1].Version)
+	assert.Equal(t, int64(5), fsm.Revision())
+}
+
+func TestFSM_ListConfigsBySchema(t *testing.T) {
+	// Arrange
+	fsm := NewFSM(0, nil)
+	for i, cmd := range []Command{
+		{Type: CommandTypeCreateConfig, ProjectID: "proj-2", Key: "a", SchemaID: "schema-1", SchemaVersion: 1, Content: json.RawMessage(`{}`)},
+		{Type: CommandTypeCreateConfig, ProjectID: "proj-1", Key: "b", SchemaID: "schema-1", SchemaVersion: 2, Content: json.RawMessage(`{}`)},
+		{Type: CommandTypeCreateConfig, ProjectID: "proj-1", Key: "a", SchemaID: "schema-1", SchemaVersion: 1, Content: json.RawMessage(`{}`)},
+		{Type: CommandTypeCreateConfig, ProjectID: "proj-1", Key: "c", SchemaID: "schema-2", SchemaVersion: 1, Content: json.RawMessage(`{}`)},
+	} {
+		apply(t, fsm, uint64(i+1), cmd)
+	}
+
+	// Act
+	configs := fsm.ListConfigsBySchema("schema-1")
+
+	// Assert
+	require.Len(t, configs, 3)
+	assert.Equal(t, []string{"proj-1/a", "proj-1/b", "proj-2/a"}, []string{
+		configs[0].ProjectID + "/" + configs[0].Key,
+		configs[1].ProjectID + "/" + configs[1].Key,
+		configs[2].ProjectID + "/" + configs[2].Key,
+	})
+	assert.Empty(t, fsm.ListConfigsBySchema("schema-3"))
+	assert.Equal(t, 3, fsm.CountConfigsBySchema("schema-1"))
+	assert.Equal(t, 0, fsm.CountConfigsBySchema("schema-3"))
+}
+
+func TestFSM_TombstoneCompaction(t *testing.T) {
+	fsm := NewFSM(2, nil)
+	for i, key := range []string{"a", "b", "c"} {
+		apply(t, fsm, uint64(i+1), createCmd(key))
+	}
+
+	apply(t, fsm, 4, deleteCmd("a"))
+	apply(t, fsm, 5, deleteCmd("b"))
+	apply(t, fsm, 6, deleteCmd("c"))
+
+	changes := fsm.Changes("proj-1", 4)
+	assert.Equal(t, int64(4), changes.CompactedRevision)
+	require.Len(t, changes.Deleted, 2)
+	assert.Equal(t, "b", changes.Deleted[0].Key)
+	assert.Equal(t, "c", changes.Deleted[1].Key)
+}
+
+func TestFSM_SnapshotRestore(t *testing.T) {
+	// Arrange
+	fsm := NewFSM(1, nil)
+	apply(t, fsm, 1, createCmd("a"))
+	apply(t, fsm, 2, createCmd("b"))
+	apply(t, fsm, 3, createCmd("c"))
+	apply(t, fsm, 4, deleteCmd("a"))
+	apply(t, fsm, 5, deleteCmd("b"))
+
+	snapshot, err := fsm.Snapshot()
+	require.NoError(t, err)
+	sink := &memorySink{}
+	require.NoError(t, snapshot.Persist(sink))
+
+	// Act
+	restored := NewFSM(1, nil)
+	require.NoError(t, restored.Restore(io.NopCloser(&sink.Buffer)))
+
+	// Assert
+	assert.Equal(t, fsm.Changes("proj-1", 4), restored.Changes("proj-1", 4))
+	assert.Equal(t, int64(4), restored.Changes("proj-1", 4).CompactedRevision)
+
+	t.Run("reads snapshots from before revisions", func(t *testing.T) {
+		legacy := `{"proj-1:a":{"project_id":"proj-1","key":"a","version":3,"content":{}}}`
+
+		restored := NewFSM(0, nil)
+		require.NoError(t, restored.Restore(io.NopCloser(bytes.NewBufferString(legacy))))
+
+		changes := restored.Changes("proj-1", 0)
+		require.Len(t, changes.Changed, 1)
+		assert.Equal(t, int64(3), changes.Changed[0].Version)
+		assert.Equal(t, int64(0), changes.Revision)
+	})
+}
+
+func TestFSM_Events(t *testing.T) {
+	// Arrange
+	publisher := &recordingPublisher{}
+	fsm := NewFSM(0, publisher)
+	appendedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	applyAt := func(index uint64, cmd Command) {
+		data, err := json.Marshal(cmd)
+		require.NoError(t, err)
+		fsm.Apply(&raft.Log{Index: index, Data: data, AppendedAt: appendedAt})
+	}
+	update := func(key string, expected int64, content string) Command {
+		return Command{Type: CommandTypeUpdateConfig, ProjectID: "proj-1", Key: key, ExpectedVersion: expected, Content: json.RawMessage(content), UpdatedByUserID: "user-1"}
+	}
+
+	// Act
+	applyAt(3, createCmd("a"))
+	applyAt(4, createCmd("b"))
+	applyAt(5, update("a", 1, `{"x":1}`))
+	applyAt(6, update("a", 1, `{"x":2}`)) // stale, changes nothing
+	rollback := update("a", 2, `{}`)
+	rollback.RollbackToVersion = 1
+	applyAt(7, rollback)
+	applyAt(8, Command{Type: CommandTypeApplyBatch, Batch: []BatchEntry{
+		{ProjectID: "proj-1", Key: "a", ExpectedVersion: 3, SchemaID: "schema-1", SchemaVersion: 2, Content: json.RawMessage(`{}`)},
+		{ProjectID: "proj-1", Key: "b", ExpectedVersion: 1, SchemaID: "schema-1", SchemaVersion: 2, Content: json.RawMessage(`{}`)},
+	}, UpdatedByUserID: "user-2"})
+	applyAt(9, Command{Type: CommandTypeDeleteConfig, ProjectID: "proj-1", Key: "b", UpdatedByUserID: "user-3"})
+
+	// Assert: one publish per successful write, identified by its log index
+	require.Len(t, publisher.writes, 6)
+	ids := make([]uint64, len(publisher.writes))
+	for i, write := range publisher.writes {
+		ids[i] = write.id
+	}
+	assert.Equal(t, []uint64{3, 4, 5, 7, 8, 9}, ids)
+
+	created := publisher.writes[0].events[0].(*events.ConfigCreated)
+	assert.Equal(t, "a", created.ConfigKey)
+	assert.Equal(t, "3-0", created.EventID)
+	assert.Equal(t, appendedAt, created.OccurredAt, "every node reports the leader's time")
+
+	updated := publisher.writes[2].events[0].(*events.ConfigUpdated)
+	assert.Equal(t, int64(1), updated.PreviousVersion.Value())
+	assert.Equal(t, int64(2), updated.NewVersion.Value())
+
+	rolledBack := publisher.writes[3].events[0].(*events.ConfigRolledBack)
+	assert.Equal(t, int64(2), rolledBack.FromVersion.Value())
+	assert.Equal(t, int64(1), rolledBack.ToVersion.Value())
+	assert.Equal(t, int64(3), rolledBack.NewVersion.Value())
+
+	batch := publisher.writes[4].events
+	require.Len(t, batch, 2)
+	assert.Equal(t, "b", batch[1].(*events.ConfigUpdated).ConfigKey)
+	assert.Equal(t, "8-1", batch[1].GetEventID())
+
+	deleted := publisher.writes[5].events[0].(*events.ConfigDeleted)
+	assert.Equal(t, int64(2), deleted.LastVersion.Value())
+	assert.Equal(t, "user-3", deleted.DeletedByUserID)
+
+	t.Run("restoring a snapshot resets the publisher", func(t *testing.T) {
+		snapshot, err := fsm.Snapshot()
+		require.NoError(t, err)
+		sink := &memorySink{}
+		require.NoError(t, snapshot.Persist(sink))
+
+		restored := &recordingPublisher{}
+		require.NoError(t, NewFSM(0, restored).Restore(io.NopCloser(&sink.Buffer)))
+
+		assert.Equal(t, []uint64{9}, restored.resets)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/raft"
 	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
 // Store manages the Raft consensus and provides config operations
@@ -37,6 +38,8 @@ type StoreConfig struct {
 	SnapshotInterval     time.Duration
 	SnapshotThreshold    uint64
 	TrailingLogs         uint64
+	MaxTombstones        int                     // Deleted configs remembered for delta sync (0 = default)
+	Events               outbound.EventPublisher // Receives the events of every applied change (nil = none)
 }
 
 // NewStore creates a new Raft store
@@ -78,7 +81,7 @@ func NewStore(cfg StoreConfig) (*Store, error) {
 	}
 	
 	// Create FSM
-	store.fsm = NewFSM()
+	store.fsm = NewFSM(cfg.MaxTombstones, cfg.Events)
 	
 	// Initialize Raft
 	if err := store.initRaft(cfg); err != nil {
@@ -158,7 +161,7 @@ func (s *Store) initRaft(cfg StoreConfig) error {
 }
 
 // CreateConfig creates a new config through Raft consensus
-func (s *Store) CreateConfig(ctx context.Context, projectID, key, schemaID string, content json.RawMessage, userID string) (*ConfigState, error) {
+func (s *Store) CreateConfig(ctx context.Context, projectID, key, schemaID string, schemaVersion int32, content json.RawMessage, userID string) (*ConfigState, error) {
 	if !s.IsLeader() {
 		return nil, fmt.Errorf("not the leader")
 	}
@@ -168,6 +171,7 @@ func (s *Store) CreateConfig(ctx context.Context, projectID, key, schemaID strin
 		ProjectID:       projectID,
 		Key:             key,
 		SchemaID:        schemaID,
+		SchemaVersion:   schemaVersion,
 		Content:         content,
 		UpdatedByUserID: userID,
 	}
@@ -175,17 +179,38 @@ func (s *Store) CreateConfig(ctx context.Context, projectID, key, schemaID strin
 	return s.applyCommand(ctx, cmd)
 }
 
-// UpdateConfig updates an existing config through Raft consensus
-func (s *Store) UpdateConfig(ctx context.Context, projectID, key string, expectedVersion int64, content json.RawMessage, userID string) (*ConfigState, error) {
+// UpdateConfig updates an existing config through Raft consensus;
+// rollbackToVersion is the version whose content is restored, if any
+func (s *Store) UpdateConfig(ctx context.Context, projectID, key string, expectedVersion int64, content json.RawMessage, userID string, rollbackToVersion int64) (*ConfigState, error) {
 	if !s.IsLeader() {
 		return nil, fmt.Errorf("not the leader")
 	}
 	
 	cmd := Command{
-		Type:            CommandTypeUpdateConfig,
+		Type:              CommandTypeUpdateConfig,
+		ProjectID:         projectID,
+		Key:               key,
+		Content:           content,
+		ExpectedVersion:   expectedVersion,
+		UpdatedByUserID:   userID,
+		RollbackToVersion: rollbackToVersion,
+	}
+	
+	return s.applyCommand(ctx, cmd)
+}
+
+// ChangeSchema pins an existing config to a schema version through Raft consensus
+func (s *Store) ChangeSchema(ctx context.Context, projectID, key string, expectedVersion int64, schemaID string, schemaVersion int32, userID string) (*ConfigState, error) {
+	if !s.IsLeader() {
+		return nil, fmt.Errorf("not the leader")
+	}
+	
+	cmd := Command{
+		Type:            CommandTypeChangeSchema,
 		ProjectID:       projectID,
 		Key:             key,
-		Content:         content,
+		SchemaID:        schemaID,
+		SchemaVersion:   schemaVersion,
 		ExpectedVersion: expectedVersion,
 		UpdatedByUserID: userID,
 	}
@@ -193,6 +218,28 @@ func (s *Store) UpdateConfig(ctx context.Context, projectID, key string, expecte
 	return s.applyCommand(ctx, cmd)
 }
 
+// ApplyBatch rewrites several configs in one Raft log entry; either every
+// entry is applied or none is
+func (s *Store) ApplyBatch(ctx context.Context, entries []BatchEntry, userID string) ([]*ConfigState, error) {
+	if !s.IsLeader() {
+		return nil, fmt.Errorf("not the leader")
+	}
+	
+	cmd := Command{
+		Type:            CommandTypeApplyBatch,
+		Batch:           entries,
+		UpdatedByUserID: userID,
+	}
+	
+	response, err := s.apply(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	
+	configs, _ := response.([]*ConfigState)
+	return configs, nil
+}
+
 // DeleteConfig deletes a config through Raft consensus
 func (s *Store) DeleteConfig(ctx context.Context, projectID, key, userID string) error {
 	if !s.IsLeader() {
@@ -210,8 +257,22 @@ func (s *Store) DeleteConfig(ctx context.Context, projectID, key, userID string)
 	return err
 }
 
-// applyCommand applies a command through Raft consensus
+// applyCommand applies a command that changes one config through Raft consensus
 func (s *Store) applyCommand(ctx context.Context, cmd Command) (*ConfigState, error) {
+	response, err := s.apply(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	
+	if config, ok := response.(*ConfigState); ok {
+		return config, nil
+	}
+	
+	return nil, nil
+}
+
+// apply applies a command through Raft consensus and returns the FSM response
+func (s *Store) apply(ctx context.Context, cmd Command) (interface{}, error) {
 	// Serialize command
 	data, err := json.Marshal(cmd)
 	if err != nil {
@@ -233,11 +294,7 @@ func (s *Store) applyCommand(ctx context.Context, cmd Command) (*ConfigState, er
 		return nil, err
 	}
 	
-	if config, ok := response.(*ConfigState); ok {
-		return config, nil
-	}
-	
-	return nil, nil
+	return response, nil
 }
 
 // GetConfig retrieves a config (read from FSM, no consensus needed)
@@ -250,6 +307,21 @@ func (s *Store) ListConfigs(projectID string) []*ConfigState {
 	return s.fsm.ListConfigs(projectID)
 }
 
+// ListConfigsBySchema lists the configs using a schema (read from FSM)
+func (s *Store) ListConfigsBySchema(schemaID string) []*ConfigState {
+	return s.fsm.ListConfigsBySchema(schemaID)
+}
+
+// CountConfigsBySchema returns the number of configs using a schema (read from FSM)
+func (s *Store) CountConfigsBySchema(schemaID string) int {
+	return s.fsm.CountConfigsBySchema(schemaID)
+}
+
+// Changes returns a project's config changes after a revision (read from FSM)
+func (s *Store) Changes(projectID string, since int64) *ConfigChanges {
+	return s.fsm.Changes(projectID, since)
+}
+
 // IsLeader checks if this node is the Raft leader
 func (s *Store) IsLeader() bool {
 	return s.raft.State() == raft.Leader
@@ -261,6 +333,18 @@ func (s *Store) GetLeader() string {
 	return string(leaderID)
 }
 
+// Check reports whether this node can serve reads from its FSM.
+// It fails once Raft has shut down or while no leader is known.
+func (s *Store) Check(ctx context.Context) error {
+	if s.raft.State() == raft.Shutdown {
+		return fmt.Errorf("raft is shut down")
+	}
+	if s.GetLeader() == "" {
+		return fmt.Errorf("no raft leader")
+	}
+	return nil
+}
+
 // WaitForLeader waits until a leader is elected
 func (s *Store) WaitForLeader(timeout time.Duration) error {
 	ticker := time.NewTicker(100 * time.Millisecond)
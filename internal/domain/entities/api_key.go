@@ -0,0 +1,174 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+)
+
+// APIKey represents one of a project's read-only client API keys
+type APIKey struct {
+	id              string
+	projectID       string
+	name            string
+	key             valueobjects.HashedAPIKey
+	scope           valueobjects.APIKeyScope
+	createdByUserID string
+	createdAt       time.Time
+	expiresAt       *time.Time
+	lastUsedAt      *time.Time
+	revokedAt       *time.Time
+}
+
+// NewAPIKey creates a new APIKey entity
+func NewAPIKey(
+	id, projectID, name string,
+	key valueobjects.HashedAPIKey,
+	scope valueobjects.APIKeyScope,
+	createdByUserID string,
+	expiresAt *time.Time,
+) *APIKey {
+	return &APIKey{
+		id:              id,
+		projectID:       projectID,
+		name:            name,
+		key:             key,
+		scope:           scope,
+		createdByUserID: createdByUserID,
+		createdAt:       time.Now(),
+		expiresAt:       expiresAt,
+	}
+}
+
+// ReconstructAPIKey reconstructs an APIKey from persistence layer
+func ReconstructAPIKey(
+	id, projectID, name string,
+	key valueobjects.HashedAPIKey,
+	scope valueobjects.APIKeyScope,
+	createdByUserID string,
+	createdAt time.Time,
+	expiresAt, lastUsedAt, revokedAt *time.Time,
+) *APIKey {
+	return &APIKey{
+		id:              id,
+		projectID:       projectID,
+		name:            name,
+		key:             key,
+		scope:           scope,
+		createdByUserID: createdByUserID,
+		createdAt:       createdAt,
+		expiresAt:       expiresAt,
+		lastUsedAt:      lastUsedAt,
+		revokedAt:       revokedAt,
+	}
+}
+
+// ID returns the API key ID
+func (k *APIKey) ID() string {
+	return k.id
+}
+
+// ProjectID returns the project the key grants access to
+func (k *APIKey) ProjectID() string {
+	return k.projectID
+}
+
+// Name returns the key's display name
+func (k *APIKey) Name() string {
+	return k.name
+}
+
+// Key returns the stored (hashed) form of the secret key
+func (k *APIKey) Key() valueobjects.HashedAPIKey {
+	return k.key
+}
+
+// Scope returns the configs the key can read
+func (k *APIKey) Scope() valueobjects.APIKeyScope {
+	return k.scope
+}
+
+// CreatedByUserID returns the user who created the key
+func (k *APIKey) CreatedByUserID() string {
+	return k.createdByUserID
+}
+
+// CreatedAt returns the creation timestamp
+func (k *APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+// ExpiresAt returns when the key stops working, or nil if it never expires
+func (k *APIKey) ExpiresAt() *time.Time {
+	return k.expiresAt
+}
+
+// LastUsedAt returns when the key last authenticated a request
+func (k *APIKey) LastUsedAt() *time.Time {
+	return k.lastUsedAt
+}
+
+// RevokedAt returns when the key was revoked, or nil
+func (k *APIKey) RevokedAt() *time.Time {
+	return k.revokedAt
+}
+
+// IsRevoked checks if the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.revokedAt != nil
+}
+
+// IsExpired checks if the key has expired at the given time
+func (k *APIKey) IsExpired(at time.Time) bool {
+	return k.expiresAt != nil && !at.Before(*k.expiresAt)
+}
+
+// IsActive checks if the key can authenticate requests at the given time
+func (k *APIKey) IsActive(at time.Time) bool {
+	return !k.IsRevoked() && !k.IsExpired(at)
+}
+
+// Rename updates the key's display name
+func (k *APIKey) Rename(name string) {
+	k.name = name
+}
+
+// UpdateScope replaces the configs the key can read
+func (k *APIKey) UpdateScope(scope valueobjects.APIKeyScope) {
+	k.scope = scope
+}
+
+// SetExpiry sets when the key stops working; nil removes the expiry
+func (k *APIKey) SetExpiry(expiresAt *time.Time) {
+	k.expiresAt = expiresAt
+}
+
+// ExpireWithin shortens the key's lifetime so it expires no later than deadline.
+// Used during rotation to give clients a grace period on the old key.
+func (k *APIKey) ExpireWithin(deadline time.Time) {
+	if k.expiresAt == nil || deadline.Before(*k.expiresAt) {
+		k.expiresAt = &deadline
+	}
+}
+
+// RehashKey replaces the stored hash, e.g. when upgrading its hashing scheme
+func (k *APIKey) RehashKey(key valueobjects.HashedAPIKey) {
+	k.key = key
+}
+
+// Revoke immediately disables the key
+func (k *APIKey) Revoke(at time.Time) {
+	if k.revokedAt == nil {
+		k.revokedAt = &at
+	}
+}
+
+// MarkUsed records that the key authenticated a request
+func (k *APIKey) MarkUsed(at time.Time) {
+	k.lastUsedAt = &at
+}
+
+// BelongsTo checks if the key grants access to a specific project
+func (k *APIKey) BelongsTo(projectID string) bool {
+	return k.projectID == projectID
+}
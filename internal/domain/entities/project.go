@@ -2,27 +2,23 @@ package entities
 
 import (
 	"time"
-
-	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
 )
 
 // Project represents a project entity for multi-tenancy
 type Project struct {
 	id          string
 	name        string
-	apiKey      valueobjects.APIKey
 	ownerUserID string
 	createdAt   time.Time
 	updatedAt   time.Time
 }
 
 // NewProject creates a new Project entity
-func NewProject(id, name string, apiKey valueobjects.APIKey, ownerUserID string) *Project {
+func NewProject(id, name, ownerUserID string) *Project {
 	now := time.Now()
 	return &Project{
 		id:          id,
 		name:        name,
-		apiKey:      apiKey,
 		ownerUserID: ownerUserID,
 		createdAt:   now,
 		updatedAt:   now,
@@ -30,11 +26,10 @@ func NewProject(id, name string, apiKey valueobjects.APIKey, ownerUserID string)
 }
 
 // ReconstructProject reconstructs a Project from persistence layer
-func ReconstructProject(id, name string, apiKey valueobjects.APIKey, ownerUserID string, createdAt, updatedAt time.Time) *Project {
+func ReconstructProject(id, name, ownerUserID string, createdAt, updatedAt time.Time) *Project {
 	return &Project{
 		id:          id,
 		name:        name,
-		apiKey:      apiKey,
 		ownerUserID: ownerUserID,
 		createdAt:   createdAt,
 		updatedAt:   updatedAt,
@@ -51,11 +46,6 @@ func (p *Project) Name() string {
 	return p.name
 }
 
-// APIKey returns the project's API key
-func (p *Project) APIKey() valueobjects.APIKey {
-	return p.apiKey
-}
-
 // OwnerUserID returns the owner user ID
 func (p *Project) OwnerUserID() string {
 	return p.ownerUserID
@@ -77,12 +67,6 @@ func (p *Project) UpdateName(name string) {
 	p.updatedAt = time.Now()
 }
 
-// RegenerateAPIKey updates the project's API key
-func (p *Project) RegenerateAPIKey(newAPIKey valueobjects.APIKey) {
-	p.apiKey = newAPIKey
-	p.updatedAt = time.Now()
-}
-
 // IsOwnedBy checks if the project is owned by a specific user
 func (p *Project) IsOwnedBy(userID string) bool {
 	return p.ownerUserID == userID
@@ -50,6 +50,16 @@ func (e *ConfigCreated) GetEventType() string {
 	return e.EventType
 }
 
+// GetProjectID returns the project the event belongs to
+func (e *ConfigCreated) GetProjectID() string {
+	return e.ProjectID
+}
+
+// GetConfigKey returns the key of the changed config
+func (e *ConfigCreated) GetConfigKey() string {
+	return e.ConfigKey
+}
+
 // GetOccurredAt returns when the event occurred
 func (e *ConfigCreated) GetOccurredAt() time.Time {
 	return e.OccurredAt
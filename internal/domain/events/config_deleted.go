@@ -45,6 +45,16 @@ func (e *ConfigDeleted) GetEventType() string {
 	return e.EventType
 }
 
+// GetProjectID returns the project the event belongs to
+func (e *ConfigDeleted) GetProjectID() string {
+	return e.ProjectID
+}
+
+// GetConfigKey returns the key of the changed config
+func (e *ConfigDeleted) GetConfigKey() string {
+	return e.ConfigKey
+}
+
 // GetOccurredAt returns when the event occurred
 func (e *ConfigDeleted) GetOccurredAt() time.Time {
 	return e.OccurredAt
@@ -16,6 +16,7 @@ type ConfigRolledBack struct {
 	ConfigKey         string               `json:"config_key"`
 	FromVersion       valueobjects.Version `json:"from_version"`
 	ToVersion         valueobjects.Version `json:"to_version"`
+	NewVersion        valueobjects.Version `json:"new_version"` // Version created by the rollback
 	Content           json.RawMessage      `json:"content"`
 	RolledBackByUserID string              `json:"rolled_back_by_user_id"`
 }
@@ -23,7 +24,7 @@ type ConfigRolledBack struct {
 // NewConfigRolledBack creates a new ConfigRolledBack event
 func NewConfigRolledBack(
 	eventID, projectID, configKey string,
-	fromVersion, toVersion valueobjects.Version,
+	fromVersion, toVersion, newVersion valueobjects.Version,
 	content json.RawMessage,
 	rolledBackByUserID string,
 ) *ConfigRolledBack {
@@ -35,6 +36,7 @@ func NewConfigRolledBack(
 		ConfigKey:         configKey,
 		FromVersion:       fromVersion,
 		ToVersion:         toVersion,
+		NewVersion:        newVersion,
 		Content:           content,
 		RolledBackByUserID: rolledBackByUserID,
 	}
@@ -50,6 +52,16 @@ func (e *ConfigRolledBack) GetEventType() string {
 	return e.EventType
 }
 
+// GetProjectID returns the project the event belongs to
+func (e *ConfigRolledBack) GetProjectID() string {
+	return e.ProjectID
+}
+
+// GetConfigKey returns the key of the changed config
+func (e *ConfigRolledBack) GetConfigKey() string {
+	return e.ConfigKey
+}
+
 // GetOccurredAt returns when the event occurred
 func (e *ConfigRolledBack) GetOccurredAt() time.Time {
 	return e.OccurredAt
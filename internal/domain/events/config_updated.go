@@ -52,6 +52,16 @@ func (e *ConfigUpdated) GetEventType() string {
 	return e.EventType
 }
 
+// GetProjectID returns the project the event belongs to
+func (e *ConfigUpdated) GetProjectID() string {
+	return e.ProjectID
+}
+
+// GetConfigKey returns the key of the changed config
+func (e *ConfigUpdated) GetConfigKey() string {
+	return e.ConfigKey
+}
+
 // GetOccurredAt returns when the event occurred
 func (e *ConfigUpdated) GetOccurredAt() time.Time {
 	return e.OccurredAt
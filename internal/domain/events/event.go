@@ -20,6 +20,22 @@ type DomainEvent interface {
 	ToJSON() ([]byte, error)
 }
 
+// ProjectEvent is a domain event scoped to a single project
+type ProjectEvent interface {
+	DomainEvent
+	
+	// GetProjectID returns the project the event belongs to
+	GetProjectID() string
+}
+
+// ConfigEvent is a project event about a single config
+type ConfigEvent interface {
+	ProjectEvent
+	
+	// GetConfigKey returns the key of the config the event is about
+	GetConfigKey() string
+}
+
 // EventType constants for all domain events
 const (
 	EventTypeConfigCreated    = "config.created"
@@ -43,5 +59,15 @@ var (
 	_ DomainEvent = (*ConfigUpdated)(nil)
 	_ DomainEvent = (*ConfigDeleted)(nil)
 	_ DomainEvent = (*ConfigRolledBack)(nil)
+	
+	_ ProjectEvent = (*ConfigCreated)(nil)
+	_ ProjectEvent = (*ConfigUpdated)(nil)
+	_ ProjectEvent = (*ConfigDeleted)(nil)
+	_ ProjectEvent = (*ConfigRolledBack)(nil)
+	
+	_ ConfigEvent = (*ConfigCreated)(nil)
+	_ ConfigEvent = (*ConfigUpdated)(nil)
+	_ ConfigEvent = (*ConfigDeleted)(nil)
+	_ ConfigEvent = (*ConfigRolledBack)(nil)
 )
 
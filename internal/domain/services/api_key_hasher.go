@@ -0,0 +1,88 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+)
+
+// apiKeySaltLength is the size of each key's random salt in bytes
+const apiKeySaltLength = 16
+
+// APIKeyHasher hashes API keys for storage and verifies presented keys.
+// Digests are HMAC-SHA256(pepper, SHA-256(salt || key)); the pepper is a
+// server secret kept out of the database, so a dump alone cannot be used
+// to check guesses.
+type APIKeyHasher struct {
+	pepper []byte
+}
+
+// NewAPIKeyHasher creates a new APIKeyHasher
+// pepper must stay the same for the lifetime of the stored keys
+func NewAPIKeyHasher(pepper string) *APIKeyHasher {
+	return &APIKeyHasher{
+		pepper: []byte(pepper),
+	}
+}
+
+// Hash hashes an API key with a fresh salt
+func (h *APIKeyHasher) Hash(key valueobjects.APIKey) (valueobjects.HashedAPIKey, error) {
+	if key.IsEmpty() {
+		return valueobjects.HashedAPIKey{}, fmt.Errorf("api key cannot be empty")
+	}
+
+	salt := make([]byte, apiKeySaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return valueobjects.HashedAPIKey{}, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return valueobjects.NewHashedAPIKey(
+		key.LookupPrefix(),
+		salt,
+		h.applyPepper(saltedDigest(salt, key)),
+		key.Hint(),
+		valueobjects.APIKeyHashVersionPeppered,
+	)
+}
+
+// Verify checks a presented key against a stored hash in constant time
+func (h *APIKeyHasher) Verify(key valueobjects.APIKey, hashed valueobjects.HashedAPIKey) bool {
+	digest := saltedDigest(hashed.Salt(), key)
+	if !hashed.IsLegacy() {
+		digest = h.applyPepper(digest)
+	}
+	return subtle.ConstantTimeCompare(digest, hashed.Hash()) == 1
+}
+
+// Upgrade wraps a legacy digest with the pepper.
+// The key itself is not needed because the peppered scheme builds on the legacy one.
+func (h *APIKeyHasher) Upgrade(hashed valueobjects.HashedAPIKey) (valueobjects.HashedAPIKey, error) {
+	if !hashed.IsLegacy() {
+		return hashed, nil
+	}
+
+	return valueobjects.NewHashedAPIKey(
+		hashed.LookupPrefix(),
+		hashed.Salt(),
+		h.applyPepper(hashed.Hash()),
+		hashed.Hint(),
+		valueobjects.APIKeyHashVersionPeppered,
+	)
+}
+
+// applyPepper keys a digest with the server secret
+func (h *APIKeyHasher) applyPepper(digest []byte) []byte {
+	mac := hmac.New(sha256.New, h.pepper)
+	mac.Write(digest)
+	return mac.Sum(nil)
+}
+
+// saltedDigest computes SHA-256(salt || key)
+func saltedDigest(salt []byte, key valueobjects.APIKey) []byte {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), key.Value()...))
+	return sum[:]
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+)
+
+func TestAPIKeyHasher_HashAndVerify(t *testing.T) {
+	hasher := NewAPIKeyHasher("test-pepper")
+	key := NewAPIKeyGenerator().MustGenerate()
+
+	// Act
+	hashed, err := hasher.Hash(key)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, key.LookupPrefix(), hashed.LookupPrefix())
+	assert.Equal(t, key.Masked(), hashed.Masked())
+	assert.Equal(t, valueobjects.APIKeyHashVersionPeppered, hashed.Version())
+	assert.NotContains(t, string(hashed.Hash()), key.Value())
+	assert.True(t, hasher.Verify(key, hashed))
+
+	t.Run("rejects other keys", func(t *testing.T) {
+		other := NewAPIKeyGenerator().MustGenerate()
+		assert.False(t, hasher.Verify(other, hashed))
+	})
+
+	t.Run("rejects a different pepper", func(t *testing.T) {
+		assert.False(t, NewAPIKeyHasher("other-pepper").Verify(key, hashed))
+	})
+}
+
+func TestAPIKeyHasher_SaltsEachHash(t *testing.T) {
+	hasher := NewAPIKeyHasher("test-pepper")
+	key := NewAPIKeyGenerator().MustGenerate()
+
+	first, err := hasher.Hash(key)
+	require.NoError(t, err)
+	second, err := hasher.Hash(key)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.Salt(), second.Salt())
+	assert.NotEqual(t, first.Hash(), second.Hash())
+}
+
+func TestAPIKeyHasher_LegacyHash(t *testing.T) {
+	hasher := NewAPIKeyHasher("test-pepper")
+	key := NewAPIKeyGenerator().MustGenerate()
+
+	// Same digest the migration computes with sha256(salt || api_key)
+	salt := []byte("0123456789abcdef")
+	digest := sha256.Sum256(append(append([]byte{}, salt...), key.Value()...))
+	legacy, err := valueobjects.NewHashedAPIKey(key.LookupPrefix(), salt, digest[:], key.Hint(), valueobjects.APIKeyHashVersionLegacy)
+	require.NoError(t, err)
+
+	assert.True(t, hasher.Verify(key, legacy))
+
+	t.Run("upgrade adds the pepper", func(t *testing.T) {
+		upgraded, err := hasher.Upgrade(legacy)
+
+		require.NoError(t, err)
+		assert.False(t, upgraded.IsLegacy())
+		assert.Equal(t, legacy.Salt(), upgraded.Salt())
+		assert.NotEqual(t, legacy.Hash(), upgraded.Hash())
+		assert.True(t, hasher.Verify(key, upgraded))
+	})
+}
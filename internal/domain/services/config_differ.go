@@ -0,0 +1,164 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Config change operations, named as in JSON Patch (RFC 6902)
+const (
+	ChangeAdd     = "add"
+	ChangeRemove  = "remove"
+	ChangeReplace = "replace"
+)
+
+// ConfigChange is one difference between two versions of a config.
+// Path is a JSON Pointer; OldValue is unset for an add and Value for a remove.
+type ConfigChange struct {
+	Op       string          `json:"op"`
+	Path     string          `json:"path"`
+	OldValue json.RawMessage `json:"old_value,omitempty"`
+	Value    json.RawMessage `json:"value,omitempty"`
+}
+
+// ConfigDiffer compares config documents
+type ConfigDiffer struct{}
+
+// NewConfigDiffer creates a new ConfigDiffer
+func NewConfigDiffer() *ConfigDiffer {
+	return &ConfigDiffer{}
+}
+
+// Diff returns the changes that turn current into candidate, with object
+// members in key order. Arrays are compared by index; elements past the end
+// of the shorter array are removed from the last one down, or added in
+// order, so the changes apply as a JSON Patch. A nil current is a config
+// that does not exist yet: the candidate is one add at the root.
+// Numbers are compared by value, so 1.0 and 1 are equal.
+func (cd *ConfigDiffer) Diff(current, candidate json.RawMessage) ([]ConfigChange, error) {
+	newValue, err := decodeDiffValue(candidate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid candidate content: %w", err)
+	}
+
+	changes := make([]ConfigChange, 0)
+	if current == nil {
+		return append(changes, ConfigChange{Op: ChangeAdd, Path: "", Value: encodeDiffValue(newValue)}), nil
+	}
+
+	oldValue, err := decodeDiffValue(current)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current content: %w", err)
+	}
+
+	diffValues("", oldValue, newValue, &changes)
+	return changes, nil
+}
+
+// diffValues appends the changes between two decoded values
+func diffValues(pointer string, oldValue, newValue interface{}, changes *[]ConfigChange) {
+	switch o := oldValue.(type) {
+	case map[string]interface{}:
+		n, ok := newValue.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make(map[string]interface{}, len(o)+len(n))
+		for key := range o {
+			keys[key] = nil
+		}
+		for key := range n {
+			keys[key] = nil
+		}
+		for _, key := range sortedKeys(keys) {
+			memberPointer := pointer + "/" + escapePointerToken(key)
+			oldMember, inOld := o[key]
+			newMember, inNew := n[key]
+			switch {
+			case !inNew:
+				*changes = append(*changes, ConfigChange{Op: ChangeRemove, Path: memberPointer, OldValue: encodeDiffValue(oldMember)})
+			case !inOld:
+				*changes = append(*changes, ConfigChange{Op: ChangeAdd, Path: memberPointer, Value: encodeDiffValue(newMember)})
+			default:
+				diffValues(memberPointer, oldMember, newMember, changes)
+			}
+		}
+		return
+	case []interface{}:
+		n, ok := newValue.([]interface{})
+		if !ok {
+			break
+		}
+		common := len(o)
+		if len(n) < common {
+			common = len(n)
+		}
+		for i := 0; i < common; i++ {
+			diffValues(pointer+"/"+strconv.Itoa(i), o[i], n[i], changes)
+		}
+		for i := len(o) - 1; i >= common; i-- {
+			*changes = append(*changes, ConfigChange{Op: ChangeRemove, Path: pointer + "/" + strconv.Itoa(i), OldValue: encodeDiffValue(o[i])})
+		}
+		for i := common; i < len(n); i++ {
+			*changes = append(*changes, ConfigChange{Op: ChangeAdd, Path: pointer + "/" + strconv.Itoa(i), Value: encodeDiffValue(n[i])})
+		}
+		return
+	}
+
+	if !scalarsEqual(oldValue, newValue) {
+		*changes = append(*changes, ConfigChange{
+			Op:       ChangeReplace,
+			Path:     pointer,
+			OldValue: encodeDiffValue(oldValue),
+			Value:    encodeDiffValue(newValue),
+		})
+	}
+}
+
+// scalarsEqual reports whether two values are the same scalar. Containers
+// only reach here when the other side is of a different type.
+func scalarsEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if av == bv {
+			return true
+		}
+		ar, aok := new(big.Rat).SetString(av.String())
+		br, bok := new(big.Rat).SetString(bv.String())
+		return aok && bok && ar.Cmp(br) == 0
+	case string, bool, nil:
+		return a == b
+	default:
+		return false
+	}
+}
+
+// decodeDiffValue decodes JSON keeping numbers as written
+func decodeDiffValue(data json.RawMessage) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// encodeDiffValue encodes a decoded value in canonical form
+func encodeDiffValue(value interface{}) json.RawMessage {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		// Values decoded from JSON always encode
+		return json.RawMessage("null")
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigDiffer_Diff(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		candidate string
+		want      string
+	}{
+		{
+			name:      "unchanged with different spelling",
+			current:   `{"b": 1.0, "a": [true, null]}`,
+			candidate: `{"a": [true, null], "b": 1}`,
+			want:      `[]`,
+		},
+		{
+			name:      "members added, removed and replaced in key order",
+			current:   `{"port": 8080, "debug": true, "db": {"host": "a", "pool": 5}}`,
+			candidate: `{"port": 9090, "db": {"host": "a", "user": "svc"}, "tags": null}`,
+			want: `[
+				{"op": "remove", "path": "/db/pool", "old_value": 5},
+				{"op": "add", "path": "/db/user", "value": "svc"},
+				{"op": "remove", "path": "/debug", "old_value": true},
+				{"op": "replace", "path": "/port", "old_value": 8080, "value": 9090},
+				{"op": "add", "path": "/tags", "value": null}
+			]`,
+		},
+		{
+			name:      "arrays by index with removals from the end",
+			current:   `{"s": [1, 2, 3, 4]}`,
+			candidate: `{"s": [1, 5]}`,
+			want: `[
+				{"op": "replace", "path": "/s/1", "old_value": 2, "value": 5},
+				{"op": "remove", "path": "/s/3", "old_value": 4},
+				{"op": "remove", "path": "/s/2", "old_value": 3}
+			]`,
+		},
+		{
+			name:      "type change replaces the whole value",
+			current:   `{"a/b": {"x": 1}}`,
+			candidate: `{"a/b": [1]}`,
+			want:      `[{"op": "replace", "path": "/a~1b", "old_value": {"x": 1}, "value": [1]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := NewConfigDiffer().Diff(json.RawMessage(tt.current), json.RawMessage(tt.candidate))
+
+			require.NoError(t, err)
+			got, err := json.Marshal(changes)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestConfigDiffer_Diff_NewConfig(t *testing.T) {
+	changes, err := NewConfigDiffer().Diff(nil, json.RawMessage(`{"b": 1, "a": 2}`))
+
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, ChangeAdd, changes[0].Op)
+	assert.Equal(t, "", changes[0].Path)
+	assert.Equal(t, `{"a":2,"b":1}`, string(changes[0].Value))
+}
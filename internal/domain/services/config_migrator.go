@@ -0,0 +1,374 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+)
+
+// Migration operations
+const (
+	MigrateRename     = "rename"
+	MigrateMove       = "move"
+	MigrateSetDefault = "set_default"
+	MigrateConvert    = "convert"
+	MigrateRemove     = "remove"
+)
+
+// MigrationOperation is one step of a schema migration. Paths are JSON
+// Pointers or singular JSONPaths into the config.
+//
+//   - rename: renames the member at Path to To, keeping it in its object
+//   - move: moves the value at From to Path, creating missing objects
+//   - set_default: sets Path to Value when it is absent
+//   - convert: converts the value at Path to Type (string, number, integer or boolean)
+//   - remove: removes the value at Path
+//
+// An operation whose source value is absent does nothing, so a migration
+// can run on configs that never set an optional member.
+type MigrationOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	To    string          `json:"to,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Type  string          `json:"type,omitempty"`
+}
+
+// SchemaMigration rewrites config content written for the previous version
+// of a schema so that it fits the version the migration is attached to
+type SchemaMigration struct {
+	Operations []MigrationOperation `json:"operations"`
+}
+
+// ConfigMigrator validates and applies schema migrations
+type ConfigMigrator struct{}
+
+// NewConfigMigrator creates a new ConfigMigrator
+func NewConfigMigrator() *ConfigMigrator {
+	return &ConfigMigrator{}
+}
+
+// Parse decodes and validates a migration
+func (cm *ConfigMigrator) Parse(data json.RawMessage) (*SchemaMigration, error) {
+	var migration SchemaMigration
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&migration); err != nil {
+		return nil, fmt.Errorf("invalid migration: %w", err)
+	}
+	if len(migration.Operations) == 0 {
+		return nil, fmt.Errorf("invalid migration: no operations")
+	}
+
+	for i, op := range migration.Operations {
+		if err := validateMigrationOperation(op); err != nil {
+			return nil, fmt.Errorf("invalid migration operation %d: %w", i, err)
+		}
+	}
+	return &migration, nil
+}
+
+// validateMigrationOperation checks that an operation has the fields it needs
+func validateMigrationOperation(op MigrationOperation) error {
+	if _, err := migrationPath(op.Path); err != nil {
+		return fmt.Errorf("path: %w", err)
+	}
+
+	switch op.Op {
+	case MigrateRename:
+		if op.To == "" {
+			return fmt.Errorf("rename needs to")
+		}
+	case MigrateMove:
+		from, err := migrationPath(op.From)
+		if err != nil {
+			return fmt.Errorf("from: %w", err)
+		}
+		path, _ := migrationPath(op.Path)
+		if len(path) >= len(from) && strings.Join(path[:len(from)], "\x00") == strings.Join(from, "\x00") {
+			return fmt.Errorf("cannot move a value into itself")
+		}
+	case MigrateSetDefault:
+		if len(op.Value) == 0 {
+			return fmt.Errorf("set_default needs value")
+		}
+		if _, err := decodeDiffValue(op.Value); err != nil {
+			return fmt.Errorf("value: %w", err)
+		}
+	case MigrateConvert:
+		switch op.Type {
+		case "string", "number", "integer", "boolean":
+		default:
+			return fmt.Errorf("convert type must be string, number, integer or boolean")
+		}
+	case MigrateRemove:
+	default:
+		return fmt.Errorf("unknown op %q", op.Op)
+	}
+	return nil
+}
+
+// migrationPath parses a path that addresses a member, not the root
+func migrationPath(path string) ([]string, error) {
+	configPath, err := valueobjects.NewConfigPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if configPath.IsRoot() {
+		return nil, fmt.Errorf("path must not be the root")
+	}
+	return configPath.Tokens(), nil
+}
+
+// Apply runs migrations in order on content and returns the migrated
+// content in canonical form
+func (cm *ConfigMigrator) Apply(content json.RawMessage, migrations ...*SchemaMigration) (json.RawMessage, error) {
+	document, err := decodeDiffValue(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config content: %w", err)
+	}
+
+	for _, migration := range migrations {
+		for i, op := range migration.Operations {
+			document, err = applyMigrationOperation(document, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s (operation %d): %w", op.Op, op.Path, i, err)
+			}
+		}
+	}
+	return encodeDiffValue(document), nil
+}
+
+// applyMigrationOperation applies one operation to a decoded document
+func applyMigrationOperation(document interface{}, op MigrationOperation) (interface{}, error) {
+	path, err := migrationPath(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case MigrateRename:
+		value, found := lookupMember(document, path)
+		if !found {
+			return document, nil
+		}
+		target := append(append([]string(nil), path[:len(path)-1]...), op.To)
+		if _, exists := lookupMember(document, target); exists {
+			return nil, fmt.Errorf("member %q already exists", op.To)
+		}
+		if document, err = removeMember(document, path); err != nil {
+			return nil, err
+		}
+		return document, setMember(document, target, value)
+
+	case MigrateMove:
+		from, err := migrationPath(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, found := lookupMember(document, from)
+		if !found {
+			return document, nil
+		}
+		if _, exists := lookupMember(document, path); exists {
+			return nil, fmt.Errorf("target already exists")
+		}
+		if document, err = removeMember(document, from); err != nil {
+			return nil, err
+		}
+		return document, setMember(document, path, value)
+
+	case MigrateSetDefault:
+		if _, exists := lookupMember(document, path); exists {
+			return document, nil
+		}
+		value, err := decodeDiffValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return document, setMember(document, path, value)
+
+	case MigrateConvert:
+		value, found := lookupMember(document, path)
+		if !found {
+			return document, nil
+		}
+		converted, err := convertValue(value, op.Type)
+		if err != nil {
+			return nil, err
+		}
+		return document, setMember(document, path, converted)
+
+	case MigrateRemove:
+		if _, found := lookupMember(document, path); !found {
+			return document, nil
+		}
+		return removeMember(document, path)
+	}
+	return nil, fmt.Errorf("unknown op %q", op.Op)
+}
+
+// lookupMember returns the value at path
+func lookupMember(document interface{}, path []string) (interface{}, bool) {
+	current := document
+	for _, token := range path {
+		switch container := current.(type) {
+		case map[string]interface{}:
+			member, ok := container[token]
+			if !ok {
+				return nil, false
+			}
+			current = member
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(container) {
+				return nil, false
+			}
+			current = container[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setMember sets the value at path, creating missing objects on the way.
+// Array elements can be replaced but not added.
+func setMember(document interface{}, path []string, value interface{}) error {
+	current := document
+	for i, token := range path {
+		last := i == len(path)-1
+		switch container := current.(type) {
+		case map[string]interface{}:
+			if last {
+				container[token] = value
+				return nil
+			}
+			next, ok := container[token]
+			if !ok {
+				next = make(map[string]interface{})
+				container[token] = next
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(container) {
+				return fmt.Errorf("array index %q out of range", token)
+			}
+			if last {
+				container[index] = value
+				return nil
+			}
+			current = container[index]
+		default:
+			return fmt.Errorf("cannot add a member to a %s", jsonTypeName(current))
+		}
+	}
+	return nil
+}
+
+// removeMember removes the value at path and returns the document; array
+// elements after it shift down
+func removeMember(document interface{}, path []string) (interface{}, error) {
+	parentPath := path[:len(path)-1]
+	parent, found := lookupMember(document, parentPath)
+	if !found {
+		return document, nil
+	}
+	token := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		delete(container, token)
+	case []interface{}:
+		// Removing from an array changes its length, so the array is
+		// replaced in its parent
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(container) {
+			return document, nil
+		}
+		shortened := append(append([]interface{}(nil), container[:index]...), container[index+1:]...)
+		if len(parentPath) == 0 {
+			return shortened, nil
+		}
+		return document, setMember(document, parentPath, shortened)
+	}
+	return document, nil
+}
+
+// convertValue converts a scalar to a JSON type
+func convertValue(value interface{}, targetType string) (interface{}, error) {
+	switch targetType {
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case json.Number:
+			return v.String(), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	case "number", "integer":
+		var text string
+		switch v := value.(type) {
+		case json.Number:
+			text = v.String()
+		case string:
+			text = strings.TrimSpace(v)
+		case bool:
+			return nil, fmt.Errorf("cannot convert a boolean to %s", targetType)
+		default:
+			return nil, fmt.Errorf("cannot convert a %s to %s", jsonTypeName(value), targetType)
+		}
+		decoded, err := decodeDiffValue(json.RawMessage(text))
+		if _, ok := decoded.(json.Number); err != nil || !ok {
+			return nil, fmt.Errorf("%q is not a number", text)
+		}
+		if targetType == "number" {
+			return json.Number(text), nil
+		}
+		number, ok := new(big.Rat).SetString(text)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a number", text)
+		}
+		if !number.IsInt() {
+			return nil, fmt.Errorf("%q is not an integer", text)
+		}
+		return json.Number(number.Num().String()), nil
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a boolean", v)
+			}
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot convert a %s to %s", jsonTypeName(value), targetType)
+}
+
+// jsonTypeName names the JSON type of a decoded value
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
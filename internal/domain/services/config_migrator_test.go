@@ -0,0 +1,128 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigMigrator_Apply(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		migration string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "rename keeps the member in its object",
+			content:   `{"db": {"host": "a", "port": 5432}}`,
+			migration: `{"operations": [{"op": "rename", "path": "/db/host", "to": "hostname"}]}`,
+			want:      `{"db": {"hostname": "a", "port": 5432}}`,
+		},
+		{
+			name:      "move creates missing objects",
+			content:   `{"db": {"host": "a"}, "debug": true}`,
+			migration: `{"operations": [{"op": "move", "from": "/db", "path": "/storage/primary"}]}`,
+			want:      `{"storage": {"primary": {"host": "a"}}, "debug": true}`,
+		},
+		{
+			name:      "set_default only fills absent members",
+			content:   `{"timeout": 10}`,
+			migration: `{"operations": [{"op": "set_default", "path": "/timeout", "value": 30}, {"op": "set_default", "path": "$.retry.max", "value": 3}]}`,
+			want:      `{"timeout": 10, "retry": {"max": 3}}`,
+		},
+		{
+			name:      "convert scalars",
+			content:   `{"port": "8080", "ratio": "0.25", "debug": "true", "id": 12, "count": 3.0}`,
+			migration: `{"operations": [{"op": "convert", "path": "/port", "type": "integer"}, {"op": "convert", "path": "/ratio", "type": "number"}, {"op": "convert", "path": "/debug", "type": "boolean"}, {"op": "convert", "path": "/id", "type": "string"}, {"op": "convert", "path": "/count", "type": "integer"}]}`,
+			want:      `{"port": 8080, "ratio": 0.25, "debug": true, "id": "12", "count": 3}`,
+		},
+		{
+			name:      "remove array element",
+			content:   `{"hosts": ["a", "b", "c"]}`,
+			migration: `{"operations": [{"op": "remove", "path": "/hosts/1"}]}`,
+			want:      `{"hosts": ["a", "c"]}`,
+		},
+		{
+			name:      "absent sources are skipped",
+			content:   `{"a": 1}`,
+			migration: `{"operations": [{"op": "rename", "path": "/b", "to": "c"}, {"op": "move", "from": "/x", "path": "/y"}, {"op": "convert", "path": "/z", "type": "string"}, {"op": "remove", "path": "/q/r"}]}`,
+			want:      `{"a": 1}`,
+		},
+		{
+			name:      "conversion failure",
+			content:   `{"port": "http"}`,
+			migration: `{"operations": [{"op": "convert", "path": "/port", "type": "integer"}]}`,
+			wantErr:   true,
+		},
+		{
+			name:      "rename onto an existing member",
+			content:   `{"host": "a", "hostname": "b"}`,
+			migration: `{"operations": [{"op": "rename", "path": "/host", "to": "hostname"}]}`,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			migrator := NewConfigMigrator()
+			migration, err := migrator.Parse(json.RawMessage(tt.migration))
+			require.NoError(t, err)
+
+			// Act
+			got, err := migrator.Apply(json.RawMessage(tt.content), migration)
+
+			// Assert
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestConfigMigrator_Apply_Chain(t *testing.T) {
+	// Arrange
+	migrator := NewConfigMigrator()
+	v2, err := migrator.Parse(json.RawMessage(`{"operations": [{"op": "rename", "path": "/host", "to": "hostname"}]}`))
+	require.NoError(t, err)
+	v3, err := migrator.Parse(json.RawMessage(`{"operations": [{"op": "move", "from": "/hostname", "path": "/server/hostname"}]}`))
+	require.NoError(t, err)
+
+	// Act
+	got, err := migrator.Apply(json.RawMessage(`{"host": "a"}`), v2, v3)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"server": {"hostname": "a"}}`, string(got))
+}
+
+func TestConfigMigrator_Parse(t *testing.T) {
+	tests := []struct {
+		name      string
+		migration string
+	}{
+		{name: "no operations", migration: `{"operations": []}`},
+		{name: "unknown op", migration: `{"operations": [{"op": "copy", "path": "/a"}]}`},
+		{name: "root path", migration: `{"operations": [{"op": "remove", "path": ""}]}`},
+		{name: "rename without target", migration: `{"operations": [{"op": "rename", "path": "/a"}]}`},
+		{name: "move into itself", migration: `{"operations": [{"op": "move", "from": "/a", "path": "/a/b"}]}`},
+		{name: "default without value", migration: `{"operations": [{"op": "set_default", "path": "/a"}]}`},
+		{name: "unknown type", migration: `{"operations": [{"op": "convert", "path": "/a", "type": "date"}]}`},
+		{name: "unknown field", migration: `{"operations": [{"op": "remove", "path": "/a", "value": 1, "extra": true}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfigMigrator().Parse(json.RawMessage(tt.migration))
+
+			assert.Error(t, err)
+		})
+	}
+}
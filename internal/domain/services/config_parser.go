@@ -0,0 +1,646 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"gopkg.in/yaml.v3"
+)
+
+// jsonNumberPattern matches a number as written in JSON
+var jsonNumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// ConfigParser converts config files to the canonical JSON cfguardian stores:
+// object keys sorted and no insignificant whitespace. It is the inverse of
+// ConfigRenderer, with these rules:
+//   - YAML must hold a single document. Anchors and merge keys are
+//     resolved, non-string keys become strings and timestamps stay strings.
+//   - TOML dates and times become strings: RFC 3339 when they have an
+//     offset, otherwise local dates, times or date-times as in TOML.
+//   - dotenv files become a flat object keyed by variable name. Lines may
+//     start with "export"; # starts a comment. Double-quoted values are
+//     strings with \n, \r, \t, \", \$ and \\ escapes, single-quoted values
+//     are literal strings.
+//   - properties files are read like java.util.Properties.load, and keys
+//     are expanded back into nesting: "." separates object keys and [i]
+//     addresses array elements, which must be numbered from 0 without gaps.
+//   - In dotenv and properties, an unquoted value that is exactly true,
+//     false or a JSON number takes that type; anything else is a string.
+//     A name set twice, or used both as a value and a parent, is an error.
+type ConfigParser struct{}
+
+// NewConfigParser creates a new ConfigParser
+func NewConfigParser() *ConfigParser {
+	return &ConfigParser{}
+}
+
+// Parse converts a config file in a format to canonical JSON
+func (cp *ConfigParser) Parse(data []byte, format valueobjects.ConfigFormat) (json.RawMessage, error) {
+	var value interface{}
+	var err error
+
+	switch format {
+	case valueobjects.ConfigFormatJSON:
+		value, err = parseJSON(data)
+	case valueobjects.ConfigFormatYAML:
+		value, err = parseYAML(data)
+	case valueobjects.ConfigFormatTOML:
+		value, err = parseTOML(data)
+	case valueobjects.ConfigFormatDotenv:
+		value, err = parseDotenv(data)
+	case valueobjects.ConfigFormatProperties:
+		value, err = parseProperties(data)
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", format, err)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to convert %s to json: %w", format, err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// ParseContent returns the JSON to store for API request content. Without
+// a format the content is JSON already. With one, the content is a JSON
+// string holding a file in that format, which is parsed into canonical JSON.
+func (cp *ConfigParser) ParseContent(content json.RawMessage, format valueobjects.ConfigFormat) (json.RawMessage, error) {
+	if format == "" {
+		return content, nil
+	}
+
+	var file string
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("content must be a string holding the %s file when a format is given", format)
+	}
+	return cp.Parse([]byte(file), format)
+}
+
+// parseJSON decodes a single JSON value, keeping numbers as written
+func parseJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the top-level value")
+	}
+	return value, nil
+}
+
+// parseYAML decodes a single YAML document
+func parseYAML(data []byte) (interface{}, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	var document yaml.Node
+	if err := decoder.Decode(&document); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("document is empty")
+		}
+		return nil, err
+	}
+
+	var extra yaml.Node
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("input must hold a single document")
+	}
+
+	keepTimestampsAsStrings(&document)
+	var value interface{}
+	if err := document.Decode(&value); err != nil {
+		return nil, err
+	}
+	return jsonValue("", value)
+}
+
+// keepTimestampsAsStrings retags implicit YAML timestamps as strings, so a
+// date stays as written instead of becoming a full RFC 3339 time
+func keepTimestampsAsStrings(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" && node.Style&yaml.TaggedStyle == 0 {
+		node.Tag = "!!str"
+	}
+	for _, child := range node.Content {
+		keepTimestampsAsStrings(child)
+	}
+}
+
+// parseTOML decodes a TOML document
+func parseTOML(data []byte) (interface{}, error) {
+	var value map[string]interface{}
+	if err := toml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return jsonValue("", value)
+}
+
+// jsonValue converts a value decoded from YAML or TOML to one that encodes
+// to JSON
+func jsonValue(pointer string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, member := range v {
+			converted, err := jsonValue(pointer+"/"+escapePointerToken(key), member)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = converted
+		}
+		return object, nil
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, member := range v {
+			name := fmt.Sprint(key)
+			if _, exists := object[name]; exists {
+				return nil, fmt.Errorf("key %q is set twice at %s", name, pointerOrRoot(pointer))
+			}
+			converted, err := jsonValue(pointer+"/"+escapePointerToken(name), member)
+			if err != nil {
+				return nil, err
+			}
+			object[name] = converted
+		}
+		return object, nil
+	case []map[string]interface{}:
+		array := make([]interface{}, len(v))
+		for i, table := range v {
+			converted, err := jsonValue(pointer+"/"+strconv.Itoa(i), table)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = converted
+		}
+		return array, nil
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, elem := range v {
+			converted, err := jsonValue(pointer+"/"+strconv.Itoa(i), elem)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = converted
+		}
+		return array, nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("%v at %s has no JSON equivalent", v, pointerOrRoot(pointer))
+		}
+		return v, nil
+	case int:
+		return json.Number(strconv.Itoa(v)), nil
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10)), nil
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10)), nil
+	case time.Time:
+		return formatTOMLTime(v), nil
+	case string, bool, nil:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T at %s", v, pointerOrRoot(pointer))
+	}
+}
+
+// formatTOMLTime formats a TOML date or time. The TOML decoder marks local
+// dates and times, which have no offset, with named locations.
+func formatTOMLTime(t time.Time) string {
+	switch t.Location().String() {
+	case "date-local":
+		return t.Format("2006-01-02")
+	case "time-local":
+		return t.Format("15:04:05.999999999")
+	case "datetime-local":
+		return t.Format("2006-01-02T15:04:05.999999999")
+	default:
+		return t.Format(time.RFC3339Nano)
+	}
+}
+
+// pointerOrRoot names a JSON Pointer in messages, where the root is empty
+func pointerOrRoot(pointer string) string {
+	if pointer == "" {
+		return "the top level"
+	}
+	return pointer
+}
+
+// parseDotenv decodes a dotenv file into a flat object
+func parseDotenv(data []byte) (interface{}, error) {
+	object := make(map[string]interface{})
+	setOn := make(map[string]int)
+
+	for i, line := range strings.Split(string(data), "\n") {
+		lineNo := i + 1
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if strings.HasPrefix(line, "export ") {
+			line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected NAME=value", lineNo)
+		}
+		name := strings.TrimSpace(line[:eq])
+		if !isDotenvName(name) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNo, name)
+		}
+		if previous, exists := setOn[name]; exists {
+			return nil, fmt.Errorf("line %d: %s is already set on line %d", lineNo, name, previous)
+		}
+
+		value, err := parseDotenvValue(strings.TrimSpace(line[eq+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		object[name] = value
+		setOn[name] = lineNo
+	}
+
+	return object, nil
+}
+
+// isDotenvName reports whether a variable name is valid
+func isDotenvName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || r == '.' || r == '-' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDotenvValue decodes the part of a dotenv line after the =
+func parseDotenvValue(raw string) (interface{}, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	var value, rest string
+	switch raw[0] {
+	case '"':
+		var b strings.Builder
+		closed := false
+		i := 1
+		for ; i < len(raw); i++ {
+			c := raw[i]
+			if c == '"' {
+				closed = true
+				break
+			}
+			if c != '\\' || i+1 == len(raw) {
+				b.WriteByte(c)
+				continue
+			}
+			i++
+			switch raw[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(raw[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(raw[i])
+			}
+		}
+		if !closed {
+			return nil, fmt.Errorf("unterminated double-quoted value")
+		}
+		value, rest = b.String(), raw[i+1:]
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated single-quoted value")
+		}
+		value, rest = raw[1:end+1], raw[end+2:]
+	default:
+		if comment := strings.Index(raw, " #"); comment >= 0 {
+			raw = strings.TrimSpace(raw[:comment])
+		}
+		return inferScalar(raw), nil
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest != "" && rest[0] != '#' {
+		return nil, fmt.Errorf("unexpected text after quoted value")
+	}
+	return value, nil
+}
+
+// inferScalar types an unquoted value: true, false and JSON numbers keep
+// their type, anything else is a string
+func inferScalar(s string) interface{} {
+	switch {
+	case s == "true":
+		return true
+	case s == "false":
+		return false
+	case jsonNumberPattern.MatchString(s):
+		return json.Number(s)
+	default:
+		return s
+	}
+}
+
+// parseProperties decodes a properties file, expanding its keys into nesting
+func parseProperties(data []byte) (interface{}, error) {
+	entries, err := readPropertiesEntries(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	root := newPropertiesNode()
+	for _, entry := range entries {
+		path, err := parsePropertiesKey(entry.key)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", entry.line, err)
+		}
+		if err := root.set(path, inferScalar(entry.value)); err != nil {
+			return nil, fmt.Errorf("line %d: %s %w", entry.line, entry.key, err)
+		}
+	}
+
+	return root.value()
+}
+
+// propertiesEntry is one key and value read from a properties file
+type propertiesEntry struct {
+	key   string
+	value string
+	line  int
+}
+
+// readPropertiesEntries reads the key/value pairs of a properties file,
+// joining continuation lines and unescaping keys and values
+func readPropertiesEntries(data string) ([]propertiesEntry, error) {
+	data = strings.ReplaceAll(strings.ReplaceAll(data, "\r\n", "\n"), "\r", "\n")
+	lines := strings.Split(data, "\n")
+
+	var entries []propertiesEntry
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// A line ending in an odd number of backslashes continues on the next
+		for endsWithEscape(line) {
+			line = line[:len(line)-1]
+			if i+1 == len(lines) {
+				break
+			}
+			i++
+			line += strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		keyEnd := len(line)
+		for j := 0; j < len(line); j++ {
+			if line[j] == '\\' {
+				j++
+				continue
+			}
+			if strings.IndexByte("=: \t\f", line[j]) >= 0 {
+				keyEnd = j
+				break
+			}
+		}
+
+		rest := strings.TrimLeft(line[keyEnd:], " \t\f")
+		if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+			rest = strings.TrimLeft(rest[1:], " \t\f")
+		}
+
+		key, err := unescapeProperties(line[:keyEnd])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		value, err := unescapeProperties(rest)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		entries = append(entries, propertiesEntry{key: key, value: value, line: lineNo})
+	}
+	return entries, nil
+}
+
+// endsWithEscape reports whether a line ends in an odd number of backslashes
+func endsWithEscape(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+// unescapeProperties decodes the escapes of java.util.Properties
+func unescapeProperties(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var units []uint16
+	var b strings.Builder
+	flushUnits := func() {
+		if len(units) > 0 {
+			b.WriteString(string(utf16.Decode(units)))
+			units = units[:0]
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			flushUnits()
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if s[i] == 'u' {
+			if i+5 > len(s) {
+				return "", fmt.Errorf("malformed \\u escape")
+			}
+			code, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\u escape")
+			}
+			units = append(units, uint16(code))
+			i += 4
+			continue
+		}
+
+		flushUnits()
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	flushUnits()
+	return b.String(), nil
+}
+
+// parsePropertiesKey splits a key such as servers[0].host into object keys
+// (string) and array indexes (int)
+func parsePropertiesKey(key string) ([]interface{}, error) {
+	var path []interface{}
+	rest := key
+	for {
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("invalid key %q: empty name", key)
+		}
+		path = append(path, rest[:end])
+		rest = rest[end:]
+
+		for strings.HasPrefix(rest, "[") {
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid key %q: unterminated [", key)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 || strconv.Itoa(index) != rest[1:end] {
+				return nil, fmt.Errorf("invalid key %q: bad array index", key)
+			}
+			path = append(path, index)
+			rest = rest[end+1:]
+		}
+
+		if rest == "" {
+			return path, nil
+		}
+		if rest[0] != '.' {
+			return nil, fmt.Errorf("invalid key %q: expected . after ]", key)
+		}
+		rest = rest[1:]
+	}
+}
+
+// propertiesNode collects the values under one properties key prefix
+type propertiesNode struct {
+	leaf     interface{}
+	isLeaf   bool
+	members  map[string]*propertiesNode
+	elements map[int]*propertiesNode
+}
+
+func newPropertiesNode() *propertiesNode {
+	return &propertiesNode{}
+}
+
+// set stores a value at a path below the node
+func (n *propertiesNode) set(path []interface{}, value interface{}) error {
+	if len(path) == 0 {
+		if n.isLeaf {
+			return fmt.Errorf("is set twice")
+		}
+		if n.members != nil || n.elements != nil {
+			return fmt.Errorf("is both a value and a parent of other keys")
+		}
+		n.leaf, n.isLeaf = value, true
+		return nil
+	}
+	if n.isLeaf {
+		return fmt.Errorf("is both a value and a parent of other keys")
+	}
+
+	var child *propertiesNode
+	switch elem := path[0].(type) {
+	case string:
+		if n.elements != nil {
+			return fmt.Errorf("mixes object keys and array indexes")
+		}
+		if n.members == nil {
+			n.members = make(map[string]*propertiesNode)
+		}
+		if child = n.members[elem]; child == nil {
+			child = newPropertiesNode()
+			n.members[elem] = child
+		}
+	case int:
+		if n.members != nil {
+			return fmt.Errorf("mixes object keys and array indexes")
+		}
+		if n.elements == nil {
+			n.elements = make(map[int]*propertiesNode)
+		}
+		if child = n.elements[elem]; child == nil {
+			child = newPropertiesNode()
+			n.elements[elem] = child
+		}
+	}
+	return child.set(path[1:], value)
+}
+
+// value builds the JSON value of the node
+func (n *propertiesNode) value() (interface{}, error) {
+	switch {
+	case n.isLeaf:
+		return n.leaf, nil
+	case n.elements != nil:
+		indexes := make([]int, 0, len(n.elements))
+		for index := range n.elements {
+			indexes = append(indexes, index)
+		}
+		sort.Ints(indexes)
+
+		array := make([]interface{}, len(indexes))
+		for i, index := range indexes {
+			if index != i {
+				return nil, fmt.Errorf("array index %d is missing", i)
+			}
+			elem, err := n.elements[index].value()
+			if err != nil {
+				return nil, err
+			}
+			array[i] = elem
+		}
+		return array, nil
+	default:
+		object := make(map[string]interface{}, len(n.members))
+		for key, member := range n.members {
+			value, err := member.value()
+			if err != nil {
+				return nil, err
+			}
+			object[key] = value
+		}
+		return object, nil
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+)
+
+// TestConfigParser_Golden parses every file in testdata/parse and compares
+// the result with the <file>.json golden file next to it.
+// Run with -update to rewrite the golden files.
+func TestConfigParser_Golden(t *testing.T) {
+	parser := NewConfigParser()
+
+	inputs, err := filepath.Glob(filepath.Join("testdata", "parse", "*"))
+	require.NoError(t, err)
+
+	for _, input := range inputs {
+		if strings.HasSuffix(input, ".json") {
+			continue
+		}
+		format, _, ok := valueobjects.ConfigFormatForFileName(input)
+		require.True(t, ok, input)
+		golden := input + ".json"
+
+		t.Run(filepath.Base(input), func(t *testing.T) {
+			data, err := os.ReadFile(input)
+			require.NoError(t, err)
+
+			// Act
+			got, err := parser.Parse(data, format)
+			require.NoError(t, err)
+
+			// Golden files are indented for review
+			var indented bytes.Buffer
+			require.NoError(t, json.Indent(&indented, got, "", "  "))
+			indented.WriteByte('\n')
+
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(golden, indented.Bytes(), 0644))
+			}
+
+			// Assert
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), indented.String())
+		})
+	}
+}
+
+func TestConfigParser_Parse_Errors(t *testing.T) {
+	parser := NewConfigParser()
+
+	tests := []struct {
+		name          string
+		input         string
+		format        valueobjects.ConfigFormat
+		errorContains string
+	}{
+		{"json trailing data", `{} {}`, valueobjects.ConfigFormatJSON, "unexpected data"},
+		{"yaml several documents", "a: 1\n---\nb: 2\n", valueobjects.ConfigFormatYAML, "single document"},
+		{"yaml empty", "# nothing\n", valueobjects.ConfigFormatYAML, "empty"},
+		{"yaml infinity", "a: .inf\n", valueobjects.ConfigFormatYAML, "no JSON equivalent"},
+		{"toml syntax", "a = \n", valueobjects.ConfigFormatTOML, "invalid toml"},
+		{"dotenv missing =", "NAME\n", valueobjects.ConfigFormatDotenv, "line 1: expected NAME=value"},
+		{"dotenv set twice", "A=1\n\nA=2\n", valueobjects.ConfigFormatDotenv, "line 3: A is already set on line 1"},
+		{"dotenv unterminated quote", "A=\"open\n", valueobjects.ConfigFormatDotenv, "unterminated"},
+		{"properties value and parent", "a=1\na.b=2\n", valueobjects.ConfigFormatProperties, "both a value and a parent"},
+		{"properties array gap", "a[0]=1\na[2]=2\n", valueobjects.ConfigFormatProperties, "index 1 is missing"},
+		{"properties bad unicode escape", "a=\\u12\n", valueobjects.ConfigFormatProperties, "malformed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parser.Parse([]byte(tt.input), tt.format)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestConfigParser_Parse_RoundTrip(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "render", "service.json"))
+	require.NoError(t, err)
+	canonical, err := NewConfigParser().Parse(content, valueobjects.ConfigFormatJSON)
+	require.NoError(t, err)
+
+	// YAML keeps every JSON value, so rendering and parsing again is lossless
+	// up to the spelling of numbers
+	rendered, err := NewConfigRenderer().Render(content, valueobjects.ConfigFormatYAML)
+	require.NoError(t, err)
+
+	parsed, err := NewConfigParser().Parse(rendered, valueobjects.ConfigFormatYAML)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, string(canonical), string(parsed))
+}
+
+func TestConfigParser_ParseContent(t *testing.T) {
+	parser := NewConfigParser()
+
+	// Without a format the content is JSON and kept as sent
+	content, err := parser.ParseContent(json.RawMessage(`{"b": 1, "a": 2}`), "")
+	require.NoError(t, err)
+	assert.Equal(t, `{"b": 1, "a": 2}`, string(content))
+
+	content, err = parser.ParseContent(json.RawMessage(`"b: 1\na: 2\n"`), valueobjects.ConfigFormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":2,"b":1}`, string(content))
+
+	_, err = parser.ParseContent(json.RawMessage(`{"a": 2}`), valueobjects.ConfigFormatYAML)
+	assert.ErrorContains(t, err, "must be a string holding the yaml file")
+}
@@ -0,0 +1,422 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigRenderer renders stored JSON config content in other file formats.
+//
+// The same content always renders to the same bytes:
+//   - Object keys are written in byte order in every format.
+//   - Numbers keep their JSON text; TOML reads them as 64-bit integers
+//     where they fit, and as floats otherwise.
+//   - YAML and TOML keep nesting. TOML has no null, so null members are
+//     left out, and arrays holding null cannot be rendered.
+//   - dotenv and properties flatten nesting to one line per leaf value.
+//     dotenv names join the upper-cased path with "_" and replace any other
+//     character with "_" (servers[0].host becomes SERVERS_0_HOST); properties
+//     keys join object keys with "." and add array indexes as [i].
+//   - In flattened formats null, empty objects and empty arrays render as an
+//     empty value, and two paths flattening to the same name are an error.
+//   - TOML, dotenv and properties need the config to be a JSON object.
+type ConfigRenderer struct{}
+
+// NewConfigRenderer creates a new ConfigRenderer
+func NewConfigRenderer() *ConfigRenderer {
+	return &ConfigRenderer{}
+}
+
+// Render renders config content in a format
+func (cr *ConfigRenderer) Render(content json.RawMessage, format valueobjects.ConfigFormat) ([]byte, error) {
+	if format == valueobjects.ConfigFormatJSON {
+		return content, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid config content: %w", err)
+	}
+
+	if format == valueobjects.ConfigFormatYAML {
+		return renderYAML(value)
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config cannot be rendered as %s: content must be a JSON object", format)
+	}
+
+	switch format {
+	case valueobjects.ConfigFormatTOML:
+		return renderTOML(object)
+	case valueobjects.ConfigFormatDotenv:
+		return renderFlat(object, format, dotenvName, dotenvValue)
+	case valueobjects.ConfigFormatProperties:
+		return renderFlat(object, format, propertiesKey, propertiesValue)
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+}
+
+// renderYAML renders a decoded config as a YAML document
+func renderYAML(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNode(value)); err != nil {
+		return nil, fmt.Errorf("failed to render yaml: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render yaml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlNode converts a decoded JSON value to a YAML node with explicit tags,
+// so that strings such as "true" or "1.0" stay strings
+func yamlNode(value interface{}) *yaml.Node {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if len(v) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for _, key := range sortedKeys(v) {
+			node.Content = append(node.Content, yamlScalar("!!str", key), yamlNode(v[key]))
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if len(v) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for _, elem := range v {
+			node.Content = append(node.Content, yamlNode(elem))
+		}
+		return node
+	case string:
+		return yamlScalar("!!str", v)
+	case json.Number:
+		if isJSONInteger(v) {
+			return yamlScalar("!!int", v.String())
+		}
+		return yamlScalar("!!float", v.String())
+	case bool:
+		return yamlScalar("!!bool", strconv.FormatBool(v))
+	default:
+		return yamlScalar("!!null", "null")
+	}
+}
+
+// yamlScalar creates a YAML scalar node
+func yamlScalar(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+// renderTOML renders a decoded config object as a TOML document
+func renderTOML(object map[string]interface{}) ([]byte, error) {
+	table, err := tomlValue("", object)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := toml.NewEncoder(&buf)
+	encoder.Indent = ""
+	if err := encoder.Encode(table); err != nil {
+		return nil, fmt.Errorf("failed to render toml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// tomlValue converts a decoded JSON value to the Go value TOML encodes it from
+func tomlValue(pointer string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		table := make(map[string]interface{}, len(v))
+		for key, member := range v {
+			if member == nil {
+				continue
+			}
+			converted, err := tomlValue(pointer+"/"+escapePointerToken(key), member)
+			if err != nil {
+				return nil, err
+			}
+			table[key] = converted
+		}
+		return table, nil
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, elem := range v {
+			elemPointer := pointer + "/" + strconv.Itoa(i)
+			if elem == nil {
+				return nil, fmt.Errorf("config cannot be rendered as toml: null array element at %s", elemPointer)
+			}
+			converted, err := tomlValue(elemPointer, elem)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = converted
+		}
+		return array, nil
+	case json.Number:
+		if isJSONInteger(v) {
+			if n, err := v.Int64(); err == nil {
+				return n, nil
+			}
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("config cannot be rendered as toml: number out of range at %s", pointer)
+		}
+		return f, nil
+	default:
+		return v, nil
+	}
+}
+
+// configLeaf is a value of a flattened config with the path leading to it.
+// Path elements are object keys (string) and array indexes (int).
+type configLeaf struct {
+	path  []interface{}
+	value interface{}
+}
+
+// flattenConfig returns the leaves of a decoded config object in key order.
+// Empty objects and arrays are leaves with a nil value.
+func flattenConfig(object map[string]interface{}) []configLeaf {
+	var leaves []configLeaf
+
+	var walk func(path []interface{}, value interface{})
+	walk = func(path []interface{}, value interface{}) {
+		// Cap the slice so that sibling paths never share a backing array
+		path = path[:len(path):len(path)]
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if len(v) == 0 {
+				leaves = append(leaves, configLeaf{path: path})
+			}
+			for _, key := range sortedKeys(v) {
+				walk(append(path, key), v[key])
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				leaves = append(leaves, configLeaf{path: path})
+			}
+			for i, elem := range v {
+				walk(append(path, i), elem)
+			}
+		default:
+			leaves = append(leaves, configLeaf{path: path, value: v})
+		}
+	}
+
+	for _, key := range sortedKeys(object) {
+		walk([]interface{}{key}, object[key])
+	}
+	return leaves
+}
+
+// renderFlat renders a config object as sorted name=value lines
+func renderFlat(
+	object map[string]interface{},
+	format valueobjects.ConfigFormat,
+	name func(path []interface{}) string,
+	value func(leaf interface{}) string,
+) ([]byte, error) {
+	lines := make(map[string]string)
+	sources := make(map[string]string)
+
+	for _, leaf := range flattenConfig(object) {
+		n := name(leaf.path)
+		source := leafPointer(leaf.path)
+		if previous, exists := sources[n]; exists {
+			return nil, fmt.Errorf("config cannot be rendered as %s: %s and %s both flatten to %s", format, previous, source, n)
+		}
+		sources[n] = source
+		lines[n] = n + "=" + value(leaf.value)
+	}
+
+	names := make([]string, 0, len(lines))
+	for n := range lines {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	for _, n := range names {
+		buf.WriteString(lines[n])
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// dotenvName builds an environment variable name from a leaf path
+func dotenvName(path []interface{}) string {
+	var b strings.Builder
+	for i, elem := range path {
+		if i > 0 {
+			b.WriteByte('_')
+		}
+		if index, ok := elem.(int); ok {
+			b.WriteString(strconv.Itoa(index))
+			continue
+		}
+		for _, r := range strings.ToUpper(elem.(string)) {
+			if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+				b.WriteRune(r)
+			} else {
+				b.WriteByte('_')
+			}
+		}
+	}
+
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// dotenvValue renders a leaf value for a dotenv file.
+// Strings are double-quoted, with $ escaped so loaders do not expand it.
+func dotenvValue(value interface{}) string {
+	s, ok := value.(string)
+	if !ok {
+		return scalarText(value)
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\', '"', '$':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// propertiesKey builds a properties key from a leaf path
+func propertiesKey(path []interface{}) string {
+	var b strings.Builder
+	for i, elem := range path {
+		if index, ok := elem.(int); ok {
+			b.WriteString("[" + strconv.Itoa(index) + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(elem.(string))
+	}
+	return escapeProperties(b.String(), true)
+}
+
+// propertiesValue renders a leaf value for a properties file
+func propertiesValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return escapeProperties(s, false)
+	}
+	return scalarText(value)
+}
+
+// escapeProperties escapes text for java.util.Properties.load. Output is
+// ASCII only, with other characters written as \uXXXX escapes.
+func escapeProperties(s string, key bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == ' ' && (key || i == 0):
+			b.WriteString(`\ `)
+		case key && (r == '=' || r == ':' || r == '#' || r == '!'):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			for _, unit := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&b, `\u%04X`, unit)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// scalarText renders a non-string leaf value.
+// null, empty objects and empty arrays render as an empty value.
+func scalarText(value interface{}) string {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
+
+// leafPointer formats a leaf path as a JSON Pointer
+func leafPointer(path []interface{}) string {
+	var b strings.Builder
+	for _, elem := range path {
+		b.WriteByte('/')
+		if index, ok := elem.(int); ok {
+			b.WriteString(strconv.Itoa(index))
+		} else {
+			b.WriteString(escapePointerToken(elem.(string)))
+		}
+	}
+	return b.String()
+}
+
+// escapePointerToken escapes an object key for a JSON Pointer
+func escapePointerToken(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// isJSONInteger reports whether a JSON number has no fraction or exponent
+func isJSONInteger(n json.Number) bool {
+	return !strings.ContainsAny(n.String(), ".eE")
+}
+
+// sortedKeys returns the keys of an object in byte order
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
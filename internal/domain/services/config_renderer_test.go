@@ -0,0 +1,110 @@
+package services
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// goldenExtensions maps each rendered format to its golden file extension
+var goldenExtensions = map[valueobjects.ConfigFormat]string{
+	valueobjects.ConfigFormatYAML:       ".yaml",
+	valueobjects.ConfigFormatTOML:       ".toml",
+	valueobjects.ConfigFormatDotenv:     ".env",
+	valueobjects.ConfigFormatProperties: ".properties",
+}
+
+// TestConfigRenderer_Golden renders every testdata/render/*.json input in
+// every format and compares it with the golden file next to it.
+// Run with -update to rewrite the golden files.
+func TestConfigRenderer_Golden(t *testing.T) {
+	renderer := NewConfigRenderer()
+
+	inputs, err := filepath.Glob(filepath.Join("testdata", "render", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	for _, input := range inputs {
+		content, err := os.ReadFile(input)
+		require.NoError(t, err)
+
+		for format, ext := range goldenExtensions {
+			golden := strings.TrimSuffix(input, ".json") + ext
+
+			t.Run(filepath.Base(golden), func(t *testing.T) {
+				// Act
+				got, err := renderer.Render(json.RawMessage(content), format)
+				require.NoError(t, err)
+
+				if *updateGolden {
+					require.NoError(t, os.WriteFile(golden, got, 0644))
+				}
+
+				// Assert
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				assert.Equal(t, string(want), string(got))
+
+				again, err := renderer.Render(json.RawMessage(content), format)
+				require.NoError(t, err)
+				assert.Equal(t, got, again, "rendering must be deterministic")
+			})
+		}
+	}
+}
+
+func TestConfigRenderer_Render_Errors(t *testing.T) {
+	renderer := NewConfigRenderer()
+
+	tests := []struct {
+		name          string
+		content       string
+		format        valueobjects.ConfigFormat
+		errorContains string
+	}{
+		{"toml needs an object", `[1, 2]`, valueobjects.ConfigFormatTOML, "must be a JSON object"},
+		{"dotenv needs an object", `"text"`, valueobjects.ConfigFormatDotenv, "must be a JSON object"},
+		{"toml has no null array elements", `{"a": [1, null]}`, valueobjects.ConfigFormatTOML, "null array element at /a/1"},
+		{"dotenv name collision", `{"a.b": 1, "a": {"b": 2}}`, valueobjects.ConfigFormatDotenv, "both flatten to A_B"},
+		{"properties key collision", `{"a.b": 1, "a": {"b": 2}}`, valueobjects.ConfigFormatProperties, "both flatten to a.b"},
+		{"invalid content", `{`, valueobjects.ConfigFormatYAML, "invalid config content"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderer.Render(json.RawMessage(tt.content), tt.format)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestConfigRenderer_Render_YAMLScalars(t *testing.T) {
+	renderer := NewConfigRenderer()
+
+	t.Run("top-level arrays render", func(t *testing.T) {
+		got, err := renderer.Render(json.RawMessage(`[1, "a"]`), valueobjects.ConfigFormatYAML)
+
+		require.NoError(t, err)
+		assert.Equal(t, "- 1\n- a\n", string(got))
+	})
+
+	t.Run("json is returned as stored", func(t *testing.T) {
+		content := json.RawMessage(`{"b":1, "a":2}`)
+
+		got, err := renderer.Render(content, valueobjects.ConfigFormatJSON)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(got))
+	})
+}
@@ -0,0 +1,1030 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CodegenLanguage is a language SchemaCodeGenerator writes types in
+type CodegenLanguage string
+
+// Supported codegen languages
+const (
+	CodegenGo         CodegenLanguage = "go"
+	CodegenTypeScript CodegenLanguage = "typescript"
+)
+
+// ParseCodegenLanguage parses a language name; "ts" is short for typescript
+func ParseCodegenLanguage(name string) (CodegenLanguage, error) {
+	switch strings.ToLower(name) {
+	case "go", "golang":
+		return CodegenGo, nil
+	case "typescript", "ts":
+		return CodegenTypeScript, nil
+	}
+	return "", fmt.Errorf("unsupported language %q: expected go or typescript", name)
+}
+
+// FileExtension returns the extension of a source file in the language
+func (l CodegenLanguage) FileExtension() string {
+	if l == CodegenTypeScript {
+		return ".ts"
+	}
+	return ".go"
+}
+
+// CodegenOptions controls the code SchemaCodeGenerator writes
+type CodegenOptions struct {
+	Language CodegenLanguage
+
+	// TypeName names the type of the schema root; defaults to Config
+	TypeName string
+
+	// Package is the package clause of Go files; defaults to config
+	Package string
+
+	// Source is noted in the file header, as in "schema payments version 3"
+	Source string
+
+	// SchemaID and Version identify a saved schema version, whose references
+	// resolve to the versions pinned when it was published. Without them,
+	// references resolve to the latest global schemas.
+	SchemaID string
+	Version  int32
+}
+
+// SchemaCodeGenerator writes Go structs or TypeScript interfaces that
+// mirror a JSON Schema, so services can decode configs into typed values.
+//
+// The same schema always generates the same code:
+//   - Objects with properties become types named after their path (the
+//     "database" property of Config is ConfigDatabase); definitions and
+//     $defs members are named after their key, and registry schemas after
+//     their name.
+//   - Fields are written in property name byte order. Required properties
+//     are plain fields; optional ones are pointers with omitempty in Go and
+//     optional members in TypeScript. Nullable types are pointers in Go and
+//     add "| null" in TypeScript.
+//   - Enums become a named type: a union of literals in TypeScript, and in
+//     Go a string type with one constant per value, or the base type with
+//     the values in its comment when they are not strings.
+//   - Descriptions and defaults are written as comments.
+//   - allOf members are merged into one type. anyOf, oneOf and mixed types
+//     are unions in TypeScript and any in Go; tuples are arrays of unknown.
+//   - Objects without properties become maps of their additionalProperties.
+type SchemaCodeGenerator struct {
+	resolver SchemaResolver
+}
+
+// NewSchemaCodeGenerator creates a new SchemaCodeGenerator. References to
+// registry schemas are resolved with resolver, which may be nil when the
+// schemas have none.
+func NewSchemaCodeGenerator(resolver SchemaResolver) *SchemaCodeGenerator {
+	return &SchemaCodeGenerator{
+		resolver: resolver,
+	}
+}
+
+// Generate returns the source of a file declaring the types of a schema
+func (g *SchemaCodeGenerator) Generate(ctx context.Context, schemaContent string, opts CodegenOptions) ([]byte, error) {
+	if opts.TypeName == "" {
+		opts.TypeName = "Config"
+	}
+	if opts.Package == "" {
+		opts.Package = "config"
+	}
+	if !token.IsIdentifier(opts.TypeName) || !token.IsExported(opts.TypeName) {
+		return nil, fmt.Errorf("type name %q is not an exported identifier", opts.TypeName)
+	}
+	switch opts.Language {
+	case CodegenGo:
+		if !token.IsIdentifier(opts.Package) {
+			return nil, fmt.Errorf("package name %q is not an identifier", opts.Package)
+		}
+	case CodegenTypeScript:
+	default:
+		return nil, fmt.Errorf("unsupported language %q", opts.Language)
+	}
+
+	var references map[string]ResolvedSchema
+	var err error
+	if opts.SchemaID != "" {
+		references, err = pinnedReferences(ctx, g.resolver, opts.SchemaID, opts.Version, schemaContent)
+	} else {
+		references, err = resolveReferences(ctx, g.resolver, "", "", schemaContent)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve schema references: %w", err)
+	}
+
+	b := &codegenBuilder{
+		language:  opts.Language,
+		documents: make(map[string]interface{}, len(references)+1),
+		names:     make(map[string]string),
+		taken:     make(map[string]bool),
+	}
+	b.documents[""], err = decodeJSON([]byte(schemaContent))
+	if err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+	for name, referenced := range references {
+		b.documents[name], err = decodeJSON([]byte(referenced.Content))
+		if err != nil {
+			return nil, fmt.Errorf("schema %q is not valid JSON: %w", name, err)
+		}
+	}
+
+	b.named(codegenLocation{}, opts.TypeName)
+	root, _ := b.documents[""].(map[string]interface{})
+	for _, keyword := range []string{"$defs", "definitions"} {
+		definitions, _ := root[keyword].(map[string]interface{})
+		for _, name := range sortedKeys(definitions) {
+			b.named(codegenLocation{pointer: "/" + keyword + "/" + escapePointerToken(name)}, "")
+		}
+	}
+
+	if opts.Language == CodegenTypeScript {
+		return b.renderTypeScript(opts), nil
+	}
+	return b.renderGo(opts)
+}
+
+// codegenKind is the shape of a type expression
+type codegenKind int
+
+const (
+	codegenAny codegenKind = iota
+	codegenString
+	codegenInteger
+	codegenNumber
+	codegenBoolean
+	codegenArray
+	codegenMap
+	codegenUnion
+	codegenNamed
+)
+
+// codegenType is a type expression
+type codegenType struct {
+	kind     codegenKind
+	name     string         // codegenNamed
+	elem     *codegenType   // codegenArray and codegenMap
+	members  []*codegenType // codegenUnion
+	nullable bool
+}
+
+// codegenDecl is a named type
+type codegenDecl struct {
+	name        string
+	description []string
+
+	isStruct bool
+	fields   []codegenField
+
+	isEnum     bool
+	enum       []interface{}
+	constNames []string // Go constants of string enums
+
+	typ *codegenType // underlying type of enums and other named types
+}
+
+// codegenField is a member of a struct or interface
+type codegenField struct {
+	jsonName     string
+	typ          *codegenType
+	required     bool
+	description  []string
+	defaultValue string // JSON text; empty when there is no default
+}
+
+// codegenLocation is a subschema: a JSON Pointer into the root schema, or
+// into a registry schema when document is set
+type codegenLocation struct {
+	document string
+	pointer  string
+}
+
+// child returns the location of a member of the subschema
+func (l codegenLocation) child(tokens ...string) codegenLocation {
+	for _, token := range tokens {
+		l.pointer += "/" + escapePointerToken(token)
+	}
+	return l
+}
+
+// codegenBuilder collects the declarations of a schema
+type codegenBuilder struct {
+	language  CodegenLanguage
+	documents map[string]interface{}
+	decls     []*codegenDecl
+	names     map[string]string // location -> declared type name
+	taken     map[string]bool   // type and constant names in use
+}
+
+// named returns the named type declared for a location, declaring it
+// first if needed. An empty name is derived from the location.
+func (b *codegenBuilder) named(loc codegenLocation, name string) *codegenType {
+	key := loc.document + "#" + loc.pointer
+	if declared, ok := b.names[key]; ok {
+		return &codegenType{kind: codegenNamed, name: declared}
+	}
+	node, ok := pointerTarget(b.documents[loc.document], loc.pointer)
+	if !ok {
+		return &codegenType{kind: codegenAny}
+	}
+
+	// Types reached by reference carry their own description; inline types
+	// leave it to the field using them
+	describe := name == ""
+	if name == "" {
+		name = b.locationName(loc)
+	}
+	decl := &codegenDecl{name: b.unique(name)}
+	b.names[key] = decl.name
+	b.decls = append(b.decls, decl)
+
+	schema, _ := node.(map[string]interface{})
+	if describe || loc == (codegenLocation{}) {
+		decl.description = describeSchema(schema)
+	}
+	switch {
+	case isEnumSchema(schema):
+		b.fillEnum(decl, schema)
+	case b.isStructSchema(schema, loc, 0):
+		decl.isStruct = true
+		decl.fields = b.fields(schema, loc, decl.name)
+	default:
+		decl.typ = b.expr(schema, loc, decl.name)
+	}
+	return &codegenType{kind: codegenNamed, name: decl.name}
+}
+
+// locationName derives a type name from a location, prefixed with the
+// name of the registry schema holding it
+func (b *codegenBuilder) locationName(loc codegenLocation) string {
+	name := codegenName(loc.document)
+	if i := strings.LastIndex(loc.pointer, "/"); i >= 0 {
+		last := strings.ReplaceAll(strings.ReplaceAll(loc.pointer[i+1:], "~1", "/"), "~0", "~")
+		name += codegenName(last)
+	}
+	if name == "" {
+		return "Type"
+	}
+	return name
+}
+
+// unique returns name, or name with the lowest number suffix not in use
+func (b *codegenBuilder) unique(name string) string {
+	candidate := name
+	for i := 2; b.taken[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	b.taken[candidate] = true
+	return candidate
+}
+
+// typeOf returns the type of a subschema. Objects with properties and
+// enums are declared as types named hint.
+func (b *codegenBuilder) typeOf(node interface{}, loc codegenLocation, hint string) *codegenType {
+	schema, ok := node.(map[string]interface{})
+	if !ok {
+		return &codegenType{kind: codegenAny}
+	}
+	if _, ok := schema["$ref"].(string); ok {
+		return b.expr(schema, loc, hint)
+	}
+	if isEnumSchema(schema) || b.isStructSchema(schema, loc, 0) {
+		t := b.named(loc, hint)
+		_, t.nullable = nonNullTypes(schema)
+		if values, ok := enumValues(schema); ok && containsNull(values) {
+			t.nullable = true
+		}
+		return t
+	}
+	return b.expr(schema, loc, hint)
+}
+
+// expr returns the type expression of a subschema that is neither an enum
+// nor an object with properties
+func (b *codegenBuilder) expr(schema map[string]interface{}, loc codegenLocation, hint string) *codegenType {
+	if ref, ok := schema["$ref"].(string); ok {
+		if target, ok := b.resolveRef(ref, loc); ok {
+			return b.named(target, "")
+		}
+		return &codegenType{kind: codegenAny}
+	}
+
+	types, nullable := nonNullTypes(schema)
+	if len(types) == 0 {
+		for _, keyword := range []string{"oneOf", "anyOf"} {
+			if options, ok := schema[keyword].([]interface{}); ok {
+				t := b.union(options, loc.child(keyword), hint)
+				t.nullable = t.nullable || nullable
+				return t
+			}
+		}
+		types = inferTypes(schema)
+	}
+
+	var t *codegenType
+	if len(types) == 1 {
+		t = b.single(types[0], schema, loc, hint)
+	} else {
+		t = &codegenType{kind: codegenUnion}
+		for _, name := range types {
+			t.members = append(t.members, b.single(name, schema, loc, hint))
+		}
+		if len(types) == 0 {
+			t.kind = codegenAny
+		}
+	}
+	t.nullable = t.nullable || nullable
+	return t
+}
+
+// single returns the type expression of a subschema for one JSON type
+func (b *codegenBuilder) single(jsonType string, schema map[string]interface{}, loc codegenLocation, hint string) *codegenType {
+	switch jsonType {
+	case "string":
+		return &codegenType{kind: codegenString}
+	case "integer":
+		return &codegenType{kind: codegenInteger}
+	case "number":
+		return &codegenType{kind: codegenNumber}
+	case "boolean":
+		return &codegenType{kind: codegenBoolean}
+	case "array":
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok || schema["prefixItems"] != nil {
+			return &codegenType{kind: codegenArray, elem: &codegenType{kind: codegenAny}}
+		}
+		return &codegenType{kind: codegenArray, elem: b.typeOf(items, loc.child("items"), hint+"Item")}
+	case "object":
+		values, ok := schema["additionalProperties"].(map[string]interface{})
+		if !ok {
+			return &codegenType{kind: codegenMap, elem: &codegenType{kind: codegenAny}}
+		}
+		return &codegenType{kind: codegenMap, elem: b.typeOf(values, loc.child("additionalProperties"), hint+"Value")}
+	}
+	return &codegenType{kind: codegenAny}
+}
+
+// union returns the union of the oneOf or anyOf options of a subschema
+func (b *codegenBuilder) union(options []interface{}, loc codegenLocation, hint string) *codegenType {
+	t := &codegenType{kind: codegenUnion}
+	for i, option := range options {
+		name := hint + "Option" + strconv.Itoa(i+1)
+		if title, ok := option.(map[string]interface{})["title"].(string); ok && codegenName(title) != "" {
+			name = hint + codegenName(title)
+		}
+		member := b.typeOf(option, loc.child(strconv.Itoa(i)), name)
+		t.nullable = t.nullable || member.nullable
+		if member.kind == codegenAny && member.nullable {
+			// A null option only makes the union nullable
+			continue
+		}
+		t.members = append(t.members, member)
+	}
+	if len(t.members) == 1 {
+		member := *t.members[0]
+		member.nullable = t.nullable
+		return &member
+	}
+	return t
+}
+
+// fillEnum declares an enum type
+func (b *codegenBuilder) fillEnum(decl *codegenDecl, schema map[string]interface{}) {
+	values, _ := enumValues(schema)
+	decl.isEnum = true
+	decl.typ = &codegenType{kind: enumKind(values)}
+	for _, value := range values {
+		if value != nil {
+			decl.enum = append(decl.enum, value)
+		}
+	}
+
+	if b.language != CodegenGo || decl.typ.kind != codegenString {
+		return
+	}
+	for i, value := range decl.enum {
+		name := decl.name + codegenWords(value.(string))
+		if name == decl.name {
+			name += "Value" + strconv.Itoa(i+1)
+		}
+		decl.constNames = append(decl.constNames, b.unique(name))
+	}
+}
+
+// isStructSchema reports whether a subschema is an object with properties
+// of its own or from its allOf members
+func (b *codegenBuilder) isStructSchema(schema map[string]interface{}, loc codegenLocation, refDepth int) bool {
+	if schema == nil || refDepth > maxDefaultsRefDepth {
+		return false
+	}
+	if types, _ := nonNullTypes(schema); len(types) > 0 && (len(types) != 1 || types[0] != "object") {
+		return false
+	}
+	if properties, ok := schema["properties"].(map[string]interface{}); ok && len(properties) > 0 {
+		return true
+	}
+	allOf, _ := schema["allOf"].([]interface{})
+	for i, member := range allOf {
+		memberSchema, memberLoc := b.follow(member, loc.child("allOf", strconv.Itoa(i)))
+		if b.isStructSchema(memberSchema, memberLoc, refDepth+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// follow returns the subschema a member points at through $ref, if any
+func (b *codegenBuilder) follow(node interface{}, loc codegenLocation) (map[string]interface{}, codegenLocation) {
+	schema, _ := node.(map[string]interface{})
+	for depth := 0; depth < maxDefaultsRefDepth; depth++ {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			break
+		}
+		target, ok := b.resolveRef(ref, loc)
+		if !ok {
+			return nil, loc
+		}
+		targetNode, _ := pointerTarget(b.documents[target.document], target.pointer)
+		schema, _ = targetNode.(map[string]interface{})
+		loc = target
+	}
+	return schema, loc
+}
+
+// codegenProperty is a property collected for a struct
+type codegenProperty struct {
+	schema interface{}
+	loc    codegenLocation
+}
+
+// fields returns the fields of a struct, merging allOf members
+func (b *codegenBuilder) fields(schema map[string]interface{}, loc codegenLocation, structName string) []codegenField {
+	properties := make(map[string]codegenProperty)
+	required := make(map[string]bool)
+	b.collectProperties(schema, loc, properties, required, 0)
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]codegenField, 0, len(names))
+	for _, name := range names {
+		property := properties[name]
+		propertySchema, _ := property.schema.(map[string]interface{})
+		fields = append(fields, codegenField{
+			jsonName:     name,
+			typ:          b.typeOf(property.schema, property.loc, structName+codegenName(name)),
+			required:     required[name],
+			description:  describeSchema(propertySchema),
+			defaultValue: b.defaultText(property.schema, property.loc),
+		})
+	}
+	return fields
+}
+
+// collectProperties adds the properties and required names of a subschema
+// and its allOf members; the subschema's own properties win
+func (b *codegenBuilder) collectProperties(schema map[string]interface{}, loc codegenLocation, properties map[string]codegenProperty, required map[string]bool, refDepth int) {
+	if schema == nil || refDepth > maxDefaultsRefDepth {
+		return
+	}
+	allOf, _ := schema["allOf"].([]interface{})
+	for i, member := range allOf {
+		memberSchema, memberLoc := b.follow(member, loc.child("allOf", strconv.Itoa(i)))
+		b.collectProperties(memberSchema, memberLoc, properties, required, refDepth+1)
+	}
+
+	own, _ := schema["properties"].(map[string]interface{})
+	for name, property := range own {
+		properties[name] = codegenProperty{schema: property, loc: loc.child("properties", name)}
+	}
+	names, _ := schema["required"].([]interface{})
+	for _, name := range names {
+		if name, ok := name.(string); ok {
+			required[name] = true
+		}
+	}
+}
+
+// defaultText returns the default of a subschema as JSON, following $refs
+func (b *codegenBuilder) defaultText(node interface{}, loc codegenLocation) string {
+	schema, _ := node.(map[string]interface{})
+	value, ok := schema["default"]
+	if !ok {
+		target, _ := b.follow(schema, loc)
+		if value, ok = target["default"]; !ok {
+			return ""
+		}
+	}
+	text, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// resolveRef returns the location a $ref points at. Only JSON Pointer
+// fragments are followed.
+func (b *codegenBuilder) resolveRef(ref string, loc codegenLocation) (codegenLocation, bool) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return codegenLocation{}, false
+	}
+	document := loc.document
+	if !strings.HasPrefix(ref, "#") {
+		name, err := referencedSchema(ref)
+		if err != nil || name == "" {
+			return codegenLocation{}, false
+		}
+		document = name
+	}
+	if _, ok := b.documents[document]; !ok {
+		return codegenLocation{}, false
+	}
+	if u.Fragment != "" && !strings.HasPrefix(u.Fragment, "/") {
+		return codegenLocation{}, false
+	}
+	return codegenLocation{document: document, pointer: u.Fragment}, true
+}
+
+// renderGo writes the declarations as a gofmt-ed Go file
+func (b *codegenBuilder) renderGo(opts CodegenOptions) ([]byte, error) {
+	var out bytes.Buffer
+	writeCodegenHeader(&out, opts)
+	fmt.Fprintf(&out, "\npackage %s\n", opts.Package)
+
+	for _, decl := range b.decls {
+		out.WriteString("\n")
+		description := decl.description
+		if decl.isEnum && decl.typ.kind != codegenString && len(decl.enum) > 0 {
+			description = append(description[:len(description):len(description)], "One of: "+joinJSON(decl.enum, ", ")+".")
+		}
+		writeGoComment(&out, "", description)
+
+		switch {
+		case decl.isStruct:
+			fmt.Fprintf(&out, "type %s struct {\n", decl.name)
+			used := make(map[string]bool)
+			for i, field := range decl.fields {
+				b.writeGoField(&out, field, used, i > 0)
+			}
+			out.WriteString("}\n")
+
+		case len(decl.constNames) > 0:
+			fmt.Fprintf(&out, "type %s string\n\nconst (\n", decl.name)
+			for i, value := range decl.enum {
+				fmt.Fprintf(&out, "\t%s %s = %s\n", decl.constNames[i], decl.name, strconv.Quote(value.(string)))
+			}
+			out.WriteString(")\n")
+
+		default:
+			fmt.Fprintf(&out, "type %s %s\n", decl.name, goType(decl.typ))
+		}
+	}
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated Go code does not parse: %w", err)
+	}
+	return source, nil
+}
+
+// writeGoField writes a struct field, separated from the previous one by a
+// blank line when it has a comment
+func (b *codegenBuilder) writeGoField(out *bytes.Buffer, field codegenField, used map[string]bool, separate bool) {
+	comment := field.description
+	if field.defaultValue != "" {
+		if len(comment) > 0 {
+			comment = append(comment[:len(comment):len(comment)], "")
+		}
+		comment = append(comment, "Default: "+field.defaultValue)
+	}
+	tag := field.jsonName
+	if !isValidJSONTag(tag) {
+		comment = append(comment, fmt.Sprintf("Not decoded: property %q cannot be named in a struct tag.", field.jsonName))
+		tag = "-"
+	} else if !field.required {
+		tag += ",omitempty"
+	}
+	if separate && len(comment) > 0 {
+		out.WriteString("\n")
+	}
+	writeGoComment(out, "\t", comment)
+
+	name := codegenName(field.jsonName)
+	if name == "" {
+		name = "Field"
+	}
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	used[candidate] = true
+
+	typ := goType(field.typ)
+	if (!field.required || field.typ.nullable) && !b.hasNilValue(field.typ, 0) {
+		typ = "*" + typ
+	}
+	fmt.Fprintf(out, "\t%s %s `json:%s`\n", candidate, typ, strconv.Quote(tag))
+}
+
+// hasNilValue reports whether a Go type can already hold nil, so optional
+// fields of it need no pointer
+func (b *codegenBuilder) hasNilValue(t *codegenType, depth int) bool {
+	switch t.kind {
+	case codegenAny, codegenUnion, codegenArray, codegenMap:
+		return true
+	case codegenNamed:
+		for _, decl := range b.decls {
+			if decl.name == t.name {
+				return !decl.isStruct && !decl.isEnum && depth < maxDefaultsRefDepth && b.hasNilValue(decl.typ, depth+1)
+			}
+		}
+	}
+	return false
+}
+
+// goType returns the Go spelling of a type expression
+func goType(t *codegenType) string {
+	switch t.kind {
+	case codegenString:
+		return "string"
+	case codegenInteger:
+		return "int64"
+	case codegenNumber:
+		return "float64"
+	case codegenBoolean:
+		return "bool"
+	case codegenArray:
+		return "[]" + goType(t.elem)
+	case codegenMap:
+		return "map[string]" + goType(t.elem)
+	case codegenNamed:
+		return t.name
+	}
+	return "any"
+}
+
+// writeGoComment writes lines as a Go comment
+func writeGoComment(out *bytes.Buffer, indent string, lines []string) {
+	for _, line := range lines {
+		if line == "" {
+			fmt.Fprintf(out, "%s//\n", indent)
+			continue
+		}
+		fmt.Fprintf(out, "%s// %s\n", indent, line)
+	}
+}
+
+// renderTypeScript writes the declarations as a TypeScript module
+func (b *codegenBuilder) renderTypeScript(opts CodegenOptions) []byte {
+	var out bytes.Buffer
+	writeCodegenHeader(&out, opts)
+
+	for _, decl := range b.decls {
+		out.WriteString("\n")
+		writeTSDoc(&out, "", decl.description, "")
+
+		switch {
+		case decl.isStruct:
+			fmt.Fprintf(&out, "export interface %s {\n", decl.name)
+			for _, field := range decl.fields {
+				writeTSDoc(&out, "  ", field.description, field.defaultValue)
+				optional := ""
+				if !field.required {
+					optional = "?"
+				}
+				fmt.Fprintf(&out, "  %s%s: %s;\n", tsPropertyName(field.jsonName), optional, tsType(field.typ, true))
+			}
+			out.WriteString("}\n")
+
+		case decl.isEnum:
+			literals := "never"
+			if len(decl.enum) > 0 {
+				literals = joinJSON(decl.enum, " | ")
+			}
+			fmt.Fprintf(&out, "export type %s = %s;\n", decl.name, literals)
+
+		default:
+			fmt.Fprintf(&out, "export type %s = %s;\n", decl.name, tsType(decl.typ, true))
+		}
+	}
+	return out.Bytes()
+}
+
+// tsType returns the TypeScript spelling of a type expression, with
+// "| null" for nullable types when withNull is set
+func tsType(t *codegenType, withNull bool) string {
+	var spelled string
+	switch t.kind {
+	case codegenString:
+		spelled = "string"
+	case codegenInteger, codegenNumber:
+		spelled = "number"
+	case codegenBoolean:
+		spelled = "boolean"
+	case codegenArray:
+		elem := tsType(t.elem, true)
+		if strings.Contains(elem, " | ") {
+			elem = "(" + elem + ")"
+		}
+		spelled = elem + "[]"
+	case codegenMap:
+		spelled = "Record<string, " + tsType(t.elem, true) + ">"
+	case codegenUnion:
+		members := make([]string, 0, len(t.members))
+		seen := make(map[string]bool)
+		for _, member := range t.members {
+			if spelled := tsType(member, false); !seen[spelled] {
+				seen[spelled] = true
+				members = append(members, spelled)
+			}
+		}
+		spelled = strings.Join(members, " | ")
+		if spelled == "" {
+			spelled = "unknown"
+		}
+	case codegenNamed:
+		spelled = t.name
+	default:
+		return "unknown"
+	}
+	if withNull && t.nullable {
+		spelled += " | null"
+	}
+	return spelled
+}
+
+// writeTSDoc writes a description and default as a JSDoc comment
+func writeTSDoc(out *bytes.Buffer, indent string, description []string, defaultValue string) {
+	lines := description
+	if defaultValue != "" {
+		if len(lines) > 0 {
+			lines = append(lines[:len(lines):len(lines)], "")
+		}
+		lines = append(lines, "@default "+defaultValue)
+	}
+	if len(lines) == 0 {
+		return
+	}
+	if len(lines) == 1 {
+		fmt.Fprintf(out, "%s/** %s */\n", indent, escapeTSComment(lines[0]))
+		return
+	}
+
+	fmt.Fprintf(out, "%s/**\n", indent)
+	for _, line := range lines {
+		if line == "" {
+			fmt.Fprintf(out, "%s *\n", indent)
+			continue
+		}
+		fmt.Fprintf(out, "%s * %s\n", indent, escapeTSComment(line))
+	}
+	fmt.Fprintf(out, "%s */\n", indent)
+}
+
+// escapeTSComment keeps text from closing a block comment
+func escapeTSComment(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
+
+// tsPropertyName quotes property names that are not identifiers
+func tsPropertyName(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return strconv.Quote(name)
+		}
+	}
+	if name == "" {
+		return `""`
+	}
+	return name
+}
+
+// writeCodegenHeader writes the generated-code notice of a file
+func writeCodegenHeader(out *bytes.Buffer, opts CodegenOptions) {
+	out.WriteString("// Code generated by cfguardian codegen. DO NOT EDIT.\n")
+	if opts.Source != "" {
+		fmt.Fprintf(out, "// Source: %s\n", strings.ReplaceAll(opts.Source, "\n", " "))
+	}
+}
+
+// codegenInitialisms are written in upper case in generated names
+var codegenInitialisms = map[string]bool{
+	"API": true, "CIDR": true, "CPU": true, "DNS": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "JWT": true,
+	"SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true,
+	"UDP": true, "UI": true, "URI": true, "URL": true, "UUID": true,
+	"XML": true, "YAML": true,
+}
+
+// CodegenTypeName returns the root type name generated for a schema name,
+// such as PaymentsAPI for "payments-api"; Config for an empty name
+func CodegenTypeName(schemaName string) string {
+	if name := codegenName(schemaName); name != "" {
+		return name
+	}
+	return "Config"
+}
+
+// codegenName turns a property or schema name into an exported
+// identifier: "max_conns" and "maxConns" become MaxConns, "api-url" APIURL
+func codegenName(s string) string {
+	name := codegenWords(s)
+	if name != "" && !token.IsExported(name) {
+		return "X" + name
+	}
+	return name
+}
+
+// codegenWords joins the words of s capitalized, as a name suffix
+func codegenWords(s string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	var name strings.Builder
+	for _, w := range words {
+		if upper := strings.ToUpper(w); codegenInitialisms[upper] {
+			name.WriteString(upper)
+			continue
+		}
+		letters := []rune(w)
+		letters[0] = unicode.ToUpper(letters[0])
+		name.WriteString(string(letters))
+	}
+	return name.String()
+}
+
+// describeSchema returns the description of a subschema, or its title,
+// as lines
+func describeSchema(schema map[string]interface{}) []string {
+	text, ok := schema["description"].(string)
+	if !ok {
+		text, _ = schema["title"].(string)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return lines
+}
+
+// nonNullTypes returns the JSON types a subschema declares other than
+// null, and whether null is one of them
+func nonNullTypes(schema map[string]interface{}) ([]string, bool) {
+	declared, _ := schemaTypes(schema)
+	types := make([]string, 0, len(declared))
+	nullable := false
+	for _, name := range declared {
+		if name == "null" {
+			nullable = true
+			continue
+		}
+		types = append(types, name)
+	}
+	return types, nullable
+}
+
+// inferTypes guesses the type of a subschema without one from its keywords
+func inferTypes(schema map[string]interface{}) []string {
+	for _, keyword := range []string{"properties", "additionalProperties", "required"} {
+		if _, ok := schema[keyword]; ok {
+			return []string{"object"}
+		}
+	}
+	for _, keyword := range []string{"items", "prefixItems"} {
+		if _, ok := schema[keyword]; ok {
+			return []string{"array"}
+		}
+	}
+	return nil
+}
+
+// isEnumSchema reports whether a subschema restricts values with enum or const
+func isEnumSchema(schema map[string]interface{}) bool {
+	_, ok := enumValues(schema)
+	return ok
+}
+
+// enumValues returns the values an enum or const allows
+func enumValues(schema map[string]interface{}) ([]interface{}, bool) {
+	if values, ok := schema["enum"].([]interface{}); ok {
+		return values, true
+	}
+	if value, ok := schema["const"]; ok {
+		return []interface{}{value}, true
+	}
+	return nil, false
+}
+
+// containsNull reports whether null is one of values
+func containsNull(values []interface{}) bool {
+	for _, value := range values {
+		if value == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// enumKind returns the type shared by the non-null values of an enum
+func enumKind(values []interface{}) codegenKind {
+	kind := codegenAny
+	for _, value := range values {
+		var valueKind codegenKind
+		switch typed := value.(type) {
+		case nil:
+			continue
+		case string:
+			valueKind = codegenString
+		case bool:
+			valueKind = codegenBoolean
+		case json.Number:
+			valueKind = codegenNumber
+			if isJSONInteger(typed) {
+				valueKind = codegenInteger
+			}
+		default:
+			return codegenAny
+		}
+
+		switch {
+		case kind == codegenAny:
+			kind = valueKind
+		case kind == valueKind:
+		case (kind == codegenInteger || kind == codegenNumber) && (valueKind == codegenInteger || valueKind == codegenNumber):
+			kind = codegenNumber
+		default:
+			return codegenAny
+		}
+	}
+	return kind
+}
+
+// joinJSON joins values written as JSON
+func joinJSON(values []interface{}, sep string) string {
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		text, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		texts = append(texts, string(text))
+	}
+	return strings.Join(texts, sep)
+}
+
+// isValidJSONTag reports whether encoding/json accepts name in a struct tag
+func isValidJSONTag(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r):
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchemaCodeGenerator_Golden generates every testdata/codegen/*.json
+// schema in every language and compares it with the golden file next to it.
+// Run with -update to rewrite the golden files.
+func TestSchemaCodeGenerator_Golden(t *testing.T) {
+	generator := NewSchemaCodeGenerator(nil)
+
+	inputs, err := filepath.Glob(filepath.Join("testdata", "codegen", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	for _, input := range inputs {
+		content, err := os.ReadFile(input)
+		require.NoError(t, err)
+		name := strings.TrimSuffix(filepath.Base(input), ".json")
+
+		for _, language := range []CodegenLanguage{CodegenGo, CodegenTypeScript} {
+			golden := strings.TrimSuffix(input, ".json") + language.FileExtension()
+
+			t.Run(filepath.Base(golden), func(t *testing.T) {
+				opts := CodegenOptions{
+					Language: language,
+					TypeName: codegenName(name),
+					Source:   "testdata/codegen/" + name + ".json",
+				}
+
+				// Act
+				got, err := generator.Generate(context.Background(), string(content), opts)
+				require.NoError(t, err)
+
+				if *updateGolden {
+					require.NoError(t, os.WriteFile(golden, got, 0644))
+				}
+
+				// Assert
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				assert.Equal(t, string(want), string(got))
+
+				again, err := generator.Generate(context.Background(), string(content), opts)
+				require.NoError(t, err)
+				assert.Equal(t, got, again, "generation must be deterministic")
+			})
+		}
+	}
+}
+
+func TestSchemaCodeGenerator_Generate_RegistryReferences(t *testing.T) {
+	// Arrange
+	generator := NewSchemaCodeGenerator(registry{
+		"tls": `{"definitions": {"certificate": {"type": "object", "required": ["cert"], "properties": {"cert": {"type": "string"}, "key": {"type": "string"}}}}}`,
+	})
+	schema := `{"properties": {"cert": {"$ref": "cfguardian://schemas/tls#/definitions/certificate"}}}`
+
+	// Act
+	got, err := generator.Generate(context.Background(), schema, CodegenOptions{Language: CodegenTypeScript})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "  cert?: TLSCertificate;\n")
+	assert.Contains(t, string(got), "export interface TLSCertificate {\n  cert: string;\n  key?: string;\n}\n")
+}
+
+func TestSchemaCodeGenerator_Generate_Errors(t *testing.T) {
+	generator := NewSchemaCodeGenerator(nil)
+
+	tests := []struct {
+		name          string
+		schema        string
+		opts          CodegenOptions
+		errorContains string
+	}{
+		{"unknown language", `{}`, CodegenOptions{Language: "rust"}, "unsupported language"},
+		{"unexported type name", `{}`, CodegenOptions{Language: CodegenGo, TypeName: "config"}, "not an exported identifier"},
+		{"invalid package", `{}`, CodegenOptions{Language: CodegenGo, Package: "my-config"}, "not an identifier"},
+		{"invalid schema", `{`, CodegenOptions{Language: CodegenGo}, "not valid JSON"},
+		{"registry reference without a registry", `{"$ref": "cfguardian://schemas/tls"}`, CodegenOptions{Language: CodegenGo}, "no schema registry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generator.Generate(context.Background(), tt.schema, tt.opts)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestSchemaCodeGenerator_Generate_Names(t *testing.T) {
+	generator := NewSchemaCodeGenerator(nil)
+	schema := `{"properties": {"a_b": {"type": "string"}, "aB": {"type": "integer"}, "9lives": {"type": "boolean"}, "my key": {"type": "string"}, "quote\"d": {"type": "string"}}}`
+
+	t.Run("go fields are unique and exported", func(t *testing.T) {
+		got, err := generator.Generate(context.Background(), schema, CodegenOptions{Language: CodegenGo})
+
+		require.NoError(t, err)
+		assert.Equal(t, "// Code generated by cfguardian codegen. DO NOT EDIT.\n\npackage config\n\n"+
+			"type Config struct {\n"+
+			"\tX9lives *bool   `json:\"9lives,omitempty\"`\n"+
+			"\tAB      *int64  `json:\"aB,omitempty\"`\n"+
+			"\tAB2     *string `json:\"a_b,omitempty\"`\n"+
+			"\tMyKey   *string `json:\"my key,omitempty\"`\n\n"+
+			"\t// Not decoded: property \"quote\\\"d\" cannot be named in a struct tag.\n"+
+			"\tQuoteD *string `json:\"-\"`\n"+
+			"}\n", string(got))
+	})
+
+	t.Run("typescript quotes names that are not identifiers", func(t *testing.T) {
+		got, err := generator.Generate(context.Background(), schema, CodegenOptions{Language: CodegenTypeScript})
+
+		require.NoError(t, err)
+		assert.Contains(t, string(got), "  \"9lives\"?: boolean;\n")
+		assert.Contains(t, string(got), "  \"my key\"?: string;\n")
+		assert.Contains(t, string(got), "  a_b?: string;\n")
+	})
+}
+
+func TestParseCodegenLanguage(t *testing.T) {
+	for input, want := range map[string]CodegenLanguage{"go": CodegenGo, "Go": CodegenGo, "typescript": CodegenTypeScript, "ts": CodegenTypeScript} {
+		got, err := ParseCodegenLanguage(input)
+
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseCodegenLanguage("python")
+	assert.Error(t, err)
+}
@@ -0,0 +1,438 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Schema change kinds
+const (
+	SchemaChangePropertyAdded       = "property_added"
+	SchemaChangePropertyRemoved     = "property_removed"
+	SchemaChangeRequiredAdded       = "required_added"
+	SchemaChangeRequiredRemoved     = "required_removed"
+	SchemaChangeTypeNarrowed        = "type_narrowed"
+	SchemaChangeTypeWidened         = "type_widened"
+	SchemaChangeEnumValuesAdded     = "enum_values_added"
+	SchemaChangeEnumValuesRemoved   = "enum_values_removed"
+	SchemaChangeConstraintTightened = "constraint_tightened"
+	SchemaChangeConstraintRelaxed   = "constraint_relaxed"
+	SchemaChangeKeywordChanged      = "keyword_changed"
+)
+
+// Schema change classifications
+const (
+	SchemaCompatible = "backward_compatible"
+	SchemaBreaking   = "breaking"
+)
+
+// SchemaChange is one difference between two versions of a schema.
+// Pointer is the JSON Pointer of the changed keyword in the schema.
+// A change is breaking when content valid against the old schema can
+// fail the new one.
+type SchemaChange struct {
+	Pointer     string `json:"pointer"`
+	Keyword     string `json:"keyword"`
+	Kind        string `json:"kind"`
+	Breaking    bool   `json:"breaking"`
+	Description string `json:"description"`
+}
+
+// SchemaCompatibility classifies a schema change
+type SchemaCompatibility struct {
+	Classification string         `json:"classification"`
+	Changes        []SchemaChange `json:"changes"`
+}
+
+// Breaking reports whether any change is breaking
+func (sc *SchemaCompatibility) Breaking() bool {
+	return sc.Classification == SchemaBreaking
+}
+
+// SchemaCompatibilityChecker compares versions of a JSON Schema
+type SchemaCompatibilityChecker struct{}
+
+// NewSchemaCompatibilityChecker creates a new SchemaCompatibilityChecker
+func NewSchemaCompatibilityChecker() *SchemaCompatibilityChecker {
+	return &SchemaCompatibilityChecker{}
+}
+
+// lowerBoundKeywords tighten when they grow; upperBoundKeywords when they shrink
+var (
+	lowerBoundKeywords = []string{"minimum", "exclusiveMinimum", "minLength", "minItems", "minProperties"}
+	upperBoundKeywords = []string{"maximum", "exclusiveMaximum", "maxLength", "maxItems", "maxProperties"}
+)
+
+// valueKeywords tighten when added or changed and relax when removed
+var valueKeywords = []string{"const", "pattern", "format", "multipleOf"}
+
+// annotationKeywords never affect validation
+var annotationKeywords = map[string]bool{
+	"$schema": true, "$id": true, "id": true, "$comment": true,
+	"title": true, "description": true, "default": true, "examples": true,
+	"readOnly": true, "writeOnly": true, "deprecated": true,
+}
+
+// comparedKeywords are the keywords the checker classifies itself
+var comparedKeywords = func() map[string]bool {
+	keywords := map[string]bool{
+		"type": true, "enum": true, "required": true, "properties": true,
+		"additionalProperties": true, "items": true,
+	}
+	for _, group := range [][]string{lowerBoundKeywords, upperBoundKeywords, valueKeywords} {
+		for _, keyword := range group {
+			keywords[keyword] = true
+		}
+	}
+	return keywords
+}()
+
+// Compare classifies the change from current to candidate. It walks
+// properties, items and the usual assertions: a new required property, a
+// removed property, a narrowed type, removed enum values or a tightened
+// bound are breaking. Any other keyword that changes, such as $ref or a
+// combinator, is reported as breaking since its effect is not analysed.
+func (scc *SchemaCompatibilityChecker) Compare(current, candidate string) (*SchemaCompatibility, error) {
+	oldSchema, err := decodeDiffValue(json.RawMessage(current))
+	if err != nil {
+		return nil, fmt.Errorf("invalid current schema: %w", err)
+	}
+	newSchema, err := decodeDiffValue(json.RawMessage(candidate))
+	if err != nil {
+		return nil, fmt.Errorf("invalid candidate schema: %w", err)
+	}
+
+	changes := make([]SchemaChange, 0)
+	compareSchemas("", oldSchema, newSchema, &changes)
+
+	result := &SchemaCompatibility{Classification: SchemaCompatible, Changes: changes}
+	for _, change := range changes {
+		if change.Breaking {
+			result.Classification = SchemaBreaking
+			break
+		}
+	}
+	return result, nil
+}
+
+// compareSchemas appends the changes between two decoded schemas
+func compareSchemas(pointer string, oldValue, newValue interface{}, changes *[]SchemaChange) {
+	add := func(keyword, kind string, breaking bool, format string, args ...interface{}) {
+		*changes = append(*changes, SchemaChange{
+			Pointer:     pointer + "/" + escapePointerToken(keyword),
+			Keyword:     keyword,
+			Kind:        kind,
+			Breaking:    breaking,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	oldSchema, oldIsObject := oldValue.(map[string]interface{})
+	newSchema, newIsObject := newValue.(map[string]interface{})
+	if !oldIsObject || !newIsObject {
+		// Boolean schemas: true accepts everything and false nothing
+		if bytes.Equal(encodeDiffValue(oldValue), encodeDiffValue(newValue)) {
+			return
+		}
+		*changes = append(*changes, SchemaChange{
+			Pointer:     pointer,
+			Kind:        SchemaChangeKeywordChanged,
+			Breaking:    newValue != true && oldValue != false,
+			Description: "schema replaced",
+		})
+		return
+	}
+
+	compareTypes(oldSchema, newSchema, add)
+	compareEnums(oldSchema, newSchema, add)
+	compareBounds(oldSchema, newSchema, add)
+	compareRequired(pointer, oldSchema, newSchema, changes)
+	compareProperties(pointer, oldSchema, newSchema, changes)
+	compareAdditionalProperties(oldSchema, newSchema, add)
+
+	if oldItems, ok := oldSchema["items"]; ok {
+		if newItems, ok := newSchema["items"]; ok {
+			compareSchemas(pointer+"/items", oldItems, newItems, changes)
+		} else {
+			add("items", SchemaChangeConstraintRelaxed, false, "item schema removed")
+		}
+	} else if _, ok := newSchema["items"]; ok {
+		add("items", SchemaChangeConstraintTightened, true, "item schema added")
+	}
+
+	// Keywords not analysed above break when they change at all
+	keywords := make(map[string]interface{}, len(oldSchema)+len(newSchema))
+	for keyword := range oldSchema {
+		keywords[keyword] = nil
+	}
+	for keyword := range newSchema {
+		keywords[keyword] = nil
+	}
+	for _, keyword := range sortedKeys(keywords) {
+		if comparedKeywords[keyword] || annotationKeywords[keyword] {
+			continue
+		}
+		oldKeyword, inOld := oldSchema[keyword]
+		newKeyword, inNew := newSchema[keyword]
+		if inOld && inNew && bytes.Equal(encodeDiffValue(oldKeyword), encodeDiffValue(newKeyword)) {
+			continue
+		}
+		add(keyword, SchemaChangeKeywordChanged, true, "%s changed", keyword)
+	}
+}
+
+// compareTypes classifies a change of the type keyword. A missing type
+// accepts every type, and integer is a subset of number.
+func compareTypes(oldSchema, newSchema map[string]interface{}, add func(string, string, bool, string, ...interface{})) {
+	oldTypes, oldOK := schemaTypes(oldSchema)
+	newTypes, newOK := schemaTypes(newSchema)
+	if !oldOK && !newOK {
+		return
+	}
+	if !newOK {
+		add("type", SchemaChangeTypeWidened, false, "type no longer restricted")
+		return
+	}
+	if !oldOK {
+		add("type", SchemaChangeTypeNarrowed, true, "type restricted to %s", strings.Join(newTypes, ", "))
+		return
+	}
+
+	var removed, added []string
+	for _, t := range oldTypes {
+		if !typeAccepted(t, newTypes) {
+			removed = append(removed, t)
+		}
+	}
+	for _, t := range newTypes {
+		if !typeAccepted(t, oldTypes) {
+			added = append(added, t)
+		}
+	}
+	switch {
+	case len(removed) > 0:
+		add("type", SchemaChangeTypeNarrowed, true, "type no longer accepts %s", strings.Join(removed, ", "))
+	case len(added) > 0:
+		add("type", SchemaChangeTypeWidened, false, "type now accepts %s", strings.Join(added, ", "))
+	}
+}
+
+// schemaTypes returns the sorted types a schema declares
+func schemaTypes(schema map[string]interface{}) ([]string, bool) {
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			if name, ok := item.(string); ok {
+				types = append(types, name)
+			}
+		}
+	default:
+		return nil, false
+	}
+	sort.Strings(types)
+	return types, true
+}
+
+// typeAccepted reports whether every value of type t is one of types
+func typeAccepted(t string, types []string) bool {
+	for _, candidate := range types {
+		if candidate == t || (t == "integer" && candidate == "number") {
+			return true
+		}
+	}
+	return false
+}
+
+// compareEnums classifies added and removed enum values
+func compareEnums(oldSchema, newSchema map[string]interface{}, add func(string, string, bool, string, ...interface{})) {
+	oldEnum, oldOK := oldSchema["enum"].([]interface{})
+	newEnum, newOK := newSchema["enum"].([]interface{})
+	switch {
+	case !oldOK && !newOK:
+		return
+	case !newOK:
+		add("enum", SchemaChangeConstraintRelaxed, false, "enum removed")
+		return
+	case !oldOK:
+		add("enum", SchemaChangeConstraintTightened, true, "enum added")
+		return
+	}
+
+	removed := enumDifference(oldEnum, newEnum)
+	added := enumDifference(newEnum, oldEnum)
+	if len(removed) > 0 {
+		add("enum", SchemaChangeEnumValuesRemoved, true, "enum values removed: %s", strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		add("enum", SchemaChangeEnumValuesAdded, false, "enum values added: %s", strings.Join(added, ", "))
+	}
+}
+
+// enumDifference returns the encoded values of a that are not in b
+func enumDifference(a, b []interface{}) []string {
+	inB := make(map[string]bool, len(b))
+	for _, value := range b {
+		inB[string(encodeDiffValue(value))] = true
+	}
+	var difference []string
+	for _, value := range a {
+		if encoded := string(encodeDiffValue(value)); !inB[encoded] {
+			difference = append(difference, encoded)
+		}
+	}
+	return difference
+}
+
+// compareBounds classifies changes of numeric bounds and value assertions
+func compareBounds(oldSchema, newSchema map[string]interface{}, add func(string, string, bool, string, ...interface{})) {
+	for _, keyword := range lowerBoundKeywords {
+		compareBound(keyword, oldSchema, newSchema, 1, add)
+	}
+	for _, keyword := range upperBoundKeywords {
+		compareBound(keyword, oldSchema, newSchema, -1, add)
+	}
+
+	for _, keyword := range valueKeywords {
+		oldKeyword, inOld := oldSchema[keyword]
+		newKeyword, inNew := newSchema[keyword]
+		switch {
+		case inOld && !inNew:
+			add(keyword, SchemaChangeConstraintRelaxed, false, "%s removed", keyword)
+		case !inOld && inNew:
+			add(keyword, SchemaChangeConstraintTightened, true, "%s %s added", keyword, encodeDiffValue(newKeyword))
+		case inOld && inNew && !bytes.Equal(encodeDiffValue(oldKeyword), encodeDiffValue(newKeyword)):
+			add(keyword, SchemaChangeConstraintTightened, true, "%s changed from %s to %s", keyword, encodeDiffValue(oldKeyword), encodeDiffValue(newKeyword))
+		}
+	}
+}
+
+// compareBound classifies a change of one bound; tighter is the sign of
+// the comparison that makes the new bound stricter
+func compareBound(keyword string, oldSchema, newSchema map[string]interface{}, tighter int, add func(string, string, bool, string, ...interface{})) {
+	oldBound, oldOK := schemaNumber(oldSchema[keyword])
+	newBound, newOK := schemaNumber(newSchema[keyword])
+	switch {
+	case !oldOK && !newOK:
+	case !newOK:
+		add(keyword, SchemaChangeConstraintRelaxed, false, "%s %s removed", keyword, oldBound.RatString())
+	case !oldOK:
+		add(keyword, SchemaChangeConstraintTightened, true, "%s %s added", keyword, newBound.RatString())
+	case newBound.Cmp(oldBound) == tighter:
+		add(keyword, SchemaChangeConstraintTightened, true, "%s changed from %s to %s", keyword, oldBound.RatString(), newBound.RatString())
+	case newBound.Cmp(oldBound) == -tighter:
+		add(keyword, SchemaChangeConstraintRelaxed, false, "%s changed from %s to %s", keyword, oldBound.RatString(), newBound.RatString())
+	}
+}
+
+// schemaNumber reads a numeric keyword value; draft 4 boolean
+// exclusiveMinimum and exclusiveMaximum are not numbers
+func schemaNumber(value interface{}) (*big.Rat, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetString(number.String())
+}
+
+// compareRequired classifies added and removed required properties
+func compareRequired(pointer string, oldSchema, newSchema map[string]interface{}, changes *[]SchemaChange) {
+	oldRequired := stringSet(oldSchema["required"])
+	newRequired := stringSet(newSchema["required"])
+
+	for _, name := range sortedKeys(newRequired) {
+		if _, ok := oldRequired[name]; !ok {
+			*changes = append(*changes, SchemaChange{
+				Pointer:     pointer + "/required",
+				Keyword:     "required",
+				Kind:        SchemaChangeRequiredAdded,
+				Breaking:    true,
+				Description: fmt.Sprintf("property %q is now required", name),
+			})
+		}
+	}
+	for _, name := range sortedKeys(oldRequired) {
+		if _, ok := newRequired[name]; !ok {
+			*changes = append(*changes, SchemaChange{
+				Pointer:     pointer + "/required",
+				Keyword:     "required",
+				Kind:        SchemaChangeRequiredRemoved,
+				Description: fmt.Sprintf("property %q is no longer required", name),
+			})
+		}
+	}
+}
+
+// stringSet returns the strings of a JSON array as a set
+func stringSet(value interface{}) map[string]interface{} {
+	set := make(map[string]interface{})
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		if name, ok := item.(string); ok {
+			set[name] = nil
+		}
+	}
+	return set
+}
+
+// compareProperties classifies added and removed properties and compares
+// the schemas of properties in both versions
+func compareProperties(pointer string, oldSchema, newSchema map[string]interface{}, changes *[]SchemaChange) {
+	oldProperties, _ := oldSchema["properties"].(map[string]interface{})
+	newProperties, _ := newSchema["properties"].(map[string]interface{})
+
+	names := make(map[string]interface{}, len(oldProperties)+len(newProperties))
+	for name := range oldProperties {
+		names[name] = nil
+	}
+	for name := range newProperties {
+		names[name] = nil
+	}
+	for _, name := range sortedKeys(names) {
+		propertyPointer := pointer + "/properties/" + escapePointerToken(name)
+		oldProperty, inOld := oldProperties[name]
+		newProperty, inNew := newProperties[name]
+		switch {
+		case !inNew:
+			*changes = append(*changes, SchemaChange{
+				Pointer:     propertyPointer,
+				Keyword:     "properties",
+				Kind:        SchemaChangePropertyRemoved,
+				Breaking:    true,
+				Description: fmt.Sprintf("property %q removed", name),
+			})
+		case !inOld:
+			*changes = append(*changes, SchemaChange{
+				Pointer:     propertyPointer,
+				Keyword:     "properties",
+				Kind:        SchemaChangePropertyAdded,
+				Description: fmt.Sprintf("property %q added", name),
+			})
+		default:
+			compareSchemas(propertyPointer, oldProperty, newProperty, changes)
+		}
+	}
+}
+
+// compareAdditionalProperties classifies allowing or disallowing
+// properties that are not declared
+func compareAdditionalProperties(oldSchema, newSchema map[string]interface{}, add func(string, string, bool, string, ...interface{})) {
+	oldAllowed := oldSchema["additionalProperties"] != false
+	newAllowed := newSchema["additionalProperties"] != false
+	switch {
+	case oldAllowed && !newAllowed:
+		add("additionalProperties", SchemaChangeConstraintTightened, true, "undeclared properties no longer allowed")
+	case !oldAllowed && newAllowed:
+		add("additionalProperties", SchemaChangeConstraintRelaxed, false, "undeclared properties now allowed")
+	case oldAllowed && newAllowed && !bytes.Equal(encodeDiffValue(oldSchema["additionalProperties"]), encodeDiffValue(newSchema["additionalProperties"])):
+		if newSchema["additionalProperties"] != nil && newSchema["additionalProperties"] != true {
+			add("additionalProperties", SchemaChangeKeywordChanged, true, "additionalProperties changed")
+		}
+	}
+}
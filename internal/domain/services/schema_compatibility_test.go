@@ -0,0 +1,103 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCompatibilityChecker_Compare(t *testing.T) {
+	const base = `{
+		"type": "object",
+		"properties": {
+			"port": {"type": "integer", "minimum": 1},
+			"mode": {"type": "string", "enum": ["dev", "prod"]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["port"]
+	}`
+
+	tests := []struct {
+		name         string
+		candidate    string
+		wantBreaking bool
+		wantChanges  []SchemaChange
+	}{
+		{
+			name:        "annotations only",
+			candidate:   `{"title": "API", "type": "object", "properties": {"port": {"type": "integer", "minimum": 1, "description": "listen port"}, "mode": {"type": "string", "enum": ["dev", "prod"]}, "tags": {"type": "array", "items": {"type": "string"}}}, "required": ["port"]}`,
+			wantChanges: []SchemaChange{},
+		},
+		{
+			name:      "optional property added and enum widened",
+			candidate: `{"type": "object", "properties": {"port": {"type": "integer", "minimum": 1}, "mode": {"type": "string", "enum": ["dev", "prod", "test"]}, "tags": {"type": "array", "items": {"type": "string"}}, "host": {"type": "string"}}, "required": ["port"]}`,
+			wantChanges: []SchemaChange{
+				{Pointer: "/properties/host", Keyword: "properties", Kind: SchemaChangePropertyAdded, Description: `property "host" added`},
+				{Pointer: "/properties/mode/enum", Keyword: "enum", Kind: SchemaChangeEnumValuesAdded, Description: `enum values added: "test"`},
+			},
+		},
+		{
+			name:         "new required property",
+			candidate:    `{"type": "object", "properties": {"port": {"type": "integer", "minimum": 1}, "mode": {"type": "string", "enum": ["dev", "prod"]}, "tags": {"type": "array", "items": {"type": "string"}}}, "required": ["port", "mode"]}`,
+			wantBreaking: true,
+			wantChanges: []SchemaChange{
+				{Pointer: "/required", Keyword: "required", Kind: SchemaChangeRequiredAdded, Breaking: true, Description: `property "mode" is now required`},
+			},
+		},
+		{
+			name:         "property removed and enum value removed",
+			candidate:    `{"type": "object", "properties": {"port": {"type": "integer", "minimum": 1}, "mode": {"type": "string", "enum": ["prod"]}}, "required": ["port"]}`,
+			wantBreaking: true,
+			wantChanges: []SchemaChange{
+				{Pointer: "/properties/mode/enum", Keyword: "enum", Kind: SchemaChangeEnumValuesRemoved, Breaking: true, Description: `enum values removed: "dev"`},
+				{Pointer: "/properties/tags", Keyword: "properties", Kind: SchemaChangePropertyRemoved, Breaking: true, Description: `property "tags" removed`},
+			},
+		},
+		{
+			name:         "types narrowed and bound tightened",
+			candidate:    `{"type": "object", "properties": {"port": {"type": "integer", "minimum": 1024}, "mode": {"type": "string", "enum": ["dev", "prod"]}, "tags": {"type": "array", "items": {"type": "integer"}}}, "required": ["port"]}`,
+			wantBreaking: true,
+			wantChanges: []SchemaChange{
+				{Pointer: "/properties/port/minimum", Keyword: "minimum", Kind: SchemaChangeConstraintTightened, Breaking: true, Description: "minimum changed from 1 to 1024"},
+				{Pointer: "/properties/tags/items/type", Keyword: "type", Kind: SchemaChangeTypeNarrowed, Breaking: true, Description: "type no longer accepts string"},
+			},
+		},
+		{
+			name:      "type widened and requirement dropped",
+			candidate: `{"type": "object", "properties": {"port": {"type": "number"}, "mode": {"type": ["string", "null"], "enum": ["dev", "prod"]}, "tags": {"type": "array", "items": {"type": "string"}}}}`,
+			wantChanges: []SchemaChange{
+				{Pointer: "/required", Keyword: "required", Kind: SchemaChangeRequiredRemoved, Description: `property "port" is no longer required`},
+				{Pointer: "/properties/mode/type", Keyword: "type", Kind: SchemaChangeTypeWidened, Description: "type now accepts null"},
+				{Pointer: "/properties/port/type", Keyword: "type", Kind: SchemaChangeTypeWidened, Description: "type now accepts number"},
+				{Pointer: "/properties/port/minimum", Keyword: "minimum", Kind: SchemaChangeConstraintRelaxed, Description: "minimum 1 removed"},
+			},
+		},
+		{
+			name:         "unanalysed keyword",
+			candidate:    `{"type": "object", "properties": {"port": {"type": "integer", "minimum": 1}, "mode": {"type": "string", "enum": ["dev", "prod"]}, "tags": {"type": "array", "items": {"type": "string"}}}, "required": ["port"], "anyOf": [{"required": ["mode"]}, {"required": ["tags"]}]}`,
+			wantBreaking: true,
+			wantChanges: []SchemaChange{
+				{Pointer: "/anyOf", Keyword: "anyOf", Kind: SchemaChangeKeywordChanged, Breaking: true, Description: "anyOf changed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := NewSchemaCompatibilityChecker().Compare(base, tt.candidate)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBreaking, result.Breaking())
+			assert.Equal(t, tt.wantChanges, result.Changes)
+		})
+	}
+}
+
+func TestSchemaCompatibilityChecker_Compare_InvalidJSON(t *testing.T) {
+	_, err := NewSchemaCompatibilityChecker().Compare(`{"type": "object"}`, `{`)
+
+	assert.Error(t, err)
+}
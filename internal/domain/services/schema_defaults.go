@@ -0,0 +1,217 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxDefaultsRefDepth bounds how many $refs ApplyDefaults follows in a row,
+// so a schema that references itself without nesting cannot loop
+const maxDefaultsRefDepth = 32
+
+// ApplyDefaults returns content with every absent property that has a
+// "default" in the schema filled in. It follows properties, items,
+// prefixItems, allOf and $ref, including references to registry schemas,
+// which resolve as they do in Validate and share its compiled schema cache.
+// Properties under anyOf, oneOf, not and if/then/else are left alone, as
+// which branch applies is not known before validation. An absent object
+// without a default of its own is not created for its members' defaults.
+// Content is returned unchanged when no default applies.
+func (sv *SchemaValidator) ApplyDefaults(ctx context.Context, schemaID string, version int32, schemaContent string, content json.RawMessage) (json.RawMessage, error) {
+	entry, err := sv.compileVersion(ctx, schemaID, version, schemaContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+
+	applier := defaultsApplier{documents: make(map[string]interface{}, len(entry.documents))}
+	for name, document := range entry.documents {
+		applier.documents[name], err = decodeJSON([]byte(document))
+		if err != nil {
+			return nil, fmt.Errorf("schema %q is not valid JSON: %w", name, err)
+		}
+	}
+	root, err := decodeJSON([]byte(schemaContent))
+	if err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+	value, err := decodeJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("content is not valid JSON: %w", err)
+	}
+
+	value = applier.apply(root, root, value, 0)
+	if !applier.changed {
+		return content, nil
+	}
+	return json.Marshal(value)
+}
+
+// defaultsApplier fills in defaults while walking a schema and content
+// together
+type defaultsApplier struct {
+	documents map[string]interface{}
+	changed   bool
+}
+
+// apply fills in the defaults of schema in value and returns the value.
+// document is the schema document that local $refs in schema point into.
+func (a *defaultsApplier) apply(schema, document, value interface{}, refDepth int) interface{} {
+	node, ok := schema.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	if ref, ok := node["$ref"].(string); ok && refDepth < maxDefaultsRefDepth {
+		if target, targetDocument, ok := a.resolve(ref, document); ok {
+			value = a.apply(target, targetDocument, value, refDepth+1)
+		}
+	}
+	if allOf, ok := node["allOf"].([]interface{}); ok {
+		for _, subschema := range allOf {
+			value = a.apply(subschema, document, value, refDepth)
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		properties, _ := node["properties"].(map[string]interface{})
+		for name, subschema := range properties {
+			if _, present := typed[name]; !present {
+				defaultValue, ok := a.defaultOf(subschema, document, 0)
+				if !ok {
+					continue
+				}
+				typed[name] = defaultValue
+				a.changed = true
+			}
+			typed[name] = a.apply(subschema, document, typed[name], 0)
+		}
+
+	case []interface{}:
+		prefixItems, _ := node["prefixItems"].([]interface{})
+		items := node["items"]
+		if tuple, ok := items.([]interface{}); ok {
+			// Draft-07 tuples are an items array
+			prefixItems, items = tuple, node["additionalItems"]
+		}
+		for i := range typed {
+			if i < len(prefixItems) {
+				typed[i] = a.apply(prefixItems[i], document, typed[i], 0)
+			} else {
+				typed[i] = a.apply(items, document, typed[i], 0)
+			}
+		}
+	}
+
+	return value
+}
+
+// defaultOf returns a copy of the default of schema, following $refs
+func (a *defaultsApplier) defaultOf(schema, document interface{}, refDepth int) (interface{}, bool) {
+	node, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if defaultValue, ok := node["default"]; ok {
+		return copyJSON(defaultValue), true
+	}
+	if ref, ok := node["$ref"].(string); ok && refDepth < maxDefaultsRefDepth {
+		if target, targetDocument, ok := a.resolve(ref, document); ok {
+			return a.defaultOf(target, targetDocument, refDepth+1)
+		}
+	}
+	return nil, false
+}
+
+// resolve returns the subschema a $ref points at and the document holding
+// it. Only JSON Pointer fragments are followed.
+func (a *defaultsApplier) resolve(ref string, document interface{}) (interface{}, interface{}, bool) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, nil, false
+	}
+	if !strings.HasPrefix(ref, "#") {
+		name, err := referencedSchema(ref)
+		if err != nil || name == "" {
+			return nil, nil, false
+		}
+		registered, ok := a.documents[name]
+		if !ok {
+			return nil, nil, false
+		}
+		document = registered
+	}
+
+	target, ok := pointerTarget(document, u.Fragment)
+	return target, document, ok
+}
+
+// pointerTarget returns the value at a JSON Pointer fragment of a document
+func pointerTarget(document interface{}, fragment string) (interface{}, bool) {
+	if fragment == "" {
+		return document, true
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		// Anchors are not followed
+		return nil, false
+	}
+
+	value := document
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch node := value.(type) {
+		case map[string]interface{}:
+			child, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			value = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			value = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// decodeJSON decodes JSON keeping numbers exact
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// copyJSON deep-copies a decoded JSON value, so a default inserted twice
+// is not shared
+func copyJSON(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(typed))
+		for key, child := range typed {
+			copied[key] = copyJSON(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(typed))
+		for i, child := range typed {
+			copied[i] = copyJSON(child)
+		}
+		return copied
+	default:
+		return value
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaValidator_ApplyDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		content  string
+		expected string
+	}{
+		{
+			name:     "fills in absent properties",
+			schema:   `{"properties": {"port": {"type": "integer", "default": 8080}, "debug": {"default": false}, "host": {"type": "string"}}}`,
+			content:  `{"host": "api"}`,
+			expected: `{"debug": false, "host": "api", "port": 8080}`,
+		},
+		{
+			name:     "keeps present values",
+			schema:   `{"properties": {"port": {"default": 8080}}}`,
+			content:  `{"port": 9090}`,
+			expected: `{"port": 9090}`,
+		},
+		{
+			name:     "fills in nested objects that are present",
+			schema:   `{"properties": {"db": {"properties": {"pool": {"default": 10}}}, "cache": {"properties": {"ttl": {"default": "5m"}}}}}`,
+			content:  `{"db": {}}`,
+			expected: `{"db": {"pool": 10}}`,
+		},
+		{
+			name:     "fills in a defaulted object's own members",
+			schema:   `{"properties": {"tls": {"default": {}, "properties": {"enabled": {"default": true}}}}}`,
+			content:  `{}`,
+			expected: `{"tls": {"enabled": true}}`,
+		},
+		{
+			name:     "fills in array items",
+			schema:   `{"properties": {"servers": {"items": {"properties": {"weight": {"default": 1}}}}}}`,
+			content:  `{"servers": [{"host": "a"}, {"host": "b", "weight": 3}]}`,
+			expected: `{"servers": [{"host": "a", "weight": 1}, {"host": "b", "weight": 3}]}`,
+		},
+		{
+			name:     "follows local references and allOf",
+			schema:   `{"$defs": {"timeout": {"default": "30s"}}, "allOf": [{"properties": {"timeout": {"$ref": "#/$defs/timeout"}}}], "properties": {"retries": {"default": 3}}}`,
+			content:  `{}`,
+			expected: `{"retries": 3, "timeout": "30s"}`,
+		},
+		{
+			name:     "leaves anyOf branches alone",
+			schema:   `{"anyOf": [{"properties": {"a": {"default": 1}}}, {"properties": {"b": {"default": 2}}}]}`,
+			content:  `{}`,
+			expected: `{}`,
+		},
+		{
+			name:     "keeps large numbers exact",
+			schema:   `{"properties": {"limit": {"default": 9007199254740993}}}`,
+			content:  `{}`,
+			expected: `{"limit": 9007199254740993}`,
+		},
+	}
+
+	validator := NewSchemaValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := validator.ApplyDefaults(context.Background(), "schema-1", 1, tt.schema, json.RawMessage(tt.content))
+
+			// Assert
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(result))
+		})
+	}
+
+	t.Run("returns content unchanged when no default applies", func(t *testing.T) {
+		// Arrange
+		content := json.RawMessage(`{ "port" : 9090 }`)
+
+		// Act
+		result, err := validator.ApplyDefaults(context.Background(), "schema-1", 1, `{"properties": {"port": {"default": 8080}}}`, content)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(result))
+	})
+
+	t.Run("defaults inserted twice are not shared", func(t *testing.T) {
+		// Arrange
+		schema := `{"items": {"properties": {"labels": {"default": {}, "properties": {"tier": {"default": "web"}}}}}}`
+
+		// Act
+		result, err := validator.ApplyDefaults(context.Background(), "schema-1", 1, schema, json.RawMessage(`[{}, {"labels": {"tier": "db"}}, {}]`))
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"labels": {"tier": "web"}}, {"labels": {"tier": "db"}}, {"labels": {"tier": "web"}}]`, string(result))
+	})
+
+	t.Run("follows references to registry schemas", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{
+			"tls": `{"definitions": {"tls": {"properties": {"min_version": {"$ref": "#/definitions/version"}}}, "version": {"default": "1.2"}}}`,
+		}, DefaultSchemaCacheSize)
+		schema := `{"properties": {"tls": {"$ref": "cfguardian://schemas/tls#/definitions/tls"}}}`
+
+		// Act
+		result, err := validator.ApplyDefaults(context.Background(), "schema-1", 1, schema, json.RawMessage(`{"tls": {}}`))
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"tls": {"min_version": "1.2"}}`, string(result))
+	})
+
+	t.Run("reuses the referenced schemas compiled for validation", func(t *testing.T) {
+		// Arrange
+		schemas := registry{"tls": `{"definitions": {"tls": {"properties": {"min_version": {"default": "1.2"}}}}}`}
+		validator := NewSchemaValidatorWithResolver(schemas, DefaultSchemaCacheSize)
+		schema := `{"properties": {"tls": {"$ref": "cfguardian://schemas/tls#/definitions/tls"}}}`
+		_, err := validator.Validate(context.Background(), "service", 1, schema, json.RawMessage(`{"tls": {}}`))
+		require.NoError(t, err)
+		delete(schemas, "tls")
+
+		// Act
+		result, err := validator.ApplyDefaults(context.Background(), "service", 1, schema, json.RawMessage(`{"tls": {}}`))
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"tls": {"min_version": "1.2"}}`, string(result))
+		assert.Equal(t, SchemaCacheStats{Hits: 1, Misses: 1, Entries: 1}, validator.CacheStats())
+	})
+
+	t.Run("self reference without nesting terminates", func(t *testing.T) {
+		// Act
+		result, err := validator.ApplyDefaults(context.Background(), "schema-1", 1, `{"$ref": "#"}`, json.RawMessage(`{}`))
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(result))
+	})
+}
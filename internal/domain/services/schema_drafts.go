@@ -0,0 +1,224 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// $schema URIs of the drafts compiled by the modern backend. Schemas that
+// declare any other draft, or none, are compiled as draft-07.
+const (
+	Draft201909 = "https://json-schema.org/draft/2019-09/schema"
+	Draft202012 = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// modernRootURI is where the schema being compiled is loaded from. It is
+// outside cfguardian://schemas/ so it cannot clash with a registry schema.
+const modernRootURI = "cfguardian://validation/root"
+
+// messagePrinter renders the modern backend's error messages
+var messagePrinter = message.NewPrinter(language.English)
+
+// isModernDraft reports whether a schema declares draft 2019-09 or 2020-12
+// in its $schema field
+func isModernDraft(schemaContent string) bool {
+	var document struct {
+		Schema string `json:"$schema"`
+	}
+	if err := json.Unmarshal([]byte(schemaContent), &document); err != nil {
+		return false
+	}
+	uri := strings.TrimSuffix(document.Schema, "#")
+	uri = strings.Replace(uri, "http://", "https://", 1)
+	return uri == Draft201909 || uri == Draft202012
+}
+
+// compileModern compiles a schema and the registry schemas it references
+// with the 2019-09/2020-12 backend. Each schema keeps the draft its own
+// $schema declares; schemas without one are read as draft-07.
+func compileModern(schemaContent string, documents map[string]string) (compiledSchema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft7)
+	compiler.AssertFormat()
+	compiler.UseLoader(refusingURLLoader{})
+	for _, format := range SchemaFormats {
+		compiler.RegisterFormat(&jsonschema.Format{
+			Name: format.Name,
+			Validate: func(v any) error {
+				value, ok := v.(string)
+				if !ok {
+					return nil
+				}
+				return format.Check(value)
+			},
+		})
+	}
+
+	for name, content := range documents {
+		if err := addModernResource(compiler, schemaRefURI(name), content); err != nil {
+			return nil, fmt.Errorf("schema %q: %w", name, err)
+		}
+	}
+	if err := addModernResource(compiler, modernRootURI, schemaContent); err != nil {
+		return nil, err
+	}
+
+	schema, err := compiler.Compile(modernRootURI)
+	if err != nil {
+		return nil, err
+	}
+	return modernSchema{schema: schema}, nil
+}
+
+// addModernResource makes schema content loadable from uri
+func addModernResource(compiler *jsonschema.Compiler, uri, content string) error {
+	document, err := jsonschema.UnmarshalJSON(strings.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+	return compiler.AddResource(uri, document)
+}
+
+// refusingURLLoader fails every load, so only added schemas are resolved
+type refusingURLLoader struct{}
+
+// Load implements jsonschema.URLLoader
+func (refusingURLLoader) Load(uri string) (any, error) {
+	return nil, fmt.Errorf("$ref %q: %w", uri, ErrRemoteSchemaRef)
+}
+
+// modernSchema is a schema compiled by the 2019-09/2020-12 backend
+type modernSchema struct {
+	schema *jsonschema.Schema
+}
+
+// validate implements compiledSchema
+func (s modernSchema) validate(content json.RawMessage) ([]ValidationError, error) {
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("content is not valid JSON: %w", err)
+	}
+
+	err = s.schema.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	errs := make([]ValidationError, 0)
+	collectModernErrors(validationErr, instance, &errs)
+	return errs, nil
+}
+
+// unevaluatedKeywords reject values through a false subschema; errors
+// raised there are reported under the keyword
+var unevaluatedKeywords = map[string]bool{
+	"unevaluatedProperties": true,
+	"unevaluatedItems":      true,
+	"additionalProperties":  true,
+	"items":                 true,
+	"additionalItems":       true,
+}
+
+// collectModernErrors flattens an error tree into ValidationErrors. Wrapper
+// errors are skipped; anyOf and oneOf are reported once rather than once
+// per failing branch, as the draft-07 backend does.
+func collectModernErrors(e *jsonschema.ValidationError, instance any, errs *[]ValidationError) {
+	tokens := e.InstanceLocation
+
+	switch k := e.ErrorKind.(type) {
+	case *kind.Schema, *kind.Group, *kind.Reference, *kind.AllOf:
+		for _, cause := range e.Causes {
+			collectModernErrors(cause, instance, errs)
+		}
+
+	case *kind.Required:
+		// Point at each missing property, which has no value
+		for _, property := range k.Missing {
+			*errs = append(*errs, ValidationError{
+				Pointer: jsonPointer(append(tokens[:len(tokens):len(tokens)], property)),
+				Keyword: "required",
+				Message: (&kind.Required{Missing: []string{property}}).LocalizedString(messagePrinter),
+			})
+		}
+
+	case *kind.AdditionalProperties:
+		// Point at each disallowed property
+		for _, property := range k.Properties {
+			propertyTokens := append(tokens[:len(tokens):len(tokens)], property)
+			*errs = append(*errs, ValidationError{
+				Pointer: jsonPointer(propertyTokens),
+				Keyword: "additionalProperties",
+				Message: (&kind.AdditionalProperties{Properties: []string{property}}).LocalizedString(messagePrinter),
+				Value:   valueAt(instance, propertyTokens),
+			})
+		}
+
+	case *kind.FalseSchema:
+		keyword, message := "false", "not allowed by a false schema"
+		if i := strings.LastIndex(e.SchemaURL, "/"); i >= 0 && unevaluatedKeywords[e.SchemaURL[i+1:]] {
+			keyword = e.SchemaURL[i+1:]
+			message = "not allowed by " + keyword
+		}
+		*errs = append(*errs, ValidationError{
+			Pointer: jsonPointer(tokens),
+			Keyword: keyword,
+			Message: message,
+			Value:   valueAt(instance, tokens),
+		})
+
+	default:
+		keyword := "false"
+		if path := k.KeywordPath(); len(path) > 0 {
+			keyword = path[0]
+		}
+		*errs = append(*errs, ValidationError{
+			Pointer: jsonPointer(tokens),
+			Keyword: keyword,
+			Message: k.LocalizedString(messagePrinter),
+			Value:   valueAt(instance, tokens),
+		})
+	}
+}
+
+// jsonPointer builds a JSON Pointer from unescaped tokens
+func jsonPointer(tokens []string) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		pointer.WriteString("/")
+		pointer.WriteString(escapePointerToken(token))
+	}
+	return pointer.String()
+}
+
+// valueAt returns the value at unescaped tokens, or nil if there is none
+func valueAt(instance any, tokens []string) any {
+	value := instance
+	for _, token := range tokens {
+		switch node := value.(type) {
+		case map[string]any:
+			value = node[token]
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			value = node[i]
+		default:
+			return nil
+		}
+	}
+	return value
+}
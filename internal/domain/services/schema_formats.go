@@ -0,0 +1,231 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SchemaFormat is a custom "format" a schema can assert on string values.
+// Check returns why a value does not match; other types always match.
+type SchemaFormat struct {
+	Name  string
+	Check func(value string) error
+}
+
+// SchemaFormats are the custom formats every schema can use, whatever its draft
+var SchemaFormats = []SchemaFormat{
+	{Name: "go-duration", Check: checkGoDuration},
+	{Name: "cron", Check: checkCron},
+	{Name: "semver", Check: checkSemver},
+	{Name: "cidr", Check: checkCIDR},
+	{Name: "host-port", Check: checkHostPort},
+	URLFormat("http-url", "http", "https"),
+	URLFormat("https-url", "https"),
+}
+
+// URLFormat returns a format for absolute URLs with one of the given schemes.
+// Formats are registered by name for every schema, so only the schemes of
+// the formats in SchemaFormats can be asserted through "format".
+func URLFormat(name string, schemes ...string) SchemaFormat {
+	return SchemaFormat{
+		Name: name,
+		Check: func(value string) error {
+			u, err := url.Parse(value)
+			if err != nil {
+				return err
+			}
+			if u.Host == "" {
+				return errors.New("not an absolute URL with a host")
+			}
+			for _, scheme := range schemes {
+				if strings.EqualFold(u.Scheme, scheme) {
+					return nil
+				}
+			}
+			return fmt.Errorf("scheme %q is not one of %s", u.Scheme, strings.Join(schemes, ", "))
+		},
+	}
+}
+
+// registerFormatsOnce adds SchemaFormats to gojsonschema's global checkers
+var registerFormatsOnce sync.Once
+
+// registerDraft07Formats makes SchemaFormats available to draft-07 schemas
+func registerDraft07Formats() {
+	registerFormatsOnce.Do(func() {
+		for _, format := range SchemaFormats {
+			gojsonschema.FormatCheckers.Add(format.Name, formatChecker(format))
+		}
+	})
+}
+
+// formatChecker adapts a SchemaFormat to gojsonschema
+type formatChecker SchemaFormat
+
+// IsFormat implements gojsonschema.FormatChecker
+func (f formatChecker) IsFormat(input interface{}) bool {
+	value, ok := input.(string)
+	if !ok {
+		return true
+	}
+	return f.Check(value) == nil
+}
+
+// checkGoDuration accepts durations as parsed by time.ParseDuration, like "1h30m"
+func checkGoDuration(value string) error {
+	_, err := time.ParseDuration(value)
+	return err
+}
+
+// semverPattern is the SemVer 2.0.0 grammar, without a "v" prefix
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// checkSemver accepts semantic versions such as "1.4.0-rc.1+build.5"
+func checkSemver(value string) error {
+	if !semverPattern.MatchString(value) {
+		return errors.New("not a semantic version")
+	}
+	return nil
+}
+
+// checkCIDR accepts IPv4 and IPv6 prefixes such as "10.0.0.0/8"
+func checkCIDR(value string) error {
+	_, err := netip.ParsePrefix(value)
+	return err
+}
+
+// hostnamePattern matches RFC 1123 host names
+var hostnamePattern = regexp.MustCompile(`^(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?)(?:\.(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?))*$`)
+
+// checkHostPort accepts "host:port" with a host name or IP address and a
+// port from 1 to 65535; IPv6 addresses are bracketed, as in "[::1]:443"
+func checkHostPort(value string) error {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return err
+	}
+	if _, err := netip.ParseAddr(host); err != nil && (len(host) > 253 || !hostnamePattern.MatchString(host)) {
+		return fmt.Errorf("invalid host %q", host)
+	}
+	number, err := strconv.Atoi(port)
+	if err != nil || number < 1 || number > 65535 {
+		return fmt.Errorf("invalid port %q", port)
+	}
+	return nil
+}
+
+// cronDescriptors are the predefined schedules accepted instead of fields
+var cronDescriptors = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+// cronField is the range and names of one field of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names[i] stands for min+i
+}
+
+// cronFields are the minute, hour, day of month, month and day of week fields
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// checkCron accepts five-field cron expressions, such as "*/15 9-17 * * mon-fri",
+// the descriptors @hourly to @yearly, and "@every <duration>"
+func checkCron(value string) error {
+	if strings.HasPrefix(value, "@") {
+		if every, ok := strings.CutPrefix(value, "@every "); ok {
+			duration, err := time.ParseDuration(strings.TrimSpace(every))
+			if err != nil || duration <= 0 {
+				return fmt.Errorf("invalid @every duration %q", every)
+			}
+			return nil
+		}
+		if !cronDescriptors[value] {
+			return fmt.Errorf("unknown descriptor %q", value)
+		}
+		return nil
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected %d fields, got %d", len(cronFields), len(fields))
+	}
+	for i, field := range fields {
+		if err := cronFields[i].check(field); err != nil {
+			return fmt.Errorf("%s: %w", cronFields[i].name, err)
+		}
+	}
+	return nil
+}
+
+// check validates one field: a comma-separated list of "*", values or
+// ranges, each optionally followed by "/step"
+func (f cronField) check(field string) error {
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid step %q", step)
+			}
+		}
+		if rangePart == "*" {
+			continue
+		}
+
+		low, high, isRange := strings.Cut(rangePart, "-")
+		from, err := f.value(low)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+		to, err := f.value(high)
+		if err != nil {
+			return err
+		}
+		if from > to {
+			return fmt.Errorf("invalid range %q", rangePart)
+		}
+	}
+	return nil
+}
+
+// value parses a number or name in the field's range
+func (f cronField) value(token string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(token, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(token)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid value %q", token)
+	}
+	return n, nil
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SchemaRefPrefix is the $ref prefix of schemas in the registry.
+// A schema references another one by name, as in
+// cfguardian://schemas/tls#/definitions/certificate.
+const SchemaRefPrefix = "cfguardian://schemas/"
+
+// ResolvedSchema is the registry schema version a reference resolves to
+type ResolvedSchema struct {
+	Name     string // Name the $ref uses
+	SchemaID string
+	Version  int32
+	Content  string
+}
+
+// SchemaResolver looks up registry schemas for $ref resolution.
+// A saved schema version validates with the schema versions it resolved
+// to when it was published, so publishing a schema never changes how the
+// schemas referencing it validate. Unsaved content resolves to the latest
+// versions.
+type SchemaResolver interface {
+	// LatestSchema returns the latest version of a schema by name, as seen
+	// from projectID; an empty projectID sees only the global schemas
+	LatestSchema(ctx context.Context, projectID, name string) (*ResolvedSchema, error)
+
+	// PinnedSchemas returns every schema a saved version references,
+	// directly or not, at the versions pinned when it was published
+	PinnedSchemas(ctx context.Context, schemaID string, version int32) ([]ResolvedSchema, error)
+}
+
+// ErrRemoteSchemaRef is returned for a $ref outside the schema registry
+var ErrRemoteSchemaRef = errors.New("only local and " + SchemaRefPrefix + "<name> references are allowed")
+
+// ErrSchemaRefCycle is returned when schemas reference each other in a cycle
+var ErrSchemaRefCycle = errors.New("schema reference cycle")
+
+// dataKeywords hold instance values rather than subschemas, so a "$ref"
+// member inside them is not a reference
+var dataKeywords = map[string]bool{
+	"const":    true,
+	"default":  true,
+	"enum":     true,
+	"examples": true,
+}
+
+// References returns the names of the registry schemas a schema references
+// directly, sorted by name. Fragment-only references are local and are not
+// returned; any other reference is rejected with ErrRemoteSchemaRef.
+func References(schemaContent string) ([]string, error) {
+	var document interface{}
+	if err := json.Unmarshal([]byte(schemaContent), &document); err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	var walk func(node interface{}) error
+	walk = func(node interface{}) error {
+		switch value := node.(type) {
+		case map[string]interface{}:
+			if ref, ok := value["$ref"].(string); ok {
+				name, err := referencedSchema(ref)
+				if err != nil {
+					return err
+				}
+				if name != "" && !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+			for _, key := range sortedKeys(value) {
+				if dataKeywords[key] {
+					continue
+				}
+				if err := walk(value[key]); err != nil {
+					return err
+				}
+			}
+		case []interface{}:
+			for _, child := range value {
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := walk(document); err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// referencedSchema returns the registry schema name of a $ref, or an empty
+// name for a local reference
+func referencedSchema(ref string) (string, error) {
+	if strings.HasPrefix(ref, "#") {
+		return "", nil
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil || u.Scheme != "cfguardian" || u.Host != "schemas" {
+		return "", fmt.Errorf("$ref %q: %w", ref, ErrRemoteSchemaRef)
+	}
+	name := strings.TrimPrefix(u.Path, "/")
+	if name == "" || strings.Contains(name, "/") || u.RawQuery != "" {
+		return "", fmt.Errorf("$ref %q does not name a schema", ref)
+	}
+	return name, nil
+}
+
+// schemaRefURI returns the canonical URI of a registry schema, as both
+// backends resolve it from a $ref
+func schemaRefURI(name string) string {
+	return (&url.URL{Scheme: "cfguardian", Host: "schemas", Path: "/" + name}).String()
+}
+
+// resolveReferences returns the latest version of every schema reachable
+// from unsaved schemaContent, by name. rootName is the name of the schema
+// itself, if it has one, so a reference back to it is reported as a cycle.
+func resolveReferences(ctx context.Context, resolver SchemaResolver, projectID, rootName, schemaContent string) (map[string]ResolvedSchema, error) {
+	documents := make(map[string]ResolvedSchema)
+	visiting := make(map[string]bool)
+
+	path := []string{"(schema)"}
+	if rootName != "" {
+		visiting[rootName] = true
+		path = []string{rootName}
+	}
+
+	var visit func(content string, path []string) error
+	visit = func(content string, path []string) error {
+		names, err := References(content)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if visiting[name] {
+				return fmt.Errorf("%w: %s -> %s", ErrSchemaRefCycle, strings.Join(path, " -> "), name)
+			}
+			if _, ok := documents[name]; ok {
+				continue
+			}
+			if resolver == nil {
+				return fmt.Errorf("cannot resolve %s%s: no schema registry", SchemaRefPrefix, name)
+			}
+
+			referenced, err := resolver.LatestSchema(ctx, projectID, name)
+			if err != nil {
+				return fmt.Errorf("cannot resolve %s%s: %w", SchemaRefPrefix, name, err)
+			}
+			referenced.Name = name
+
+			visiting[name] = true
+			if err := visit(referenced.Content, append(path[:len(path):len(path)], name)); err != nil {
+				return err
+			}
+			visiting[name] = false
+			documents[name] = *referenced
+		}
+		return nil
+	}
+
+	if err := visit(schemaContent, path); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// pinnedReferences returns the schemas a saved schema version references,
+// by name, at the versions pinned when it was published
+func pinnedReferences(ctx context.Context, resolver SchemaResolver, schemaID string, version int32, schemaContent string) (map[string]ResolvedSchema, error) {
+	names, err := References(schemaContent)
+	if err != nil {
+		return nil, err
+	}
+	documents := make(map[string]ResolvedSchema)
+	if len(names) == 0 {
+		return documents, nil
+	}
+	if resolver == nil {
+		return nil, fmt.Errorf("cannot resolve %s%s: no schema registry", SchemaRefPrefix, names[0])
+	}
+
+	pinned, err := resolver.PinnedSchemas(ctx, schemaID, version)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve the references of %s version %d: %w", schemaID, version, err)
+	}
+	for _, referenced := range pinned {
+		documents[referenced.Name] = referenced
+	}
+	for _, name := range names {
+		if _, ok := documents[name]; !ok {
+			return nil, fmt.Errorf("cannot resolve %s%s: not pinned by %s version %d", SchemaRefPrefix, name, schemaID, version)
+		}
+	}
+	return documents, nil
+}
+
+// referenceContents returns the content of resolved schemas by name
+func referenceContents(references map[string]ResolvedSchema) map[string]string {
+	contents := make(map[string]string, len(references))
+	for name, referenced := range references {
+		contents[name] = referenced.Content
+	}
+	return contents
+}
+
+// compileWithReferences compiles a schema together with the registry
+// schemas it references, given by name. The modern backend is used when
+// any of them declares draft 2019-09 or 2020-12.
+func compileWithReferences(schemaContent string, documents map[string]string) (compiledSchema, error) {
+	modern := isModernDraft(schemaContent)
+	for _, content := range documents {
+		modern = modern || isModernDraft(content)
+	}
+
+	if modern {
+		return compileModern(schemaContent, documents)
+	}
+	return compileDraft07(schemaContent, documents)
+}
+
+// compileDraft07 compiles a schema and the registry schemas it references
+// with gojsonschema
+func compileDraft07(schemaContent string, documents map[string]string) (compiledSchema, error) {
+	schemaLoader := gojsonschema.NewSchemaLoader()
+	for name, content := range documents {
+		if err := schemaLoader.AddSchema(schemaRefURI(name), gojsonschema.NewStringLoader(content)); err != nil {
+			return nil, fmt.Errorf("schema %q: %w", name, err)
+		}
+	}
+
+	schema, err := schemaLoader.Compile(registryLoader{gojsonschema.NewStringLoader(schemaContent)})
+	if err != nil {
+		return nil, err
+	}
+	return draft07Schema{schema: schema}, nil
+}
+
+// registryLoader loads a root schema whose references may only be served
+// from the schemas added to the loader, never fetched
+type registryLoader struct {
+	gojsonschema.JSONLoader
+}
+
+// LoaderFactory implements gojsonschema.JSONLoader
+func (registryLoader) LoaderFactory() gojsonschema.JSONLoaderFactory {
+	return refusingLoaderFactory{}
+}
+
+// refusingLoaderFactory builds loaders that fail instead of fetching
+type refusingLoaderFactory struct{}
+
+// New implements gojsonschema.JSONLoaderFactory
+func (refusingLoaderFactory) New(source string) gojsonschema.JSONLoader {
+	return refusingLoader{JSONLoader: gojsonschema.NewReferenceLoader(source), source: source}
+}
+
+// refusingLoader is a reference that was not added to the schema loader
+type refusingLoader struct {
+	gojsonschema.JSONLoader
+	source string
+}
+
+// LoadJSON implements gojsonschema.JSONLoader
+func (l refusingLoader) LoadJSON() (interface{}, error) {
+	return nil, fmt.Errorf("$ref %q: %w", l.source, ErrRemoteSchemaRef)
+}
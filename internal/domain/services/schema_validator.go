@@ -1,110 +1,388 @@
 package services
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/xeipuuv/gojsonschema"
 )
 
-// ValidationError represents a schema validation error
+// DefaultSchemaCacheSize is the number of compiled schemas NewSchemaValidator keeps
+const DefaultSchemaCacheSize = 512
+
+// ValidationError represents a schema validation error.
+// Pointer is the JSON Pointer of the failing value; for a missing or
+// disallowed property it points at that property. Keyword is the JSON
+// Schema keyword that failed.
 type ValidationError struct {
-	Field       string
-	Message     string
-	Value       interface{}
-	Description string
+	Pointer string      `json:"pointer"`
+	Keyword string      `json:"keyword"`
+	Message string      `json:"message"`
+	Value   interface{} `json:"value,omitempty"`
 }
 
 // Error implements the error interface
 func (ve ValidationError) Error() string {
-	return fmt.Sprintf("%s: %s", ve.Field, ve.Message)
+	pointer := ve.Pointer
+	if pointer == "" {
+		pointer = "(root)"
+	}
+	return fmt.Sprintf("%s: %s", pointer, ve.Message)
+}
+
+// ContentValidationError is returned when content fails its schema.
+// It carries every validation error so callers can report them one by one.
+type ContentValidationError struct {
+	Errors []ValidationError
+}
+
+// Error implements the error interface
+func (e ContentValidationError) Error() string {
+	errMsg := fmt.Sprintf("validation failed with %d error(s):", len(e.Errors))
+	for i, ve := range e.Errors {
+		errMsg += fmt.Sprintf("\n  %d. %s", i+1, ve.Error())
+	}
+	return errMsg
+}
+
+// AsContentValidationError finds a ContentValidationError in an error chain
+func AsContentValidationError(err error) (ContentValidationError, bool) {
+	var validationErr ContentValidationError
+	ok := errors.As(err, &validationErr)
+	return validationErr, ok
+}
+
+// schemaKeywords maps gojsonschema error types to the keywords that raise them
+var schemaKeywords = map[string]string{
+	"false":                           "false",
+	"required":                        "required",
+	"invalid_type":                    "type",
+	"number_any_of":                   "anyOf",
+	"number_one_of":                   "oneOf",
+	"number_all_of":                   "allOf",
+	"number_not":                      "not",
+	"missing_dependency":              "dependencies",
+	"const":                           "const",
+	"enum":                            "enum",
+	"array_no_additional_items":       "additionalItems",
+	"array_min_items":                 "minItems",
+	"array_max_items":                 "maxItems",
+	"unique":                          "uniqueItems",
+	"contains":                        "contains",
+	"array_min_properties":            "minProperties",
+	"array_max_properties":            "maxProperties",
+	"additional_property_not_allowed": "additionalProperties",
+	"invalid_property_pattern":        "patternProperties",
+	"invalid_property_name":           "propertyNames",
+	"string_gte":                      "minLength",
+	"string_lte":                      "maxLength",
+	"pattern":                         "pattern",
+	"format":                          "format",
+	"multiple_of":                     "multipleOf",
+	"number_gte":                      "minimum",
+	"number_gt":                       "exclusiveMinimum",
+	"number_lte":                      "maximum",
+	"number_lt":                       "exclusiveMaximum",
+	"condition_then":                  "then",
+	"condition_else":                  "else",
+}
+
+// contextSeparator splits a gojsonschema context into its tokens.
+// It cannot clash with member names, which are JSON strings without NUL.
+const contextSeparator = "\x00"
+
+// newValidationError converts a gojsonschema error
+func newValidationError(resultErr gojsonschema.ResultError) ValidationError {
+	// The context starts with "(root)"
+	tokens := strings.Split(resultErr.Context().String(contextSeparator), contextSeparator)[1:]
+
+	keyword, ok := schemaKeywords[resultErr.Type()]
+	if !ok {
+		keyword = resultErr.Type()
+	}
+
+	value := resultErr.Value()
+	switch keyword {
+	case "required", "additionalProperties":
+		// Point at the property itself, which has no value when missing
+		if property, ok := resultErr.Details()["property"].(string); ok {
+			tokens = append(tokens, property)
+			if keyword == "required" {
+				value = nil
+			} else if object, ok := value.(map[string]interface{}); ok {
+				value = object[property]
+			}
+		}
+	}
+
+	return ValidationError{
+		Pointer: jsonPointer(tokens),
+		Keyword: keyword,
+		Message: resultErr.Description(),
+		Value:   value,
+	}
 }
 
 // ValidationResult holds the result of schema validation
 type ValidationResult struct {
-	Valid  bool
-	Errors []ValidationError
+	Valid  bool              `json:"valid"`
+	Errors []ValidationError `json:"errors"`
 }
 
-// SchemaValidator validates configuration content against JSON Schemas
-type SchemaValidator struct{}
+// SchemaCacheStats reports the use of the compiled schema cache
+type SchemaCacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
 
-// NewSchemaValidator creates a new SchemaValidator
-func NewSchemaValidator() *SchemaValidator {
-	return &SchemaValidator{}
+// compiledSchema is a schema compiled by one of the validator backends
+type compiledSchema interface {
+	// validate returns the errors of content; none when it is valid
+	validate(content json.RawMessage) ([]ValidationError, error)
 }
 
-// Validate validates content against a JSON Schema
-func (sv *SchemaValidator) Validate(schemaContent string, content json.RawMessage) (*ValidationResult, error) {
-	// Parse schema
-	schemaLoader := gojsonschema.NewStringLoader(schemaContent)
-	
-	// Parse content
-	contentLoader := gojsonschema.NewBytesLoader(content)
+// draft07Schema is a schema compiled by gojsonschema, which handles
+// drafts 04 to 07
+type draft07Schema struct {
+	schema *gojsonschema.Schema
+}
+
+// validate implements compiledSchema
+func (s draft07Schema) validate(content json.RawMessage) ([]ValidationError, error) {
+	result, err := s.schema.Validate(gojsonschema.NewBytesLoader(content))
+	if err != nil {
+		return nil, err
+	}
 	
+	errs := make([]ValidationError, 0, len(result.Errors()))
+	for _, resultErr := range result.Errors() {
+		errs = append(errs, newValidationError(resultErr))
+	}
+	return errs, nil
+}
+
+// SchemaValidator validates configuration content against JSON Schemas.
+// Schemas declaring draft 2019-09 or 2020-12 in $schema are compiled by
+// santhosh-tekuri/jsonschema, others by gojsonschema as draft-07; both
+// accept the custom SchemaFormats.
+// Compiled schemas are kept in an LRU cache keyed by schema ID, version and
+// content hash. A saved version is immutable and its references are pinned,
+// so its entry never goes stale.
+// References to other registry schemas are resolved through a
+// SchemaResolver; other remote references are rejected.
+type SchemaValidator struct {
+	resolver SchemaResolver
+	cache    *lru.Cache[string, *cachedSchema]
+	hits     atomic.Uint64
+	misses   atomic.Uint64
+}
+
+// draftKeyPrefix starts the cache keys of unsaved schema content
+const draftKeyPrefix = "draft:"
+
+// cachedSchema is a compiled schema with the registry schemas compiled
+// into it: their content by name, which ApplyDefaults reuses, and their IDs
+type cachedSchema struct {
+	schema     compiledSchema
+	documents  map[string]string
+	references []string
+}
+
+// NewSchemaValidator creates a new SchemaValidator with the default cache size
+func NewSchemaValidator() *SchemaValidator {
+	return NewSchemaValidatorWithCacheSize(DefaultSchemaCacheSize)
+}
+
+// NewSchemaValidatorWithCacheSize creates a new SchemaValidator that keeps up
+// to size compiled schemas. A size of 0 or less compiles on every call.
+// Without a resolver, references to registry schemas cannot be resolved.
+func NewSchemaValidatorWithCacheSize(size int) *SchemaValidator {
+	return NewSchemaValidatorWithResolver(nil, size)
+}
+
+// NewSchemaValidatorWithResolver creates a new SchemaValidator that resolves
+// cfguardian://schemas/<name> references through resolver
+func NewSchemaValidatorWithResolver(resolver SchemaResolver, size int) *SchemaValidator {
+	registerDraft07Formats()
+	sv := &SchemaValidator{resolver: resolver}
+	if size > 0 {
+		// lru.New only fails for a size below 1
+		sv.cache, _ = lru.New[string, *cachedSchema](size)
+	}
+	return sv
+}
+
+// Validate validates content against a saved schema version. Its
+// references resolve to the versions pinned when it was published.
+func (sv *SchemaValidator) Validate(ctx context.Context, schemaID string, version int32, schemaContent string, content json.RawMessage) (*ValidationResult, error) {
+	entry, err := sv.compileVersion(ctx, schemaID, version, schemaContent)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	return validateCompiled(entry.schema, content)
+}
+
+// compileVersion returns the compiled entry of a saved schema version
+func (sv *SchemaValidator) compileVersion(ctx context.Context, schemaID string, version int32, schemaContent string) (*cachedSchema, error) {
+	key := schemaID + "@" + strconv.Itoa(int(version)) + "@" + contentHash(schemaContent)
+	return sv.compile(key, schemaContent, func() (map[string]ResolvedSchema, error) {
+		return pinnedReferences(ctx, sv.resolver, schemaID, version, schemaContent)
+	})
+}
+
+// ValidateDraft validates content against unsaved schema content, such as
+// a version about to be published. Its references resolve to the latest
+// versions the project sees.
+func (sv *SchemaValidator) ValidateDraft(ctx context.Context, projectID, schemaContent string, content json.RawMessage) (*ValidationResult, error) {
+	key := draftKeyPrefix + projectID + "@" + contentHash(schemaContent)
+	entry, err := sv.compile(key, schemaContent, func() (map[string]ResolvedSchema, error) {
+		return resolveReferences(ctx, sv.resolver, projectID, "", schemaContent)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	return validateCompiled(entry.schema, content)
+}
+
+// validateCompiled validates content against a compiled schema
+func validateCompiled(schema compiledSchema, content json.RawMessage) (*ValidationResult, error) {
 	// Perform validation
-	result, err := gojsonschema.Validate(schemaLoader, contentLoader)
+	errs, err := schema.validate(content)
 	if err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 	
 	// Build validation result
 	validationResult := &ValidationResult{
-		Valid:  result.Valid(),
-		Errors: make([]ValidationError, 0),
+		Valid:  len(errs) == 0,
+		Errors: make([]ValidationError, 0, len(errs)),
 	}
+	validationResult.Errors = append(validationResult.Errors, errs...)
 	
-	// Collect errors if validation failed
-	if !result.Valid() {
-		for _, err := range result.Errors() {
-			validationResult.Errors = append(validationResult.Errors, ValidationError{
-				Field:       err.Field(),
-				Message:     err.Description(),
-				Value:       err.Value(),
-				Description: err.String(),
-			})
+	return validationResult, nil
+}
+
+// Invalidate drops every compiled version of a schema from the cache,
+// along with the unsaved content compiled with its latest version.
+// Call it when a schema is published or deleted.
+func (sv *SchemaValidator) Invalidate(schemaID string) {
+	if sv.cache == nil {
+		return
+	}
+	prefix := schemaID + "@"
+	for _, key := range sv.cache.Keys() {
+		if strings.HasPrefix(key, prefix) {
+			sv.cache.Remove(key)
+			continue
+		}
+		if entry, ok := sv.cache.Peek(key); ok && strings.HasPrefix(key, draftKeyPrefix) && slices.Contains(entry.references, schemaID) {
+			sv.cache.Remove(key)
 		}
 	}
+}
+
+// CacheStats returns the hits, misses and size of the compiled schema cache
+func (sv *SchemaValidator) CacheStats() SchemaCacheStats {
+	stats := SchemaCacheStats{
+		Hits:   sv.hits.Load(),
+		Misses: sv.misses.Load(),
+	}
+	if sv.cache != nil {
+		stats.Entries = sv.cache.Len()
+	}
+	return stats
+}
+
+// compile returns the entry cached under key, compiling and caching it on
+// a miss with the references resolve returns
+func (sv *SchemaValidator) compile(key, schemaContent string, resolve func() (map[string]ResolvedSchema, error)) (*cachedSchema, error) {
+	if sv.cache != nil {
+		if entry, ok := sv.cache.Get(key); ok {
+			sv.hits.Add(1)
+			return entry, nil
+		}
+	}
+	sv.misses.Add(1)
 	
-	return validationResult, nil
+	references, err := resolve()
+	if err != nil {
+		return nil, err
+	}
+	documents := referenceContents(references)
+	schema, err := compileWithReferences(schemaContent, documents)
+	if err != nil {
+		return nil, err
+	}
+	
+	entry := &cachedSchema{schema: schema, documents: documents, references: make([]string, 0, len(references))}
+	for _, referenced := range references {
+		entry.references = append(entry.references, referenced.SchemaID)
+	}
+	if sv.cache != nil {
+		sv.cache.Add(key, entry)
+	}
+	return entry, nil
+}
+
+// contentHash returns the hex SHA-256 of schema content
+func contentHash(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
 }
 
-// ValidateOrError validates and returns an error if validation fails
-func (sv *SchemaValidator) ValidateOrError(schemaContent string, content json.RawMessage) error {
-	result, err := sv.Validate(schemaContent, content)
+// ValidateOrError validates and returns a ContentValidationError if
+// validation fails
+func (sv *SchemaValidator) ValidateOrError(ctx context.Context, schemaID string, version int32, schemaContent string, content json.RawMessage) error {
+	result, err := sv.Validate(ctx, schemaID, version, schemaContent, content)
 	if err != nil {
 		return err
 	}
 	
 	if !result.Valid {
-		// Build comprehensive error message
-		errMsg := fmt.Sprintf("validation failed with %d error(s):", len(result.Errors))
-		for i, ve := range result.Errors {
-			errMsg += fmt.Sprintf("\n  %d. %s", i+1, ve.Error())
-		}
-		return errors.New(errMsg)
+		return ContentValidationError{Errors: result.Errors}
 	}
 	
 	return nil
 }
 
-// ValidateSchema validates that a schema itself is a valid JSON Schema
-func (sv *SchemaValidator) ValidateSchema(schemaContent string) error {
+// ValidateSchema validates that a schema itself is a valid JSON Schema and
+// that its references resolve to the latest versions projectID sees. name
+// is the schema's registry name, used to detect references back to it; it
+// may be empty for an unsaved schema. It returns every schema the content
+// reaches, sorted by name, for the version to pin when it is published.
+func (sv *SchemaValidator) ValidateSchema(ctx context.Context, projectID, name, schemaContent string) ([]ResolvedSchema, error) {
 	// Parse as JSON first
 	var schema interface{}
 	if err := json.Unmarshal([]byte(schemaContent), &schema); err != nil {
-		return fmt.Errorf("schema is not valid JSON: %w", err)
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
 	}
 	
-	// Try to load as JSON Schema
-	schemaLoader := gojsonschema.NewStringLoader(schemaContent)
-	_, err := gojsonschema.NewSchema(schemaLoader)
+	// Try to load as JSON Schema, with every schema it references
+	references, err := resolveReferences(ctx, sv.resolver, projectID, name, schemaContent)
 	if err != nil {
-		return fmt.Errorf("invalid JSON Schema: %w", err)
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	if _, err := compileWithReferences(schemaContent, referenceContents(references)); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
 	}
 	
-	return nil
+	resolved := make([]ResolvedSchema, 0, len(references))
+	for _, referenced := range references {
+		resolved = append(resolved, referenced)
+	}
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].Name < resolved[j].Name })
+	return resolved, nil
 }
 
 // ValidateContent validates that content is valid JSON
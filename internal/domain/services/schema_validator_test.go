@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -185,7 +187,7 @@ func TestSchemaValidator_Validate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Act
-			result, err := validator.Validate(tt.schema, tt.content)
+			result, err := validator.Validate(context.Background(), "schema-1", 1, tt.schema, tt.content)
 
 			// Assert
 			require.NoError(t, err, "Validation should not return error")
@@ -196,7 +198,7 @@ func TestSchemaValidator_Validate(t *testing.T) {
 				// Check that at least one error contains the expected string
 				found := false
 				for _, validationErr := range result.Errors {
-					if contains(validationErr.Description, tt.errorContains) {
+					if contains(validationErr.Error(), tt.errorContains) {
 						found = true
 						break
 					}
@@ -249,7 +251,7 @@ func TestSchemaValidator_ValidateOrError(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Act
-			err := validator.ValidateOrError(tt.schema, tt.content)
+			err := validator.ValidateOrError(context.Background(), "schema-1", 1, tt.schema, tt.content)
 
 			// Assert
 			if tt.expectError {
@@ -329,7 +331,7 @@ func TestSchemaValidator_ValidateSchema(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Act
-			err := validator.ValidateSchema(tt.schema)
+			_, err := validator.ValidateSchema(context.Background(), "", "", tt.schema)
 
 			// Assert
 			if tt.expectError {
@@ -413,7 +415,8 @@ func TestSchemaValidator_ValidateContent(t *testing.T) {
 func TestValidationError_Error(t *testing.T) {
 	// Arrange
 	ve := ValidationError{
-		Field:   "user.email",
+		Pointer: "/user/email",
+		Keyword: "format",
 		Message: "Invalid format",
 		Value:   "not-an-email",
 	}
@@ -422,10 +425,73 @@ func TestValidationError_Error(t *testing.T) {
 	errMsg := ve.Error()
 
 	// Assert
-	assert.Contains(t, errMsg, "user.email")
+	assert.Contains(t, errMsg, "/user/email")
 	assert.Contains(t, errMsg, "Invalid format")
 }
 
+func TestSchemaValidator_Validate_Pointers(t *testing.T) {
+	// Arrange
+	validator := NewSchemaValidator()
+	schema := `{
+		"type": "object",
+		"properties": {
+			"servers": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {"port": {"type": "integer", "maximum": 65535}},
+					"required": ["host"]
+				}
+			},
+			"a.b/c": {"type": "string"}
+		},
+		"additionalProperties": false
+	}`
+	content := json.RawMessage(`{"servers": [{"host": "a"}, {"port": 70000}], "a.b/c": 1, "extra": true}`)
+
+	// Act
+	result, err := validator.Validate(context.Background(), "schema-1", 1, schema, content)
+
+	// Assert
+	require.NoError(t, err)
+	got := make(map[string]ValidationError)
+	for _, ve := range result.Errors {
+		got[ve.Pointer] = ve
+	}
+	assert.Len(t, got, 4)
+
+	assert.Equal(t, "required", got["/servers/1/host"].Keyword)
+	assert.Nil(t, got["/servers/1/host"].Value)
+
+	assert.Equal(t, "maximum", got["/servers/1/port"].Keyword)
+	assert.EqualValues(t, json.Number("70000"), got["/servers/1/port"].Value)
+
+	assert.Equal(t, "type", got["/a.b~1c"].Keyword)
+
+	assert.Equal(t, "additionalProperties", got["/extra"].Keyword)
+	assert.Equal(t, true, got["/extra"].Value)
+}
+
+func TestSchemaValidator_ValidateOrError_Typed(t *testing.T) {
+	// Arrange
+	validator := NewSchemaValidator()
+	schema := `{"type": "object", "required": ["port"]}`
+
+	// Act
+	err := validator.ValidateOrError(context.Background(), "schema-1", 1, schema, json.RawMessage(`{}`))
+	wrapped := fmt.Errorf("content validation failed: %w", err)
+
+	// Assert
+	validationErr, ok := AsContentValidationError(wrapped)
+	require.True(t, ok)
+	require.Len(t, validationErr.Errors, 1)
+	assert.Equal(t, "/port", validationErr.Errors[0].Pointer)
+	assert.Contains(t, wrapped.Error(), "validation failed with 1 error(s)")
+
+	_, ok = AsContentValidationError(fmt.Errorf("schema not found"))
+	assert.False(t, ok)
+}
+
 func TestSchemaValidator_RealWorldScenario(t *testing.T) {
 	// This test simulates a real configuration validation scenario
 	validator := NewSchemaValidator()
@@ -472,7 +538,7 @@ func TestSchemaValidator_RealWorldScenario(t *testing.T) {
 			"poolSize": 20
 		}`)
 
-		err := validator.ValidateOrError(dbConfigSchema, validConfig)
+		err := validator.ValidateOrError(context.Background(), "db-config", 1, dbConfigSchema, validConfig)
 		assert.NoError(t, err)
 	})
 
@@ -483,7 +549,7 @@ func TestSchemaValidator_RealWorldScenario(t *testing.T) {
 			"port": 5432
 		}`)
 
-		err := validator.ValidateOrError(dbConfigSchema, invalidConfig)
+		err := validator.ValidateOrError(context.Background(), "db-config", 1, dbConfigSchema, invalidConfig)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "database")
 	})
@@ -496,7 +562,7 @@ func TestSchemaValidator_RealWorldScenario(t *testing.T) {
 			"database": "myapp"
 		}`)
 
-		err := validator.ValidateOrError(dbConfigSchema, invalidConfig)
+		err := validator.ValidateOrError(context.Background(), "db-config", 1, dbConfigSchema, invalidConfig)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "port")
 	})
@@ -510,9 +576,683 @@ func TestSchemaValidator_RealWorldScenario(t *testing.T) {
 			"timeout": 30
 		}`)
 
-		err := validator.ValidateOrError(dbConfigSchema, invalidConfig)
+		err := validator.ValidateOrError(context.Background(), "db-config", 1, dbConfigSchema, invalidConfig)
+		assert.Error(t, err)
+	})
+}
+
+func TestSchemaValidator_Cache(t *testing.T) {
+	schemaV1 := `{"type": "object", "properties": {"port": {"type": "integer"}}}`
+	schemaV2 := `{"type": "object", "properties": {"port": {"type": "string"}}}`
+	content := json.RawMessage(`{"port": 8080}`)
+
+	t.Run("reuses the compiled schema", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidator()
+
+		// Act
+		for i := 0; i < 3; i++ {
+			_, err := validator.Validate(context.Background(), "schema-1", 1, schemaV1, content)
+			require.NoError(t, err)
+		}
+
+		// Assert
+		assert.Equal(t, SchemaCacheStats{Hits: 2, Misses: 1, Entries: 1}, validator.CacheStats())
+	})
+
+	t.Run("new version compiles a new entry", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidator()
+		_, err := validator.Validate(context.Background(), "schema-1", 1, schemaV1, content)
+		require.NoError(t, err)
+
+		// Act
+		result, err := validator.Validate(context.Background(), "schema-1", 2, schemaV2, content)
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, SchemaCacheStats{Misses: 2, Entries: 2}, validator.CacheStats())
+	})
+
+	t.Run("invalidate drops only that schema", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidator()
+		_, err := validator.Validate(context.Background(), "schema-1", 1, schemaV1, content)
+		require.NoError(t, err)
+		_, err = validator.Validate(context.Background(), "schema-1", 2, schemaV2, content)
+		require.NoError(t, err)
+		_, err = validator.Validate(context.Background(), "schema-10", 1, schemaV1, content)
+		require.NoError(t, err)
+
+		// Act
+		validator.Invalidate("schema-1")
+
+		// Assert
+		assert.Equal(t, 1, validator.CacheStats().Entries)
+		_, err = validator.Validate(context.Background(), "schema-10", 1, schemaV1, content)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), validator.CacheStats().Hits)
+	})
+
+	t.Run("least recently used entry is evicted", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithCacheSize(1)
+
+		// Act
+		_, err := validator.Validate(context.Background(), "schema-1", 1, schemaV1, content)
+		require.NoError(t, err)
+		_, err = validator.Validate(context.Background(), "schema-2", 1, schemaV1, content)
+		require.NoError(t, err)
+		_, err = validator.Validate(context.Background(), "schema-1", 1, schemaV1, content)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, SchemaCacheStats{Misses: 3, Entries: 1}, validator.CacheStats())
+	})
+
+	t.Run("disabled cache compiles every time", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithCacheSize(0)
+
+		// Act
+		for i := 0; i < 2; i++ {
+			_, err := validator.Validate(context.Background(), "schema-1", 1, schemaV1, content)
+			require.NoError(t, err)
+		}
+		validator.Invalidate("schema-1")
+
+		// Assert
+		assert.Equal(t, SchemaCacheStats{Misses: 2}, validator.CacheStats())
+	})
+
+	t.Run("invalid schema is not cached", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidator()
+
+		// Act
+		_, err := validator.Validate(context.Background(), "schema-1", 1, `{"type": 12}`, content)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 0, validator.CacheStats().Entries)
+	})
+}
+
+func TestSchemaValidator_ModernDrafts(t *testing.T) {
+	validator := NewSchemaValidator()
+	schema := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs": {"port": {"type": "integer", "maximum": 65535}},
+		"type": "object",
+		"properties": {"host": {"type": "string"}, "port": {"$ref": "#/$defs/port"}},
+		"dependentRequired": {"tls_cert": ["tls_key"]},
+		"allOf": [{"properties": {"tls_cert": {"type": "string"}, "tls_key": {"type": "string"}}}],
+		"required": ["host"],
+		"unevaluatedProperties": false
+	}`
+
+	tests := []struct {
+		name           string
+		content        json.RawMessage
+		expectValid    bool
+		expectPointers map[string]string
+	}{
+		{
+			name:        "valid content",
+			content:     json.RawMessage(`{"host": "db", "port": 5432, "tls_cert": "a", "tls_key": "b"}`),
+			expectValid: true,
+		},
+		{
+			name:           "$defs reference",
+			content:        json.RawMessage(`{"host": "db", "port": 70000}`),
+			expectPointers: map[string]string{"/port": "maximum"},
+		},
+		{
+			name:           "dependentRequired",
+			content:        json.RawMessage(`{"host": "db", "tls_cert": "a"}`),
+			expectPointers: map[string]string{"": "dependentRequired"},
+		},
+		{
+			name:           "unevaluatedProperties sees properties evaluated in allOf",
+			content:        json.RawMessage(`{"host": "db", "tls_key": "b", "debug": true}`),
+			expectPointers: map[string]string{"/debug": "unevaluatedProperties"},
+		},
+		{
+			name:           "required points at the missing property",
+			content:        json.RawMessage(`{"port": 1}`),
+			expectPointers: map[string]string{"/host": "required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := validator.Validate(context.Background(), "schema-1", 1, schema, tt.content)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectValid, result.Valid)
+			got := make(map[string]string)
+			for _, ve := range result.Errors {
+				got[ve.Pointer] = ve.Keyword
+				assert.NotEmpty(t, ve.Message)
+			}
+			if tt.expectPointers == nil {
+				assert.Empty(t, got)
+			} else {
+				assert.Equal(t, tt.expectPointers, got)
+			}
+		})
+	}
+}
+
+func TestSchemaValidator_DraftSelection(t *testing.T) {
+	content := json.RawMessage(`{"extra": 1}`)
+
+	tests := []struct {
+		name        string
+		schema      string
+		expectValid bool
+	}{
+		{
+			name:        "draft-07 ignores unevaluatedProperties",
+			schema:      `{"$schema": "http://json-schema.org/draft-07/schema#", "unevaluatedProperties": false}`,
+			expectValid: true,
+		},
+		{
+			name:        "no $schema is read as draft-07",
+			schema:      `{"unevaluatedProperties": false}`,
+			expectValid: true,
+		},
+		{
+			name:   "2019-09 applies unevaluatedProperties",
+			schema: `{"$schema": "https://json-schema.org/draft/2019-09/schema", "unevaluatedProperties": false}`,
+		},
+		{
+			name:   "2020-12 applies unevaluatedProperties",
+			schema: `{"$schema": "https://json-schema.org/draft/2020-12/schema#", "unevaluatedProperties": false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			validator := NewSchemaValidator()
+
+			// Act
+			result, err := validator.Validate(context.Background(), "schema-1", 1, tt.schema, content)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectValid, result.Valid)
+		})
+	}
+
+	t.Run("invalid 2020-12 schema is rejected", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidator()
+
+		// Act
+		_, err := validator.ValidateSchema(context.Background(), "", "", `{"$schema": "https://json-schema.org/draft/2020-12/schema", "minLength": -1}`)
+
+		// Assert
 		assert.Error(t, err)
 	})
+
+	t.Run("2020-12 schema references a draft-07 schema", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{
+			"port": `{"definitions": {"port": {"type": "integer"}}}`,
+		}, DefaultSchemaCacheSize)
+		schema := `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"properties": {"port": {"$ref": "cfguardian://schemas/port#/definitions/port"}}
+		}`
+
+		// Act
+		result, err := validator.Validate(context.Background(), "service", 1, schema, json.RawMessage(`{"port": "80"}`))
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "/port", result.Errors[0].Pointer)
+		assert.Equal(t, "type", result.Errors[0].Keyword)
+	})
+}
+
+func TestSchemaValidator_Formats(t *testing.T) {
+	tests := []struct {
+		format  string
+		valid   []string
+		invalid []string
+	}{
+		{
+			format:  "go-duration",
+			valid:   []string{"30s", "1h30m", "250ms", "0"},
+			invalid: []string{"30", "1 hour", "PT30S"},
+		},
+		{
+			format:  "cron",
+			valid:   []string{"*/15 9-17 * * mon-fri", "0 0 1,15 * *", "5 4 * JAN sun", "@hourly", "@every 90s"},
+			invalid: []string{"* * * *", "60 * * * *", "* * * 13 *", "5-1 * * * *", "*/0 * * * *", "@often", "@every soon"},
+		},
+		{
+			format:  "semver",
+			valid:   []string{"1.0.0", "0.4.12-rc.1+build.5", "2.0.0-alpha"},
+			invalid: []string{"v1.0.0", "1.0", "01.0.0", "1.0.0-"},
+		},
+		{
+			format:  "cidr",
+			valid:   []string{"10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32"},
+			invalid: []string{"10.0.0.0", "10.0.0.0/33", "example.com/8"},
+		},
+		{
+			format:  "host-port",
+			valid:   []string{"localhost:8080", "db.internal:5432", "10.0.0.1:443", "[::1]:443"},
+			invalid: []string{"localhost", ":8080", "db:0", "db:70000", "db:http", "bad_host:80"},
+		},
+		{
+			format:  "http-url",
+			valid:   []string{"http://example.com", "https://api.example.com/v1?x=1"},
+			invalid: []string{"ftp://example.com", "example.com", "https://", "/relative"},
+		},
+		{
+			format:  "https-url",
+			valid:   []string{"https://example.com"},
+			invalid: []string{"http://example.com"},
+		},
+	}
+
+	drafts := map[string]string{
+		"draft-07": "http://json-schema.org/draft-07/schema#",
+		"2020-12":  Draft202012,
+	}
+
+	validator := NewSchemaValidator()
+	for _, tt := range tests {
+		for draft, uri := range drafts {
+			t.Run(tt.format+"/"+draft, func(t *testing.T) {
+				// Arrange
+				schema := fmt.Sprintf(`{"$schema": %q, "properties": {"value": {"format": %q}}}`, uri, tt.format)
+
+				for _, value := range tt.valid {
+					// Act
+					content, _ := json.Marshal(map[string]string{"value": value})
+					result, err := validator.Validate(context.Background(), "formats", 1, schema, content)
+
+					// Assert
+					require.NoError(t, err)
+					assert.True(t, result.Valid, "%q should be a valid %s", value, tt.format)
+				}
+				for _, value := range tt.invalid {
+					// Act
+					content, _ := json.Marshal(map[string]string{"value": value})
+					result, err := validator.Validate(context.Background(), "formats", 1, schema, content)
+
+					// Assert
+					require.NoError(t, err)
+					require.False(t, result.Valid, "%q should not be a valid %s", value, tt.format)
+					assert.Equal(t, "format", result.Errors[0].Keyword)
+				}
+			})
+		}
+	}
+}
+
+// registry is a SchemaResolver over the latest schema content by name.
+// Every saved version pins all of its schemas.
+type registry map[string]string
+
+func (r registry) LatestSchema(_ context.Context, _, name string) (*ResolvedSchema, error) {
+	content, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("schema %q not found", name)
+	}
+	return &ResolvedSchema{Name: name, SchemaID: name, Version: 1, Content: content}, nil
+}
+
+func (r registry) PinnedSchemas(context.Context, string, int32) ([]ResolvedSchema, error) {
+	pinned := make([]ResolvedSchema, 0, len(r))
+	for name, content := range r {
+		pinned = append(pinned, ResolvedSchema{Name: name, SchemaID: name, Version: 1, Content: content})
+	}
+	return pinned, nil
+}
+
+// pinnedRegistry is a SchemaResolver over the references pinned by each
+// saved version, keyed by schema ID and version, with the latest versions
+// in latest
+type pinnedRegistry struct {
+	latest registry
+	pinned map[string][]ResolvedSchema
+}
+
+func (r pinnedRegistry) LatestSchema(ctx context.Context, projectID, name string) (*ResolvedSchema, error) {
+	return r.latest.LatestSchema(ctx, projectID, name)
+}
+
+func (r pinnedRegistry) PinnedSchemas(_ context.Context, schemaID string, version int32) ([]ResolvedSchema, error) {
+	return r.pinned[fmt.Sprintf("%s@%d", schemaID, version)], nil
+}
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		name        string
+		schema      string
+		expected    []string
+		expectError error
+	}{
+		{
+			name:     "no references",
+			schema:   `{"type": "object"}`,
+			expected: []string{},
+		},
+		{
+			name:     "local reference",
+			schema:   `{"definitions": {"port": {"type": "integer"}}, "properties": {"port": {"$ref": "#/definitions/port"}}}`,
+			expected: []string{},
+		},
+		{
+			name:     "registry references sorted by name",
+			schema:   `{"properties": {"tls": {"$ref": "cfguardian://schemas/tls#/definitions/tls"}, "db": {"$ref": "cfguardian://schemas/db"}, "backup": {"$ref": "cfguardian://schemas/tls"}}, "required": ["tls"]}`,
+			expected: []string{"db", "tls"},
+		},
+		{
+			name:     "$ref inside data keywords is ignored",
+			schema:   `{"enum": [{"$ref": "http://example.com"}], "default": {"$ref": "file:///etc/passwd"}}`,
+			expected: []string{},
+		},
+		{
+			name:        "http reference is rejected",
+			schema:      `{"$ref": "https://example.com/schema.json"}`,
+			expectError: ErrRemoteSchemaRef,
+		},
+		{
+			name:        "relative reference is rejected",
+			schema:      `{"items": [{"$ref": "other.json#/definitions/x"}]}`,
+			expectError: ErrRemoteSchemaRef,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			names, err := References(tt.schema)
+
+			// Assert
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			if len(tt.expected) == 0 {
+				assert.Empty(t, names)
+			} else {
+				assert.Equal(t, tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestSchemaValidator_RegistryReferences(t *testing.T) {
+	tlsSchema := `{
+		"definitions": {
+			"tls": {
+				"type": "object",
+				"properties": {"enabled": {"type": "boolean"}, "cert": {"$ref": "#/definitions/path"}},
+				"required": ["enabled"]
+			},
+			"path": {"type": "string", "pattern": "^/"}
+		}
+	}`
+	serviceSchema := `{
+		"type": "object",
+		"properties": {"tls": {"$ref": "cfguardian://schemas/tls#/definitions/tls"}}
+	}`
+
+	t.Run("resolves a reference to another schema", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{"tls": tlsSchema}, DefaultSchemaCacheSize)
+
+		// Act
+		valid, err := validator.Validate(context.Background(), "service", 1, serviceSchema, json.RawMessage(`{"tls": {"enabled": true, "cert": "/etc/cert.pem"}}`))
+		require.NoError(t, err)
+		invalid, err := validator.Validate(context.Background(), "service", 1, serviceSchema, json.RawMessage(`{"tls": {"cert": "cert.pem"}}`))
+		require.NoError(t, err)
+
+		// Assert
+		assert.True(t, valid.Valid)
+		assert.False(t, invalid.Valid)
+		pointers := make([]string, len(invalid.Errors))
+		for i, ve := range invalid.Errors {
+			pointers[i] = ve.Pointer
+		}
+		assert.ElementsMatch(t, []string{"/tls/enabled", "/tls/cert"}, pointers)
+	})
+
+	t.Run("resolves references transitively", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{
+			"tls":     tlsSchema,
+			"service": serviceSchema,
+		}, DefaultSchemaCacheSize)
+		gatewaySchema := `{"properties": {"upstream": {"$ref": "cfguardian://schemas/service"}}}`
+
+		// Act
+		result, err := validator.Validate(context.Background(), "gateway", 1, gatewaySchema, json.RawMessage(`{"upstream": {"tls": {"enabled": "yes"}}}`))
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "/upstream/tls/enabled", result.Errors[0].Pointer)
+	})
+
+	t.Run("saved version validates with the versions it pinned", func(t *testing.T) {
+		// Arrange
+		tlsV2 := `{"definitions": {"tls": {"type": "object", "required": ["cert"]}}}`
+		validator := NewSchemaValidatorWithResolver(pinnedRegistry{
+			latest: registry{"tls": tlsV2},
+			pinned: map[string][]ResolvedSchema{
+				"service@1": {{Name: "tls", SchemaID: "tls", Version: 1, Content: tlsSchema}},
+			},
+		}, DefaultSchemaCacheSize)
+		content := json.RawMessage(`{"tls": {"enabled": true}}`)
+
+		// Act
+		saved, err := validator.Validate(context.Background(), "service", 1, serviceSchema, content)
+		require.NoError(t, err)
+		draft, err := validator.ValidateDraft(context.Background(), "", serviceSchema, content)
+		require.NoError(t, err)
+
+		// Assert
+		assert.True(t, saved.Valid, "tls version 1 does not require cert")
+		assert.False(t, draft.Valid, "unsaved content resolves the latest tls")
+	})
+
+	t.Run("reference missing from the pinned versions is reported", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(pinnedRegistry{latest: registry{"tls": tlsSchema}}, DefaultSchemaCacheSize)
+
+		// Act
+		_, err := validator.Validate(context.Background(), "service", 1, serviceSchema, json.RawMessage(`{}`))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not pinned by service version 1")
+	})
+
+	t.Run("validate schema returns every schema it reaches", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{
+			"tls":     tlsSchema,
+			"service": serviceSchema,
+		}, DefaultSchemaCacheSize)
+
+		// Act
+		references, err := validator.ValidateSchema(context.Background(), "", "gateway", `{"properties": {"upstream": {"$ref": "cfguardian://schemas/service"}}}`)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []ResolvedSchema{
+			{Name: "service", SchemaID: "service", Version: 1, Content: serviceSchema},
+			{Name: "tls", SchemaID: "tls", Version: 1, Content: tlsSchema},
+		}, references)
+	})
+
+	t.Run("remote reference is rejected", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{}, DefaultSchemaCacheSize)
+		schema := `{"properties": {"tls": {"$ref": "https://example.com/tls.json"}}}`
+
+		// Act
+		_, err := validator.ValidateSchema(context.Background(), "", "service", schema)
+		_, validateErr := validator.Validate(context.Background(), "service", 1, schema, json.RawMessage(`{}`))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrRemoteSchemaRef)
+		assert.ErrorIs(t, validateErr, ErrRemoteSchemaRef)
+	})
+
+	t.Run("remote reference in a referenced schema is rejected", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{
+			"tls": `{"$ref": "http://169.254.169.254/latest/meta-data"}`,
+		}, DefaultSchemaCacheSize)
+
+		// Act
+		_, err := validator.ValidateSchema(context.Background(), "", "service", serviceSchema)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrRemoteSchemaRef)
+	})
+
+	t.Run("cycle through the schema itself is rejected", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{
+			"tls": `{"properties": {"owner": {"$ref": "cfguardian://schemas/service"}}}`,
+		}, DefaultSchemaCacheSize)
+
+		// Act
+		_, err := validator.ValidateSchema(context.Background(), "", "service", serviceSchema)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSchemaRefCycle)
+		assert.Contains(t, err.Error(), "service -> tls -> service")
+	})
+
+	t.Run("cycle between referenced schemas is rejected", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{
+			"a": `{"$ref": "cfguardian://schemas/b"}`,
+			"b": `{"$ref": "cfguardian://schemas/a"}`,
+		}, DefaultSchemaCacheSize)
+
+		// Act
+		_, err := validator.ValidateDraft(context.Background(), "", `{"$ref": "cfguardian://schemas/a"}`, json.RawMessage(`{}`))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSchemaRefCycle)
+	})
+
+	t.Run("missing schema is reported", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidatorWithResolver(registry{}, DefaultSchemaCacheSize)
+
+		// Act
+		_, err := validator.ValidateSchema(context.Background(), "", "service", serviceSchema)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cfguardian://schemas/tls")
+	})
+
+	t.Run("without a resolver references cannot be resolved", func(t *testing.T) {
+		// Arrange
+		validator := NewSchemaValidator()
+
+		// Act
+		_, err := validator.ValidateSchema(context.Background(), "", "service", serviceSchema)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no schema registry")
+	})
+
+	t.Run("draft is recompiled once a schema it references is invalidated", func(t *testing.T) {
+		// Arrange
+		schemas := registry{"tls": tlsSchema}
+		validator := NewSchemaValidatorWithResolver(schemas, DefaultSchemaCacheSize)
+		content := json.RawMessage(`{"tls": {"enabled": true}}`)
+		_, err := validator.ValidateDraft(context.Background(), "", serviceSchema, content)
+		require.NoError(t, err)
+		_, err = validator.Validate(context.Background(), "service", 1, serviceSchema, content)
+		require.NoError(t, err)
+		schemas["tls"] = `{"definitions": {"tls": {"required": ["enabled", "cert"]}}}`
+
+		// Act
+		validator.Invalidate("tls")
+		result, err := validator.ValidateDraft(context.Background(), "", serviceSchema, content)
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, SchemaCacheStats{Misses: 3, Entries: 2}, validator.CacheStats())
+	})
+}
+
+// benchmarkSchema builds a schema with many nested properties, like the
+// large schemas that make bulk imports slow, and content that passes it
+func benchmarkSchema() (string, json.RawMessage) {
+	properties := make(map[string]interface{})
+	values := make(map[string]interface{})
+	for i := 0; i < 200; i++ {
+		name := fmt.Sprintf("field_%d", i)
+		properties[name] = map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"enabled": map[string]interface{}{"type": "boolean"},
+				"timeout": map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 3600},
+				"url":     map[string]interface{}{"type": "string", "pattern": "^https?://"},
+				"tags":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			},
+			"required":             []string{"enabled"},
+			"additionalProperties": false,
+		}
+		values[name] = map[string]interface{}{
+			"enabled": true,
+			"timeout": 30,
+			"url":     "https://example.com",
+			"tags":    []string{"a", "b"},
+		}
+	}
+	schema, _ := json.Marshal(map[string]interface{}{"type": "object", "properties": properties})
+	content, _ := json.Marshal(values)
+	return string(schema), content
+}
+
+func BenchmarkSchemaValidator_Validate(b *testing.B) {
+	schema, content := benchmarkSchema()
+
+	b.Run("uncached", func(b *testing.B) {
+		validator := NewSchemaValidatorWithCacheSize(0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := validator.Validate(context.Background(), "schema-1", 1, schema, content); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		validator := NewSchemaValidator()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := validator.Validate(context.Background(), "schema-1", 1, schema, content); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
 }
 
 // Helper function to check if a string contains a substring (case-insensitive)
@@ -0,0 +1,51 @@
+// Code generated by cfguardian codegen. DO NOT EDIT.
+// Source: testdata/codegen/checkout.json
+
+package config
+
+type Checkout struct {
+	Amount    any              `json:"amount,omitempty"`
+	Children  []Checkout       `json:"children,omitempty"`
+	CreatedAt *string          `json:"created_at,omitempty"`
+	Currency  CheckoutCurrency `json:"currency"`
+	Fallback  *string          `json:"fallback,omitempty"`
+	ID        string           `json:"id"`
+	Mode      *CheckoutMode    `json:"mode,omitempty"`
+
+	// Free text; may contain */ markers.
+	Note any `json:"note,omitempty"`
+
+	// Queue priority, 1 is highest.
+	Priority *CheckoutPriority `json:"priority,omitempty"`
+	Provider any               `json:"provider"`
+	Window   []any             `json:"window,omitempty"`
+}
+
+type CheckoutCurrency string
+
+const (
+	CheckoutCurrencyEUR CheckoutCurrency = "EUR"
+)
+
+type CheckoutMode string
+
+const (
+	CheckoutModeLive    CheckoutMode = "live"
+	CheckoutModeSandbox CheckoutMode = "sandbox"
+)
+
+// One of: 1, 2, 3.
+type CheckoutPriority int64
+
+type CheckoutProviderStripe struct {
+	Key string `json:"key"`
+}
+
+type CheckoutProviderAdyen struct {
+	Merchant *string `json:"merchant,omitempty"`
+}
+
+type Base struct {
+	CreatedAt *string `json:"created_at,omitempty"`
+	ID        string  `json:"id"`
+}
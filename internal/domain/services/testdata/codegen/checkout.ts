@@ -0,0 +1,37 @@
+// Code generated by cfguardian codegen. DO NOT EDIT.
+// Source: testdata/codegen/checkout.json
+
+export interface Checkout {
+  amount?: number | string;
+  children?: Checkout[];
+  created_at?: string;
+  currency: CheckoutCurrency;
+  fallback?: string | null;
+  id: string;
+  mode?: CheckoutMode | null;
+  /** Free text; may contain *\/ markers. */
+  note?: unknown;
+  /** Queue priority, 1 is highest. */
+  priority?: CheckoutPriority;
+  provider: CheckoutProviderStripe | CheckoutProviderAdyen;
+  window?: unknown[];
+}
+
+export type CheckoutCurrency = "EUR";
+
+export type CheckoutMode = "live" | "sandbox";
+
+export type CheckoutPriority = 1 | 2 | 3;
+
+export interface CheckoutProviderStripe {
+  key: string;
+}
+
+export interface CheckoutProviderAdyen {
+  merchant?: string;
+}
+
+export interface Base {
+  created_at?: string;
+  id: string;
+}
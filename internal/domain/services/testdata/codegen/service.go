@@ -0,0 +1,91 @@
+// Code generated by cfguardian codegen. DO NOT EDIT.
+// Source: testdata/codegen/service.json
+
+package config
+
+// Settings of one deployed service.
+type Service struct {
+	APIURL *string `json:"api-url,omitempty"`
+
+	// Primary database.
+	Database ServiceDatabase `json:"database"`
+
+	// Default: false
+	Debug  *bool                         `json:"debug,omitempty"`
+	Extra  any                           `json:"extra,omitempty"`
+	Labels map[string]string             `json:"labels,omitempty"`
+	Limits map[string]ServiceLimitsValue `json:"limits,omitempty"`
+
+	// Default: "info"
+	LogLevel ServiceLogLevel `json:"log_level"`
+
+	// Service name as registered in discovery.
+	Name string `json:"name"`
+
+	// Team that owns the service.
+	Owner *string `json:"owner,omitempty"`
+
+	// Default: 8080
+	Port     *int64     `json:"port,omitempty"`
+	Replicas []Endpoint `json:"replicas,omitempty"`
+	Retry    *Retry     `json:"retry,omitempty"`
+
+	// Default: 0.25
+	SampleRate *float64 `json:"sample_rate,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+type ServiceDatabase struct {
+	Host string `json:"host"`
+
+	// Default: 10
+	MaxConns *int64 `json:"maxConns,omitempty"`
+	TLS      *TLS   `json:"tls,omitempty"`
+}
+
+// TLS settings.
+// Disabled unless enabled is set.
+type TLS struct {
+	// Default: false
+	Enabled    *bool          `json:"enabled,omitempty"`
+	MinVersion *TLSMinVersion `json:"min_version,omitempty"`
+}
+
+type TLSMinVersion string
+
+const (
+	TLSMinVersion12 TLSMinVersion = "1.2"
+	TLSMinVersion13 TLSMinVersion = "1.3"
+)
+
+type ServiceLimitsValue struct {
+	Burst *int64   `json:"burst,omitempty"`
+	Rate  *float64 `json:"rate,omitempty"`
+}
+
+type ServiceLogLevel string
+
+const (
+	ServiceLogLevelDebug ServiceLogLevel = "debug"
+	ServiceLogLevelInfo  ServiceLogLevel = "info"
+	ServiceLogLevelWarn  ServiceLogLevel = "warn"
+	ServiceLogLevelError ServiceLogLevel = "error"
+)
+
+type Endpoint struct {
+	Host string `json:"host"`
+	Port int64  `json:"port"`
+
+	// Default: 1
+	Weight *int64 `json:"weight,omitempty"`
+}
+
+type Retry struct {
+	// Default: 3
+	Attempts *int64 `json:"attempts,omitempty"`
+
+	// Default: "100ms"
+	Backoff *string `json:"backoff,omitempty"`
+}
+
+type Duration string
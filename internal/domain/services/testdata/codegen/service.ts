@@ -0,0 +1,69 @@
+// Code generated by cfguardian codegen. DO NOT EDIT.
+// Source: testdata/codegen/service.json
+
+/** Settings of one deployed service. */
+export interface Service {
+  "api-url"?: string;
+  /** Primary database. */
+  database: ServiceDatabase;
+  /** @default false */
+  debug?: boolean;
+  extra?: unknown;
+  labels?: Record<string, string>;
+  limits?: Record<string, ServiceLimitsValue>;
+  /** @default "info" */
+  log_level: ServiceLogLevel;
+  /** Service name as registered in discovery. */
+  name: string;
+  /** Team that owns the service. */
+  owner?: string | null;
+  /** @default 8080 */
+  port?: number;
+  replicas?: Endpoint[];
+  retry?: Retry;
+  /** @default 0.25 */
+  sample_rate?: number;
+  tags?: string[];
+}
+
+export interface ServiceDatabase {
+  host: string;
+  /** @default 10 */
+  maxConns?: number;
+  tls?: TLS;
+}
+
+/**
+ * TLS settings.
+ * Disabled unless enabled is set.
+ */
+export interface TLS {
+  /** @default false */
+  enabled?: boolean;
+  min_version?: TLSMinVersion;
+}
+
+export type TLSMinVersion = "1.2" | "1.3";
+
+export interface ServiceLimitsValue {
+  burst?: number;
+  rate?: number;
+}
+
+export type ServiceLogLevel = "debug" | "info" | "warn" | "error";
+
+export interface Endpoint {
+  host: string;
+  port: number;
+  /** @default 1 */
+  weight?: number;
+}
+
+export interface Retry {
+  /** @default 3 */
+  attempts?: number;
+  /** @default "100ms" */
+  backoff?: string;
+}
+
+export type Duration = string;
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
@@ -25,10 +26,17 @@ func (e VersionConflictError) Error() string {
 
 // IsVersionConflict checks if an error is a version conflict error
 func IsVersionConflict(err error) bool {
-	_, ok := err.(VersionConflictError)
+	_, ok := AsVersionConflict(err)
 	return ok
 }
 
+// AsVersionConflict finds a VersionConflictError in an error chain
+func AsVersionConflict(err error) (VersionConflictError, bool) {
+	var conflictErr VersionConflictError
+	ok := errors.As(err, &conflictErr)
+	return conflictErr, ok
+}
+
 // VersionManager manages version-related operations for optimistic locking
 type VersionManager struct{}
 
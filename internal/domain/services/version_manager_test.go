@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -452,6 +453,23 @@ func TestVersionConflictError(t *testing.T) {
 		assert.True(t, IsVersionConflict(err))
 	})
 
+	t.Run("IsVersionConflict finds a wrapped VersionConflictError", func(t *testing.T) {
+		// Arrange
+		err := fmt.Errorf("failed to update config: %w", VersionConflictError{
+			Expected: valueobjects.MustNewVersion(1),
+			Current:  valueobjects.MustNewVersion(2),
+			Key:      "test",
+		})
+
+		// Act
+		conflict, ok := AsVersionConflict(err)
+
+		// Assert
+		assert.True(t, IsVersionConflict(err))
+		require.True(t, ok)
+		assert.Equal(t, int64(2), conflict.Current.Value())
+	})
+
 	t.Run("IsVersionConflict returns false for other errors", func(t *testing.T) {
 		// Arrange
 		err := errors.New("some other error")
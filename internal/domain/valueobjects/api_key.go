@@ -15,11 +15,21 @@ const (
 
 	// APIKeyRandomPartLength is the length of the random part
 	APIKeyRandomPartLength = 32
+
+	// APIKeyLookupPrefixLength is how much of a key is stored in plaintext
+	// to find its hash: cfg_ (4) + 8 random chars
+	APIKeyLookupPrefixLength = 12
+
+	// APIKeyHintLength is how many trailing characters are kept for display
+	APIKeyHintLength = 4
 )
 
 // apiKeyRegex validates the API key format (base64 URL-safe: alphanumeric + - and _)
 var apiKeyRegex = regexp.MustCompile(`^cfg_[a-zA-Z0-9_-]{32}$`)
 
+// embeddedAPIKeyRegex finds API keys inside arbitrary text such as URLs and log lines
+var embeddedAPIKeyRegex = regexp.MustCompile(`cfg_[a-zA-Z0-9_-]{32}`)
+
 // APIKey represents a validated API key for client access
 type APIKey struct {
 	value string
@@ -85,10 +95,41 @@ func (a APIKey) Masked() string {
 	if len(a.value) < 8 {
 		return "***"
 	}
-	return fmt.Sprintf("%s...%s", APIKeyPrefix, a.value[len(a.value)-4:])
+	return maskAPIKey(a.Hint())
+}
+
+// LookupPrefix returns the leading part of the key used to find its stored hash
+func (a APIKey) LookupPrefix() string {
+	if len(a.value) < APIKeyLookupPrefixLength {
+		return a.value
+	}
+	return a.value[:APIKeyLookupPrefixLength]
+}
+
+// Hint returns the last characters of the key, kept so users can tell keys apart
+func (a APIKey) Hint() string {
+	if len(a.value) < APIKeyHintLength {
+		return a.value
+	}
+	return a.value[len(a.value)-APIKeyHintLength:]
 }
 
 // HasPrefix checks if the API key has the correct prefix
 func (a APIKey) HasPrefix() bool {
 	return strings.HasPrefix(a.value, APIKeyPrefix)
 }
+
+// MaskAPIKeys replaces every API key found in s with its masked form
+func MaskAPIKeys(s string) string {
+	if !strings.Contains(s, APIKeyPrefix) {
+		return s
+	}
+	return embeddedAPIKeyRegex.ReplaceAllStringFunc(s, func(key string) string {
+		return APIKey{value: key}.Masked()
+	})
+}
+
+// maskAPIKey formats a key's hint for display
+func maskAPIKey(hint string) string {
+	return fmt.Sprintf("%s...%s", APIKeyPrefix, hint)
+}
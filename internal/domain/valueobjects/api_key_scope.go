@@ -0,0 +1,64 @@
+package valueobjects
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxAPIKeyScopePrefixes limits how many config key prefixes a single API key may be scoped to
+const MaxAPIKeyScopePrefixes = 50
+
+// APIKeyScope restricts which configs an API key can read.
+// API keys are always read-only; an empty scope grants every config in the project.
+type APIKeyScope struct {
+	keyPrefixes []string
+}
+
+// NewAPIKeyScope creates a scope limited to configs whose key starts with one of the prefixes.
+// Duplicates are removed; no prefixes means the whole project.
+func NewAPIKeyScope(keyPrefixes []string) (APIKeyScope, error) {
+	if len(keyPrefixes) > MaxAPIKeyScopePrefixes {
+		return APIKeyScope{}, fmt.Errorf("api key scope cannot have more than %d key prefixes", MaxAPIKeyScopePrefixes)
+	}
+
+	seen := make(map[string]struct{}, len(keyPrefixes))
+	prefixes := make([]string, 0, len(keyPrefixes))
+	for _, prefix := range keyPrefixes {
+		trimmed := strings.TrimSpace(prefix)
+		if trimmed == "" {
+			return APIKeyScope{}, fmt.Errorf("api key scope prefixes cannot be empty")
+		}
+		if _, ok := seen[trimmed]; ok {
+			continue
+		}
+		seen[trimmed] = struct{}{}
+		prefixes = append(prefixes, trimmed)
+	}
+	sort.Strings(prefixes)
+
+	return APIKeyScope{keyPrefixes: prefixes}, nil
+}
+
+// KeyPrefixes returns the config key prefixes the scope is limited to
+func (s APIKeyScope) KeyPrefixes() []string {
+	return append([]string(nil), s.keyPrefixes...)
+}
+
+// IsUnrestricted reports whether the scope covers every config in the project
+func (s APIKeyScope) IsUnrestricted() bool {
+	return len(s.keyPrefixes) == 0
+}
+
+// AllowsConfigKey checks if a config key is within the scope
+func (s APIKeyScope) AllowsConfigKey(key string) bool {
+	if s.IsUnrestricted() {
+		return true
+	}
+	for _, prefix := range s.keyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
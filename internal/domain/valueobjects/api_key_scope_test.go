@@ -0,0 +1,64 @@
+package valueobjects
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKeyScope(t *testing.T) {
+	t.Run("no prefixes is unrestricted", func(t *testing.T) {
+		scope, err := NewAPIKeyScope(nil)
+		require.NoError(t, err)
+
+		assert.True(t, scope.IsUnrestricted())
+		assert.True(t, scope.AllowsConfigKey("anything"))
+		assert.Empty(t, scope.KeyPrefixes())
+	})
+
+	t.Run("normalizes prefixes", func(t *testing.T) {
+		scope, err := NewAPIKeyScope([]string{" mobile.", "web.", "mobile."})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"mobile.", "web."}, scope.KeyPrefixes())
+	})
+
+	t.Run("rejects empty prefix", func(t *testing.T) {
+		_, err := NewAPIKeyScope([]string{"web.", "  "})
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects too many prefixes", func(t *testing.T) {
+		prefixes := make([]string, MaxAPIKeyScopePrefixes+1)
+		for i := range prefixes {
+			prefixes[i] = strings.Repeat("p", i+1)
+		}
+
+		_, err := NewAPIKeyScope(prefixes)
+		assert.Error(t, err)
+	})
+}
+
+func TestAPIKeyScope_AllowsConfigKey(t *testing.T) {
+	scope, err := NewAPIKeyScope([]string{"mobile.", "feature-flags"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"mobile.ios", true},
+		{"feature-flags", true},
+		{"feature-flags-beta", true},
+		{"web.app", false},
+		{"mobile", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, scope.AllowsConfigKey(tt.key))
+		})
+	}
+}
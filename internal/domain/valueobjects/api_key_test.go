@@ -104,6 +104,28 @@ func TestAPIKey_Masked(t *testing.T) {
 	assert.NotEqual(t, value, masked)
 }
 
+func TestMaskAPIKeys(t *testing.T) {
+	key := "cfg_" + strings.Repeat("a", 28) + "wxyz"
+	
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"url path", "/api/v1/read/" + key + "/app-config", "/api/v1/read/cfg_...wxyz/app-config"},
+		{"header value", "ApiKey " + key, "ApiKey cfg_...wxyz"},
+		{"multiple keys", key + "," + key, "cfg_...wxyz,cfg_...wxyz"},
+		{"no key", "/api/v1/projects", "/api/v1/projects"},
+		{"prefix only", "cfg_short", "cfg_short"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MaskAPIKeys(tt.input))
+		})
+	}
+}
+
 func TestAPIKey_Equals(t *testing.T) {
 	key1, _ := NewAPIKey("cfg_" + strings.Repeat("a", 32))
 	key2, _ := NewAPIKey("cfg_" + strings.Repeat("a", 32))
@@ -0,0 +1,112 @@
+package valueobjects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigFormat is a file format a config can be rendered in
+type ConfigFormat string
+
+const (
+	// ConfigFormatJSON is the stored form of every config
+	ConfigFormatJSON ConfigFormat = "json"
+
+	// ConfigFormatYAML renders a config as a YAML document
+	ConfigFormatYAML ConfigFormat = "yaml"
+
+	// ConfigFormatTOML renders a config as a TOML document
+	ConfigFormatTOML ConfigFormat = "toml"
+
+	// ConfigFormatDotenv renders a config as flattened KEY=value lines
+	ConfigFormatDotenv ConfigFormat = "dotenv"
+
+	// ConfigFormatProperties renders a config as a Java .properties file
+	ConfigFormatProperties ConfigFormat = "properties"
+)
+
+// configFormatMediaTypes maps each format to the media types it is served
+// as. The first entry is the Content-Type of a rendered config.
+var configFormatMediaTypes = map[ConfigFormat][]string{
+	ConfigFormatJSON:       {"application/json"},
+	ConfigFormatYAML:       {"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"},
+	ConfigFormatTOML:       {"application/toml", "text/x-toml"},
+	ConfigFormatDotenv:     {"text/x-dotenv", "application/x-dotenv"},
+	ConfigFormatProperties: {"text/x-java-properties", "text/x-properties"},
+}
+
+// AllConfigFormats returns all valid config formats
+func AllConfigFormats() []ConfigFormat {
+	return []ConfigFormat{ConfigFormatJSON, ConfigFormatYAML, ConfigFormatTOML, ConfigFormatDotenv, ConfigFormatProperties}
+}
+
+// NewConfigFormat creates a new ConfigFormat with validation.
+// The aliases yml and env are accepted for yaml and dotenv.
+func NewConfigFormat(format string) (ConfigFormat, error) {
+	normalized := strings.ToLower(strings.TrimSpace(format))
+
+	switch normalized {
+	case "yml":
+		return ConfigFormatYAML, nil
+	case "env":
+		return ConfigFormatDotenv, nil
+	}
+
+	for _, f := range AllConfigFormats() {
+		if normalized == string(f) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("invalid config format: %s (must be json, yaml, toml, dotenv, or properties)", format)
+}
+
+// ConfigFormatForMediaType returns the format served as a media type
+func ConfigFormatForMediaType(mediaType string) (ConfigFormat, bool) {
+	mediaType = strings.ToLower(mediaType)
+	for format, mediaTypes := range configFormatMediaTypes {
+		for _, mt := range mediaTypes {
+			if mt == mediaType {
+				return format, true
+			}
+		}
+	}
+	return "", false
+}
+
+// configFormatExtensions maps file extensions to the format of the file
+var configFormatExtensions = map[string]ConfigFormat{
+	".json":       ConfigFormatJSON,
+	".yaml":       ConfigFormatYAML,
+	".yml":        ConfigFormatYAML,
+	".toml":       ConfigFormatTOML,
+	".env":        ConfigFormatDotenv,
+	".properties": ConfigFormatProperties,
+}
+
+// ConfigFormatForFileName returns the format of a file from its extension,
+// along with the name without the extension
+func ConfigFormatForFileName(name string) (ConfigFormat, string, bool) {
+	dot := strings.LastIndexByte(name, '.')
+	if dot < 0 {
+		return "", name, false
+	}
+	format, ok := configFormatExtensions[strings.ToLower(name[dot:])]
+	if !ok {
+		return "", name, false
+	}
+	return format, name[:dot], true
+}
+
+// String returns the string representation
+func (f ConfigFormat) String() string {
+	return string(f)
+}
+
+// MediaType returns the Content-Type of a config rendered in this format
+func (f ConfigFormat) MediaType() string {
+	mediaTypes, ok := configFormatMediaTypes[f]
+	if !ok {
+		return ""
+	}
+	return mediaTypes[0]
+}
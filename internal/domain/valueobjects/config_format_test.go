@@ -0,0 +1,78 @@
+package valueobjects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    ConfigFormat
+		wantErr bool
+	}{
+		{name: "json", input: "json", want: ConfigFormatJSON},
+		{name: "case insensitive", input: "YAML", want: ConfigFormatYAML},
+		{name: "yml alias", input: "yml", want: ConfigFormatYAML},
+		{name: "env alias", input: "env", want: ConfigFormatDotenv},
+		{name: "properties", input: " properties ", want: ConfigFormatProperties},
+		{name: "unknown format", input: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConfigFormat(tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfigFormatForMediaType(t *testing.T) {
+	for _, format := range AllConfigFormats() {
+		got, ok := ConfigFormatForMediaType(format.MediaType())
+		assert.True(t, ok, format)
+		assert.Equal(t, format, got)
+	}
+
+	got, ok := ConfigFormatForMediaType("text/yaml")
+	assert.True(t, ok)
+	assert.Equal(t, ConfigFormatYAML, got)
+
+	_, ok = ConfigFormatForMediaType("text/html")
+	assert.False(t, ok)
+}
+
+func TestConfigFormatForFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     ConfigFormat
+		wantBase string
+		wantOK   bool
+	}{
+		{"mobile/api.yaml", ConfigFormatYAML, "mobile/api", true},
+		{"app.YML", ConfigFormatYAML, "app", true},
+		{"app.prod.env", ConfigFormatDotenv, "app.prod", true},
+		{"app.properties", ConfigFormatProperties, "app", true},
+		{"README.md", "", "README.md", false},
+		{"Makefile", "", "Makefile", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, base, ok := ConfigFormatForFileName(tt.name)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantBase, base)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package valueobjects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConfigPath addresses a fragment of a config document. It is parsed from a
+// JSON Pointer (RFC 6901, "/database/primary") or a singular JSONPath
+// ("$.database.primary", "$.servers[0]", "$['a.b']") and is always
+// written back as a JSON Pointer. The zero value addresses the whole document.
+type ConfigPath struct {
+	tokens []string
+}
+
+// NewConfigPath creates a new ConfigPath with validation
+func NewConfigPath(path string) (ConfigPath, error) {
+	switch {
+	case path == "":
+		return ConfigPath{}, nil
+	case strings.HasPrefix(path, "/"):
+		return parseJSONPointer(path)
+	case strings.HasPrefix(path, "$"):
+		return parseJSONPath(path)
+	default:
+		return ConfigPath{}, fmt.Errorf("invalid path %q: must be a JSON Pointer (/a/b) or a JSONPath ($.a.b)", path)
+	}
+}
+
+// parseJSONPointer splits a JSON Pointer into unescaped reference tokens
+func parseJSONPointer(pointer string) (ConfigPath, error) {
+	parts := strings.Split(pointer[1:], "/")
+	tokens := make([]string, len(parts))
+	for i, part := range parts {
+		for j := 0; j < len(part); j++ {
+			if part[j] == '~' && (j+1 == len(part) || (part[j+1] != '0' && part[j+1] != '1')) {
+				return ConfigPath{}, fmt.Errorf("invalid path %q: ~ must be followed by 0 or 1", pointer)
+			}
+		}
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+	}
+	return ConfigPath{tokens: tokens}, nil
+}
+
+// parseJSONPath parses a JSONPath that selects at most one value: member
+// names and array indexes only, without wildcards, slices, filters or
+// recursive descent
+func parseJSONPath(path string) (ConfigPath, error) {
+	invalid := func(reason string) (ConfigPath, error) {
+		return ConfigPath{}, fmt.Errorf("invalid path %q: %s", path, reason)
+	}
+
+	var tokens []string
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			if name == "" {
+				return invalid("recursive descent is not supported")
+			}
+			if name == "*" {
+				return invalid("wildcards are not supported")
+			}
+			tokens = append(tokens, name)
+			rest = rest[end:]
+
+		case '[':
+			rest = rest[1:]
+			if rest != "" && (rest[0] == '\'' || rest[0] == '"') {
+				name, remaining, err := parseJSONPathName(rest)
+				if err != nil {
+					return invalid(err.Error())
+				}
+				tokens = append(tokens, name)
+				rest = remaining
+			} else {
+				end := strings.IndexByte(rest, ']')
+				if end < 0 {
+					return invalid("unterminated [")
+				}
+				if _, ok := arrayIndex(rest[:end]); !ok {
+					return invalid("only member names and array indexes are supported")
+				}
+				tokens = append(tokens, rest[:end])
+				rest = rest[end:]
+			}
+			if !strings.HasPrefix(rest, "]") {
+				return invalid("expected ]")
+			}
+			rest = rest[1:]
+
+		default:
+			return invalid("expected . or [")
+		}
+	}
+	return ConfigPath{tokens: tokens}, nil
+}
+
+// parseJSONPathName reads a quoted member name such as 'a.b' and returns it
+// with the input following the closing quote
+func parseJSONPathName(s string) (string, string, error) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 == len(s) {
+				return "", "", fmt.Errorf("unterminated escape")
+			}
+			i++
+			b.WriteByte(s[i])
+		case quote:
+			return b.String(), s[i+1:], nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", fmt.Errorf("unterminated member name")
+}
+
+// arrayIndex parses an array index token: a decimal without leading zeros
+func arrayIndex(token string) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	for i := 0; i < len(token); i++ {
+		if token[i] < '0' || token[i] > '9' {
+			return 0, false
+		}
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, false
+	}
+	return index, true
+}
+
+// IsRoot reports whether the path addresses the whole document
+func (p ConfigPath) IsRoot() bool {
+	return len(p.tokens) == 0
+}
+
+// Tokens returns the unescaped reference tokens of the path
+func (p ConfigPath) Tokens() []string {
+	return append([]string(nil), p.tokens...)
+}
+
+// String returns the path as a JSON Pointer
+func (p ConfigPath) String() string {
+	var b strings.Builder
+	for _, token := range p.tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// Resolve returns the fragment of content at the path as canonical JSON
+// (object keys sorted, no insignificant whitespace), so that equal fragments
+// are byte-for-byte equal. found is false when the path does not exist.
+func (p ConfigPath) Resolve(content json.RawMessage) (fragment json.RawMessage, found bool, err error) {
+	current := content
+	for _, token := range p.tokens {
+		trimmed := bytes.TrimLeft(current, " \t\r\n")
+		if len(trimmed) == 0 {
+			return nil, false, fmt.Errorf("invalid config content")
+		}
+
+		switch trimmed[0] {
+		case '{':
+			var object map[string]json.RawMessage
+			if err := json.Unmarshal(trimmed, &object); err != nil {
+				return nil, false, fmt.Errorf("invalid config content: %w", err)
+			}
+			member, ok := object[token]
+			if !ok {
+				return nil, false, nil
+			}
+			current = member
+		case '[':
+			var array []json.RawMessage
+			if err := json.Unmarshal(trimmed, &array); err != nil {
+				return nil, false, fmt.Errorf("invalid config content: %w", err)
+			}
+			index, ok := arrayIndex(token)
+			if !ok || index >= len(array) {
+				return nil, false, nil
+			}
+			current = array[index]
+		default:
+			// Scalars have no members
+			return nil, false, nil
+		}
+	}
+
+	canonical, err := canonicalJSON(current)
+	if err != nil {
+		return nil, false, err
+	}
+	return canonical, true, nil
+}
+
+// canonicalJSON re-encodes JSON with sorted object keys, no insignificant
+// whitespace and numbers kept as written
+func canonicalJSON(data json.RawMessage) (json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid config content: %w", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to encode config fragment: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
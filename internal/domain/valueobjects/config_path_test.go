@@ -0,0 +1,82 @@
+package valueobjects
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty path is the whole document", input: "", want: ""},
+		{name: "json pointer", input: "/database/primary", want: "/database/primary"},
+		{name: "json pointer escapes", input: "/a~1b/c~0d", want: "/a~1b/c~0d"},
+		{name: "json pointer bad escape", input: "/a~2", wantErr: true},
+		{name: "jsonpath members", input: "$.database.primary", want: "/database/primary"},
+		{name: "jsonpath index", input: "$.servers[0].host", want: "/servers/0/host"},
+		{name: "jsonpath quoted member", input: `$['a.b']["c/d"]`, want: "/a.b/c~1d"},
+		{name: "jsonpath root", input: "$", want: ""},
+		{name: "jsonpath wildcard", input: "$.servers[*]", wantErr: true},
+		{name: "jsonpath recursive descent", input: "$..host", wantErr: true},
+		{name: "jsonpath filter", input: "$.servers[?(@.up)]", wantErr: true},
+		{name: "neither pointer nor path", input: "database.primary", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConfigPath(tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestConfigPath_Resolve(t *testing.T) {
+	content := json.RawMessage(`{
+		"database": {"primary": {"port": 5432, "host": "db-1"}},
+		"servers": [{"host": "a"}, {"host": "b"}],
+		"a/b": 1.50
+	}`)
+
+	tests := []struct {
+		name      string
+		path      string
+		want      string
+		wantFound bool
+	}{
+		{"object member is canonical", "/database/primary", `{"host":"db-1","port":5432}`, true},
+		{"array element", "$.servers[1].host", `"b"`, true},
+		{"escaped member keeps number text", "/a~1b", `1.50`, true},
+		{"missing member", "/database/replica", "", false},
+		{"index out of range", "/servers/2", "", false},
+		{"index with leading zero", "/servers/01", "", false},
+		{"member of a scalar", "/database/primary/port/x", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := NewConfigPath(tt.path)
+			require.NoError(t, err)
+
+			fragment, found, err := path.Resolve(content)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFound, found)
+			if tt.wantFound {
+				assert.Equal(t, tt.want, string(fragment))
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package valueobjects
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// APIKeyHashVersionLegacy is SHA-256(salt || key) without the server pepper.
+	// Keys converted from plaintext by a database migration start here.
+	APIKeyHashVersionLegacy = 1
+
+	// APIKeyHashVersionPeppered is HMAC-SHA256(pepper, SHA-256(salt || key))
+	APIKeyHashVersionPeppered = 2
+)
+
+// HashedAPIKey is the stored form of an API key: a lookup prefix plus a
+// salted hash. The full key cannot be recovered from it.
+type HashedAPIKey struct {
+	lookupPrefix string
+	salt         []byte
+	hash         []byte
+	hint         string
+	version      int
+}
+
+// NewHashedAPIKey creates a new HashedAPIKey with validation
+func NewHashedAPIKey(lookupPrefix string, salt, hash []byte, hint string, version int) (HashedAPIKey, error) {
+	if len(lookupPrefix) != APIKeyLookupPrefixLength || !strings.HasPrefix(lookupPrefix, APIKeyPrefix) {
+		return HashedAPIKey{}, fmt.Errorf("invalid api key lookup prefix")
+	}
+
+	if len(salt) == 0 {
+		return HashedAPIKey{}, fmt.Errorf("api key salt cannot be empty")
+	}
+
+	if len(hash) == 0 {
+		return HashedAPIKey{}, fmt.Errorf("api key hash cannot be empty")
+	}
+
+	if len(hint) != APIKeyHintLength {
+		return HashedAPIKey{}, fmt.Errorf("api key hint must be exactly %d characters", APIKeyHintLength)
+	}
+
+	if version != APIKeyHashVersionLegacy && version != APIKeyHashVersionPeppered {
+		return HashedAPIKey{}, fmt.Errorf("unsupported api key hash version: %d", version)
+	}
+
+	return HashedAPIKey{
+		lookupPrefix: lookupPrefix,
+		salt:         salt,
+		hash:         hash,
+		hint:         hint,
+		version:      version,
+	}, nil
+}
+
+// LookupPrefix returns the plaintext prefix used to find the hash
+func (h HashedAPIKey) LookupPrefix() string {
+	return h.lookupPrefix
+}
+
+// Salt returns the per-key salt
+func (h HashedAPIKey) Salt() []byte {
+	return h.salt
+}
+
+// Hash returns the key's digest
+func (h HashedAPIKey) Hash() []byte {
+	return h.hash
+}
+
+// Hint returns the last characters of the key
+func (h HashedAPIKey) Hint() string {
+	return h.hint
+}
+
+// Version returns the hashing scheme the digest was made with
+func (h HashedAPIKey) Version() int {
+	return h.version
+}
+
+// IsLegacy checks if the digest predates the server pepper
+func (h HashedAPIKey) IsLegacy() bool {
+	return h.version == APIKeyHashVersionLegacy
+}
+
+// Masked returns the same masked form as APIKey.Masked
+func (h HashedAPIKey) Masked() string {
+	return maskAPIKey(h.hint)
+}
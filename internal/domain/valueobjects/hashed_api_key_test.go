@@ -0,0 +1,58 @@
+package valueobjects
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHashedAPIKey(t *testing.T) {
+	salt := []byte("salt")
+	hash := []byte("hash")
+
+	t.Run("valid", func(t *testing.T) {
+		hashed, err := NewHashedAPIKey("cfg_abcdefgh", salt, hash, "wxyz", APIKeyHashVersionPeppered)
+		require.NoError(t, err)
+
+		assert.Equal(t, "cfg_abcdefgh", hashed.LookupPrefix())
+		assert.Equal(t, "cfg_...wxyz", hashed.Masked())
+		assert.False(t, hashed.IsLegacy())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			lookupPrefix string
+			salt         []byte
+			hash         []byte
+			hint         string
+			version      int
+		}{
+			{"short lookup prefix", "cfg_abc", salt, hash, "wxyz", APIKeyHashVersionPeppered},
+			{"wrong prefix", "key_abcdefgh", salt, hash, "wxyz", APIKeyHashVersionPeppered},
+			{"empty salt", "cfg_abcdefgh", nil, hash, "wxyz", APIKeyHashVersionPeppered},
+			{"empty hash", "cfg_abcdefgh", salt, nil, "wxyz", APIKeyHashVersionPeppered},
+			{"short hint", "cfg_abcdefgh", salt, hash, "xyz", APIKeyHashVersionPeppered},
+			{"unknown version", "cfg_abcdefgh", salt, hash, "wxyz", 99},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := NewHashedAPIKey(tt.lookupPrefix, tt.salt, tt.hash, tt.hint, tt.version)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestHashedAPIKey_MaskedMatchesAPIKey(t *testing.T) {
+	key := MustNewAPIKey("cfg_" + strings.Repeat("a", 28) + "wxyz")
+
+	hashed, err := NewHashedAPIKey(key.LookupPrefix(), []byte("salt"), []byte("hash"), key.Hint(), APIKeyHashVersionPeppered)
+	require.NoError(t, err)
+
+	assert.Equal(t, key.Masked(), hashed.Masked())
+	assert.Equal(t, "cfg_aaaaaaaa", key.LookupPrefix())
+}
@@ -1,6 +1,7 @@
 package valueobjects
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
@@ -72,3 +73,21 @@ func (v Version) IsInitial() bool {
 	return v.value == 1
 }
 
+// MarshalJSON encodes the version as a plain number
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// UnmarshalJSON decodes a version from a plain number
+func (v *Version) UnmarshalJSON(data []byte) error {
+	var value int64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid version: %w", err)
+	}
+	parsed, err := NewVersion(value)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
@@ -1,6 +1,7 @@
 package valueobjects
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -125,6 +126,28 @@ func TestVersion_Value(t *testing.T) {
 	}
 }
 
+func TestVersion_JSON(t *testing.T) {
+	t.Run("marshals as a number", func(t *testing.T) {
+		data, err := json.Marshal(struct {
+			Version Version `json:"version"`
+		}{MustNewVersion(7)})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"version":7}`, string(data))
+	})
+
+	t.Run("unmarshals from a number", func(t *testing.T) {
+		var v Version
+		require.NoError(t, json.Unmarshal([]byte("42"), &v))
+		assert.Equal(t, int64(42), v.Value())
+	})
+
+	t.Run("rejects invalid versions", func(t *testing.T) {
+		var v Version
+		assert.Error(t, json.Unmarshal([]byte("0"), &v))
+		assert.Error(t, json.Unmarshal([]byte(`"abc"`), &v))
+	})
+}
+
 func TestVersion_OptimisticLockingScenario(t *testing.T) {
 	// Simulate optimistic locking scenario
 	
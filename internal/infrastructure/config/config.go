@@ -10,6 +10,7 @@ import (
 // Config holds all application configuration
 type Config struct {
 	Server      ServerConfig
+	GRPC        GRPCConfig
 	Database    DatabaseConfig
 	Redis       RedisConfig
 	JWT         JWTConfig
@@ -17,6 +18,9 @@ type Config struct {
 	Telemetry   TelemetryConfig
 	Security    SecurityConfig
 	RateLimit   RateLimitConfig
+	Stream      StreamConfig
+	AuthCache   AuthCacheConfig
+	SchemaCache SchemaCacheConfig
 	Environment string
 	LogLevel    string
 }
@@ -31,6 +35,13 @@ type ServerConfig struct {
 	ShutdownTimeout time.Duration
 }
 
+// GRPCConfig holds gRPC server configuration
+type GRPCConfig struct {
+	Enabled    bool
+	Port       int
+	Reflection bool
+}
+
 // DatabaseConfig holds PostgreSQL configuration
 type DatabaseConfig struct {
 	Host            string
@@ -75,6 +86,7 @@ type RaftConfig struct {
 	ElectionTimeout    time.Duration
 	Bootstrap          bool
 	JoinAddresses      []string
+	MaxTombstones      int
 }
 
 // TelemetryConfig holds OpenTelemetry configuration
@@ -96,6 +108,19 @@ type SecurityConfig struct {
 	APIKeyPrefix    string
 	MaxRequestSize  int64
 	MaxResponseSize int64
+
+	// APIKeyInPathEnabled keeps the deprecated /read/{apiKey}/... routes
+	APIKeyInPathEnabled bool
+
+	// APIKeyRotationGracePeriod is how long a rotated API key keeps working by default
+	APIKeyRotationGracePeriod time.Duration
+
+	// APIKeyPepper is the server secret mixed into stored API key hashes.
+	// Changing it invalidates every API key.
+	APIKeyPepper string
+
+	// PlatformAdminUserIDs lists the users allowed to manage global schemas
+	PlatformAdminUserIDs []string
 }
 
 // RateLimitConfig holds rate limiting configuration
@@ -106,6 +131,26 @@ type RateLimitConfig struct {
 	CleanupInterval       time.Duration
 }
 
+// StreamConfig holds config change stream (SSE) configuration
+type StreamConfig struct {
+	ReplayBufferSize     int
+	SubscriberBufferSize int
+	HeartbeatInterval    time.Duration
+}
+
+// AuthCacheConfig holds configuration of the project and API key metadata
+// cache that keeps /read available while the database is down
+type AuthCacheConfig struct {
+	RefreshInterval time.Duration
+	MaxStaleness    time.Duration
+}
+
+// SchemaCacheConfig holds configuration of the compiled JSON Schema cache
+type SchemaCacheConfig struct {
+	// Size is the number of compiled schema versions kept; 0 disables the cache
+	Size int
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	cfg := &Config{
@@ -121,6 +166,12 @@ func Load() (*Config, error) {
 			ShutdownTimeout: getEnvDuration("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
 		},
 		
+		GRPC: GRPCConfig{
+			Enabled:    getEnvBool("GRPC_ENABLED", true),
+			Port:       getEnvInt("GRPC_PORT", 9090),
+			Reflection: getEnvBool("GRPC_REFLECTION_ENABLED", true),
+		},
+		
 		Database: DatabaseConfig{
 			Host:            getEnv("DB_HOST", "localhost"),
 			Port:            getEnvInt("DB_PORT", 5432),
@@ -161,6 +212,7 @@ func Load() (*Config, error) {
 			ElectionTimeout:   getEnvDuration("RAFT_ELECTION_TIMEOUT", 1*time.Second),
 			Bootstrap:         getEnvBool("RAFT_BOOTSTRAP", true),
 			JoinAddresses:     getEnvSlice("RAFT_JOIN_ADDRESSES", []string{}),
+			MaxTombstones:     getEnvInt("RAFT_MAX_TOMBSTONES", 10000),
 		},
 		
 		Telemetry: TelemetryConfig{
@@ -175,11 +227,15 @@ func Load() (*Config, error) {
 		},
 		
 		Security: SecurityConfig{
-			BCryptCost:      getEnvInt("BCRYPT_COST", 10),
-			APIKeyLength:    getEnvInt("API_KEY_LENGTH", 32),
-			APIKeyPrefix:    getEnv("API_KEY_PREFIX", "cfg_"),
-			MaxRequestSize:  int64(getEnvInt("MAX_REQUEST_SIZE", 10*1024*1024)), // 10MB
-			MaxResponseSize: int64(getEnvInt("MAX_RESPONSE_SIZE", 10*1024*1024)),
+			BCryptCost:                getEnvInt("BCRYPT_COST", 10),
+			APIKeyLength:              getEnvInt("API_KEY_LENGTH", 32),
+			APIKeyPrefix:              getEnv("API_KEY_PREFIX", "cfg_"),
+			MaxRequestSize:            int64(getEnvInt("MAX_REQUEST_SIZE", 10*1024*1024)), // 10MB
+			MaxResponseSize:           int64(getEnvInt("MAX_RESPONSE_SIZE", 10*1024*1024)),
+			APIKeyInPathEnabled:       getEnvBool("API_KEY_IN_PATH_ENABLED", true),
+			APIKeyRotationGracePeriod: getEnvDuration("API_KEY_ROTATION_GRACE_PERIOD", 24*time.Hour),
+			APIKeyPepper:              mustGetEnv("API_KEY_PEPPER"),
+			PlatformAdminUserIDs:      getEnvSlice("PLATFORM_ADMIN_USER_IDS", nil),
 		},
 		
 		RateLimit: RateLimitConfig{
@@ -188,6 +244,21 @@ func Load() (*Config, error) {
 			Burst:             getEnvInt("RATE_LIMIT_BURST", 200),
 			CleanupInterval:   getEnvDuration("RATE_LIMIT_CLEANUP_INTERVAL", 1*time.Minute),
 		},
+		
+		Stream: StreamConfig{
+			ReplayBufferSize:     getEnvInt("STREAM_REPLAY_BUFFER_SIZE", 1024),
+			SubscriberBufferSize: getEnvInt("STREAM_SUBSCRIBER_BUFFER_SIZE", 64),
+			HeartbeatInterval:    getEnvDuration("STREAM_HEARTBEAT_INTERVAL", 15*time.Second),
+		},
+		
+		AuthCache: AuthCacheConfig{
+			RefreshInterval: getEnvDuration("AUTH_CACHE_REFRESH_INTERVAL", 30*time.Second),
+			MaxStaleness:    getEnvDuration("AUTH_CACHE_MAX_STALENESS", 24*time.Hour),
+		},
+		
+		SchemaCache: SchemaCacheConfig{
+			Size: getEnvInt("SCHEMA_CACHE_SIZE", 512),
+		},
 	}
 	
 	if err := cfg.Validate(); err != nil {
@@ -203,6 +274,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
 	}
 	
+	if c.GRPC.Enabled && (c.GRPC.Port < 1 || c.GRPC.Port > 65535 || c.GRPC.Port == c.Server.Port) {
+		return fmt.Errorf("invalid gRPC port: %d", c.GRPC.Port)
+	}
+	
 	if c.Database.Name == "" {
 		return fmt.Errorf("database name is required")
 	}
@@ -211,6 +286,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT secret is required")
 	}
 	
+	if c.Security.APIKeyPepper == "" {
+		return fmt.Errorf("API key pepper is required")
+	}
+	
+	if c.AuthCache.RefreshInterval <= 0 {
+		return fmt.Errorf("auth cache refresh interval must be positive")
+	}
+	
+	if c.SchemaCache.Size < 0 {
+		return fmt.Errorf("schema cache size must not be negative")
+	}
+	
 	if c.Security.BCryptCost < 4 || c.Security.BCryptCost > 31 {
 		return fmt.Errorf("bcrypt cost must be between 4 and 31")
 	}
@@ -18,12 +18,15 @@ const (
 	ErrCodeForbidden        ErrorCode = "FORBIDDEN"
 	ErrCodeConflict         ErrorCode = "CONFLICT"
 	ErrCodeValidation       ErrorCode = "VALIDATION_ERROR"
+	ErrCodePayloadTooLarge  ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrCodeNotAcceptable    ErrorCode = "NOT_ACCEPTABLE"
 	
 	// Auth errors
 	ErrCodeInvalidCredentials ErrorCode = "INVALID_CREDENTIALS"
 	ErrCodeInvalidToken       ErrorCode = "INVALID_TOKEN"
 	ErrCodeTokenExpired       ErrorCode = "TOKEN_EXPIRED"
 	ErrCodeInvalidAPIKey      ErrorCode = "INVALID_API_KEY"
+	ErrCodeAPIKeyNotFound     ErrorCode = "API_KEY_NOT_FOUND"
 	
 	// User errors
 	ErrCodeUserNotFound     ErrorCode = "USER_NOT_FOUND"
@@ -39,11 +42,17 @@ const (
 	ErrCodeConfigExists         ErrorCode = "CONFIG_ALREADY_EXISTS"
 	ErrCodeConfigVersionMismatch ErrorCode = "CONFIG_VERSION_MISMATCH"
 	ErrCodeConfigValidation     ErrorCode = "CONFIG_VALIDATION_ERROR"
+	ErrCodeConfigPathNotFound   ErrorCode = "CONFIG_PATH_NOT_FOUND"
+	ErrCodeResyncRequired       ErrorCode = "RESYNC_REQUIRED"
 	
 	// Schema errors
 	ErrCodeSchemaNotFound   ErrorCode = "SCHEMA_NOT_FOUND"
 	ErrCodeSchemaExists     ErrorCode = "SCHEMA_ALREADY_EXISTS"
 	ErrCodeSchemaInvalid    ErrorCode = "SCHEMA_INVALID"
+	ErrCodeSchemaVersionNotFound ErrorCode = "SCHEMA_VERSION_NOT_FOUND"
+	ErrCodeSchemaIncompatible    ErrorCode = "SCHEMA_INCOMPATIBLE"
+	ErrCodeMigrationNotFound     ErrorCode = "MIGRATION_NOT_FOUND"
+	ErrCodeMigrationFailed       ErrorCode = "MIGRATION_FAILED"
 	
 	// Role errors
 	ErrCodeRoleNotFound     ErrorCode = "ROLE_NOT_FOUND"
@@ -60,11 +69,15 @@ type AppError struct {
 	Details    string    `json:"details,omitempty"`
 	Err        error     `json:"-"`
 	StatusCode int       `json:"-"`
+	
+	// Extensions are extra members of the problem response, such as the
+	// current version of a config on a version conflict
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // Error implements the error interface
 func (e *AppError) Error() string {
-	if e.Err != nil {
+	if e.Err != nil && e.Err.Error() != e.Message {
 		return fmt.Sprintf("%s: %s (%v)", e.Code, e.Message, e.Err)
 	}
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
@@ -100,6 +113,15 @@ func (e *AppError) WithDetails(details string) *AppError {
 	return e
 }
 
+// WithExtension adds a member to the problem response
+func (e *AppError) WithExtension(key string, value interface{}) *AppError {
+	if e.Extensions == nil {
+		e.Extensions = make(map[string]interface{})
+	}
+	e.Extensions[key] = value
+	return e
+}
+
 // IsAppError checks if error is AppError
 func IsAppError(err error) bool {
 	var appErr *AppError
@@ -113,10 +135,22 @@ func GetAppError(err error) (*AppError, bool) {
 	return appErr, ok
 }
 
+// HasCode reports whether err is an AppError with the given code
+func HasCode(err error, code ErrorCode) bool {
+	appErr, ok := GetAppError(err)
+	return ok && appErr.Code == code
+}
+
+// StatusCode returns the HTTP status code for an error code
+func StatusCode(code ErrorCode) int {
+	return getHTTPStatusCode(code)
+}
+
 // getHTTPStatusCode maps error codes to HTTP status codes
 func getHTTPStatusCode(code ErrorCode) int {
 	switch code {
-	case ErrCodeBadRequest, ErrCodeValidation, ErrCodeInvalidEmail:
+	case ErrCodeBadRequest, ErrCodeValidation, ErrCodeInvalidEmail,
+	     ErrCodeConfigValidation, ErrCodeSchemaInvalid:
 		return http.StatusBadRequest
 	case ErrCodeUnauthorized, ErrCodeInvalidCredentials, ErrCodeInvalidToken, 
 	     ErrCodeTokenExpired, ErrCodeInvalidAPIKey:
@@ -124,11 +158,22 @@ func getHTTPStatusCode(code ErrorCode) int {
 	case ErrCodeForbidden, ErrCodeInsufficientRole:
 		return http.StatusForbidden
 	case ErrCodeNotFound, ErrCodeUserNotFound, ErrCodeProjectNotFound, 
-	     ErrCodeConfigNotFound, ErrCodeSchemaNotFound, ErrCodeRoleNotFound:
+	     ErrCodeConfigNotFound, ErrCodeSchemaNotFound, ErrCodeRoleNotFound,
+	     ErrCodeAPIKeyNotFound, ErrCodeConfigPathNotFound, ErrCodeSchemaVersionNotFound,
+	     ErrCodeMigrationNotFound:
 		return http.StatusNotFound
 	case ErrCodeConflict, ErrCodeUserExists, ErrCodeProjectExists, 
-	     ErrCodeConfigExists, ErrCodeSchemaExists, ErrCodeConfigVersionMismatch:
+	     ErrCodeConfigExists, ErrCodeSchemaExists, ErrCodeConfigVersionMismatch,
+	     ErrCodeSchemaIncompatible:
 		return http.StatusConflict
+	case ErrCodeMigrationFailed:
+		return http.StatusUnprocessableEntity
+	case ErrCodeNotAcceptable:
+		return http.StatusNotAcceptable
+	case ErrCodeResyncRequired:
+		return http.StatusGone
+	case ErrCodePayloadTooLarge:
+		return http.StatusRequestEntityTooLarge
 	case ErrCodeRateLimitExceeded:
 		return http.StatusTooManyRequests
 	default:
@@ -158,7 +203,13 @@ func Conflict(message string) *AppError {
 	return New(ErrCodeConflict, message)
 }
 
+// Internal wraps an unexpected error. If err already carries an AppError,
+// such as a not-found error from a repository, that error is returned
+// instead so it keeps its code.
 func Internal(err error, message string) *AppError {
+	if appErr, ok := GetAppError(err); ok {
+		return appErr
+	}
 	return Wrap(err, ErrCodeInternal, message)
 }
 
@@ -166,3 +217,9 @@ func ValidationError(details string) *AppError {
 	return New(ErrCodeValidation, "Validation failed").WithDetails(details)
 }
 
+// Invalid turns an error about bad input into a BAD_REQUEST error with the
+// same message
+func Invalid(err error) *AppError {
+	return Wrap(err, ErrCodeBadRequest, err.Error())
+}
+
@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
 )
 
 // LogLevel represents logging levels
@@ -26,6 +28,7 @@ func Setup(level string, environment string) *slog.Logger {
 	opts := &slog.HandlerOptions{
 		Level: logLevel,
 		AddSource: logLevel == slog.LevelDebug,
+		ReplaceAttr: redactAttr,
 	}
 	
 	// Use JSON handler for production, text handler for development
@@ -43,6 +46,16 @@ func Setup(level string, environment string) *slog.Logger {
 	return logger
 }
 
+// redactAttr masks API keys in string attributes so secrets never reach log output
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindString {
+		if masked := valueobjects.MaskAPIKeys(a.Value.String()); masked != a.Value.String() {
+			return slog.String(a.Key, masked)
+		}
+	}
+	return a
+}
+
 // parseLogLevel converts string to slog.Level
 func parseLogLevel(level string) slog.Level {
 	switch strings.ToLower(level) {
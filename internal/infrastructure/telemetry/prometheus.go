@@ -12,6 +12,11 @@ type PrometheusMetrics struct {
 	HTTPRequestDuration *prometheus.HistogramVec
 	HTTPRequestsInFlight prometheus.Gauge
 	
+	// gRPC Metrics
+	GRPCRequestsTotal *prometheus.CounterVec
+	GRPCRequestDuration *prometheus.HistogramVec
+	GRPCStreamsActive prometheus.Gauge
+	
 	// Config Metrics
 	ConfigOperationsTotal *prometheus.CounterVec
 	ConfigVersionConflicts prometheus.Counter
@@ -60,6 +65,32 @@ func NewPrometheusMetrics(namespace string) *PrometheusMetrics {
 			},
 		),
 		
+		// gRPC Metrics
+		GRPCRequestsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "grpc_requests_total",
+				Help:      "Total number of gRPC requests",
+			},
+			[]string{"method", "code"},
+		),
+		GRPCRequestDuration: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "grpc_request_duration_seconds",
+				Help:      "gRPC unary request duration in seconds",
+				Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			},
+			[]string{"method"},
+		),
+		GRPCStreamsActive: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "grpc_streams_active",
+				Help:      "Current number of open gRPC server streams",
+			},
+		),
+		
 		// Config Metrics
 		ConfigOperationsTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
@@ -156,3 +187,56 @@ func NewPrometheusMetrics(namespace string) *PrometheusMetrics {
 	}
 }
 
+
+// RegisterSchemaCacheMetrics exposes the compiled JSON Schema cache: hits,
+// misses, entries and the hit ratio since startup. stats reports the
+// current counters of the cache.
+func RegisterSchemaCacheMetrics(namespace string, stats func() (hits, misses uint64, entries int)) {
+	promauto.NewCounterFunc(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "schema_cache_hits_total",
+			Help:      "Total number of validations that reused a compiled schema",
+		},
+		func() float64 {
+			hits, _, _ := stats()
+			return float64(hits)
+		},
+	)
+	promauto.NewCounterFunc(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "schema_cache_misses_total",
+			Help:      "Total number of validations that compiled their schema",
+		},
+		func() float64 {
+			_, misses, _ := stats()
+			return float64(misses)
+		},
+	)
+	promauto.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "schema_cache_entries",
+			Help:      "Current number of compiled schemas in the cache",
+		},
+		func() float64 {
+			_, _, entries := stats()
+			return float64(entries)
+		},
+	)
+	promauto.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "schema_cache_hit_ratio",
+			Help:      "Share of validations that reused a compiled schema since startup",
+		},
+		func() float64 {
+			hits, misses, _ := stats()
+			if hits+misses == 0 {
+				return 0
+			}
+			return float64(hits) / float64(hits+misses)
+		},
+	)
+}
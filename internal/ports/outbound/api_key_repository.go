@@ -0,0 +1,85 @@
+package outbound
+
+import (
+	"context"
+	"time"
+)
+
+// APIKey represents a project's client API key.
+// Only a lookup prefix and a salted hash of the secret are stored.
+type APIKey struct {
+	ID              string
+	ProjectID       string
+	Name            string
+	LookupPrefix    string
+	KeyHash         []byte
+	KeySalt         []byte
+	KeyHint         string // Last characters of the key, for display
+	HashVersion     int
+	KeyPrefixes     []string // Config key prefixes the key is limited to (empty = all)
+	CreatedByUserID string
+	CreatedAt       time.Time
+	ExpiresAt       *time.Time
+	LastUsedAt      *time.Time
+	RevokedAt       *time.Time
+}
+
+// CreateAPIKeyParams holds parameters for creating an API key
+type CreateAPIKeyParams struct {
+	ID              string
+	ProjectID       string
+	Name            string
+	LookupPrefix    string
+	KeyHash         []byte
+	KeySalt         []byte
+	KeyHint         string
+	HashVersion     int
+	KeyPrefixes     []string
+	CreatedByUserID string
+	ExpiresAt       *time.Time
+}
+
+// UpdateAPIKeyParams holds parameters for updating an API key.
+// Nil fields are left unchanged.
+type UpdateAPIKeyParams struct {
+	ID          string
+	ProjectID   string
+	Name        *string
+	KeyPrefixes []string
+	ExpiresAt   *time.Time
+	ClearExpiry bool // Remove the expiry; takes precedence over ExpiresAt
+}
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	// Create creates a new API key
+	Create(ctx context.Context, params CreateAPIKeyParams) (*APIKey, error)
+
+	// GetByID retrieves a project's API key by ID
+	GetByID(ctx context.Context, projectID, id string) (*APIKey, error)
+
+	// ListByLookupPrefix retrieves the API keys whose secret starts with prefix.
+	// Callers must verify the presented key against each candidate's hash.
+	ListByLookupPrefix(ctx context.Context, lookupPrefix string) ([]*APIKey, error)
+
+	// ListActive retrieves every API key that is neither revoked nor expired
+	ListActive(ctx context.Context) ([]*APIKey, error)
+
+	// ListByProject retrieves all API keys of a project, including revoked ones
+	ListByProject(ctx context.Context, projectID string) ([]*APIKey, error)
+
+	// Update updates an API key's name, scope or expiry
+	Update(ctx context.Context, params UpdateAPIKeyParams) (*APIKey, error)
+
+	// Revoke marks an API key as revoked
+	Revoke(ctx context.Context, projectID, id string) (*APIKey, error)
+
+	// SetExpiry sets when an API key stops working
+	SetExpiry(ctx context.Context, projectID, id string, expiresAt time.Time) (*APIKey, error)
+
+	// UpdateHash replaces an API key's stored hash
+	UpdateHash(ctx context.Context, id string, keyHash []byte, hashVersion int) error
+
+	// TouchLastUsed records when an API key last authenticated a request
+	TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error
+}
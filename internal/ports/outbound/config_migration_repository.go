@@ -0,0 +1,55 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// ConfigMigration records one run of schema migrations over the configs
+// using a schema, so that the run can be rolled back
+type ConfigMigration struct {
+	ID                 string
+	SchemaID           string
+	TargetVersion      int32
+	CreatedByUserID    string
+	CreatedAt          time.Time
+	RolledBackByUserID string
+	RolledBackAt       *time.Time
+	Items              []ConfigMigrationItem
+}
+
+// ConfigMigrationItem is a config rewritten by a migration run
+type ConfigMigrationItem struct {
+	ProjectID         string
+	ConfigKey         string
+	FromSchemaVersion int32
+	FromVersion       int64 // Config version before the run
+	ToVersion         int64 // Config version written by the run
+	PreviousContent   json.RawMessage
+}
+
+// CreateConfigMigrationParams holds parameters for recording a migration run
+type CreateConfigMigrationParams struct {
+	ID              string
+	SchemaID        string
+	TargetVersion   int32
+	CreatedByUserID string
+	Items           []ConfigMigrationItem
+}
+
+// ConfigMigrationRepository defines the interface for migration run data access
+type ConfigMigrationRepository interface {
+	// Create records a migration run together with its items
+	Create(ctx context.Context, params CreateConfigMigrationParams) (*ConfigMigration, error)
+
+	// GetByID retrieves a migration run with its items
+	GetByID(ctx context.Context, id string) (*ConfigMigration, error)
+
+	// ListBySchema retrieves the migration runs of a schema, newest first, without items
+	ListBySchema(ctx context.Context, schemaID string) ([]*ConfigMigration, error)
+
+	// MarkRolledBack records that a run was rolled back.
+	// It fails with a conflict if the run was already rolled back.
+	MarkRolledBack(ctx context.Context, id, userID string) (*ConfigMigration, error)
+}
@@ -10,37 +10,74 @@ type Config struct {
 	ProjectID       string
 	Key             string
 	SchemaID        string
+	SchemaVersion   int32 // Schema version the content is validated against
 	Version         int64
 	Content         json.RawMessage
 	UpdatedByUserID string
+	Revision        int64 // Global revision of the last change
 	CreatedAt       string
 	UpdatedAt       string
 }
 
+// ConfigChanges holds a project's config changes after a revision
+type ConfigChanges struct {
+	Revision          int64            // Current global revision, the cursor for the next call
+	CompactedRevision int64            // Deletes at or below this revision are no longer tracked
+	Changed           []*Config        // Configs created or updated after the revision
+	Deleted           []*DeletedConfig // Configs deleted after the revision
+}
+
+// DeletedConfig identifies a deleted config
+type DeletedConfig struct {
+	Key      string
+	Revision int64 // Global revision of the delete
+}
+
 // CreateConfigParams holds parameters for creating a config
 type CreateConfigParams struct {
 	ProjectID       string
 	Key             string
 	SchemaID        string
+	SchemaVersion   int32
 	Content         json.RawMessage
 	UpdatedByUserID string
 }
 
 // UpdateConfigParams holds parameters for updating a config with optimistic locking
 type UpdateConfigParams struct {
+	ProjectID         string
+	Key               string
+	ExpectedVersion   int64 // For optimistic locking
+	Content           json.RawMessage
+	UpdatedByUserID   string
+	RollbackToVersion int64 // Version whose content is restored; 0 for a regular update
+}
+
+// ChangeSchemaParams holds parameters for pinning a config to another schema
+// or schema version with optimistic locking
+type ChangeSchemaParams struct {
 	ProjectID       string
 	Key             string
 	ExpectedVersion int64 // For optimistic locking
-	Content         json.RawMessage
+	SchemaID        string
+	SchemaVersion   int32
 	UpdatedByUserID string
 }
 
-// ChangeSchemaParams holds parameters for changing a config's schema
-type ChangeSchemaParams struct {
+// UpdateBatchParams holds configs to rewrite together, each with optimistic locking
+type UpdateBatchParams struct {
+	Items           []UpdateBatchItem
+	UpdatedByUserID string
+}
+
+// UpdateBatchItem holds the new content and schema version of one config
+type UpdateBatchItem struct {
 	ProjectID       string
 	Key             string
+	ExpectedVersion int64 // For optimistic locking
 	SchemaID        string
-	UpdatedByUserID string
+	SchemaVersion   int32
+	Content         json.RawMessage
 }
 
 // SearchConfigsParams holds parameters for searching configs
@@ -65,6 +102,10 @@ type ConfigRepository interface {
 	// ListByProject retrieves all configs for a project
 	ListByProject(ctx context.Context, projectID string) ([]*Config, error)
 	
+	// ListChanges retrieves a project's configs changed and deleted after a
+	// global revision; since = 0 returns every config
+	ListChanges(ctx context.Context, projectID string, since int64) (*ConfigChanges, error)
+	
 	// ListBySchema retrieves all configs using a specific schema
 	ListBySchema(ctx context.Context, schemaID string) ([]*Config, error)
 	
@@ -72,11 +113,16 @@ type ConfigRepository interface {
 	// Returns error if version mismatch (concurrent modification detected)
 	Update(ctx context.Context, params UpdateConfigParams) (*Config, error)
 	
-	// ChangeSchema changes the schema ID for a config
+	// ChangeSchema pins a config to a schema version, keeping its content
+	// Returns error if version mismatch (concurrent modification detected)
 	ChangeSchema(ctx context.Context, params ChangeSchemaParams) (*Config, error)
 	
+	// UpdateBatch rewrites several configs at once, across projects
+	// Either every item is applied or none is; returns error if any version mismatches
+	UpdateBatch(ctx context.Context, params UpdateBatchParams) ([]*Config, error)
+	
 	// Delete deletes a config
-	Delete(ctx context.Context, projectID, key string) error
+	Delete(ctx context.Context, projectID, key, deletedByUserID string) error
 	
 	// Exists checks if a config exists
 	Exists(ctx context.Context, projectID, key string) (bool, error)
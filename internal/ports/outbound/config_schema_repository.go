@@ -2,62 +2,122 @@ package outbound
 
 import (
 	"context"
+	"encoding/json"
 )
 
 // ConfigSchema represents a reusable JSON Schema definition
 type ConfigSchema struct {
 	ID              string
 	Name            string
-	SchemaContent   string
+	SchemaContent   string // Content of the latest version
+	LatestVersion   int32
+	ProjectID       string // Owning project; empty for a global schema
 	CreatedByUserID string
 	CreatedAt       string
 	UpdatedAt       string
 }
 
+// ConfigSchemaVersion is one immutable, numbered version of a schema
+type ConfigSchemaVersion struct {
+	SchemaID        string
+	Version         int32
+	SchemaContent   string
+	Migration       json.RawMessage // Rewrites configs on the previous version; nil when none
+	CreatedByUserID string
+	CreatedAt       string
+}
+
+// ConfigSchemaReference is a registry schema version a schema version
+// references, directly or through other schemas
+type ConfigSchemaReference struct {
+	Name          string // Name the $ref used when the version was published
+	SchemaID      string
+	Version       int32
+	SchemaContent string // Set when listed
+}
+
 // CreateConfigSchemaParams holds parameters for creating a config schema
 type CreateConfigSchemaParams struct {
 	ID              string
 	Name            string
 	SchemaContent   string
+	ProjectID       string // Empty creates a global schema
+	References      []ConfigSchemaReference // Schemas the content reaches, pinned to a version
 	CreatedByUserID string
 }
 
-// UpdateConfigSchemaParams holds parameters for updating a config schema
+// UpdateConfigSchemaParams holds parameters for updating a config schema.
+// Content is never changed in place; see PublishConfigSchemaVersionParams.
 type UpdateConfigSchemaParams struct {
-	ID            string
-	Name          *string
-	SchemaContent *string
+	ID   string
+	Name *string
+}
+
+// PublishConfigSchemaVersionParams holds parameters for publishing a new schema version
+type PublishConfigSchemaVersionParams struct {
+	SchemaID        string
+	SchemaContent   string
+	Migration       json.RawMessage
+	References      []ConfigSchemaReference // Schemas the content reaches, pinned to a version
+	CreatedByUserID string
 }
 
 // ConfigSchemaRepository defines the interface for config schema data access
 type ConfigSchemaRepository interface {
-	// Create creates a new config schema
+	// Create creates a new config schema with its content as version 1
 	Create(ctx context.Context, params CreateConfigSchemaParams) (*ConfigSchema, error)
 	
 	// GetByID retrieves a config schema by ID
 	GetByID(ctx context.Context, id string) (*ConfigSchema, error)
 	
-	// GetByName retrieves a config schema by name
-	GetByName(ctx context.Context, name string) (*ConfigSchema, error)
+	// GetByName retrieves a config schema by name among the schemas a
+	// project sees: its own and the global ones. An empty projectID sees only
+	// the global schemas.
+	GetByName(ctx context.Context, projectID, name string) (*ConfigSchema, error)
 	
 	// List retrieves all config schemas
 	List(ctx context.Context) ([]*ConfigSchema, error)
 	
+	// ListByProject retrieves the global schemas and the schemas of a project
+	ListByProject(ctx context.Context, projectID string) ([]*ConfigSchema, error)
+	
+	// ListForProjects retrieves the global schemas and the schemas of any of
+	// the given projects
+	ListForProjects(ctx context.Context, projectIDs []string) ([]*ConfigSchema, error)
+	
+	// ListReferencing retrieves the schemas with any version referencing a schema
+	ListReferencing(ctx context.Context, schemaID string) ([]*ConfigSchema, error)
+	
 	// ListByCreator retrieves config schemas created by a specific user
 	ListByCreator(ctx context.Context, creatorUserID string) ([]*ConfigSchema, error)
 	
-	// Update updates a config schema
+	// Update updates a config schema's metadata
 	Update(ctx context.Context, params UpdateConfigSchemaParams) (*ConfigSchema, error)
 	
+	// PublishVersion appends the next numbered version and makes it the latest
+	PublishVersion(ctx context.Context, params PublishConfigSchemaVersionParams) (*ConfigSchemaVersion, error)
+	
+	// GetVersion retrieves one version of a schema
+	GetVersion(ctx context.Context, schemaID string, version int32) (*ConfigSchemaVersion, error)
+	
+	// ListVersions retrieves every version of a schema, newest first
+	ListVersions(ctx context.Context, schemaID string) ([]*ConfigSchemaVersion, error)
+	
+	// ListVersionReferences retrieves the schemas a version references,
+	// directly or not, at the versions pinned when it was published
+	ListVersionReferences(ctx context.Context, schemaID string, version int32) ([]*ConfigSchemaReference, error)
+	
 	// Delete deletes a config schema
-	// This should fail if any configs are using this schema (foreign key constraint)
+	// This should fail if other schemas reference this schema (foreign key constraint)
 	Delete(ctx context.Context, id string) error
 	
 	// Exists checks if a config schema exists by ID
 	Exists(ctx context.Context, id string) (bool, error)
 	
-	// ExistsByName checks if a config schema exists by name
-	ExistsByName(ctx context.Context, name string) (bool, error)
+	// ExistsByName checks if a name is taken for a schema of a project: by
+	// the project's or a global schema. An empty projectID, for a global
+	// schema, checks every schema, since every project sees it.
+	ExistsByName(ctx context.Context, projectID, name string) (bool, error)
 	
 	// Count returns the total number of config schemas
 	Count(ctx context.Context) (int64, error)
@@ -0,0 +1,53 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/domain/events"
+)
+
+// StreamEvent is a published domain event stamped with its position in the stream
+type StreamEvent struct {
+	ID         uint64 // Raft log index of the write; shared by the events of one write
+	Last       bool   // Last event of its write; only then is ID a complete resume position
+	ProjectID  string
+	ConfigKey  string // Set for config events; used to apply API key scopes
+	Type       string
+	Data       json.RawMessage
+	OccurredAt time.Time
+}
+
+// EventSubscription is a live feed of events for a single project
+type EventSubscription interface {
+	// Events delivers replayed and live events in ID order
+	Events() <-chan *StreamEvent
+
+	// Dropped is closed when the subscriber was disconnected because it fell
+	// too far behind or the node skipped events, and must resume from its last ID
+	Dropped() <-chan struct{}
+
+	// Gap reports whether events after the requested ID were no longer retained
+	Gap() bool
+
+	// Close unsubscribes and releases the subscription
+	Close()
+}
+
+// EventPublisher defines the interface for publishing domain events
+type EventPublisher interface {
+	// Publish publishes the project-scoped domain events of one committed
+	// write; id is the write's position in the stream and must increase
+	Publish(ctx context.Context, id uint64, events ...events.ProjectEvent) error
+
+	// Reset drops retained events and subscribers after the publisher skipped
+	// writes, so that only events after id can be replayed
+	Reset(id uint64)
+}
+
+// EventSubscriber defines the interface for subscribing to published events
+type EventSubscriber interface {
+	// Subscribe subscribes to a project's events, replaying retained events with ID > afterID
+	Subscribe(ctx context.Context, projectID string, afterID uint64) (EventSubscription, error)
+}
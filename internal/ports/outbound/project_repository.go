@@ -8,7 +8,6 @@ import (
 type Project struct {
 	ID          string
 	Name        string
-	APIKey      string
 	OwnerUserID string
 	CreatedAt   string
 	UpdatedAt   string
@@ -18,15 +17,13 @@ type Project struct {
 type CreateProjectParams struct {
 	ID          string
 	Name        string
-	APIKey      string
 	OwnerUserID string
 }
 
 // UpdateProjectParams holds parameters for updating a project
 type UpdateProjectParams struct {
-	ID     string
-	Name   *string
-	APIKey *string
+	ID   string
+	Name *string
 }
 
 // ProjectRepository defines the interface for project data access
@@ -37,9 +34,6 @@ type ProjectRepository interface {
 	// GetByID retrieves a project by ID
 	GetByID(ctx context.Context, id string) (*Project, error)
 	
-	// GetByAPIKey retrieves a project by API key
-	GetByAPIKey(ctx context.Context, apiKey string) (*Project, error)
-	
 	// List retrieves all projects
 	List(ctx context.Context) ([]*Project, error)
 	
@@ -55,9 +49,6 @@ type ProjectRepository interface {
 	// Exists checks if a project exists by ID
 	Exists(ctx context.Context, id string) (bool, error)
 	
-	// ExistsByAPIKey checks if a project exists by API key
-	ExistsByAPIKey(ctx context.Context, apiKey string) (bool, error)
-	
 	// ExistsByName checks if a project exists by name
 	ExistsByName(ctx context.Context, name string) (bool, error)
 	
@@ -0,0 +1,137 @@
+package apikey
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/domain/entities"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+const (
+	// MaxAPIKeyNameLength bounds API key display names
+	MaxAPIKeyNameLength = 100
+
+	// API key statuses reported to clients
+	StatusActive  = "active"
+	StatusExpired = "expired"
+	StatusRevoked = "revoked"
+)
+
+// APIKeyResponse holds API key data with the secret masked
+type APIKeyResponse struct {
+	ID              string   `json:"id"`
+	ProjectID       string   `json:"project_id"`
+	Name            string   `json:"name"`
+	MaskedKey       string   `json:"masked_key"`
+	KeyPrefixes     []string `json:"key_prefixes"`
+	Status          string   `json:"status"`
+	CreatedByUserID string   `json:"created_by_user_id,omitempty"`
+	CreatedAt       string   `json:"created_at"`
+	ExpiresAt       *string  `json:"expires_at"`
+	LastUsedAt      *string  `json:"last_used_at"`
+	RevokedAt       *string  `json:"revoked_at"`
+}
+
+// toEntity converts a stored API key into its domain entity
+func toEntity(stored *outbound.APIKey) (*entities.APIKey, error) {
+	key, err := toHashedKey(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := valueobjects.NewAPIKeyScope(stored.KeyPrefixes)
+	if err != nil {
+		return nil, apperrors.Internal(err, "stored API key scope is invalid")
+	}
+
+	return entities.ReconstructAPIKey(
+		stored.ID,
+		stored.ProjectID,
+		stored.Name,
+		key,
+		scope,
+		stored.CreatedByUserID,
+		stored.CreatedAt,
+		stored.ExpiresAt,
+		stored.LastUsedAt,
+		stored.RevokedAt,
+	), nil
+}
+
+// toHashedKey reads the stored hash of an API key
+func toHashedKey(stored *outbound.APIKey) (valueobjects.HashedAPIKey, error) {
+	key, err := valueobjects.NewHashedAPIKey(
+		stored.LookupPrefix,
+		stored.KeySalt,
+		stored.KeyHash,
+		stored.KeyHint,
+		stored.HashVersion,
+	)
+	if err != nil {
+		return valueobjects.HashedAPIKey{}, apperrors.Internal(err, "stored API key is invalid")
+	}
+	return key, nil
+}
+
+// toResponse converts a stored API key into a response, masking the secret
+func toResponse(stored *outbound.APIKey) (*APIKeyResponse, error) {
+	keyEntity, err := toEntity(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	status := StatusActive
+	switch {
+	case keyEntity.IsRevoked():
+		status = StatusRevoked
+	case keyEntity.IsExpired(time.Now()):
+		status = StatusExpired
+	}
+
+	return &APIKeyResponse{
+		ID:              keyEntity.ID(),
+		ProjectID:       keyEntity.ProjectID(),
+		Name:            keyEntity.Name(),
+		MaskedKey:       keyEntity.Key().Masked(),
+		KeyPrefixes:     keyEntity.Scope().KeyPrefixes(),
+		Status:          status,
+		CreatedByUserID: keyEntity.CreatedByUserID(),
+		CreatedAt:       keyEntity.CreatedAt().Format(time.RFC3339),
+		ExpiresAt:       formatOptionalTime(keyEntity.ExpiresAt()),
+		LastUsedAt:      formatOptionalTime(keyEntity.LastUsedAt()),
+		RevokedAt:       formatOptionalTime(keyEntity.RevokedAt()),
+	}, nil
+}
+
+// formatOptionalTime formats a nullable timestamp for responses
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+// validateName validates an API key display name
+func validateName(name string) (string, error) {
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" {
+		return "", apperrors.BadRequest("API key name is required")
+	}
+	if len(trimmed) > MaxAPIKeyNameLength {
+		return "", apperrors.BadRequest(fmt.Sprintf("API key name must be at most %d characters", MaxAPIKeyNameLength))
+	}
+	return trimmed, nil
+}
+
+// validateExpiry ensures an expiry lies in the future
+func validateExpiry(expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return apperrors.BadRequest("API key expiry must be in the future")
+	}
+	return nil
+}
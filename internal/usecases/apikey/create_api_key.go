@@ -0,0 +1,137 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vlone310/cfguardian/internal/domain/entities"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// CreateAPIKeyRequest holds API key creation data
+type CreateAPIKeyRequest struct {
+	ProjectID       string     `json:"-"`
+	Name            string     `json:"name"`
+	KeyPrefixes     []string   `json:"key_prefixes"` // Limit the key to these config key prefixes (empty = all)
+	ExpiresAt       *time.Time `json:"expires_at"`
+	CreatedByUserID string     `json:"-"`
+}
+
+// CreateAPIKeyResponse holds a newly created API key.
+// The full key is only ever returned here.
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	APIKey string `json:"api_key"`
+}
+
+// CreateAPIKeyUseCase handles API key creation
+type CreateAPIKeyUseCase struct {
+	apiKeyRepo      outbound.APIKeyRepository
+	projectRepo     outbound.ProjectRepository
+	apiKeyGenerator *services.APIKeyGenerator
+	apiKeyHasher    *services.APIKeyHasher
+}
+
+// NewCreateAPIKeyUseCase creates a new CreateAPIKeyUseCase
+func NewCreateAPIKeyUseCase(
+	apiKeyRepo outbound.APIKeyRepository,
+	projectRepo outbound.ProjectRepository,
+	apiKeyGenerator *services.APIKeyGenerator,
+	apiKeyHasher *services.APIKeyHasher,
+) *CreateAPIKeyUseCase {
+	return &CreateAPIKeyUseCase{
+		apiKeyRepo:      apiKeyRepo,
+		projectRepo:     projectRepo,
+		apiKeyGenerator: apiKeyGenerator,
+		apiKeyHasher:    apiKeyHasher,
+	}
+}
+
+// Execute creates a new API key for a project
+func (uc *CreateAPIKeyUseCase) Execute(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	// Validate input
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	name, err := validateName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	scope, err := valueobjects.NewAPIKeyScope(req.KeyPrefixes)
+	if err != nil {
+		return nil, apperrors.Invalid(err)
+	}
+	if err := validateExpiry(req.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	// Check if project exists
+	exists, err := uc.projectRepo.Exists(ctx, req.ProjectID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to check if project exists")
+	}
+	if !exists {
+		return nil, apperrors.New(apperrors.ErrCodeProjectNotFound, "project not found")
+	}
+
+	return createKey(ctx, uc.apiKeyRepo, uc.apiKeyGenerator, uc.apiKeyHasher, req.ProjectID, name, scope, req.CreatedByUserID, req.ExpiresAt)
+}
+
+// createKey generates a new API key and persists its hash
+func createKey(
+	ctx context.Context,
+	apiKeyRepo outbound.APIKeyRepository,
+	apiKeyGenerator *services.APIKeyGenerator,
+	apiKeyHasher *services.APIKeyHasher,
+	projectID, name string,
+	scope valueobjects.APIKeyScope,
+	createdByUserID string,
+	expiresAt *time.Time,
+) (*CreateAPIKeyResponse, error) {
+	// Generate API key
+	key, err := apiKeyGenerator.Generate()
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to generate API key")
+	}
+
+	// Only the hash is stored; the key itself is returned once below
+	hashed, err := apiKeyHasher.Hash(key)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to hash API key")
+	}
+
+	// Create domain entity
+	keyEntity := entities.NewAPIKey(uuid.New().String(), projectID, name, hashed, scope, createdByUserID, expiresAt)
+
+	// Persist to repository
+	stored, err := apiKeyRepo.Create(ctx, outbound.CreateAPIKeyParams{
+		ID:              keyEntity.ID(),
+		ProjectID:       keyEntity.ProjectID(),
+		Name:            keyEntity.Name(),
+		LookupPrefix:    keyEntity.Key().LookupPrefix(),
+		KeyHash:         keyEntity.Key().Hash(),
+		KeySalt:         keyEntity.Key().Salt(),
+		KeyHint:         keyEntity.Key().Hint(),
+		HashVersion:     keyEntity.Key().Version(),
+		KeyPrefixes:     keyEntity.Scope().KeyPrefixes(),
+		CreatedByUserID: keyEntity.CreatedByUserID(),
+		ExpiresAt:       keyEntity.ExpiresAt(),
+	})
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to create API key")
+	}
+
+	resp, err := toResponse(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateAPIKeyResponse{
+		APIKeyResponse: *resp,
+		APIKey:         key.Value(),
+	}, nil
+}
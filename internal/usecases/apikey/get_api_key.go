@@ -0,0 +1,43 @@
+package apikey
+
+import (
+	"context"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// GetAPIKeyRequest holds get API key request data
+type GetAPIKeyRequest struct {
+	ProjectID string `json:"project_id"`
+	KeyID     string `json:"key_id"`
+}
+
+// GetAPIKeyUseCase handles retrieving a single API key
+type GetAPIKeyUseCase struct {
+	apiKeyRepo outbound.APIKeyRepository
+}
+
+// NewGetAPIKeyUseCase creates a new GetAPIKeyUseCase
+func NewGetAPIKeyUseCase(apiKeyRepo outbound.APIKeyRepository) *GetAPIKeyUseCase {
+	return &GetAPIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// Execute retrieves a project's API key by ID
+func (uc *GetAPIKeyUseCase) Execute(ctx context.Context, req GetAPIKeyRequest) (*APIKeyResponse, error) {
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	if req.KeyID == "" {
+		return nil, apperrors.BadRequest("API key ID is required")
+	}
+
+	apiKey, err := uc.apiKeyRepo.GetByID(ctx, req.ProjectID, req.KeyID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get API key")
+	}
+
+	return toResponse(apiKey)
+}
@@ -0,0 +1,57 @@
+package apikey
+
+import (
+	"context"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// ListAPIKeysRequest holds list API keys request data
+type ListAPIKeysRequest struct {
+	ProjectID string `json:"project_id"`
+}
+
+// ListAPIKeysResponse holds a project's API keys
+type ListAPIKeysResponse struct {
+	APIKeys []*APIKeyResponse `json:"api_keys"`
+	Total   int               `json:"total"`
+}
+
+// ListAPIKeysUseCase handles listing a project's API keys
+type ListAPIKeysUseCase struct {
+	apiKeyRepo outbound.APIKeyRepository
+}
+
+// NewListAPIKeysUseCase creates a new ListAPIKeysUseCase
+func NewListAPIKeysUseCase(apiKeyRepo outbound.APIKeyRepository) *ListAPIKeysUseCase {
+	return &ListAPIKeysUseCase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// Execute lists all API keys of a project, newest first
+func (uc *ListAPIKeysUseCase) Execute(ctx context.Context, req ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+
+	apiKeys, err := uc.apiKeyRepo.ListByProject(ctx, req.ProjectID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list API keys")
+	}
+
+	responses := make([]*APIKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		resp, err := toResponse(apiKey)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, resp)
+	}
+
+	return &ListAPIKeysResponse{
+		APIKeys: responses,
+		Total:   len(responses),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"context"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// RevokeAPIKeyRequest holds revoke API key request data
+type RevokeAPIKeyRequest struct {
+	ProjectID string `json:"project_id"`
+	KeyID     string `json:"key_id"`
+}
+
+// RevokeAPIKeyUseCase handles immediately disabling an API key.
+// Revoked keys are kept so their metadata stays auditable.
+type RevokeAPIKeyUseCase struct {
+	apiKeyRepo outbound.APIKeyRepository
+}
+
+// NewRevokeAPIKeyUseCase creates a new RevokeAPIKeyUseCase
+func NewRevokeAPIKeyUseCase(apiKeyRepo outbound.APIKeyRepository) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// Execute revokes an API key
+func (uc *RevokeAPIKeyUseCase) Execute(ctx context.Context, req RevokeAPIKeyRequest) (*APIKeyResponse, error) {
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	if req.KeyID == "" {
+		return nil, apperrors.BadRequest("API key ID is required")
+	}
+
+	revoked, err := uc.apiKeyRepo.Revoke(ctx, req.ProjectID, req.KeyID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to revoke API key")
+	}
+
+	return toResponse(revoked)
+}
@@ -0,0 +1,128 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// MaxRotationGracePeriod bounds how long a rotated key may keep working
+const MaxRotationGracePeriod = 30 * 24 * time.Hour
+
+// RotateAPIKeyRequest holds API key rotation data
+type RotateAPIKeyRequest struct {
+	ProjectID       string         `json:"-"`
+	KeyID           string         `json:"-"`
+	GracePeriod     *time.Duration `json:"-"` // How long the old key keeps working (nil = default)
+	ExpiresAt       *time.Time     `json:"-"` // Expiry of the replacement key
+	RotatedByUserID string         `json:"-"`
+}
+
+// RotateAPIKeyResponse holds the replacement key and the retiring key
+type RotateAPIKeyResponse struct {
+	APIKey      *CreateAPIKeyResponse `json:"api_key"`
+	ReplacedKey *APIKeyResponse       `json:"replaced_key"`
+}
+
+// RotateAPIKeyUseCase replaces an API key with a new one that has the same
+// name and scope. The old key keeps working for a grace period so clients
+// can roll over without downtime.
+type RotateAPIKeyUseCase struct {
+	apiKeyRepo         outbound.APIKeyRepository
+	apiKeyGenerator    *services.APIKeyGenerator
+	apiKeyHasher       *services.APIKeyHasher
+	defaultGracePeriod time.Duration
+}
+
+// NewRotateAPIKeyUseCase creates a new RotateAPIKeyUseCase
+func NewRotateAPIKeyUseCase(
+	apiKeyRepo outbound.APIKeyRepository,
+	apiKeyGenerator *services.APIKeyGenerator,
+	apiKeyHasher *services.APIKeyHasher,
+	defaultGracePeriod time.Duration,
+) *RotateAPIKeyUseCase {
+	return &RotateAPIKeyUseCase{
+		apiKeyRepo:         apiKeyRepo,
+		apiKeyGenerator:    apiKeyGenerator,
+		apiKeyHasher:       apiKeyHasher,
+		defaultGracePeriod: defaultGracePeriod,
+	}
+}
+
+// Execute issues a replacement key and schedules the old key's expiry
+func (uc *RotateAPIKeyUseCase) Execute(ctx context.Context, req RotateAPIKeyRequest) (*RotateAPIKeyResponse, error) {
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	if req.KeyID == "" {
+		return nil, apperrors.BadRequest("API key ID is required")
+	}
+
+	gracePeriod := uc.defaultGracePeriod
+	if req.GracePeriod != nil {
+		gracePeriod = *req.GracePeriod
+	}
+	if gracePeriod < 0 {
+		return nil, apperrors.BadRequest("grace period cannot be negative")
+	}
+	if gracePeriod > MaxRotationGracePeriod {
+		return nil, apperrors.BadRequest(fmt.Sprintf("grace period cannot exceed %s", MaxRotationGracePeriod))
+	}
+	if err := validateExpiry(req.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	stored, err := uc.apiKeyRepo.GetByID(ctx, req.ProjectID, req.KeyID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get API key")
+	}
+
+	oldKey, err := toEntity(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !oldKey.IsActive(now) {
+		return nil, apperrors.Conflict("only active API keys can be rotated")
+	}
+
+	// Issue the replacement before retiring the old key so clients
+	// always have a working key
+	replacement, err := createKey(
+		ctx,
+		uc.apiKeyRepo,
+		uc.apiKeyGenerator,
+		uc.apiKeyHasher,
+		oldKey.ProjectID(),
+		oldKey.Name(),
+		oldKey.Scope(),
+		req.RotatedByUserID,
+		req.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The grace period never extends an expiry that is already sooner
+	oldKey.ExpireWithin(now.Add(gracePeriod))
+
+	retired, err := uc.apiKeyRepo.SetExpiry(ctx, oldKey.ProjectID(), oldKey.ID(), *oldKey.ExpiresAt())
+	if err != nil {
+		return nil, apperrors.Internal(err, "replacement key created but failed to expire old key")
+	}
+
+	replaced, err := toResponse(retired)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RotateAPIKeyResponse{
+		APIKey:      replacement,
+		ReplacedKey: replaced,
+	}, nil
+}
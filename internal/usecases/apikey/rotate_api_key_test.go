@@ -0,0 +1,281 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+const oldTestKey = "cfg_abcdefghijklmnopqrstuvwxyz012345"
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, params outbound.CreateAPIKeyParams) (*outbound.APIKey, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, projectID, id string) (*outbound.APIKey, error) {
+	args := m.Called(ctx, projectID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByLookupPrefix(ctx context.Context, lookupPrefix string) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx, lookupPrefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListActive(ctx context.Context) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByProject(ctx context.Context, projectID string) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, params outbound.UpdateAPIKeyParams) (*outbound.APIKey, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, projectID, id string) (*outbound.APIKey, error) {
+	args := m.Called(ctx, projectID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) SetExpiry(ctx context.Context, projectID, id string, expiresAt time.Time) (*outbound.APIKey, error) {
+	args := m.Called(ctx, projectID, id, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) UpdateHash(ctx context.Context, id string, keyHash []byte, hashVersion int) error {
+	args := m.Called(ctx, id, keyHash, hashVersion)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
+// storedKey returns an active, scoped key of proj-1
+func storedKey() *outbound.APIKey {
+	hashed, err := services.NewAPIKeyHasher("test-pepper").Hash(valueobjects.MustNewAPIKey(oldTestKey))
+	if err != nil {
+		panic(err)
+	}
+	return &outbound.APIKey{
+		ID:           "key-old",
+		ProjectID:    "proj-1",
+		Name:         "mobile app",
+		LookupPrefix: hashed.LookupPrefix(),
+		KeyHash:      hashed.Hash(),
+		KeySalt:      hashed.Salt(),
+		KeyHint:      hashed.Hint(),
+		HashVersion:  hashed.Version(),
+		KeyPrefixes:  []string{"mobile."},
+		CreatedAt:    time.Now().Add(-24 * time.Hour),
+	}
+}
+
+// echoCreate makes Create return what it was given
+func echoCreate(repo *MockAPIKeyRepository) {
+	created := &outbound.APIKey{}
+	repo.On("Create", mock.Anything, mock.AnythingOfType("outbound.CreateAPIKeyParams")).
+		Run(func(args mock.Arguments) {
+			params := args.Get(1).(outbound.CreateAPIKeyParams)
+			*created = outbound.APIKey{
+				ID:           params.ID,
+				ProjectID:    params.ProjectID,
+				Name:         params.Name,
+				LookupPrefix: params.LookupPrefix,
+				KeyHash:      params.KeyHash,
+				KeySalt:      params.KeySalt,
+				KeyHint:      params.KeyHint,
+				HashVersion:  params.HashVersion,
+				KeyPrefixes:  params.KeyPrefixes,
+				ExpiresAt:    params.ExpiresAt,
+				CreatedAt:    time.Now(),
+			}
+		}).
+		Return(created, nil)
+}
+
+// echoSetExpiry makes SetExpiry return the stored key with the new expiry
+func echoSetExpiry(repo *MockAPIKeyRepository, stored *outbound.APIKey, expiresAt *time.Time) {
+	retired := &outbound.APIKey{}
+	repo.On("SetExpiry", mock.Anything, "proj-1", stored.ID, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			*expiresAt = args.Get(3).(time.Time)
+			*retired = *stored
+			retired.ExpiresAt = expiresAt
+		}).
+		Return(retired, nil)
+}
+
+func TestRotateAPIKeyUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	useCase := NewRotateAPIKeyUseCase(repo, services.NewAPIKeyGenerator(), services.NewAPIKeyHasher("test-pepper"), 24*time.Hour)
+
+	stored := storedKey()
+	repo.On("GetByID", ctx, "proj-1", "key-old").Return(stored, nil)
+	echoCreate(repo)
+	var oldExpiry time.Time
+	echoSetExpiry(repo, stored, &oldExpiry)
+
+	// Act
+	before := time.Now()
+	resp, err := useCase.Execute(ctx, RotateAPIKeyRequest{ProjectID: "proj-1", KeyID: "key-old"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, oldTestKey, resp.APIKey.APIKey)
+	assert.Equal(t, "mobile app", resp.APIKey.Name)
+	assert.Equal(t, []string{"mobile."}, resp.APIKey.KeyPrefixes)
+	assert.Equal(t, StatusActive, resp.APIKey.Status)
+
+	// Only a hash of the new key is persisted
+	params := repo.Calls[1].Arguments.Get(1).(outbound.CreateAPIKeyParams)
+	assert.NotContains(t, string(params.KeyHash), resp.APIKey.APIKey)
+	hashed, err := valueobjects.NewHashedAPIKey(params.LookupPrefix, params.KeySalt, params.KeyHash, params.KeyHint, params.HashVersion)
+	require.NoError(t, err)
+	assert.True(t, services.NewAPIKeyHasher("test-pepper").Verify(valueobjects.MustNewAPIKey(resp.APIKey.APIKey), hashed))
+	assert.Equal(t, hashed.Masked(), resp.APIKey.MaskedKey)
+
+	// Old key keeps working for the default grace period
+	assert.WithinDuration(t, before.Add(24*time.Hour), oldExpiry, time.Minute)
+	assert.Equal(t, StatusActive, resp.ReplacedKey.Status)
+	assert.NotContains(t, resp.ReplacedKey.MaskedKey, oldTestKey)
+
+	repo.AssertExpectations(t)
+}
+
+func TestRotateAPIKeyUseCase_Execute_KeepsSoonerExpiry(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	useCase := NewRotateAPIKeyUseCase(repo, services.NewAPIKeyGenerator(), services.NewAPIKeyHasher("test-pepper"), 24*time.Hour)
+
+	stored := storedKey()
+	soon := time.Now().Add(time.Hour)
+	stored.ExpiresAt = &soon
+	repo.On("GetByID", ctx, "proj-1", "key-old").Return(stored, nil)
+	echoCreate(repo)
+	var oldExpiry time.Time
+	echoSetExpiry(repo, stored, &oldExpiry)
+
+	// Act
+	_, err := useCase.Execute(ctx, RotateAPIKeyRequest{ProjectID: "proj-1", KeyID: "key-old"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, oldExpiry.Equal(soon), "grace period must not extend an earlier expiry")
+}
+
+func TestRotateAPIKeyUseCase_Execute_CustomGracePeriod(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	useCase := NewRotateAPIKeyUseCase(repo, services.NewAPIKeyGenerator(), services.NewAPIKeyHasher("test-pepper"), 24*time.Hour)
+
+	stored := storedKey()
+	repo.On("GetByID", ctx, "proj-1", "key-old").Return(stored, nil)
+	echoCreate(repo)
+	var oldExpiry time.Time
+	echoSetExpiry(repo, stored, &oldExpiry)
+	grace := time.Duration(0)
+
+	// Act
+	before := time.Now()
+	resp, err := useCase.Execute(ctx, RotateAPIKeyRequest{ProjectID: "proj-1", KeyID: "key-old", GracePeriod: &grace})
+
+	// Assert
+	require.NoError(t, err)
+	assert.WithinDuration(t, before, oldExpiry, time.Minute)
+	assert.Equal(t, StatusExpired, resp.ReplacedKey.Status)
+}
+
+func TestRotateAPIKeyUseCase_Execute_RejectsRevokedKey(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	useCase := NewRotateAPIKeyUseCase(repo, services.NewAPIKeyGenerator(), services.NewAPIKeyHasher("test-pepper"), 24*time.Hour)
+
+	stored := storedKey()
+	revokedAt := time.Now().Add(-time.Minute)
+	stored.RevokedAt = &revokedAt
+	repo.On("GetByID", ctx, "proj-1", "key-old").Return(stored, nil)
+
+	// Act
+	resp, err := useCase.Execute(ctx, RotateAPIKeyRequest{ProjectID: "proj-1", KeyID: "key-old"})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "active")
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRotateAPIKeyUseCase_Execute_InvalidGracePeriod(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	useCase := NewRotateAPIKeyUseCase(repo, services.NewAPIKeyGenerator(), services.NewAPIKeyHasher("test-pepper"), 24*time.Hour)
+
+	for _, grace := range []time.Duration{-time.Second, MaxRotationGracePeriod + time.Hour} {
+		grace := grace
+		_, err := useCase.Execute(ctx, RotateAPIKeyRequest{ProjectID: "proj-1", KeyID: "key-old", GracePeriod: &grace})
+		assert.Error(t, err)
+	}
+	repo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRotateAPIKeyUseCase_Execute_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	useCase := NewRotateAPIKeyUseCase(repo, services.NewAPIKeyGenerator(), services.NewAPIKeyHasher("test-pepper"), 24*time.Hour)
+	repo.On("GetByID", ctx, "proj-1", "missing").Return(nil, errors.New("API key not found"))
+
+	_, err := useCase.Execute(ctx, RotateAPIKeyRequest{ProjectID: "proj-1", KeyID: "missing"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API key not found")
+}
@@ -0,0 +1,94 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// UpdateAPIKeyRequest holds API key update data.
+// Omitted fields are left unchanged.
+type UpdateAPIKeyRequest struct {
+	ProjectID   string     `json:"-"`
+	KeyID       string     `json:"-"`
+	Name        *string    `json:"name"`
+	KeyPrefixes *[]string  `json:"key_prefixes"` // An empty list removes the restriction
+	ExpiresAt   *time.Time `json:"expires_at"`
+	ClearExpiry bool       `json:"clear_expiry"` // Make the key never expire
+}
+
+// UpdateAPIKeyUseCase handles API key updates
+type UpdateAPIKeyUseCase struct {
+	apiKeyRepo outbound.APIKeyRepository
+}
+
+// NewUpdateAPIKeyUseCase creates a new UpdateAPIKeyUseCase
+func NewUpdateAPIKeyUseCase(apiKeyRepo outbound.APIKeyRepository) *UpdateAPIKeyUseCase {
+	return &UpdateAPIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// Execute updates an API key's name, scope or expiry
+func (uc *UpdateAPIKeyUseCase) Execute(ctx context.Context, req UpdateAPIKeyRequest) (*APIKeyResponse, error) {
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	if req.KeyID == "" {
+		return nil, apperrors.BadRequest("API key ID is required")
+	}
+	if req.ClearExpiry && req.ExpiresAt != nil {
+		return nil, apperrors.BadRequest("expires_at and clear_expiry cannot be combined")
+	}
+
+	params := outbound.UpdateAPIKeyParams{
+		ID:          req.KeyID,
+		ProjectID:   req.ProjectID,
+		ClearExpiry: req.ClearExpiry,
+	}
+
+	if req.Name != nil {
+		name, err := validateName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		params.Name = &name
+	}
+
+	if req.KeyPrefixes != nil {
+		scope, err := valueobjects.NewAPIKeyScope(*req.KeyPrefixes)
+		if err != nil {
+			return nil, apperrors.Invalid(err)
+		}
+		params.KeyPrefixes = scope.KeyPrefixes()
+		if params.KeyPrefixes == nil {
+			params.KeyPrefixes = []string{}
+		}
+	}
+
+	if req.ExpiresAt != nil {
+		if err := validateExpiry(req.ExpiresAt); err != nil {
+			return nil, err
+		}
+		params.ExpiresAt = req.ExpiresAt
+	}
+
+	// Revoked keys stay revoked; only their metadata may change
+	existing, err := uc.apiKeyRepo.GetByID(ctx, req.ProjectID, req.KeyID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get API key")
+	}
+	if existing.RevokedAt != nil && (params.ExpiresAt != nil || params.ClearExpiry) {
+		return nil, apperrors.Conflict("cannot change the expiry of a revoked API key")
+	}
+
+	updated, err := uc.apiKeyRepo.Update(ctx, params)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to update API key")
+	}
+
+	return toResponse(updated)
+}
@@ -2,9 +2,9 @@ package auth
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -42,22 +42,22 @@ func NewLoginUserUseCase(
 func (uc *LoginUserUseCase) Execute(ctx context.Context, req LoginRequest) (*LoginResponse, error) {
 	// Validate input
 	if req.Email == "" {
-		return nil, fmt.Errorf("email is required")
+		return nil, apperrors.BadRequest("email is required")
 	}
 	if req.Password == "" {
-		return nil, fmt.Errorf("password is required")
+		return nil, apperrors.BadRequest("password is required")
 	}
 	
 	// Find user by email
 	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		// Don't reveal whether user exists
-		return nil, fmt.Errorf("invalid email or password")
+		return nil, apperrors.New(apperrors.ErrCodeInvalidCredentials, "invalid email or password")
 	}
 	
 	// Verify password
 	if err := uc.passwordHasher.Verify(req.Password, user.PasswordHash); err != nil {
-		return nil, fmt.Errorf("invalid email or password")
+		return nil, apperrors.New(apperrors.ErrCodeInvalidCredentials, "invalid email or password")
 	}
 	
 	// Return response (token generation will be handled by adapter layer)
@@ -2,8 +2,8 @@ package auth
 
 import (
 	"context"
-	"fmt"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -35,7 +35,7 @@ func NewRefreshTokenUseCase(userRepo outbound.UserRepository) *RefreshTokenUseCa
 func (uc *RefreshTokenUseCase) Execute(ctx context.Context, req RefreshTokenRequest) (*RefreshTokenResponse, error) {
 	// Validate input
 	if req.RefreshToken == "" {
-		return nil, fmt.Errorf("refresh token is required")
+		return nil, apperrors.BadRequest("refresh token is required")
 	}
 	
 	// Note: Refresh token validation would typically involve:
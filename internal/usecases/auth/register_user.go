@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -44,30 +45,30 @@ func (uc *RegisterUserUseCase) Execute(ctx context.Context, req RegisterRequest)
 	// Validate email
 	email, err := valueobjects.NewEmail(req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("invalid email: %w", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeInvalidEmail, fmt.Sprintf("invalid email: %v", err))
 	}
 	
 	// Validate password
 	if req.Password == "" {
-		return nil, fmt.Errorf("password is required")
+		return nil, apperrors.BadRequest("password is required")
 	}
 	if len(req.Password) < 8 {
-		return nil, fmt.Errorf("password must be at least 8 characters")
+		return nil, apperrors.BadRequest("password must be at least 8 characters")
 	}
 	
 	// Check if user already exists
 	exists, err := uc.userRepo.ExistsByEmail(ctx, email.Value())
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if user exists: %w", err)
+		return nil, apperrors.Internal(err, "failed to check if user exists")
 	}
 	if exists {
-		return nil, fmt.Errorf("user with email %s already exists", email.Value())
+		return nil, apperrors.New(apperrors.ErrCodeUserExists, fmt.Sprintf("user with email %s already exists", email.Value()))
 	}
 	
 	// Hash password
 	passwordHash, err := uc.passwordHasher.Hash(req.Password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return nil, apperrors.Internal(err, "failed to hash password")
 	}
 	
 	// Create user
@@ -78,7 +79,7 @@ func (uc *RegisterUserUseCase) Execute(ctx context.Context, req RegisterRequest)
 		PasswordHash: passwordHash,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+		return nil, apperrors.Internal(err, "failed to create user")
 	}
 	
 	return &RegisterResponse{
@@ -2,12 +2,18 @@ package auth
 
 import (
 	"context"
-	"fmt"
+	"time"
 
+	"github.com/vlone310/cfguardian/internal/domain/entities"
+	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
+// lastUsedUpdateInterval limits how often a key's last-used timestamp is written
+const lastUsedUpdateInterval = time.Minute
+
 // ValidateAPIKeyRequest holds API key validation request
 type ValidateAPIKeyRequest struct {
 	APIKey string
@@ -20,33 +26,129 @@ type ValidateAPIKeyResponse struct {
 	ProjectName string
 }
 
+// APIKeyPrincipal is a client authenticated by one of its project's API keys
+type APIKeyPrincipal struct {
+	KeyID     string
+	ProjectID string
+	Scope     valueobjects.APIKeyScope
+}
+
 // ValidateAPIKeyUseCase validates API keys for client access
 type ValidateAPIKeyUseCase struct {
-	projectRepo outbound.ProjectRepository
+	apiKeyRepo   outbound.APIKeyRepository
+	projectRepo  outbound.ProjectRepository
+	apiKeyHasher *services.APIKeyHasher
 }
 
 // NewValidateAPIKeyUseCase creates a new ValidateAPIKeyUseCase
-func NewValidateAPIKeyUseCase(projectRepo outbound.ProjectRepository) *ValidateAPIKeyUseCase {
+func NewValidateAPIKeyUseCase(
+	apiKeyRepo outbound.APIKeyRepository,
+	projectRepo outbound.ProjectRepository,
+	apiKeyHasher *services.APIKeyHasher,
+) *ValidateAPIKeyUseCase {
 	return &ValidateAPIKeyUseCase{
-		projectRepo: projectRepo,
+		apiKeyRepo:   apiKeyRepo,
+		projectRepo:  projectRepo,
+		apiKeyHasher: apiKeyHasher,
 	}
 }
 
-// Execute validates an API key
-func (uc *ValidateAPIKeyUseCase) Execute(ctx context.Context, req ValidateAPIKeyRequest) (*ValidateAPIKeyResponse, error) {
+// Authenticate resolves an active API key to the project and scope it grants
+func (uc *ValidateAPIKeyUseCase) Authenticate(ctx context.Context, apiKeyStr string) (*APIKeyPrincipal, error) {
 	// Validate API key format
-	apiKey, err := valueobjects.NewAPIKey(req.APIKey)
+	apiKey, err := valueobjects.NewAPIKey(apiKeyStr)
 	if err != nil {
-		return &ValidateAPIKeyResponse{Valid: false}, nil
+		return nil, apperrors.New(apperrors.ErrCodeInvalidAPIKey, "invalid API key format")
 	}
-	
-	// Find project by API key
-	project, err := uc.projectRepo.GetByAPIKey(ctx, apiKey.Value())
+
+	keyEntity, err := uc.findKey(ctx, apiKey)
 	if err != nil {
-		// API key not found or error
+		return nil, err
+	}
+
+	now := time.Now()
+	if keyEntity.IsRevoked() {
+		return nil, apperrors.New(apperrors.ErrCodeInvalidAPIKey, "API key has been revoked")
+	}
+	if keyEntity.IsExpired(now) {
+		return nil, apperrors.New(apperrors.ErrCodeInvalidAPIKey, "API key has expired")
+	}
+
+	// Keys converted from plaintext by migration get the pepper on first use.
+	// Failing to upgrade must not fail the request; it is retried next time.
+	if keyEntity.Key().IsLegacy() {
+		if upgraded, err := uc.apiKeyHasher.Upgrade(keyEntity.Key()); err == nil {
+			if err := uc.apiKeyRepo.UpdateHash(ctx, keyEntity.ID(), upgraded.Hash(), upgraded.Version()); err == nil {
+				keyEntity.RehashKey(upgraded)
+			}
+		}
+	}
+
+	// Record usage at most once per interval to keep reads cheap.
+	// Failing to record it must not fail the request.
+	if last := keyEntity.LastUsedAt(); last == nil || now.Sub(*last) >= lastUsedUpdateInterval {
+		_ = uc.apiKeyRepo.TouchLastUsed(ctx, keyEntity.ID(), now)
+	}
+
+	return &APIKeyPrincipal{
+		KeyID:     keyEntity.ID(),
+		ProjectID: keyEntity.ProjectID(),
+		Scope:     keyEntity.Scope(),
+	}, nil
+}
+
+// findKey returns the stored key matching apiKey.
+// Keys are found by their lookup prefix and then verified against each
+// candidate's hash in constant time.
+func (uc *ValidateAPIKeyUseCase) findKey(ctx context.Context, apiKey valueobjects.APIKey) (*entities.APIKey, error) {
+	candidates, err := uc.apiKeyRepo.ListByLookupPrefix(ctx, apiKey.LookupPrefix())
+	if err != nil {
+		// Not a key mismatch: clients must not discard a valid key over an outage
+		return nil, apperrors.Internal(err, "failed to look up API key")
+	}
+
+	for _, stored := range candidates {
+		hashed, err := valueobjects.NewHashedAPIKey(
+			stored.LookupPrefix,
+			stored.KeySalt,
+			stored.KeyHash,
+			stored.KeyHint,
+			stored.HashVersion,
+		)
+		if err != nil || !uc.apiKeyHasher.Verify(apiKey, hashed) {
+			continue
+		}
+
+		scope, err := valueobjects.NewAPIKeyScope(stored.KeyPrefixes)
+		if err != nil {
+			return nil, apperrors.Wrap(err, apperrors.ErrCodeInvalidAPIKey, "invalid API key scope")
+		}
+
+		return entities.ReconstructAPIKey(
+			stored.ID,
+			stored.ProjectID,
+			stored.Name,
+			hashed,
+			scope,
+			stored.CreatedByUserID,
+			stored.CreatedAt,
+			stored.ExpiresAt,
+			stored.LastUsedAt,
+			stored.RevokedAt,
+		), nil
+	}
+
+	return nil, apperrors.New(apperrors.ErrCodeInvalidAPIKey, "invalid API key")
+}
+
+// Execute validates an API key
+func (uc *ValidateAPIKeyUseCase) Execute(ctx context.Context, req ValidateAPIKeyRequest) (*ValidateAPIKeyResponse, error) {
+	project, err := uc.ValidateAndGetProject(ctx, req.APIKey)
+	if err != nil {
+		// API key not found, revoked or expired
 		return &ValidateAPIKeyResponse{Valid: false}, nil
 	}
-	
+
 	return &ValidateAPIKeyResponse{
 		Valid:       true,
 		ProjectID:   project.ID,
@@ -56,18 +158,15 @@ func (uc *ValidateAPIKeyUseCase) Execute(ctx context.Context, req ValidateAPIKey
 
 // ValidateAndGetProject validates API key and returns project if valid
 func (uc *ValidateAPIKeyUseCase) ValidateAndGetProject(ctx context.Context, apiKeyStr string) (*outbound.Project, error) {
-	// Validate API key format
-	apiKey, err := valueobjects.NewAPIKey(apiKeyStr)
+	principal, err := uc.Authenticate(ctx, apiKeyStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid API key format")
+		return nil, err
 	}
-	
-	// Find project by API key
-	project, err := uc.projectRepo.GetByAPIKey(ctx, apiKey.Value())
+
+	project, err := uc.projectRepo.GetByID(ctx, principal.ProjectID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid API key")
+		return nil, apperrors.New(apperrors.ErrCodeInvalidAPIKey, "invalid API key")
 	}
-	
+
 	return project, nil
 }
-
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+const testAPIKey = "cfg_abcdefghijklmnopqrstuvwxyz012345"
+
+// MockAPIKeyRepository mocks the API key lookups used for authentication
+type MockAPIKeyRepository struct {
+	mock.Mock
+	outbound.APIKeyRepository
+}
+
+func (m *MockAPIKeyRepository) ListByLookupPrefix(ctx context.Context, lookupPrefix string) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx, lookupPrefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) UpdateHash(ctx context.Context, id string, keyHash []byte, hashVersion int) error {
+	args := m.Called(ctx, id, keyHash, hashVersion)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	return nil
+}
+
+// storedAPIKey returns the stored form of a hashed key of proj-1
+func storedAPIKey(id string, hashed valueobjects.HashedAPIKey) *outbound.APIKey {
+	return &outbound.APIKey{
+		ID:           id,
+		ProjectID:    "proj-1",
+		LookupPrefix: hashed.LookupPrefix(),
+		KeyHash:      hashed.Hash(),
+		KeySalt:      hashed.Salt(),
+		KeyHint:      hashed.Hint(),
+		HashVersion:  hashed.Version(),
+		KeyPrefixes:  []string{"mobile."},
+	}
+}
+
+func TestValidateAPIKeyUseCase_Authenticate_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	hasher := services.NewAPIKeyHasher("test-pepper")
+	useCase := NewValidateAPIKeyUseCase(repo, nil, hasher)
+
+	key := valueobjects.MustNewAPIKey(testAPIKey)
+	hashed, err := hasher.Hash(key)
+	require.NoError(t, err)
+
+	// Another key sharing the lookup prefix must not match
+	other, err := hasher.Hash(valueobjects.MustNewAPIKey(testAPIKey[:12] + "ZZZZZZZZZZZZZZZZZZZZZZZZ"))
+	require.NoError(t, err)
+
+	repo.On("ListByLookupPrefix", ctx, key.LookupPrefix()).Return([]*outbound.APIKey{
+		storedAPIKey("key-other", other),
+		storedAPIKey("key-1", hashed),
+	}, nil)
+
+	// Act
+	principal, err := useCase.Authenticate(ctx, testAPIKey)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", principal.KeyID)
+	assert.Equal(t, "proj-1", principal.ProjectID)
+	assert.Equal(t, []string{"mobile."}, principal.Scope.KeyPrefixes())
+	repo.AssertNotCalled(t, "UpdateHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestValidateAPIKeyUseCase_Authenticate_WrongKey(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	hasher := services.NewAPIKeyHasher("test-pepper")
+	useCase := NewValidateAPIKeyUseCase(repo, nil, hasher)
+
+	hashed, err := hasher.Hash(valueobjects.MustNewAPIKey(testAPIKey))
+	require.NoError(t, err)
+	presented := testAPIKey[:12] + "ZZZZZZZZZZZZZZZZZZZZZZZZ"
+	repo.On("ListByLookupPrefix", ctx, hashed.LookupPrefix()).Return([]*outbound.APIKey{storedAPIKey("key-1", hashed)}, nil)
+
+	principal, err := useCase.Authenticate(ctx, presented)
+
+	require.Error(t, err)
+	assert.Nil(t, principal)
+	assert.Contains(t, err.Error(), "invalid API key")
+}
+
+func TestValidateAPIKeyUseCase_Authenticate_RepositoryError(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	hasher := services.NewAPIKeyHasher("test-pepper")
+	useCase := NewValidateAPIKeyUseCase(repo, nil, hasher)
+
+	hashed, err := hasher.Hash(valueobjects.MustNewAPIKey(testAPIKey))
+	require.NoError(t, err)
+	repo.On("ListByLookupPrefix", ctx, hashed.LookupPrefix()).Return(nil, errors.New("connection refused"))
+
+	principal, err := useCase.Authenticate(ctx, testAPIKey)
+
+	require.Error(t, err)
+	assert.Nil(t, principal)
+	assert.True(t, apperrors.HasCode(err, apperrors.ErrCodeInternal))
+}
+
+func TestValidateAPIKeyUseCase_Authenticate_UpgradesLegacyHash(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	hasher := services.NewAPIKeyHasher("test-pepper")
+	useCase := NewValidateAPIKeyUseCase(repo, nil, hasher)
+
+	// As converted by the hash_api_keys migration: sha256(salt || api_key)
+	key := valueobjects.MustNewAPIKey(testAPIKey)
+	salt := []byte("0123456789abcdef")
+	digest := sha256.Sum256(append(append([]byte{}, salt...), testAPIKey...))
+	legacy, err := valueobjects.NewHashedAPIKey(key.LookupPrefix(), salt, digest[:], key.Hint(), valueobjects.APIKeyHashVersionLegacy)
+	require.NoError(t, err)
+
+	repo.On("ListByLookupPrefix", ctx, key.LookupPrefix()).Return([]*outbound.APIKey{storedAPIKey("key-1", legacy)}, nil)
+	var upgradedHash []byte
+	repo.On("UpdateHash", ctx, "key-1", mock.Anything, valueobjects.APIKeyHashVersionPeppered).
+		Run(func(args mock.Arguments) {
+			upgradedHash = args.Get(2).([]byte)
+		}).
+		Return(nil)
+
+	// Act
+	principal, err := useCase.Authenticate(ctx, testAPIKey)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", principal.KeyID)
+	repo.AssertExpectations(t)
+
+	upgraded, err := valueobjects.NewHashedAPIKey(key.LookupPrefix(), salt, upgradedHash, key.Hint(), valueobjects.APIKeyHashVersionPeppered)
+	require.NoError(t, err)
+	assert.True(t, hasher.Verify(key, upgraded))
+}
+
+func TestValidateAPIKeyUseCase_Authenticate_RevokedKey(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAPIKeyRepository)
+	hasher := services.NewAPIKeyHasher("test-pepper")
+	useCase := NewValidateAPIKeyUseCase(repo, nil, hasher)
+
+	hashed, err := hasher.Hash(valueobjects.MustNewAPIKey(testAPIKey))
+	require.NoError(t, err)
+	stored := storedAPIKey("key-1", hashed)
+	revokedAt := time.Now().Add(-time.Minute)
+	stored.RevokedAt = &revokedAt
+	repo.On("ListByLookupPrefix", ctx, hashed.LookupPrefix()).Return([]*outbound.APIKey{stored}, nil)
+
+	_, err = useCase.Authenticate(ctx, testAPIKey)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "revoked")
+}
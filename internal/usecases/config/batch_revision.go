@@ -0,0 +1,42 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vlone310/cfguardian/internal/domain/entities"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// recordBatchRevision records the revision of one config rewritten by a
+// batch. The batch has already been committed, so failures are logged, not
+// returned.
+func recordBatchRevision(
+	ctx context.Context,
+	revisionRepo outbound.ConfigRevisionRepository,
+	updated *outbound.Config,
+) {
+	newVersion, _ := valueobjects.NewVersion(updated.Version)
+	revisionEntity := entities.NewConfigRevision(
+		uuid.New().String(),
+		updated.ProjectID,
+		updated.Key,
+		newVersion,
+		updated.Content,
+		updated.UpdatedByUserID,
+	)
+
+	_, err := revisionRepo.Create(ctx, outbound.CreateConfigRevisionParams{
+		ID:              revisionEntity.ID(),
+		ProjectID:       revisionEntity.ProjectID(),
+		ConfigKey:       revisionEntity.ConfigKey(),
+		Version:         revisionEntity.Version().Value(),
+		Content:         revisionEntity.Content(),
+		CreatedByUserID: revisionEntity.CreatedByUserID(),
+	})
+	if err != nil {
+		fmt.Printf("Warning: failed to create config revision: %v\n", err)
+	}
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/vlone310/cfguardian/internal/domain/events"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// configWatch narrows a project subscription to the events of one config.
+// With a path it passes on only events that change that fragment, with the
+// event's content replaced by the fragment (null once it no longer exists).
+type configWatch struct {
+	outbound.EventSubscription
+	key  string
+	path valueobjects.ConfigPath
+
+	// Last fragment passed on, or the one read when the watch started
+	fragment json.RawMessage
+	found    bool
+
+	// Events up to this version are already reflected in fragment
+	seedVersion int64
+
+	events    chan *outbound.StreamEvent
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newConfigWatch starts filtering sub. fragment, found and seedVersion
+// describe the config when the watch started; seedVersion 0 means unknown,
+// in which case the first event touching the fragment is passed on.
+func newConfigWatch(
+	sub outbound.EventSubscription,
+	key string,
+	path valueobjects.ConfigPath,
+	fragment json.RawMessage,
+	found bool,
+	seedVersion int64,
+) *configWatch {
+	w := &configWatch{
+		EventSubscription: sub,
+		key:               key,
+		path:              path,
+		fragment:          fragment,
+		found:             found,
+		seedVersion:       seedVersion,
+		events:            make(chan *outbound.StreamEvent),
+		done:              make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Events delivers the filtered events
+func (w *configWatch) Events() <-chan *outbound.StreamEvent {
+	return w.events
+}
+
+// Close stops filtering and closes the underlying subscription
+func (w *configWatch) Close() {
+	w.closeOnce.Do(func() {
+		close(w.done)
+		w.EventSubscription.Close()
+	})
+}
+
+// run forwards matching events until the watch is closed
+func (w *configWatch) run() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case event := <-w.EventSubscription.Events():
+			event, ok := w.filter(event)
+			if !ok {
+				continue
+			}
+			select {
+			case w.events <- event:
+			case <-w.done:
+				return
+			}
+		}
+	}
+}
+
+// configEventPayload holds the event fields a fragment watch needs
+type configEventPayload struct {
+	Version    int64           `json:"version"`
+	NewVersion int64           `json:"new_version"`
+	Content    json.RawMessage `json:"content"`
+}
+
+// filter reports whether an event is passed on, and in which form
+func (w *configWatch) filter(event *outbound.StreamEvent) (*outbound.StreamEvent, bool) {
+	if event.ConfigKey != w.key {
+		return nil, false
+	}
+	if w.path.IsRoot() {
+		return event, true
+	}
+
+	var payload configEventPayload
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		return nil, false
+	}
+
+	if event.Type != events.EventTypeConfigDeleted {
+		version := payload.NewVersion
+		if version == 0 {
+			version = payload.Version
+		}
+		// Replayed or queued events the starting state already includes
+		if version <= w.seedVersion {
+			return nil, false
+		}
+	}
+	// Versions restart after a delete, so the guard only holds until the first newer event
+	w.seedVersion = 0
+
+	var fragment json.RawMessage
+	found := false
+	if event.Type != events.EventTypeConfigDeleted {
+		var err error
+		fragment, found, err = w.path.Resolve(payload.Content)
+		if err != nil {
+			return nil, false
+		}
+	}
+
+	if found == w.found && bytes.Equal(fragment, w.fragment) {
+		return nil, false
+	}
+	w.fragment, w.found = fragment, found
+
+	data, err := fragmentEventData(event.Data, w.path, fragment)
+	if err != nil {
+		return nil, false
+	}
+	filtered := *event
+	filtered.Data = data
+	return &filtered, true
+}
+
+// fragmentEventData rewrites an event's JSON to carry a fragment as its
+// content, along with the path it was read from
+func fragmentEventData(data json.RawMessage, path valueobjects.ConfigPath, fragment json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	pathJSON, err := json.Marshal(path.String())
+	if err != nil {
+		return nil, err
+	}
+	fields["path"] = pathJSON
+
+	if fragment == nil {
+		fragment = json.RawMessage("null")
+	}
+	fields["content"] = fragment
+
+	return json.Marshal(fields)
+}
@@ -7,12 +7,29 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/vlone310/cfguardian/internal/domain/entities"
-	"github.com/vlone310/cfguardian/internal/domain/events"
 	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
+// reservedConfigKeys name the client read endpoints that sit next to
+// /read/{configKey}; a config with one of these keys could not be read by key
+var reservedConfigKeys = map[string]bool{
+	"batch":   true,
+	"bundle":  true,
+	"changes": true,
+	"stream":  true,
+}
+
+// checkConfigKey rejects keys that are reserved for read endpoints
+func checkConfigKey(key string) error {
+	if reservedConfigKeys[key] {
+		return apperrors.BadRequest(fmt.Sprintf("config key '%s' is reserved", key))
+	}
+	return nil
+}
+
 // CreateConfigRequest holds config creation data
 type CreateConfigRequest struct {
 	ProjectID       string          `json:"project_id"`
@@ -20,6 +37,14 @@ type CreateConfigRequest struct {
 	SchemaID        string          `json:"schema_id"`
 	Content         json.RawMessage `json:"content"`
 	UpdatedByUserID string          `json:"updated_by_user_id"`
+
+	// Format is the file format Content is written in. When set, Content
+	// is a JSON string holding the file; when empty, Content is JSON.
+	Format valueobjects.ConfigFormat `json:"format,omitempty"`
+
+	// ApplyDefaults stores the schema's defaults for properties Content
+	// leaves out, so the stored content and its revision include them
+	ApplyDefaults bool `json:"apply_defaults,omitempty"`
 }
 
 // CreateConfigResponse holds created config data
@@ -27,6 +52,7 @@ type CreateConfigResponse struct {
 	ProjectID       string          `json:"project_id"`
 	Key             string          `json:"key"`
 	SchemaID        string          `json:"schema_id"`
+	SchemaVersion   int32           `json:"schema_version"`
 	Version         int64           `json:"version"`
 	Content         json.RawMessage `json:"content"`
 	UpdatedByUserID string          `json:"updated_by_user_id"`
@@ -40,6 +66,7 @@ type CreateConfigUseCase struct {
 	schemaRepo      outbound.ConfigSchemaRepository
 	projectRepo     outbound.ProjectRepository
 	schemaValidator *services.SchemaValidator
+	parser          *services.ConfigParser
 }
 
 // NewCreateConfigUseCase creates a new CreateConfigUseCase
@@ -56,6 +83,7 @@ func NewCreateConfigUseCase(
 		schemaRepo:      schemaRepo,
 		projectRepo:     projectRepo,
 		schemaValidator: schemaValidator,
+		parser:          services.NewConfigParser(),
 	}
 }
 
@@ -63,47 +91,64 @@ func NewCreateConfigUseCase(
 func (uc *CreateConfigUseCase) Execute(ctx context.Context, req CreateConfigRequest) (*CreateConfigResponse, error) {
 	// Validate input
 	if req.ProjectID == "" {
-		return nil, fmt.Errorf("project ID is required")
+		return nil, apperrors.BadRequest("project ID is required")
 	}
 	if req.Key == "" {
-		return nil, fmt.Errorf("config key is required")
+		return nil, apperrors.BadRequest("config key is required")
+	}
+	if err := checkConfigKey(req.Key); err != nil {
+		return nil, err
 	}
 	if req.SchemaID == "" {
-		return nil, fmt.Errorf("schema ID is required")
+		return nil, apperrors.BadRequest("schema ID is required")
 	}
 	if len(req.Content) == 0 {
-		return nil, fmt.Errorf("config content is required")
+		return nil, apperrors.BadRequest("config content is required")
 	}
 	if req.UpdatedByUserID == "" {
-		return nil, fmt.Errorf("user ID is required")
+		return nil, apperrors.BadRequest("user ID is required")
+	}
+	
+	// Convert content written in another format to canonical JSON
+	content, err := uc.parser.ParseContent(req.Content, req.Format)
+	if err != nil {
+		return nil, apperrors.Invalid(err)
 	}
 	
 	// Verify project exists
 	projectExists, err := uc.projectRepo.Exists(ctx, req.ProjectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify project exists: %w", err)
+		return nil, apperrors.Internal(err, "failed to verify project exists")
 	}
 	if !projectExists {
-		return nil, fmt.Errorf("project not found")
+		return nil, apperrors.New(apperrors.ErrCodeProjectNotFound, "project not found")
 	}
 	
 	// Check if config already exists
 	configExists, err := uc.configRepo.Exists(ctx, req.ProjectID, req.Key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if config exists: %w", err)
+		return nil, apperrors.Internal(err, "failed to check if config exists")
 	}
 	if configExists {
-		return nil, fmt.Errorf("config with key '%s' already exists in project", req.Key)
+		return nil, apperrors.New(apperrors.ErrCodeConfigExists, fmt.Sprintf("config with key '%s' already exists in project", req.Key))
 	}
 	
-	// Get schema for validation
-	schema, err := uc.schemaRepo.GetByID(ctx, req.SchemaID)
+	// Get schema for validation; it must be global or owned by the project
+	schema, err := usableSchema(ctx, uc.schemaRepo, req.ProjectID, req.SchemaID)
 	if err != nil {
-		return nil, fmt.Errorf("schema not found: %w", err)
+		return nil, err
+	}
+	
+	// Fill in defaults before validation, so they are validated and stored
+	if req.ApplyDefaults {
+		content, err = applySchemaDefaults(ctx, uc.schemaValidator, schema.ID, schema.LatestVersion, schema.SchemaContent, content)
+		if err != nil {
+			return nil, err
+		}
 	}
 	
-	// Validate content against schema
-	if err := uc.schemaValidator.ValidateOrError(schema.SchemaContent, req.Content); err != nil {
+	// Validate content against the latest schema version, which the config pins
+	if err := uc.schemaValidator.ValidateOrError(ctx, schema.ID, schema.LatestVersion, schema.SchemaContent, content); err != nil {
 		return nil, fmt.Errorf("content validation failed: %w", err)
 	}
 	
@@ -112,7 +157,7 @@ func (uc *CreateConfigUseCase) Execute(ctx context.Context, req CreateConfigRequ
 		req.ProjectID,
 		req.Key,
 		req.SchemaID,
-		req.Content,
+		content,
 		req.UpdatedByUserID,
 	)
 	
@@ -121,11 +166,12 @@ func (uc *CreateConfigUseCase) Execute(ctx context.Context, req CreateConfigRequ
 		ProjectID:       configEntity.ProjectID(),
 		Key:             configEntity.Key(),
 		SchemaID:        configEntity.SchemaID(),
+		SchemaVersion:   schema.LatestVersion,
 		Content:         configEntity.Content(),
 		UpdatedByUserID: configEntity.UpdatedByUserID(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create config: %w", err)
+		return nil, apperrors.Internal(err, "failed to create config")
 	}
 	
 	// Create initial revision
@@ -153,21 +199,11 @@ func (uc *CreateConfigUseCase) Execute(ctx context.Context, req CreateConfigRequ
 		fmt.Printf("Warning: failed to create config revision: %v\n", err)
 	}
 	
-	// TODO: Publish ConfigCreated event
-	_ = events.NewConfigCreated(
-		uuid.New().String(),
-		config.ProjectID,
-		config.Key,
-		config.SchemaID,
-		valueobjects.MustNewVersion(config.Version),
-		config.Content,
-		config.UpdatedByUserID,
-	)
-	
 	return &CreateConfigResponse{
 		ProjectID:       config.ProjectID,
 		Key:             config.Key,
 		SchemaID:        config.SchemaID,
+		SchemaVersion:   config.SchemaVersion,
 		Version:         config.Version,
 		Content:         config.Content,
 		UpdatedByUserID: config.UpdatedByUserID,
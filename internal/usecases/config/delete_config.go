@@ -2,11 +2,8 @@ package config
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/google/uuid"
-	"github.com/vlone310/cfguardian/internal/domain/events"
-	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -33,37 +30,26 @@ func NewDeleteConfigUseCase(configRepo outbound.ConfigRepository) *DeleteConfigU
 func (uc *DeleteConfigUseCase) Execute(ctx context.Context, req DeleteConfigRequest) error {
 	// Validate input
 	if req.ProjectID == "" {
-		return fmt.Errorf("project ID is required")
+		return apperrors.BadRequest("project ID is required")
 	}
 	if req.Key == "" {
-		return fmt.Errorf("config key is required")
+		return apperrors.BadRequest("config key is required")
 	}
 	if req.DeletedByUserID == "" {
-		return fmt.Errorf("user ID is required")
+		return apperrors.BadRequest("user ID is required")
 	}
 	
-	// Get config before deleting (for event)
-	config, err := uc.configRepo.Get(ctx, req.ProjectID, req.Key)
-	if err != nil {
-		return fmt.Errorf("config not found: %w", err)
+	// Make sure the config exists
+	if _, err := uc.configRepo.Get(ctx, req.ProjectID, req.Key); err != nil {
+		return apperrors.Internal(err, "failed to get config")
 	}
 	
 	// Delete config
 	// Note: Foreign key CASCADE will automatically delete all config_revisions
-	if err := uc.configRepo.Delete(ctx, req.ProjectID, req.Key); err != nil {
-		return fmt.Errorf("failed to delete config: %w", err)
+	if err := uc.configRepo.Delete(ctx, req.ProjectID, req.Key, req.DeletedByUserID); err != nil {
+		return apperrors.Internal(err, "failed to delete config")
 	}
 	
-	// TODO: Publish ConfigDeleted event
-	lastVersion, _ := valueobjects.NewVersion(config.Version)
-	_ = events.NewConfigDeleted(
-		uuid.New().String(),
-		req.ProjectID,
-		req.Key,
-		lastVersion,
-		req.DeletedByUserID,
-	)
-	
 	return nil
 }
 
@@ -5,21 +5,32 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
-// GetConfigRequest holds get config request data
+// GetConfigRequest holds get config request data.
+// A Path other than the root reads only that fragment of the config.
+// WithDefaults fills in the defaults of the pinned schema version for
+// properties the stored content leaves out; nothing is written.
 type GetConfigRequest struct {
-	ProjectID string `json:"project_id"`
-	Key       string `json:"key"`
+	ProjectID    string                  `json:"project_id"`
+	Key          string                  `json:"key"`
+	Path         valueobjects.ConfigPath `json:"-"`
+	WithDefaults bool                    `json:"-"`
 }
 
-// GetConfigResponse holds config data
+// GetConfigResponse holds config data.
+// Path is set when Content is a fragment of the config.
 type GetConfigResponse struct {
 	ProjectID       string          `json:"project_id"`
 	Key             string          `json:"key"`
 	SchemaID        string          `json:"schema_id"`
+	SchemaVersion   int32           `json:"schema_version"`
 	Version         int64           `json:"version"`
+	Path            string          `json:"path,omitempty"`
 	Content         json.RawMessage `json:"content"`
 	UpdatedByUserID string          `json:"updated_by_user_id"`
 	CreatedAt       string          `json:"created_at"`
@@ -28,13 +39,21 @@ type GetConfigResponse struct {
 
 // GetConfigUseCase handles retrieving a config
 type GetConfigUseCase struct {
-	configRepo outbound.ConfigRepository
+	configRepo      outbound.ConfigRepository
+	schemaRepo      outbound.ConfigSchemaRepository
+	schemaValidator *services.SchemaValidator
 }
 
 // NewGetConfigUseCase creates a new GetConfigUseCase
-func NewGetConfigUseCase(configRepo outbound.ConfigRepository) *GetConfigUseCase {
+func NewGetConfigUseCase(
+	configRepo outbound.ConfigRepository,
+	schemaRepo outbound.ConfigSchemaRepository,
+	schemaValidator *services.SchemaValidator,
+) *GetConfigUseCase {
 	return &GetConfigUseCase{
-		configRepo: configRepo,
+		configRepo:      configRepo,
+		schemaRepo:      schemaRepo,
+		schemaValidator: schemaValidator,
 	}
 }
 
@@ -42,27 +61,55 @@ func NewGetConfigUseCase(configRepo outbound.ConfigRepository) *GetConfigUseCase
 func (uc *GetConfigUseCase) Execute(ctx context.Context, req GetConfigRequest) (*GetConfigResponse, error) {
 	// Validate input
 	if req.ProjectID == "" {
-		return nil, fmt.Errorf("project ID is required")
+		return nil, apperrors.BadRequest("project ID is required")
 	}
 	if req.Key == "" {
-		return nil, fmt.Errorf("config key is required")
+		return nil, apperrors.BadRequest("config key is required")
 	}
 	
 	// Get config from repository
 	config, err := uc.configRepo.Get(ctx, req.ProjectID, req.Key)
 	if err != nil {
-		return nil, fmt.Errorf("config not found: %w", err)
+		return nil, apperrors.Internal(err, "failed to get config")
+	}
+	
+	content := config.Content
+	if req.WithDefaults {
+		content = pinnedContentWithDefaults(ctx, uc.schemaRepo, uc.schemaValidator, config)
+	}
+	
+	content, err = resolveConfigPath(content, req.Path)
+	if err != nil {
+		return nil, err
 	}
 	
 	return &GetConfigResponse{
 		ProjectID:       config.ProjectID,
 		Key:             config.Key,
 		SchemaID:        config.SchemaID,
+		SchemaVersion:   config.SchemaVersion,
 		Version:         config.Version,
-		Content:         config.Content,
+		Path:            req.Path.String(),
+		Content:         content,
 		UpdatedByUserID: config.UpdatedByUserID,
 		CreatedAt:       config.CreatedAt,
 		UpdatedAt:       config.UpdatedAt,
 	}, nil
 }
 
+// resolveConfigPath returns the fragment of content at path, or content
+// itself for the root path
+func resolveConfigPath(content json.RawMessage, path valueobjects.ConfigPath) (json.RawMessage, error) {
+	if path.IsRoot() {
+		return content, nil
+	}
+	
+	fragment, found, err := path.Resolve(content)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeBadRequest, fmt.Sprintf("failed to read path %s: %v", path, err))
+	}
+	if !found {
+		return nil, apperrors.New(apperrors.ErrCodeConfigPathNotFound, fmt.Sprintf("path %s not found in config", path))
+	}
+	return fragment, nil
+}
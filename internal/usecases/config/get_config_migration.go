@@ -0,0 +1,98 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// ConfigMigrationResponse holds a migration run. Items are only set when a
+// single run is requested.
+type ConfigMigrationResponse struct {
+	ID                 string                        `json:"id"`
+	SchemaID           string                        `json:"schema_id"`
+	TargetVersion      int32                         `json:"target_version"`
+	CreatedByUserID    string                        `json:"created_by_user_id"`
+	CreatedAt          string                        `json:"created_at"`
+	RolledBackByUserID string                        `json:"rolled_back_by_user_id,omitempty"`
+	RolledBackAt       *string                       `json:"rolled_back_at,omitempty"`
+	Items              []ConfigMigrationItemResponse `json:"items,omitempty"`
+}
+
+// ConfigMigrationItemResponse is a config rewritten by a migration run
+type ConfigMigrationItemResponse struct {
+	ProjectID         string `json:"project_id"`
+	Key               string `json:"key"`
+	FromSchemaVersion int32  `json:"from_schema_version"`
+	FromVersion       int64  `json:"from_version"`
+	ToVersion         int64  `json:"to_version"`
+}
+
+// GetConfigMigrationUseCase retrieves one migration run of a schema
+type GetConfigMigrationUseCase struct {
+	migrationRepo outbound.ConfigMigrationRepository
+}
+
+// NewGetConfigMigrationUseCase creates a new GetConfigMigrationUseCase
+func NewGetConfigMigrationUseCase(migrationRepo outbound.ConfigMigrationRepository) *GetConfigMigrationUseCase {
+	return &GetConfigMigrationUseCase{
+		migrationRepo: migrationRepo,
+	}
+}
+
+// Execute retrieves a migration run with the configs it rewrote
+func (uc *GetConfigMigrationUseCase) Execute(ctx context.Context, schemaID, migrationID string) (*ConfigMigrationResponse, error) {
+	migration, err := getSchemaMigration(ctx, uc.migrationRepo, schemaID, migrationID)
+	if err != nil {
+		return nil, err
+	}
+	return toConfigMigrationResponse(migration), nil
+}
+
+// getSchemaMigration retrieves a migration run, treating a run of another
+// schema as not found
+func getSchemaMigration(ctx context.Context, migrationRepo outbound.ConfigMigrationRepository, schemaID, migrationID string) (*outbound.ConfigMigration, error) {
+	if schemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+	if migrationID == "" {
+		return nil, apperrors.BadRequest("migration ID is required")
+	}
+
+	migration, err := migrationRepo.GetByID(ctx, migrationID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get migration")
+	}
+	if migration.SchemaID != schemaID {
+		return nil, apperrors.New(apperrors.ErrCodeMigrationNotFound, "migration not found")
+	}
+	return migration, nil
+}
+
+// toConfigMigrationResponse converts a stored migration run to its response
+func toConfigMigrationResponse(migration *outbound.ConfigMigration) *ConfigMigrationResponse {
+	resp := &ConfigMigrationResponse{
+		ID:                 migration.ID,
+		SchemaID:           migration.SchemaID,
+		TargetVersion:      migration.TargetVersion,
+		CreatedByUserID:    migration.CreatedByUserID,
+		CreatedAt:          migration.CreatedAt.Format(time.RFC3339),
+		RolledBackByUserID: migration.RolledBackByUserID,
+	}
+	if migration.RolledBackAt != nil {
+		rolledBackAt := migration.RolledBackAt.Format(time.RFC3339)
+		resp.RolledBackAt = &rolledBackAt
+	}
+	for _, item := range migration.Items {
+		resp.Items = append(resp.Items, ConfigMigrationItemResponse{
+			ProjectID:         item.ProjectID,
+			Key:               item.ConfigKey,
+			FromSchemaVersion: item.FromSchemaVersion,
+			FromVersion:       item.FromVersion,
+			ToVersion:         item.ToVersion,
+		})
+	}
+	return resp
+}
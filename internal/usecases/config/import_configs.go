@@ -0,0 +1,353 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// Import file statuses
+const (
+	// ImportStatusValid marks a file that converted and validated (dry run)
+	ImportStatusValid = "valid"
+
+	// ImportStatusInvalid marks a file that failed conversion or validation
+	ImportStatusInvalid = "invalid"
+
+	// ImportStatusSkipped marks a file that is not a config file
+	ImportStatusSkipped = "skipped"
+
+	// ImportStatusCreated marks a file stored as a new config
+	ImportStatusCreated = "created"
+
+	// ImportStatusUpdated marks a file stored as a new version of a config
+	ImportStatusUpdated = "updated"
+
+	// ImportStatusFailed marks a valid file whose write failed
+	ImportStatusFailed = "failed"
+)
+
+// Import actions
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+)
+
+// ImportFile is one file of a bulk import
+type ImportFile struct {
+	// Name is the path of the file in the upload
+	Name string
+
+	// Key is the config key to store the file as. When empty it is derived
+	// from Name: the extension is dropped and "/" becomes ".".
+	Key string
+
+	// SchemaID is the schema of a new config. When empty the request's
+	// SchemaID is used.
+	SchemaID string
+
+	Data []byte
+}
+
+// ImportConfigsRequest holds bulk import data
+type ImportConfigsRequest struct {
+	ProjectID       string
+	SchemaID        string
+	Files           []ImportFile
+	DryRun          bool
+	UpdatedByUserID string
+}
+
+// ImportFileResult reports what happened to one file of an import
+type ImportFileResult struct {
+	File     string          `json:"file"`
+	Key      string          `json:"key,omitempty"`
+	Format   string          `json:"format,omitempty"`
+	SchemaID string          `json:"schema_id,omitempty"`
+	Action   string          `json:"action,omitempty"`
+	Status   string          `json:"status"`
+	Version  int64           `json:"version,omitempty"`
+	Content  json.RawMessage `json:"content,omitempty"` // converted content, dry run only
+	Errors   []string        `json:"errors,omitempty"`
+
+	// ValidationErrors lists where the converted content fails its schema
+	ValidationErrors []services.ValidationError `json:"validation_errors,omitempty"`
+}
+
+// ImportConfigsResponse holds the per-file results of a bulk import
+type ImportConfigsResponse struct {
+	DryRun  bool                `json:"dry_run"`
+	Valid   bool                `json:"valid"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Failed  int                 `json:"failed"`
+	Files   []*ImportFileResult `json:"files"`
+}
+
+// ImportConfigsUseCase converts, validates and stores many config files at once
+type ImportConfigsUseCase struct {
+	createUseCase   *CreateConfigUseCase
+	updateUseCase   *UpdateConfigUseCase
+	configRepo      outbound.ConfigRepository
+	schemaRepo      outbound.ConfigSchemaRepository
+	projectRepo     outbound.ProjectRepository
+	schemaValidator *services.SchemaValidator
+	parser          *services.ConfigParser
+}
+
+// NewImportConfigsUseCase creates a new ImportConfigsUseCase
+func NewImportConfigsUseCase(
+	createUseCase *CreateConfigUseCase,
+	updateUseCase *UpdateConfigUseCase,
+	configRepo outbound.ConfigRepository,
+	schemaRepo outbound.ConfigSchemaRepository,
+	projectRepo outbound.ProjectRepository,
+	schemaValidator *services.SchemaValidator,
+) *ImportConfigsUseCase {
+	return &ImportConfigsUseCase{
+		createUseCase:   createUseCase,
+		updateUseCase:   updateUseCase,
+		configRepo:      configRepo,
+		schemaRepo:      schemaRepo,
+		projectRepo:     projectRepo,
+		schemaValidator: schemaValidator,
+		parser:          services.NewConfigParser(),
+	}
+}
+
+// importPlan is a file that passed conversion and validation
+type importPlan struct {
+	result          *ImportFileResult
+	content         json.RawMessage
+	expectedVersion int64
+}
+
+// Execute converts every file to canonical JSON and validates it against
+// its schema. Files are stored only if all of them are valid and the
+// request is not a dry run: new keys are created, existing keys are
+// updated at their current version and keep their schema.
+func (uc *ImportConfigsUseCase) Execute(ctx context.Context, req ImportConfigsRequest) (*ImportConfigsResponse, error) {
+	// Validate input
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	if req.UpdatedByUserID == "" {
+		return nil, apperrors.BadRequest("user ID is required")
+	}
+	if len(req.Files) == 0 {
+		return nil, apperrors.BadRequest("no files to import")
+	}
+
+	// Verify project exists
+	projectExists, err := uc.projectRepo.Exists(ctx, req.ProjectID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to verify project exists")
+	}
+	if !projectExists {
+		return nil, apperrors.New(apperrors.ErrCodeProjectNotFound, "project not found")
+	}
+
+	files := make([]ImportFile, len(req.Files))
+	copy(files, req.Files)
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	resp := &ImportConfigsResponse{DryRun: req.DryRun, Valid: true}
+	plans := make([]*importPlan, 0, len(files))
+	keyFiles := make(map[string]string, len(files))
+	schemas := make(map[string]*outbound.ConfigSchemaVersion)
+
+	for _, file := range files {
+		result := &ImportFileResult{File: file.Name}
+		resp.Files = append(resp.Files, result)
+
+		format, base, ok := valueobjects.ConfigFormatForFileName(file.Name)
+		if !ok {
+			if file.Key == "" {
+				result.Status = ImportStatusSkipped
+				continue
+			}
+			result.Status = ImportStatusInvalid
+			result.Errors = []string{"unsupported file type: use .json, .yaml, .yml, .toml, .env or .properties"}
+			resp.Valid = false
+			continue
+		}
+		result.Format = format.String()
+
+		result.Key = file.Key
+		if result.Key == "" {
+			result.Key = strings.ReplaceAll(base, "/", ".")
+		}
+
+		plan, problems := uc.planFile(ctx, req, file, format, result, keyFiles, schemas)
+		if plan == nil {
+			result.Status = ImportStatusInvalid
+			result.Errors = problems
+			resp.Valid = false
+			continue
+		}
+
+		result.Status = ImportStatusValid
+		if req.DryRun {
+			result.Content = plan.content
+		}
+		plans = append(plans, plan)
+	}
+
+	if len(plans) == 0 && resp.Valid {
+		return nil, apperrors.BadRequest("no config files to import: use .json, .yaml, .yml, .toml, .env or .properties files")
+	}
+	if req.DryRun || !resp.Valid {
+		return resp, nil
+	}
+
+	// Every file is valid: store them
+	for _, plan := range plans {
+		uc.apply(ctx, req, plan, resp)
+	}
+
+	return resp, nil
+}
+
+// planFile converts and validates one file. If the file cannot be stored it
+// returns no plan, with the problems found; schema validation errors are
+// recorded on the result instead.
+func (uc *ImportConfigsUseCase) planFile(
+	ctx context.Context,
+	req ImportConfigsRequest,
+	file ImportFile,
+	format valueobjects.ConfigFormat,
+	result *ImportFileResult,
+	keyFiles map[string]string,
+	schemas map[string]*outbound.ConfigSchemaVersion,
+) (*importPlan, []string) {
+	if result.Key == "" {
+		return nil, []string{"cannot derive a config key from the file name"}
+	}
+	if err := checkConfigKey(result.Key); err != nil {
+		return nil, []string{err.Error()}
+	}
+	if other, taken := keyFiles[result.Key]; taken {
+		return nil, []string{fmt.Sprintf("key %s is also imported from %s", result.Key, other)}
+	}
+	keyFiles[result.Key] = file.Name
+
+	content, err := uc.parser.Parse(file.Data, format)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	plan := &importPlan{result: result, content: content}
+
+	// Existing configs are updated and keep their schema
+	exists, err := uc.configRepo.Exists(ctx, req.ProjectID, result.Key)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("failed to check if config exists: %v", err)}
+	}
+	schemaID := file.SchemaID
+	var current *outbound.Config
+	if exists {
+		current, err = uc.configRepo.Get(ctx, req.ProjectID, result.Key)
+		if err != nil {
+			return nil, []string{fmt.Sprintf("failed to get config: %v", err)}
+		}
+		if schemaID != "" && schemaID != current.SchemaID {
+			return nil, []string{fmt.Sprintf("config %s uses schema %s; import cannot change it", result.Key, current.SchemaID)}
+		}
+		schemaID = current.SchemaID
+		result.Action = ImportActionUpdate
+		plan.expectedVersion = current.Version
+	} else {
+		if schemaID == "" {
+			schemaID = req.SchemaID
+		}
+		if schemaID == "" {
+			return nil, []string{"schema ID is required for a new config"}
+		}
+		result.Action = ImportActionCreate
+	}
+	result.SchemaID = schemaID
+
+	// Existing configs are validated against the schema version they pin,
+	// new ones against the latest version
+	schemaKey := schemaID
+	if current != nil {
+		schemaKey = fmt.Sprintf("%s@%d", schemaID, current.SchemaVersion)
+	}
+	schemaVersion, ok := schemas[schemaKey]
+	if !ok {
+		schemaVersion = &outbound.ConfigSchemaVersion{SchemaID: schemaID}
+		if current != nil {
+			schemaVersion.Version = current.SchemaVersion
+			schemaVersion.SchemaContent, err = pinnedSchemaContent(ctx, uc.schemaRepo, current)
+		} else {
+			var schema *outbound.ConfigSchema
+			schema, err = usableSchema(ctx, uc.schemaRepo, req.ProjectID, schemaID)
+			if schema != nil {
+				schemaVersion.Version, schemaVersion.SchemaContent = schema.LatestVersion, schema.SchemaContent
+			}
+		}
+		if err != nil {
+			return nil, []string{fmt.Sprintf("schema not found: %v", err)}
+		}
+		schemas[schemaKey] = schemaVersion
+	}
+
+	validation, err := uc.schemaValidator.Validate(ctx, schemaID, schemaVersion.Version, schemaVersion.SchemaContent, content)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	if !validation.Valid {
+		result.ValidationErrors = validation.Errors
+		return nil, nil
+	}
+
+	return plan, nil
+}
+
+// apply stores one validated file and records the outcome
+func (uc *ImportConfigsUseCase) apply(ctx context.Context, req ImportConfigsRequest, plan *importPlan, resp *ImportConfigsResponse) {
+	result := plan.result
+
+	if result.Action == ImportActionCreate {
+		created, err := uc.createUseCase.Execute(ctx, CreateConfigRequest{
+			ProjectID:       req.ProjectID,
+			Key:             result.Key,
+			SchemaID:        result.SchemaID,
+			Content:         plan.content,
+			UpdatedByUserID: req.UpdatedByUserID,
+		})
+		if err != nil {
+			result.Status = ImportStatusFailed
+			result.Errors = []string{err.Error()}
+			resp.Failed++
+			return
+		}
+		result.Status = ImportStatusCreated
+		result.Version = created.Version
+		resp.Created++
+		return
+	}
+
+	updated, err := uc.updateUseCase.Execute(ctx, UpdateConfigRequest{
+		ProjectID:       req.ProjectID,
+		Key:             result.Key,
+		ExpectedVersion: plan.expectedVersion,
+		Content:         plan.content,
+		UpdatedByUserID: req.UpdatedByUserID,
+	})
+	if err != nil {
+		result.Status = ImportStatusFailed
+		result.Errors = []string{err.Error()}
+		resp.Failed++
+		return
+	}
+	result.Status = ImportStatusUpdated
+	result.Version = updated.Version
+	resp.Updated++
+}
@@ -0,0 +1,253 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+func (m *MockConfigRepository) Exists(ctx context.Context, projectID, key string) (bool, error) {
+	args := m.Called(ctx, projectID, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockConfigRepository) Create(ctx context.Context, params outbound.CreateConfigParams) (*outbound.Config, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.Config), args.Error(1)
+}
+
+func (m *MockConfigRepository) Update(ctx context.Context, params outbound.UpdateConfigParams) (*outbound.Config, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.Config), args.Error(1)
+}
+
+// MockConfigSchemaRepository mocks schema lookups
+type MockConfigSchemaRepository struct {
+	mock.Mock
+	outbound.ConfigSchemaRepository
+}
+
+func (m *MockConfigSchemaRepository) GetByID(ctx context.Context, id string) (*outbound.ConfigSchema, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.ConfigSchema), args.Error(1)
+}
+
+func (m *MockConfigSchemaRepository) GetVersion(ctx context.Context, schemaID string, version int32) (*outbound.ConfigSchemaVersion, error) {
+	args := m.Called(ctx, schemaID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.ConfigSchemaVersion), args.Error(1)
+}
+
+// MockConfigRevisionRepository mocks the revision audit log
+type MockConfigRevisionRepository struct {
+	mock.Mock
+	outbound.ConfigRevisionRepository
+}
+
+func (m *MockConfigRevisionRepository) Create(ctx context.Context, params outbound.CreateConfigRevisionParams) (*outbound.ConfigRevision, error) {
+	args := m.Called(ctx, params)
+	return &outbound.ConfigRevision{}, args.Error(0)
+}
+
+const importTestSchema = `{
+	"type": "object",
+	"properties": {"port": {"type": "integer"}, "debug": {"type": "boolean"}},
+	"required": ["port"]
+}`
+
+func TestImportConfigsUseCase_Execute_DryRun(t *testing.T) {
+	// Arrange: "api" exists at version 4, the other keys are new
+	ctx := context.Background()
+	projectRepo := new(MockProjectRepository)
+	configRepo := new(MockConfigRepository)
+	schemaRepo := new(MockConfigSchemaRepository)
+	validator := services.NewSchemaValidator()
+	useCase := NewImportConfigsUseCase(nil, nil, configRepo, schemaRepo, projectRepo, validator)
+
+	projectRepo.On("Exists", ctx, "proj-1").Return(true, nil)
+	schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", SchemaContent: importTestSchema, LatestVersion: 1}, nil)
+	schemaRepo.On("GetVersion", ctx, "schema-1", int32(1)).Return(&outbound.ConfigSchemaVersion{SchemaID: "schema-1", Version: 1, SchemaContent: importTestSchema}, nil)
+	configRepo.On("Exists", ctx, "proj-1", "api").Return(true, nil)
+	configRepo.On("Get", ctx, "proj-1", "api").Return(&outbound.Config{Key: "api", SchemaID: "schema-1", SchemaVersion: 1, Version: 4}, nil)
+	configRepo.On("Exists", ctx, "proj-1", "web").Return(false, nil)
+	configRepo.On("Exists", ctx, "proj-1", "mobile.flags").Return(false, nil)
+
+	// Act
+	resp, err := useCase.Execute(ctx, ImportConfigsRequest{
+		ProjectID: "proj-1",
+		SchemaID:  "schema-1",
+		Files: []ImportFile{
+			{Name: "web.env", Data: []byte("port=8080\ndebug=true\n")},
+			{Name: "api.yaml", Data: []byte("port: 9090\n")},
+			{Name: "README.md", Data: []byte("# configs\n")},
+			{Name: "mobile/flags.toml", Data: []byte("debug = true\n")},
+			{Name: "broken.properties", Data: []byte("a=1\na.b=2\n")},
+		},
+		DryRun:          true,
+		UpdatedByUserID: "user-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.False(t, resp.Valid)
+	require.Len(t, resp.Files, 5)
+
+	byFile := make(map[string]*ImportFileResult)
+	for _, result := range resp.Files {
+		byFile[result.File] = result
+	}
+
+	assert.Equal(t, ImportStatusValid, byFile["api.yaml"].Status)
+	assert.Equal(t, ImportActionUpdate, byFile["api.yaml"].Action)
+
+	web := byFile["web.env"]
+	assert.Equal(t, ImportStatusValid, web.Status)
+	assert.Equal(t, ImportActionCreate, web.Action)
+	assert.Equal(t, "dotenv", web.Format)
+	assert.JSONEq(t, `{"debug":true,"port":8080}`, string(web.Content))
+
+	assert.Equal(t, ImportStatusSkipped, byFile["README.md"].Status)
+
+	flags := byFile["mobile/flags.toml"]
+	assert.Equal(t, "mobile.flags", flags.Key)
+	assert.Equal(t, ImportStatusInvalid, flags.Status)
+	require.Len(t, flags.ValidationErrors, 1)
+	assert.Equal(t, "/port", flags.ValidationErrors[0].Pointer)
+	assert.Equal(t, "required", flags.ValidationErrors[0].Keyword)
+
+	assert.Equal(t, ImportStatusInvalid, byFile["broken.properties"].Status)
+	assert.Contains(t, byFile["broken.properties"].Errors[0], "invalid properties")
+
+	configRepo.AssertExpectations(t)
+	schemaRepo.AssertExpectations(t)
+	configRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	configRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestImportConfigsUseCase_Execute_Apply(t *testing.T) {
+	// Arrange: "api" exists at version 4 and "web" is new
+	ctx := context.Background()
+	projectRepo := new(MockProjectRepository)
+	configRepo := new(MockConfigRepository)
+	schemaRepo := new(MockConfigSchemaRepository)
+	revisionRepo := new(MockConfigRevisionRepository)
+	validator := services.NewSchemaValidator()
+	createUseCase := NewCreateConfigUseCase(configRepo, revisionRepo, schemaRepo, projectRepo, validator)
+	updateUseCase := NewUpdateConfigUseCase(configRepo, revisionRepo, schemaRepo, validator, services.NewVersionManager())
+	useCase := NewImportConfigsUseCase(createUseCase, updateUseCase, configRepo, schemaRepo, projectRepo, validator)
+
+	projectRepo.On("Exists", ctx, "proj-1").Return(true, nil)
+	schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", SchemaContent: importTestSchema, LatestVersion: 1}, nil)
+	schemaRepo.On("GetVersion", ctx, "schema-1", int32(1)).Return(&outbound.ConfigSchemaVersion{SchemaID: "schema-1", Version: 1, SchemaContent: importTestSchema}, nil)
+	configRepo.On("Exists", ctx, "proj-1", "api").Return(true, nil)
+	configRepo.On("Get", ctx, "proj-1", "api").Return(&outbound.Config{Key: "api", SchemaID: "schema-1", SchemaVersion: 1, Version: 4}, nil)
+	configRepo.On("Exists", ctx, "proj-1", "web").Return(false, nil)
+	revisionRepo.On("Create", ctx, mock.Anything).Return(nil)
+	configRepo.On("Create", ctx, outbound.CreateConfigParams{
+		ProjectID:       "proj-1",
+		Key:             "web",
+		SchemaID:        "schema-1",
+		SchemaVersion:   1,
+		Content:         json.RawMessage(`{"debug":true,"port":8080}`),
+		UpdatedByUserID: "user-1",
+	}).Return(&outbound.Config{ProjectID: "proj-1", Key: "web", SchemaID: "schema-1", Version: 1}, nil)
+	configRepo.On("Update", ctx, outbound.UpdateConfigParams{
+		ProjectID:       "proj-1",
+		Key:             "api",
+		ExpectedVersion: 4,
+		Content:         json.RawMessage(`{"port":9090}`),
+		UpdatedByUserID: "user-1",
+	}).Return(&outbound.Config{ProjectID: "proj-1", Key: "api", SchemaID: "schema-1", Version: 5}, nil)
+
+	// Act
+	resp, err := useCase.Execute(ctx, ImportConfigsRequest{
+		ProjectID: "proj-1",
+		SchemaID:  "schema-1",
+		Files: []ImportFile{
+			{Name: "web.env", Data: []byte("export port=8080 # default\ndebug=true\n")},
+			{Name: "services/api.yaml", Key: "api", Data: []byte("port: 9090\n")},
+		},
+		UpdatedByUserID: "user-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Valid)
+	assert.Equal(t, 1, resp.Created)
+	assert.Equal(t, 1, resp.Updated)
+	assert.Equal(t, 0, resp.Failed)
+	assert.Equal(t, ImportStatusUpdated, resp.Files[0].Status)
+	assert.Equal(t, int64(5), resp.Files[0].Version)
+	assert.Nil(t, resp.Files[0].Content, "content is only echoed on a dry run")
+	assert.Equal(t, ImportStatusCreated, resp.Files[1].Status)
+	configRepo.AssertExpectations(t)
+	schemaRepo.AssertExpectations(t)
+}
+
+func TestImportConfigsUseCase_Execute_DuplicateKey(t *testing.T) {
+	ctx := context.Background()
+	projectRepo := new(MockProjectRepository)
+	configRepo := new(MockConfigRepository)
+	schemaRepo := new(MockConfigSchemaRepository)
+	useCase := NewImportConfigsUseCase(nil, nil, configRepo, schemaRepo, projectRepo, services.NewSchemaValidator())
+	projectRepo.On("Exists", ctx, "proj-1").Return(true, nil)
+	schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", SchemaContent: importTestSchema, LatestVersion: 1}, nil)
+	configRepo.On("Exists", ctx, "proj-1", "web").Return(false, nil)
+
+	resp, err := useCase.Execute(ctx, ImportConfigsRequest{
+		ProjectID: "proj-1",
+		SchemaID:  "schema-1",
+		Files: []ImportFile{
+			{Name: "web.json", Data: []byte(`{"port": 1}`)},
+			{Name: "web.yaml", Data: []byte("port: 2\n")},
+		},
+		UpdatedByUserID: "user-1",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+	assert.Equal(t, ImportStatusInvalid, resp.Files[1].Status)
+	assert.Contains(t, resp.Files[1].Errors[0], "also imported from web.json")
+	configRepo.AssertExpectations(t)
+	schemaRepo.AssertExpectations(t)
+}
+
+func TestImportConfigsUseCase_Execute_ReservedKey(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	projectRepo := new(MockProjectRepository)
+	projectRepo.On("Exists", ctx, "proj-1").Return(true, nil)
+	useCase := NewImportConfigsUseCase(nil, nil, nil, nil, projectRepo, services.NewSchemaValidator())
+
+	// Act
+	resp, err := useCase.Execute(ctx, ImportConfigsRequest{
+		ProjectID:       "proj-1",
+		SchemaID:        "schema-1",
+		Files:           []ImportFile{{Name: "stream.json", Data: []byte(`{"port": 1}`)}},
+		UpdatedByUserID: "user-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+	assert.Equal(t, ImportStatusInvalid, resp.Files[0].Status)
+	assert.Contains(t, resp.Files[0].Errors[0], "reserved")
+}
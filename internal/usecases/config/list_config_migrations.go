@@ -0,0 +1,47 @@
+package config
+
+import (
+	"context"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// ListConfigMigrationsResponse holds the migration runs of a schema
+type ListConfigMigrationsResponse struct {
+	SchemaID   string                     `json:"schema_id"`
+	Migrations []*ConfigMigrationResponse `json:"migrations"`
+}
+
+// ListConfigMigrationsUseCase lists the migration runs of a schema
+type ListConfigMigrationsUseCase struct {
+	migrationRepo outbound.ConfigMigrationRepository
+}
+
+// NewListConfigMigrationsUseCase creates a new ListConfigMigrationsUseCase
+func NewListConfigMigrationsUseCase(migrationRepo outbound.ConfigMigrationRepository) *ListConfigMigrationsUseCase {
+	return &ListConfigMigrationsUseCase{
+		migrationRepo: migrationRepo,
+	}
+}
+
+// Execute lists the migration runs of a schema, newest first
+func (uc *ListConfigMigrationsUseCase) Execute(ctx context.Context, schemaID string) (*ListConfigMigrationsResponse, error) {
+	if schemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+
+	migrations, err := uc.migrationRepo.ListBySchema(ctx, schemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list migrations")
+	}
+
+	resp := &ListConfigMigrationsResponse{
+		SchemaID:   schemaID,
+		Migrations: make([]*ConfigMigrationResponse, len(migrations)),
+	}
+	for i, migration := range migrations {
+		resp.Migrations[i] = toConfigMigrationResponse(migration)
+	}
+	return resp, nil
+}
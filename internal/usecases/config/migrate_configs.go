@@ -0,0 +1,256 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// MigrateConfigsRequest selects the schema version to migrate configs to.
+// TargetVersion 0 migrates to the latest version.
+type MigrateConfigsRequest struct {
+	SchemaID        string `json:"schema_id"`
+	TargetVersion   int32  `json:"target_version"`
+	DryRun          bool   `json:"dry_run"`
+	UpdatedByUserID string `json:"updated_by_user_id"`
+}
+
+// MigrateConfigsResponse reports a migration run, or what a dry run would do.
+// MigrationID identifies the run for rollback and is empty on a dry run.
+type MigrateConfigsResponse struct {
+	MigrationID   string           `json:"migration_id,omitempty"`
+	SchemaID      string           `json:"schema_id"`
+	TargetVersion int32            `json:"target_version"`
+	DryRun        bool             `json:"dry_run"`
+	Configs       []MigratedConfig `json:"configs"`
+	Failed        []FailedConfig   `json:"failed"`
+}
+
+// MigratedConfig is a config a run rewrites. Version is the config version
+// written by the run and is 0 on a dry run.
+type MigratedConfig struct {
+	ProjectID         string                  `json:"project_id"`
+	Key               string                  `json:"key"`
+	FromSchemaVersion int32                   `json:"from_schema_version"`
+	PreviousVersion   int64                   `json:"previous_version"`
+	Version           int64                   `json:"version,omitempty"`
+	Diff              []services.ConfigChange `json:"diff"`
+}
+
+// FailedConfig is a config that cannot be migrated: a migration operation
+// failed on it, or the migrated content fails the target version
+type FailedConfig struct {
+	ProjectID     string                     `json:"project_id"`
+	Key           string                     `json:"key"`
+	SchemaVersion int32                      `json:"schema_version"`
+	Errors        []services.ValidationError `json:"errors"`
+}
+
+// MigrateConfigsUseCase applies the migrations attached to schema versions
+// to every config pinned to an older version, as one batched Raft write
+type MigrateConfigsUseCase struct {
+	configRepo      outbound.ConfigRepository
+	revisionRepo    outbound.ConfigRevisionRepository
+	schemaRepo      outbound.ConfigSchemaRepository
+	migrationRepo   outbound.ConfigMigrationRepository
+	schemaValidator *services.SchemaValidator
+	migrator        *services.ConfigMigrator
+	differ          *services.ConfigDiffer
+}
+
+// NewMigrateConfigsUseCase creates a new MigrateConfigsUseCase
+func NewMigrateConfigsUseCase(
+	configRepo outbound.ConfigRepository,
+	revisionRepo outbound.ConfigRevisionRepository,
+	schemaRepo outbound.ConfigSchemaRepository,
+	migrationRepo outbound.ConfigMigrationRepository,
+	schemaValidator *services.SchemaValidator,
+	migrator *services.ConfigMigrator,
+) *MigrateConfigsUseCase {
+	return &MigrateConfigsUseCase{
+		configRepo:      configRepo,
+		revisionRepo:    revisionRepo,
+		schemaRepo:      schemaRepo,
+		migrationRepo:   migrationRepo,
+		schemaValidator: schemaValidator,
+		migrator:        migrator,
+		differ:          services.NewConfigDiffer(),
+	}
+}
+
+// Execute migrates the configs using a schema to the target version. Each
+// config runs the migrations of every version after the one it pins, in
+// order, and the result must pass the target version. A run either
+// migrates every config or, if any fails, none; a dry run only reports.
+func (uc *MigrateConfigsUseCase) Execute(ctx context.Context, req MigrateConfigsRequest) (*MigrateConfigsResponse, error) {
+	// Validate input
+	if req.SchemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+	if req.TargetVersion < 0 {
+		return nil, apperrors.BadRequest("target version must be >= 1, or 0 for the latest")
+	}
+	if req.UpdatedByUserID == "" {
+		return nil, apperrors.BadRequest("user ID is required")
+	}
+
+	// Resolve the target version
+	targetVersion := req.TargetVersion
+	if targetVersion == 0 {
+		schema, err := uc.schemaRepo.GetByID(ctx, req.SchemaID)
+		if err != nil {
+			return nil, apperrors.Internal(err, "failed to get schema")
+		}
+		targetVersion = schema.LatestVersion
+	}
+	target, err := uc.schemaRepo.GetVersion(ctx, req.SchemaID, targetVersion)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema version")
+	}
+
+	versions, err := uc.schemaRepo.ListVersions(ctx, req.SchemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list schema versions")
+	}
+	migrations := make(map[int32]*services.SchemaMigration, len(versions))
+	for _, version := range versions {
+		if len(version.Migration) == 0 || version.Version > targetVersion {
+			continue
+		}
+		migration, err := uc.migrator.Parse(version.Migration)
+		if err != nil {
+			return nil, apperrors.Internal(err, fmt.Sprintf("invalid migration on schema version %d", version.Version))
+		}
+		migrations[version.Version] = migration
+	}
+
+	configs, err := uc.configRepo.ListBySchema(ctx, req.SchemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list configs using schema")
+	}
+
+	resp := &MigrateConfigsResponse{
+		SchemaID:      req.SchemaID,
+		TargetVersion: targetVersion,
+		DryRun:        req.DryRun,
+		Configs:       make([]MigratedConfig, 0),
+		Failed:        make([]FailedConfig, 0),
+	}
+	var items []outbound.UpdateBatchItem
+	var pending []*outbound.Config
+	for _, config := range configs {
+		if config.SchemaVersion >= targetVersion {
+			continue
+		}
+
+		var chain []*services.SchemaMigration
+		for version := config.SchemaVersion + 1; version <= targetVersion; version++ {
+			if migration, ok := migrations[version]; ok {
+				chain = append(chain, migration)
+			}
+		}
+
+		content, err := uc.migrator.Apply(config.Content, chain...)
+		if err != nil {
+			resp.Failed = append(resp.Failed, FailedConfig{
+				ProjectID:     config.ProjectID,
+				Key:           config.Key,
+				SchemaVersion: config.SchemaVersion,
+				Errors:        []services.ValidationError{{Keyword: "migration", Message: err.Error()}},
+			})
+			continue
+		}
+
+		result, err := uc.schemaValidator.Validate(ctx, target.SchemaID, target.Version, target.SchemaContent, content)
+		if err != nil {
+			return nil, apperrors.Internal(err, fmt.Sprintf("failed to validate config %s", config.Key))
+		}
+		if !result.Valid {
+			resp.Failed = append(resp.Failed, FailedConfig{
+				ProjectID:     config.ProjectID,
+				Key:           config.Key,
+				SchemaVersion: config.SchemaVersion,
+				Errors:        result.Errors,
+			})
+			continue
+		}
+
+		diff, err := uc.differ.Diff(config.Content, content)
+		if err != nil {
+			return nil, apperrors.Internal(err, fmt.Sprintf("failed to diff config %s", config.Key))
+		}
+
+		resp.Configs = append(resp.Configs, MigratedConfig{
+			ProjectID:         config.ProjectID,
+			Key:               config.Key,
+			FromSchemaVersion: config.SchemaVersion,
+			PreviousVersion:   config.Version,
+			Diff:              diff,
+		})
+		items = append(items, outbound.UpdateBatchItem{
+			ProjectID:       config.ProjectID,
+			Key:             config.Key,
+			ExpectedVersion: config.Version,
+			SchemaID:        config.SchemaID,
+			SchemaVersion:   targetVersion,
+			Content:         content,
+		})
+		pending = append(pending, config)
+	}
+
+	if req.DryRun {
+		return resp, nil
+	}
+	if len(resp.Failed) > 0 {
+		return nil, apperrors.New(
+			apperrors.ErrCodeMigrationFailed,
+			fmt.Sprintf("%d config(s) cannot be migrated to schema version %d; no config was changed", len(resp.Failed), targetVersion),
+		).WithExtension("failed", resp.Failed)
+	}
+	if len(items) == 0 {
+		return resp, nil
+	}
+
+	// Rewrite every config in one Raft log entry (versions will be incremented)
+	updatedConfigs, err := uc.configRepo.UpdateBatch(ctx, outbound.UpdateBatchParams{
+		Items:           items,
+		UpdatedByUserID: req.UpdatedByUserID,
+	})
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to migrate configs")
+	}
+
+	migrationItems := make([]outbound.ConfigMigrationItem, len(updatedConfigs))
+	for i, updatedConfig := range updatedConfigs {
+		previous := pending[i]
+		resp.Configs[i].Version = updatedConfig.Version
+		migrationItems[i] = outbound.ConfigMigrationItem{
+			ProjectID:         previous.ProjectID,
+			ConfigKey:         previous.Key,
+			FromSchemaVersion: previous.SchemaVersion,
+			FromVersion:       previous.Version,
+			ToVersion:         updatedConfig.Version,
+			PreviousContent:   previous.Content,
+		}
+		recordBatchRevision(ctx, uc.revisionRepo, updatedConfig)
+	}
+
+	migration, err := uc.migrationRepo.Create(ctx, outbound.CreateConfigMigrationParams{
+		ID:              uuid.New().String(),
+		SchemaID:        req.SchemaID,
+		TargetVersion:   targetVersion,
+		CreatedByUserID: req.UpdatedByUserID,
+		Items:           migrationItems,
+	})
+	if err != nil {
+		// The configs are already migrated; only the rollback record is missing
+		return nil, apperrors.Internal(err, "configs were migrated but the migration could not be recorded")
+	}
+	resp.MigrationID = migration.ID
+
+	return resp, nil
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+func (m *MockConfigRepository) ListBySchema(ctx context.Context, schemaID string) ([]*outbound.Config, error) {
+	args := m.Called(ctx, schemaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.Config), args.Error(1)
+}
+
+// UpdateBatch returns the configs of the batch at their next version
+func (m *MockConfigRepository) UpdateBatch(ctx context.Context, params outbound.UpdateBatchParams) ([]*outbound.Config, error) {
+	args := m.Called(ctx, params)
+	if args.Error(0) != nil {
+		return nil, args.Error(0)
+	}
+	configs := make([]*outbound.Config, len(params.Items))
+	for i, item := range params.Items {
+		configs[i] = &outbound.Config{
+			ProjectID:       item.ProjectID,
+			Key:             item.Key,
+			SchemaID:        item.SchemaID,
+			SchemaVersion:   item.SchemaVersion,
+			Version:         item.ExpectedVersion + 1,
+			Content:         item.Content,
+			UpdatedByUserID: params.UpdatedByUserID,
+		}
+	}
+	return configs, nil
+}
+
+func (m *MockConfigSchemaRepository) ListVersions(ctx context.Context, schemaID string) ([]*outbound.ConfigSchemaVersion, error) {
+	args := m.Called(ctx, schemaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.ConfigSchemaVersion), args.Error(1)
+}
+
+// MockConfigMigrationRepository mocks the record of migration runs
+type MockConfigMigrationRepository struct {
+	mock.Mock
+	outbound.ConfigMigrationRepository
+}
+
+func (m *MockConfigMigrationRepository) Create(ctx context.Context, params outbound.CreateConfigMigrationParams) (*outbound.ConfigMigration, error) {
+	args := m.Called(ctx, params)
+	return &outbound.ConfigMigration{ID: params.ID, SchemaID: params.SchemaID, Items: params.Items}, args.Error(0)
+}
+
+// Version 2 renames "addr" to "host"; version 3 converts "port" to an integer
+var migrateTestVersions = []*outbound.ConfigSchemaVersion{
+	{
+		SchemaID:      "schema-1",
+		Version:       3,
+		SchemaContent: `{"type": "object", "properties": {"host": {"type": "string"}, "port": {"type": "integer"}}, "required": ["host", "port"]}`,
+		Migration:     json.RawMessage(`{"operations": [{"op": "convert", "path": "/port", "type": "integer"}]}`),
+	},
+	{
+		SchemaID:      "schema-1",
+		Version:       2,
+		SchemaContent: `{"type": "object", "properties": {"host": {"type": "string"}, "port": {"type": "string"}}, "required": ["host"]}`,
+		Migration:     json.RawMessage(`{"operations": [{"op": "rename", "path": "/addr", "to": "host"}]}`),
+	},
+	{
+		SchemaID:      "schema-1",
+		Version:       1,
+		SchemaContent: `{"type": "object", "properties": {"addr": {"type": "string"}, "port": {"type": "string"}}}`,
+	},
+}
+
+// migrateTestConfigs use schema-1: "api" pins version 1, "db" pins the
+// latest, version 3, and "web" pins version 2
+var migrateTestConfigs = []*outbound.Config{
+	{ProjectID: "proj-1", Key: "api", SchemaID: "schema-1", SchemaVersion: 1, Version: 4, Content: json.RawMessage(`{"addr": "a", "port": "8080"}`)},
+	{ProjectID: "proj-1", Key: "db", SchemaID: "schema-1", SchemaVersion: 3, Version: 1, Content: json.RawMessage(`{"host": "d", "port": 5432}`)},
+	{ProjectID: "proj-1", Key: "web", SchemaID: "schema-1", SchemaVersion: 2, Version: 2, Content: json.RawMessage(`{"host": "w", "port": "80"}`)},
+}
+
+// migrateTestBrokenConfig pins version 1 with a port that cannot be converted
+var migrateTestBrokenConfig = &outbound.Config{ProjectID: "proj-2", Key: "legacy", SchemaID: "schema-1", SchemaVersion: 1, Version: 7, Content: json.RawMessage(`{"addr": "l", "port": "http"}`)}
+
+func TestMigrateConfigsUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name          string
+		targetVersion int32
+		dryRun        bool
+		wantContent   map[string]string
+		wantFailed    []string
+		wantErrorCode apperrors.ErrorCode
+		arrange       func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository, migrationRepo *MockConfigMigrationRepository)
+	}{
+		{
+			name:   "dry run to latest",
+			dryRun: true,
+			wantContent: map[string]string{
+				"api": `{"host": "a", "port": 8080}`,
+				"web": `{"host": "w", "port": 80}`,
+			},
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository, migrationRepo *MockConfigMigrationRepository) {
+				schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", LatestVersion: 3}, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(3)).Return(migrateTestVersions[0], nil)
+				schemaRepo.On("ListVersions", ctx, "schema-1").Return(migrateTestVersions, nil)
+				configRepo.On("ListBySchema", ctx, "schema-1").Return(migrateTestConfigs, nil)
+			},
+		},
+		{
+			name: "run to latest",
+			wantContent: map[string]string{
+				"api": `{"host": "a", "port": 8080}`,
+				"web": `{"host": "w", "port": 80}`,
+			},
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository, migrationRepo *MockConfigMigrationRepository) {
+				schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", LatestVersion: 3}, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(3)).Return(migrateTestVersions[0], nil)
+				schemaRepo.On("ListVersions", ctx, "schema-1").Return(migrateTestVersions, nil)
+				configRepo.On("ListBySchema", ctx, "schema-1").Return(migrateTestConfigs, nil)
+				configRepo.On("UpdateBatch", ctx, mock.Anything).Return(nil)
+				revisionRepo.On("Create", ctx, mock.Anything).Return(nil)
+				migrationRepo.On("Create", ctx, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:          "run to an older version",
+			targetVersion: 2,
+			wantContent: map[string]string{
+				"api": `{"host": "a", "port": "8080"}`,
+			},
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository, migrationRepo *MockConfigMigrationRepository) {
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(2)).Return(migrateTestVersions[1], nil)
+				schemaRepo.On("ListVersions", ctx, "schema-1").Return(migrateTestVersions, nil)
+				configRepo.On("ListBySchema", ctx, "schema-1").Return(migrateTestConfigs, nil)
+				configRepo.On("UpdateBatch", ctx, mock.Anything).Return(nil)
+				revisionRepo.On("Create", ctx, mock.Anything).Return(nil)
+				migrationRepo.On("Create", ctx, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:   "dry run reports failing configs",
+			dryRun: true,
+			wantContent: map[string]string{
+				"api": `{"host": "a", "port": 8080}`,
+				"web": `{"host": "w", "port": 80}`,
+			},
+			wantFailed: []string{"legacy"},
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository, migrationRepo *MockConfigMigrationRepository) {
+				schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", LatestVersion: 3}, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(3)).Return(migrateTestVersions[0], nil)
+				schemaRepo.On("ListVersions", ctx, "schema-1").Return(migrateTestVersions, nil)
+				configRepo.On("ListBySchema", ctx, "schema-1").Return(append([]*outbound.Config{migrateTestBrokenConfig}, migrateTestConfigs...), nil)
+			},
+		},
+		{
+			name:          "run refused when a config fails",
+			wantErrorCode: apperrors.ErrCodeMigrationFailed,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository, migrationRepo *MockConfigMigrationRepository) {
+				schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", LatestVersion: 3}, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(3)).Return(migrateTestVersions[0], nil)
+				schemaRepo.On("ListVersions", ctx, "schema-1").Return(migrateTestVersions, nil)
+				configRepo.On("ListBySchema", ctx, "schema-1").Return(append([]*outbound.Config{migrateTestBrokenConfig}, migrateTestConfigs...), nil)
+			},
+		},
+		{
+			name:          "unknown target version",
+			targetVersion: 9,
+			wantErrorCode: apperrors.ErrCodeSchemaVersionNotFound,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository, migrationRepo *MockConfigMigrationRepository) {
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(9)).Return(nil, apperrors.New(apperrors.ErrCodeSchemaVersionNotFound, "config schema version not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			configRepo := new(MockConfigRepository)
+			schemaRepo := new(MockConfigSchemaRepository)
+			revisionRepo := new(MockConfigRevisionRepository)
+			migrationRepo := new(MockConfigMigrationRepository)
+			tt.arrange(ctx, configRepo, schemaRepo, revisionRepo, migrationRepo)
+			useCase := NewMigrateConfigsUseCase(configRepo, revisionRepo, schemaRepo, migrationRepo, services.NewSchemaValidator(), services.NewConfigMigrator())
+
+			// Act
+			resp, err := useCase.Execute(ctx, MigrateConfigsRequest{
+				SchemaID:        "schema-1",
+				TargetVersion:   tt.targetVersion,
+				DryRun:          tt.dryRun,
+				UpdatedByUserID: "user-1",
+			})
+
+			// Assert
+			configRepo.AssertExpectations(t)
+			schemaRepo.AssertExpectations(t)
+			migrationRepo.AssertExpectations(t)
+			if tt.wantErrorCode != "" {
+				require.Error(t, err)
+				assert.True(t, apperrors.HasCode(err, tt.wantErrorCode), "got %v", err)
+				configRepo.AssertNotCalled(t, "UpdateBatch", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, resp.Configs, len(tt.wantContent))
+			for _, migrated := range resp.Configs {
+				assert.Contains(t, tt.wantContent, migrated.Key)
+				assert.NotEmpty(t, migrated.Diff, migrated.Key)
+			}
+			failed := make([]string, 0)
+			for _, config := range resp.Failed {
+				failed = append(failed, config.Key)
+			}
+			assert.ElementsMatch(t, tt.wantFailed, failed)
+
+			if tt.dryRun {
+				assert.Empty(t, resp.MigrationID)
+				configRepo.AssertNotCalled(t, "UpdateBatch", mock.Anything, mock.Anything)
+				migrationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NotEmpty(t, resp.MigrationID)
+			batch := configRepo.Calls[len(configRepo.Calls)-1].Arguments.Get(1).(outbound.UpdateBatchParams)
+			assert.Equal(t, "user-1", batch.UpdatedByUserID)
+			require.Len(t, batch.Items, len(tt.wantContent))
+			for _, item := range batch.Items {
+				assert.Equal(t, resp.TargetVersion, item.SchemaVersion)
+				assert.JSONEq(t, tt.wantContent[item.Key], string(item.Content), item.Key)
+			}
+
+			record := migrationRepo.Calls[0].Arguments.Get(1).(outbound.CreateConfigMigrationParams)
+			require.Len(t, record.Items, len(tt.wantContent))
+			assert.Equal(t, "api", record.Items[0].ConfigKey)
+			assert.Equal(t, int64(4), record.Items[0].FromVersion)
+			assert.Equal(t, int64(5), record.Items[0].ToVersion)
+			assert.JSONEq(t, `{"addr": "a", "port": "8080"}`, string(record.Items[0].PreviousContent))
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"context"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// pinnedSchemaContent returns the content of the schema version a config is
+// pinned to. Newer versions only apply once the config is upgraded.
+func pinnedSchemaContent(ctx context.Context, schemaRepo outbound.ConfigSchemaRepository, config *outbound.Config) (string, error) {
+	version, err := schemaRepo.GetVersion(ctx, config.SchemaID, config.SchemaVersion)
+	if err != nil {
+		return "", apperrors.Internal(err, "failed to get schema version")
+	}
+	return version.SchemaContent, nil
+}
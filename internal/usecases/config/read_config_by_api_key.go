@@ -2,103 +2,141 @@ package config
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
 
+	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
-// ReadConfigByAPIKeyRequest holds client config read request
+// MaxBatchKeys limits how many configs one batch read may request
+const MaxBatchKeys = 100
+
+// ReadConfigByAPIKeyRequest holds client config read request.
+// The API key is validated beforehand (see auth.ValidateAPIKeyUseCase);
+// ProjectID and Scope carry the project and configs the key may read.
+// A Path other than the root reads only that fragment of the config.
+// WithDefaults fills in schema defaults as in GetConfigRequest.
 type ReadConfigByAPIKeyRequest struct {
-	APIKey string `json:"api_key"`
-	Key    string `json:"key"`
+	ProjectID    string                   `json:"project_id"`
+	Key          string                   `json:"key"`
+	Path         valueobjects.ConfigPath  `json:"-"`
+	Scope        valueobjects.APIKeyScope `json:"-"`
+	WithDefaults bool                     `json:"-"`
 }
 
-// ReadConfigByAPIKeyResponse holds config data for clients
+// ReadConfigByAPIKeyResponse holds config data for clients.
+// Path is set when Content is a fragment of the config.
 type ReadConfigByAPIKeyResponse struct {
 	Key     string          `json:"key"`
 	Version int64           `json:"version"`
+	Path    string          `json:"path,omitempty"`
 	Content json.RawMessage `json:"content"`
 }
 
+// ReadConfigBundleResponse holds every config an API key can read.
+// Hash changes whenever any config in the bundle is added, removed or updated;
+// Cursor is the revision to pass to ExecuteChanges for later deltas.
+type ReadConfigBundleResponse struct {
+	Hash    string                        `json:"hash"`
+	Cursor  int64                         `json:"cursor"`
+	Configs []*ReadConfigByAPIKeyResponse `json:"configs"`
+}
+
+// ReadConfigChangesResponse holds the configs changed since a cursor.
+// Deleted lists keys removed since the cursor; a key is never in both lists.
+type ReadConfigChangesResponse struct {
+	Cursor  int64                         `json:"cursor"`
+	Changed []*ReadConfigByAPIKeyResponse `json:"changed"`
+	Deleted []string                      `json:"deleted"`
+}
+
 // ReadConfigByAPIKeyUseCase handles read-only config access for clients
 // This is the primary endpoint for external applications to fetch their configs
 type ReadConfigByAPIKeyUseCase struct {
-	projectRepo outbound.ProjectRepository
-	configRepo  outbound.ConfigRepository
+	projectRepo     outbound.ProjectRepository
+	configRepo      outbound.ConfigRepository
+	schemaRepo      outbound.ConfigSchemaRepository
+	schemaValidator *services.SchemaValidator
 }
 
 // NewReadConfigByAPIKeyUseCase creates a new ReadConfigByAPIKeyUseCase
 func NewReadConfigByAPIKeyUseCase(
 	projectRepo outbound.ProjectRepository,
 	configRepo outbound.ConfigRepository,
+	schemaRepo outbound.ConfigSchemaRepository,
+	schemaValidator *services.SchemaValidator,
 ) *ReadConfigByAPIKeyUseCase {
 	return &ReadConfigByAPIKeyUseCase{
-		projectRepo: projectRepo,
-		configRepo:  configRepo,
+		projectRepo:     projectRepo,
+		configRepo:      configRepo,
+		schemaRepo:      schemaRepo,
+		schemaValidator: schemaValidator,
 	}
 }
 
 // Execute reads a config by API key (client access)
 func (uc *ReadConfigByAPIKeyUseCase) Execute(ctx context.Context, req ReadConfigByAPIKeyRequest) (*ReadConfigByAPIKeyResponse, error) {
 	// Validate input
-	if req.APIKey == "" {
-		return nil, fmt.Errorf("API key is required")
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
 	}
 	if req.Key == "" {
-		return nil, fmt.Errorf("config key is required")
+		return nil, apperrors.BadRequest("config key is required")
 	}
 	
-	// Validate and find project by API key
-	apiKey, err := valueobjects.NewAPIKey(req.APIKey)
-	if err != nil {
-		return nil, fmt.Errorf("invalid API key format")
+	// Configs outside the key's scope are indistinguishable from missing ones
+	if !req.Scope.AllowsConfigKey(req.Key) {
+		return nil, apperrors.New(apperrors.ErrCodeConfigNotFound, "config not found")
 	}
 	
-	project, err := uc.projectRepo.GetByAPIKey(ctx, apiKey.Value())
+	// Get config from project
+	config, err := uc.configRepo.Get(ctx, req.ProjectID, req.Key)
 	if err != nil {
-		return nil, fmt.Errorf("invalid API key")
+		return nil, apperrors.Internal(err, "failed to get config")
 	}
 	
-	// Get config from project
-	config, err := uc.configRepo.Get(ctx, project.ID, req.Key)
+	content := config.Content
+	if req.WithDefaults {
+		content = pinnedContentWithDefaults(ctx, uc.schemaRepo, uc.schemaValidator, config)
+	}
+	
+	content, err = resolveConfigPath(content, req.Path)
 	if err != nil {
-		return nil, fmt.Errorf("config not found")
+		return nil, err
 	}
 	
 	// Return config (without sensitive metadata)
 	return &ReadConfigByAPIKeyResponse{
 		Key:     config.Key,
 		Version: config.Version,
-		Content: config.Content,
+		Path:    req.Path.String(),
+		Content: content,
 	}, nil
 }
 
-// ExecuteMultiple reads multiple configs by API key in one call
-func (uc *ReadConfigByAPIKeyUseCase) ExecuteMultiple(ctx context.Context, apiKey string, keys []string) (map[string]*ReadConfigByAPIKeyResponse, error) {
-	if apiKey == "" {
-		return nil, fmt.Errorf("API key is required")
+// ExecuteMultiple reads multiple configs of an API key's project in one call.
+// Keys outside scope are left out like missing ones.
+func (uc *ReadConfigByAPIKeyUseCase) ExecuteMultiple(ctx context.Context, projectID string, scope valueobjects.APIKeyScope, keys []string) (map[string]*ReadConfigByAPIKeyResponse, error) {
+	if projectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
 	}
 	if len(keys) == 0 {
-		return nil, fmt.Errorf("at least one config key is required")
+		return nil, apperrors.BadRequest("at least one config key is required")
 	}
-	
-	// Validate and find project
-	apiKeyVO, err := valueobjects.NewAPIKey(apiKey)
-	if err != nil {
-		return nil, fmt.Errorf("invalid API key format")
-	}
-	
-	project, err := uc.projectRepo.GetByAPIKey(ctx, apiKeyVO.Value())
-	if err != nil {
-		return nil, fmt.Errorf("invalid API key")
+	if len(keys) > MaxBatchKeys {
+		return nil, apperrors.BadRequest(fmt.Sprintf("at most %d config keys can be read at once", MaxBatchKeys))
 	}
 	
 	// Get all configs for the project
-	configs, err := uc.configRepo.ListByProject(ctx, project.ID)
+	configs, err := uc.configRepo.ListByProject(ctx, projectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list configs: %w", err)
+		return nil, apperrors.Internal(err, "failed to list configs")
 	}
 	
 	// Build map of requested configs
@@ -110,6 +148,9 @@ func (uc *ReadConfigByAPIKeyUseCase) ExecuteMultiple(ctx context.Context, apiKey
 	
 	// Return only requested keys
 	for _, key := range keys {
+		if !scope.AllowsConfigKey(key) {
+			continue
+		}
 		if config, found := configMap[key]; found {
 			result[key] = &ReadConfigByAPIKeyResponse{
 				Key:     config.Key,
@@ -122,3 +163,95 @@ func (uc *ReadConfigByAPIKeyUseCase) ExecuteMultiple(ctx context.Context, apiKey
 	return result, nil
 }
 
+// ExecuteBundle reads every config of an API key's project that its scope allows,
+// sorted by key
+func (uc *ReadConfigByAPIKeyUseCase) ExecuteBundle(ctx context.Context, projectID string, scope valueobjects.APIKeyScope) (*ReadConfigBundleResponse, error) {
+	if projectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	
+	// Read every config together with the revision it is current at
+	changes, err := uc.configRepo.ListChanges(ctx, projectID, 0)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list configs")
+	}
+	
+	result := make([]*ReadConfigByAPIKeyResponse, 0, len(changes.Changed))
+	for _, config := range changes.Changed {
+		if !scope.AllowsConfigKey(config.Key) {
+			continue
+		}
+		result = append(result, &ReadConfigByAPIKeyResponse{
+			Key:     config.Key,
+			Version: config.Version,
+			Content: config.Content,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	
+	return &ReadConfigBundleResponse{
+		Hash:    bundleHash(result),
+		Cursor:  changes.Revision,
+		Configs: result,
+	}, nil
+}
+
+// ExecuteChanges reads the configs of an API key's project that changed or
+// were deleted after the cursor from a bundle or an earlier call.
+// It fails with "resync required" when the cursor is older than the
+// compaction horizon; the client must then fetch the bundle again. A cursor
+// of 0 always returns every config. A cursor newer than this node's revision
+// was issued by a node further along the log, so there is nothing new yet
+// and the cursor is handed back unchanged.
+func (uc *ReadConfigByAPIKeyUseCase) ExecuteChanges(ctx context.Context, projectID string, scope valueobjects.APIKeyScope, since int64) (*ReadConfigChangesResponse, error) {
+	if projectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	if since < 0 {
+		return nil, apperrors.BadRequest("cursor must not be negative")
+	}
+	
+	changes, err := uc.configRepo.ListChanges(ctx, projectID, since)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list config changes")
+	}
+	
+	if since > 0 && since < changes.CompactedRevision {
+		return nil, apperrors.New(apperrors.ErrCodeResyncRequired, fmt.Sprintf("resync required: cursor %d is older than the compaction horizon %d", since, changes.CompactedRevision))
+	}
+	
+	result := &ReadConfigChangesResponse{
+		Cursor:  max(since, changes.Revision),
+		Changed: []*ReadConfigByAPIKeyResponse{},
+		Deleted: []string{},
+	}
+	for _, config := range changes.Changed {
+		if scope.AllowsConfigKey(config.Key) {
+			result.Changed = append(result.Changed, &ReadConfigByAPIKeyResponse{
+				Key:     config.Key,
+				Version: config.Version,
+				Content: config.Content,
+			})
+		}
+	}
+	for _, deleted := range changes.Deleted {
+		if scope.AllowsConfigKey(deleted.Key) {
+			result.Deleted = append(result.Deleted, deleted.Key)
+		}
+	}
+	
+	return result, nil
+}
+
+// bundleHash hashes the keys, versions and contents of configs sorted by key.
+// Each field is length-prefixed so that no two bundles hash the same input.
+func bundleHash(configs []*ReadConfigByAPIKeyResponse) string {
+	h := sha256.New()
+	for _, config := range configs {
+		fmt.Fprintf(h, "%d:%s%d:%d:", len(config.Key), config.Key, config.Version, len(config.Content))
+		h.Write(config.Content)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,313 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// MockConfigRepository mocks the config reads of the client read API
+type MockConfigRepository struct {
+	mock.Mock
+	outbound.ConfigRepository
+}
+
+func (m *MockConfigRepository) Get(ctx context.Context, projectID, key string) (*outbound.Config, error) {
+	args := m.Called(ctx, projectID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.Config), args.Error(1)
+}
+
+func (m *MockConfigRepository) ListByProject(ctx context.Context, projectID string) ([]*outbound.Config, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.Config), args.Error(1)
+}
+
+func (m *MockConfigRepository) ListChanges(ctx context.Context, projectID string, since int64) (*outbound.ConfigChanges, error) {
+	args := m.Called(ctx, projectID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.ConfigChanges), args.Error(1)
+}
+
+func projectConfigs() []*outbound.Config {
+	return []*outbound.Config{
+		{ProjectID: "proj-1", Key: "web.theme", Version: 2, Content: json.RawMessage(`{"dark":true}`)},
+		{ProjectID: "proj-1", Key: "mobile.flags", Version: 5, Content: json.RawMessage(`{"beta":false}`)},
+		{ProjectID: "proj-1", Key: "mobile.api", Version: 1, Content: json.RawMessage(`{"url":"https://api"}`)},
+	}
+}
+
+func TestReadConfigByAPIKeyUseCase_ExecuteBundle(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	configRepo := new(MockConfigRepository)
+	useCase := NewReadConfigByAPIKeyUseCase(nil, configRepo, nil, nil)
+	configRepo.On("ListChanges", ctx, "proj-1", int64(0)).Return(&outbound.ConfigChanges{Revision: 42, Changed: projectConfigs()}, nil)
+
+	scope, err := valueobjects.NewAPIKeyScope([]string{"mobile."})
+	require.NoError(t, err)
+
+	// Act
+	bundle, err := useCase.ExecuteBundle(ctx, "proj-1", scope)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, bundle.Configs, 2)
+	assert.Equal(t, "mobile.api", bundle.Configs[0].Key)
+	assert.Equal(t, "mobile.flags", bundle.Configs[1].Key)
+	assert.Equal(t, int64(5), bundle.Configs[1].Version)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, bundle.Hash)
+	assert.Equal(t, int64(42), bundle.Cursor)
+}
+
+func TestReadConfigByAPIKeyUseCase_ExecuteBundle_Hash(t *testing.T) {
+	ctx := context.Background()
+	unrestricted, err := valueobjects.NewAPIKeyScope(nil)
+	require.NoError(t, err)
+
+	bundleHashOf := func(configs []*outbound.Config) string {
+		configRepo := new(MockConfigRepository)
+		configRepo.On("ListChanges", ctx, "proj-1", int64(0)).Return(&outbound.ConfigChanges{Changed: configs}, nil)
+		bundle, err := NewReadConfigByAPIKeyUseCase(nil, configRepo, nil, nil).ExecuteBundle(ctx, "proj-1", unrestricted)
+		require.NoError(t, err)
+		return bundle.Hash
+	}
+
+	original := bundleHashOf(projectConfigs())
+
+	t.Run("independent of listing order", func(t *testing.T) {
+		configs := projectConfigs()
+		configs[0], configs[2] = configs[2], configs[0]
+		assert.Equal(t, original, bundleHashOf(configs))
+	})
+
+	t.Run("changes with a version", func(t *testing.T) {
+		configs := projectConfigs()
+		configs[1].Version++
+		assert.NotEqual(t, original, bundleHashOf(configs))
+	})
+
+	t.Run("changes when a config is removed", func(t *testing.T) {
+		assert.NotEqual(t, original, bundleHashOf(projectConfigs()[1:]))
+	})
+}
+
+func TestReadConfigByAPIKeyUseCase_ExecuteMultiple(t *testing.T) {
+	ctx := context.Background()
+	configRepo := new(MockConfigRepository)
+	useCase := NewReadConfigByAPIKeyUseCase(nil, configRepo, nil, nil)
+	configRepo.On("ListByProject", ctx, "proj-1").Return(projectConfigs(), nil)
+
+	scope, err := valueobjects.NewAPIKeyScope([]string{"mobile."})
+	require.NoError(t, err)
+
+	t.Run("leaves out missing and out-of-scope keys", func(t *testing.T) {
+		configs, err := useCase.ExecuteMultiple(ctx, "proj-1", scope, []string{"mobile.api", "web.theme", "mobile.gone"})
+
+		require.NoError(t, err)
+		assert.Len(t, configs, 1)
+		assert.Contains(t, configs, "mobile.api")
+	})
+
+	t.Run("limits the number of keys", func(t *testing.T) {
+		keys := make([]string, MaxBatchKeys+1)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("mobile.key-%d", i)
+		}
+
+		_, err := useCase.ExecuteMultiple(ctx, "proj-1", scope, keys)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at most")
+	})
+}
+
+func TestReadConfigByAPIKeyUseCase_ExecuteChanges(t *testing.T) {
+	ctx := context.Background()
+	scope, err := valueobjects.NewAPIKeyScope([]string{"mobile."})
+	require.NoError(t, err)
+
+	t.Run("returns changes and deletes in scope", func(t *testing.T) {
+		// Arrange
+		configRepo := new(MockConfigRepository)
+		useCase := NewReadConfigByAPIKeyUseCase(nil, configRepo, nil, nil)
+		configRepo.On("ListChanges", ctx, "proj-1", int64(10)).Return(&outbound.ConfigChanges{
+			Revision:          15,
+			CompactedRevision: 4,
+			Changed:           projectConfigs(),
+			Deleted: []*outbound.DeletedConfig{
+				{Key: "mobile.legacy", Revision: 12},
+				{Key: "web.old", Revision: 13},
+			},
+		}, nil)
+
+		// Act
+		changes, err := useCase.ExecuteChanges(ctx, "proj-1", scope, 10)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(15), changes.Cursor)
+		require.Len(t, changes.Changed, 2)
+		assert.Equal(t, "mobile.flags", changes.Changed[0].Key)
+		assert.Equal(t, []string{"mobile.legacy"}, changes.Deleted)
+	})
+
+	t.Run("requires a resync for a cursor older than the compaction horizon", func(t *testing.T) {
+		configRepo := new(MockConfigRepository)
+		useCase := NewReadConfigByAPIKeyUseCase(nil, configRepo, nil, nil)
+		configRepo.On("ListChanges", ctx, "proj-1", int64(3)).Return(&outbound.ConfigChanges{
+			Revision:          15,
+			CompactedRevision: 4,
+		}, nil)
+
+		_, err := useCase.ExecuteChanges(ctx, "proj-1", scope, 3)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resync required")
+	})
+
+	t.Run("returns every config for cursor 0 after compaction", func(t *testing.T) {
+		configRepo := new(MockConfigRepository)
+		useCase := NewReadConfigByAPIKeyUseCase(nil, configRepo, nil, nil)
+		configRepo.On("ListChanges", ctx, "proj-1", int64(0)).Return(&outbound.ConfigChanges{
+			Revision:          15,
+			CompactedRevision: 4,
+			Changed:           projectConfigs(),
+		}, nil)
+
+		changes, err := useCase.ExecuteChanges(ctx, "proj-1", scope, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(15), changes.Cursor)
+		assert.Len(t, changes.Changed, 2)
+	})
+
+	t.Run("keeps a cursor ahead of a lagging node", func(t *testing.T) {
+		configRepo := new(MockConfigRepository)
+		useCase := NewReadConfigByAPIKeyUseCase(nil, configRepo, nil, nil)
+		configRepo.On("ListChanges", ctx, "proj-1", int64(16)).Return(&outbound.ConfigChanges{
+			Revision:          15,
+			CompactedRevision: 4,
+		}, nil)
+
+		changes, err := useCase.ExecuteChanges(ctx, "proj-1", scope, 16)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(16), changes.Cursor)
+		assert.Empty(t, changes.Changed)
+		assert.Empty(t, changes.Deleted)
+	})
+}
+
+func TestReadConfigByAPIKeyUseCase_Execute_Path(t *testing.T) {
+	ctx := context.Background()
+	configRepo := new(MockConfigRepository)
+	useCase := NewReadConfigByAPIKeyUseCase(nil, configRepo, nil, nil)
+	configRepo.On("Get", ctx, "proj-1", "mobile.api").Return(&outbound.Config{
+		Key:     "mobile.api",
+		Version: 7,
+		Content: json.RawMessage(`{"database": {"primary": {"port": 5432, "host": "db-1"}}}`),
+	}, nil)
+
+	scope, err := valueobjects.NewAPIKeyScope([]string{"mobile."})
+	require.NoError(t, err)
+
+	t.Run("returns the fragment", func(t *testing.T) {
+		path, err := valueobjects.NewConfigPath("$.database.primary")
+		require.NoError(t, err)
+
+		resp, err := useCase.Execute(ctx, ReadConfigByAPIKeyRequest{ProjectID: "proj-1", Key: "mobile.api", Path: path, Scope: scope})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/database/primary", resp.Path)
+		assert.Equal(t, int64(7), resp.Version)
+		assert.Equal(t, `{"host":"db-1","port":5432}`, string(resp.Content))
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		path, err := valueobjects.NewConfigPath("/database/replica")
+		require.NoError(t, err)
+
+		_, err = useCase.Execute(ctx, ReadConfigByAPIKeyRequest{ProjectID: "proj-1", Key: "mobile.api", Path: path, Scope: scope})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found in config")
+	})
+}
+
+func TestReadConfigByAPIKeyUseCase_Execute_WithDefaults(t *testing.T) {
+	ctx := context.Background()
+	configRepo := new(MockConfigRepository)
+	schemaRepo := new(MockConfigSchemaRepository)
+	useCase := NewReadConfigByAPIKeyUseCase(nil, configRepo, schemaRepo, services.NewSchemaValidator())
+	configRepo.On("Get", ctx, "proj-1", "mobile.api").Return(&outbound.Config{
+		Key:           "mobile.api",
+		SchemaID:      "schema-1",
+		SchemaVersion: 2,
+		Version:       7,
+		Content:       json.RawMessage(`{"url":"https://api"}`),
+	}, nil)
+	schemaRepo.On("GetVersion", ctx, "schema-1", int32(2)).Return(&outbound.ConfigSchemaVersion{
+		SchemaID:      "schema-1",
+		Version:       2,
+		SchemaContent: `{"properties": {"url": {"type": "string"}, "timeout": {"default": "5s"}}}`,
+	}, nil)
+
+	scope, err := valueobjects.NewAPIKeyScope([]string{"mobile."})
+	require.NoError(t, err)
+
+	t.Run("fills in defaults", func(t *testing.T) {
+		resp, err := useCase.Execute(ctx, ReadConfigByAPIKeyRequest{ProjectID: "proj-1", Key: "mobile.api", Scope: scope, WithDefaults: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), resp.Version)
+		assert.JSONEq(t, `{"url": "https://api", "timeout": "5s"}`, string(resp.Content))
+	})
+
+	t.Run("stored content without the flag", func(t *testing.T) {
+		resp, err := useCase.Execute(ctx, ReadConfigByAPIKeyRequest{ProjectID: "proj-1", Key: "mobile.api", Scope: scope})
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"url":"https://api"}`, string(resp.Content))
+	})
+}
+
+func TestReadConfigByAPIKeyUseCase_Execute_WithDefaults_RegistryUnavailable(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	configRepo := new(MockConfigRepository)
+	schemaRepo := new(MockConfigSchemaRepository)
+	useCase := NewReadConfigByAPIKeyUseCase(nil, configRepo, schemaRepo, services.NewSchemaValidator())
+	configRepo.On("Get", ctx, "proj-1", "mobile.api").Return(&outbound.Config{
+		Key:           "mobile.api",
+		SchemaID:      "schema-1",
+		SchemaVersion: 2,
+		Version:       7,
+		Content:       json.RawMessage(`{"url":"https://api"}`),
+	}, nil)
+	schemaRepo.On("GetVersion", ctx, "schema-1", int32(2)).Return(nil, fmt.Errorf("connection refused"))
+	scope, err := valueobjects.NewAPIKeyScope([]string{"mobile."})
+	require.NoError(t, err)
+
+	// Act
+	resp, err := useCase.Execute(ctx, ReadConfigByAPIKeyRequest{ProjectID: "proj-1", Key: "mobile.api", Scope: scope, WithDefaults: true})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `{"url":"https://api"}`, string(resp.Content))
+}
@@ -7,9 +7,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/vlone310/cfguardian/internal/domain/entities"
-	"github.com/vlone310/cfguardian/internal/domain/events"
 	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -62,41 +62,41 @@ func NewRollbackConfigUseCase(
 func (uc *RollbackConfigUseCase) Execute(ctx context.Context, req RollbackConfigRequest) (*RollbackConfigResponse, error) {
 	// Validate input
 	if req.ProjectID == "" {
-		return nil, fmt.Errorf("project ID is required")
+		return nil, apperrors.BadRequest("project ID is required")
 	}
 	if req.Key == "" {
-		return nil, fmt.Errorf("config key is required")
+		return nil, apperrors.BadRequest("config key is required")
 	}
 	if req.TargetVersion < 1 {
-		return nil, fmt.Errorf("target version must be >= 1")
+		return nil, apperrors.BadRequest("target version must be >= 1")
 	}
 	if req.ExpectedVersion < 1 {
-		return nil, fmt.Errorf("expected version must be >= 1")
+		return nil, apperrors.BadRequest("expected version must be >= 1")
 	}
 	if req.RolledBackByUserID == "" {
-		return nil, fmt.Errorf("user ID is required")
+		return nil, apperrors.BadRequest("user ID is required")
 	}
 	
 	// Get current config
 	currentConfig, err := uc.configRepo.Get(ctx, req.ProjectID, req.Key)
 	if err != nil {
-		return nil, fmt.Errorf("config not found: %w", err)
+		return nil, apperrors.Internal(err, "failed to get config")
 	}
 	
 	// Create version value objects
 	currentVersion, err := valueobjects.NewVersion(currentConfig.Version)
 	if err != nil {
-		return nil, fmt.Errorf("invalid current version: %w", err)
+		return nil, apperrors.Internal(err, "invalid current version")
 	}
 	
 	expectedVersion, err := valueobjects.NewVersion(req.ExpectedVersion)
 	if err != nil {
-		return nil, fmt.Errorf("invalid expected version: %w", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeBadRequest, fmt.Sprintf("invalid expected version: %v", err))
 	}
 	
 	targetVersion, err := valueobjects.NewVersion(req.TargetVersion)
 	if err != nil {
-		return nil, fmt.Errorf("invalid target version: %w", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeBadRequest, fmt.Sprintf("invalid target version: %v", err))
 	}
 	
 	// Validate optimistic lock
@@ -106,36 +106,37 @@ func (uc *RollbackConfigUseCase) Execute(ctx context.Context, req RollbackConfig
 	
 	// Validate rollback target
 	if !uc.versionManager.IsValidRollbackTarget(currentVersion, targetVersion) {
-		return nil, fmt.Errorf("invalid rollback target: version %d (current: %d)", req.TargetVersion, currentConfig.Version)
+		return nil, apperrors.BadRequest(fmt.Sprintf("invalid rollback target: version %d (current: %d)", req.TargetVersion, currentConfig.Version))
 	}
 	
 	// Get target revision
 	targetRevision, err := uc.revisionRepo.GetByVersion(ctx, req.ProjectID, req.Key, req.TargetVersion)
 	if err != nil {
-		return nil, fmt.Errorf("target version not found: %w", err)
+		return nil, apperrors.Internal(err, "failed to get target revision")
 	}
 	
-	// Get schema for validation (use current schema, not historical)
-	schema, err := uc.schemaRepo.GetByID(ctx, currentConfig.SchemaID)
+	// Get the schema version the config is pinned to now, not the historical one
+	schemaContent, err := pinnedSchemaContent(ctx, uc.schemaRepo, currentConfig)
 	if err != nil {
-		return nil, fmt.Errorf("schema not found: %w", err)
+		return nil, err
 	}
 	
 	// Validate target content against current schema
-	if err := uc.schemaValidator.ValidateOrError(schema.SchemaContent, targetRevision.Content); err != nil {
+	if err := uc.schemaValidator.ValidateOrError(ctx, currentConfig.SchemaID, currentConfig.SchemaVersion, schemaContent, targetRevision.Content); err != nil {
 		return nil, fmt.Errorf("rollback validation failed (target content doesn't match current schema): %w", err)
 	}
 	
 	// Update config to target content (creates new version)
 	updatedConfig, err := uc.configRepo.Update(ctx, outbound.UpdateConfigParams{
-		ProjectID:       req.ProjectID,
-		Key:             req.Key,
-		ExpectedVersion: req.ExpectedVersion,
-		Content:         targetRevision.Content,
-		UpdatedByUserID: req.RolledBackByUserID,
+		ProjectID:         req.ProjectID,
+		Key:               req.Key,
+		ExpectedVersion:   req.ExpectedVersion,
+		Content:           targetRevision.Content,
+		UpdatedByUserID:   req.RolledBackByUserID,
+		RollbackToVersion: req.TargetVersion,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to rollback config: %w", err)
+		return nil, apperrors.Internal(err, "failed to rollback config")
 	}
 	
 	// Create revision for the rollback
@@ -162,17 +163,6 @@ func (uc *RollbackConfigUseCase) Execute(ctx context.Context, req RollbackConfig
 		fmt.Printf("Warning: failed to create rollback revision: %v\n", err)
 	}
 	
-	// TODO: Publish ConfigRolledBack event
-	_ = events.NewConfigRolledBack(
-		uuid.New().String(),
-		updatedConfig.ProjectID,
-		updatedConfig.Key,
-		currentVersion,
-		targetVersion,
-		updatedConfig.Content,
-		req.RolledBackByUserID,
-	)
-	
 	return &RollbackConfigResponse{
 		ProjectID:       updatedConfig.ProjectID,
 		Key:             updatedConfig.Key,
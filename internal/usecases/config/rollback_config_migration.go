@@ -0,0 +1,105 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// RollbackConfigMigrationRequest identifies the migration run to undo
+type RollbackConfigMigrationRequest struct {
+	SchemaID        string `json:"schema_id"`
+	MigrationID     string `json:"migration_id"`
+	UpdatedByUserID string `json:"updated_by_user_id"`
+}
+
+// RollbackConfigMigrationUseCase restores the content and schema version
+// that configs had before a migration run
+type RollbackConfigMigrationUseCase struct {
+	configRepo    outbound.ConfigRepository
+	revisionRepo  outbound.ConfigRevisionRepository
+	migrationRepo outbound.ConfigMigrationRepository
+}
+
+// NewRollbackConfigMigrationUseCase creates a new RollbackConfigMigrationUseCase
+func NewRollbackConfigMigrationUseCase(
+	configRepo outbound.ConfigRepository,
+	revisionRepo outbound.ConfigRevisionRepository,
+	migrationRepo outbound.ConfigMigrationRepository,
+) *RollbackConfigMigrationUseCase {
+	return &RollbackConfigMigrationUseCase{
+		configRepo:    configRepo,
+		revisionRepo:  revisionRepo,
+		migrationRepo: migrationRepo,
+	}
+}
+
+// Execute rolls a migration run back as one batched Raft write. Like any
+// other write it moves configs forward to a new version. It is refused if
+// a config was changed after the run, so later edits are never lost.
+func (uc *RollbackConfigMigrationUseCase) Execute(ctx context.Context, req RollbackConfigMigrationRequest) (*ConfigMigrationResponse, error) {
+	if req.UpdatedByUserID == "" {
+		return nil, apperrors.BadRequest("user ID is required")
+	}
+
+	migration, err := getSchemaMigration(ctx, uc.migrationRepo, req.SchemaID, req.MigrationID)
+	if err != nil {
+		return nil, err
+	}
+	if migration.RolledBackAt != nil {
+		return nil, apperrors.Conflict("migration was already rolled back")
+	}
+
+	items := make([]outbound.UpdateBatchItem, len(migration.Items))
+	current := make([]*outbound.Config, len(migration.Items))
+	for i, item := range migration.Items {
+		config, err := uc.configRepo.Get(ctx, item.ProjectID, item.ConfigKey)
+		if apperrors.HasCode(err, apperrors.ErrCodeConfigNotFound) {
+			return nil, apperrors.Conflict(fmt.Sprintf("config %s was deleted after the migration", item.ConfigKey))
+		}
+		if err != nil {
+			return nil, apperrors.Internal(err, "failed to get config")
+		}
+		if config.Version != item.ToVersion {
+			return nil, apperrors.Conflict(fmt.Sprintf(
+				"config %s changed after the migration (version %d, migration wrote %d)",
+				item.ConfigKey, config.Version, item.ToVersion,
+			))
+		}
+
+		current[i] = config
+		items[i] = outbound.UpdateBatchItem{
+			ProjectID:       item.ProjectID,
+			Key:             item.ConfigKey,
+			ExpectedVersion: item.ToVersion,
+			SchemaID:        migration.SchemaID,
+			SchemaVersion:   item.FromSchemaVersion,
+			Content:         item.PreviousContent,
+		}
+	}
+
+	// The batch expects the versions the run wrote, so a concurrent
+	// rollback or edit makes it fail without changing anything
+	if len(items) > 0 {
+		updatedConfigs, err := uc.configRepo.UpdateBatch(ctx, outbound.UpdateBatchParams{
+			Items:           items,
+			UpdatedByUserID: req.UpdatedByUserID,
+		})
+		if err != nil {
+			return nil, apperrors.Internal(err, "failed to roll back migration")
+		}
+		for _, updatedConfig := range updatedConfigs {
+			recordBatchRevision(ctx, uc.revisionRepo, updatedConfig)
+		}
+	}
+
+	rolledBack, err := uc.migrationRepo.MarkRolledBack(ctx, migration.ID, req.UpdatedByUserID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "configs were restored but the migration could not be marked rolled back")
+	}
+
+	rolledBack.Items = migration.Items
+	return toConfigMigrationResponse(rolledBack), nil
+}
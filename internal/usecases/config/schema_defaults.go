@@ -0,0 +1,36 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// applySchemaDefaults fills in the properties content leaves out that have
+// a default in the schema (see services.SchemaValidator.ApplyDefaults)
+func applySchemaDefaults(ctx context.Context, schemaValidator *services.SchemaValidator, schemaID string, version int32, schemaContent string, content json.RawMessage) (json.RawMessage, error) {
+	withDefaults, err := schemaValidator.ApplyDefaults(ctx, schemaID, version, schemaContent, content)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to apply schema defaults")
+	}
+	return withDefaults, nil
+}
+
+// pinnedContentWithDefaults returns a config's content with the defaults of
+// the schema version it is pinned to. The stored content is not changed.
+// Defaults are best effort on reads: when the schema registry cannot be
+// reached, the stored content is returned as it is rather than failing.
+func pinnedContentWithDefaults(ctx context.Context, schemaRepo outbound.ConfigSchemaRepository, schemaValidator *services.SchemaValidator, config *outbound.Config) json.RawMessage {
+	schemaContent, err := pinnedSchemaContent(ctx, schemaRepo, config)
+	if err != nil {
+		return config.Content
+	}
+	withDefaults, err := applySchemaDefaults(ctx, schemaValidator, config.SchemaID, config.SchemaVersion, schemaContent, config.Content)
+	if err != nil {
+		return config.Content
+	}
+	return withDefaults
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// StreamConfigChangesRequest holds a project change stream request.
+// Callers authenticate beforehand, by API key or by project role.
+// Key narrows the stream to one config, and Path further to one fragment
+// of it: events are then only delivered when that fragment changes.
+type StreamConfigChangesRequest struct {
+	ProjectID   string                  `json:"project_id"`
+	LastEventID uint64                  `json:"last_event_id"` // Resume after this event (0 = live only)
+	Key         string                  `json:"key,omitempty"`
+	Path        valueobjects.ConfigPath `json:"-"`
+}
+
+// StreamConfigChangesUseCase handles subscriptions to a project's config changes
+type StreamConfigChangesUseCase struct {
+	projectRepo     outbound.ProjectRepository
+	configRepo      outbound.ConfigRepository
+	eventSubscriber outbound.EventSubscriber
+}
+
+// NewStreamConfigChangesUseCase creates a new StreamConfigChangesUseCase
+func NewStreamConfigChangesUseCase(
+	projectRepo outbound.ProjectRepository,
+	configRepo outbound.ConfigRepository,
+	eventSubscriber outbound.EventSubscriber,
+) *StreamConfigChangesUseCase {
+	return &StreamConfigChangesUseCase{
+		projectRepo:     projectRepo,
+		configRepo:      configRepo,
+		eventSubscriber: eventSubscriber,
+	}
+}
+
+// Execute resolves the project and subscribes to its config events.
+// The caller must Close the returned subscription.
+func (uc *StreamConfigChangesUseCase) Execute(ctx context.Context, req StreamConfigChangesRequest) (outbound.EventSubscription, error) {
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	if req.Key == "" && !req.Path.IsRoot() {
+		return nil, apperrors.BadRequest("config key is required to watch a path")
+	}
+
+	exists, err := uc.projectRepo.Exists(ctx, req.ProjectID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to verify project exists")
+	}
+	if !exists {
+		return nil, apperrors.New(apperrors.ErrCodeProjectNotFound, "project not found")
+	}
+
+	sub, err := uc.eventSubscriber.Subscribe(ctx, req.ProjectID, req.LastEventID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to subscribe to project events")
+	}
+
+	if req.Key == "" {
+		return sub, nil
+	}
+	if req.Path.IsRoot() || req.LastEventID != 0 {
+		return newConfigWatch(sub, req.Key, req.Path, nil, false, 0), nil
+	}
+
+	// Start from the current fragment, read after subscribing so that no
+	// change is missed, and skip the events it already includes
+	var fragment json.RawMessage
+	var found bool
+	var version int64
+	if current, err := uc.configRepo.Get(ctx, req.ProjectID, req.Key); err == nil {
+		fragment, found, err = req.Path.Resolve(current.Content)
+		if err != nil {
+			sub.Close()
+			return nil, apperrors.Wrap(err, apperrors.ErrCodeBadRequest, fmt.Sprintf("failed to read path %s: %v", req.Path, err))
+		}
+		version = current.Version
+	}
+
+	return newConfigWatch(sub, req.Key, req.Path, fragment, found, version), nil
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// MockProjectRepository mocks project lookups
+type MockProjectRepository struct {
+	mock.Mock
+	outbound.ProjectRepository
+}
+
+func (m *MockProjectRepository) Exists(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// fakeSubscription is an EventSubscription fed by the test
+type fakeSubscription struct {
+	events chan *outbound.StreamEvent
+	closed bool
+}
+
+func (s *fakeSubscription) Events() <-chan *outbound.StreamEvent { return s.events }
+func (s *fakeSubscription) Dropped() <-chan struct{}             { return nil }
+func (s *fakeSubscription) Gap() bool                            { return false }
+func (s *fakeSubscription) Close()                               { s.closed = true }
+
+// fakeSubscriber hands out a single fakeSubscription
+type fakeSubscriber struct {
+	sub *fakeSubscription
+}
+
+func (s *fakeSubscriber) Subscribe(ctx context.Context, projectID string, afterID uint64) (outbound.EventSubscription, error) {
+	return s.sub, nil
+}
+
+// configEvent builds a stream event for a config of proj-1
+func configEvent(id uint64, eventType, key string, version int64, content string) *outbound.StreamEvent {
+	data := fmt.Sprintf(`{"event_type":%q,"config_key":%q,"new_version":%d,"content":%s}`, eventType, key, version, content)
+	if eventType == "config.deleted" {
+		data = fmt.Sprintf(`{"event_type":%q,"config_key":%q,"last_version":%d}`, eventType, key, version)
+	}
+	return &outbound.StreamEvent{ID: id, ProjectID: "proj-1", ConfigKey: key, Type: eventType, Data: json.RawMessage(data)}
+}
+
+// receive returns the next event of a subscription, or nil if none arrives
+func receive(sub outbound.EventSubscription) *outbound.StreamEvent {
+	select {
+	case event := <-sub.Events():
+		return event
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+func TestStreamConfigChangesUseCase_Execute_PathWatch(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	projectRepo := new(MockProjectRepository)
+	configRepo := new(MockConfigRepository)
+	source := &fakeSubscription{events: make(chan *outbound.StreamEvent, 16)}
+	useCase := NewStreamConfigChangesUseCase(projectRepo, configRepo, &fakeSubscriber{sub: source})
+
+	projectRepo.On("Exists", ctx, "proj-1").Return(true, nil)
+	configRepo.On("Get", ctx, "proj-1", "app").Return(&outbound.Config{
+		Key:     "app",
+		Version: 3,
+		Content: json.RawMessage(`{"database": {"primary": {"host": "db-1"}}, "debug": false}`),
+	}, nil)
+
+	path, err := valueobjects.NewConfigPath("/database/primary")
+	require.NoError(t, err)
+
+	// Act
+	sub, err := useCase.Execute(ctx, StreamConfigChangesRequest{ProjectID: "proj-1", Key: "app", Path: path})
+	require.NoError(t, err)
+	defer sub.Close()
+
+	source.events <- configEvent(1, "config.updated", "other", 9, `{"database": {"primary": {"host": "x"}}}`)
+	source.events <- configEvent(2, "config.updated", "app", 3, `{"database": {"primary": {"host": "db-0"}}}`)
+	source.events <- configEvent(3, "config.updated", "app", 4, `{"database": {"primary": {"host": "db-1"}}, "debug": true}`)
+	source.events <- configEvent(4, "config.updated", "app", 5, `{"database": {"primary": {"host": "db-2"}}}`)
+	source.events <- configEvent(5, "config.deleted", "app", 5, ``)
+	source.events <- configEvent(6, "config.created", "app", 1, `{"database": {"primary": {"host": "db-2"}}}`)
+
+	// Assert
+	changed := receive(sub)
+	require.NotNil(t, changed, "the fragment changed in version 5")
+	assert.Equal(t, uint64(4), changed.ID)
+	assert.JSONEq(t, `{"event_type":"config.updated","config_key":"app","new_version":5,"path":"/database/primary","content":{"host":"db-2"}}`, string(changed.Data))
+
+	deleted := receive(sub)
+	require.NotNil(t, deleted)
+	assert.Equal(t, uint64(5), deleted.ID)
+	assert.Contains(t, string(deleted.Data), `"content":null`)
+
+	recreated := receive(sub)
+	require.NotNil(t, recreated, "versions restart after a delete")
+	assert.Equal(t, uint64(6), recreated.ID)
+
+	assert.Nil(t, receive(sub))
+
+	sub.Close()
+	assert.True(t, source.closed)
+}
+
+func TestStreamConfigChangesUseCase_Execute_PathRequiresKey(t *testing.T) {
+	useCase := NewStreamConfigChangesUseCase(new(MockProjectRepository), new(MockConfigRepository), &fakeSubscriber{})
+	path, err := valueobjects.NewConfigPath("/database")
+	require.NoError(t, err)
+
+	_, err = useCase.Execute(context.Background(), StreamConfigChangesRequest{ProjectID: "proj-1", Path: path})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key is required")
+}
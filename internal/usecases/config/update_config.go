@@ -7,9 +7,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/vlone310/cfguardian/internal/domain/entities"
-	"github.com/vlone310/cfguardian/internal/domain/events"
 	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -20,6 +20,14 @@ type UpdateConfigRequest struct {
 	ExpectedVersion int64           `json:"expected_version"` // For optimistic locking
 	Content         json.RawMessage `json:"content"`
 	UpdatedByUserID string          `json:"updated_by_user_id"`
+
+	// Format is the file format Content is written in. When set, Content
+	// is a JSON string holding the file; when empty, Content is JSON.
+	Format valueobjects.ConfigFormat `json:"format,omitempty"`
+
+	// ApplyDefaults stores the defaults of the pinned schema version for
+	// properties Content leaves out, as in CreateConfigRequest
+	ApplyDefaults bool `json:"apply_defaults,omitempty"`
 }
 
 // UpdateConfigResponse holds updated config data
@@ -27,6 +35,7 @@ type UpdateConfigResponse struct {
 	ProjectID       string          `json:"project_id"`
 	Key             string          `json:"key"`
 	SchemaID        string          `json:"schema_id"`
+	SchemaVersion   int32           `json:"schema_version"`
 	Version         int64           `json:"version"`
 	Content         json.RawMessage `json:"content"`
 	UpdatedByUserID string          `json:"updated_by_user_id"`
@@ -39,6 +48,7 @@ type UpdateConfigUseCase struct {
 	revisionRepo    outbound.ConfigRevisionRepository
 	schemaRepo      outbound.ConfigSchemaRepository
 	schemaValidator *services.SchemaValidator
+	parser          *services.ConfigParser
 	versionManager  *services.VersionManager
 }
 
@@ -55,6 +65,7 @@ func NewUpdateConfigUseCase(
 		revisionRepo:    revisionRepo,
 		schemaRepo:      schemaRepo,
 		schemaValidator: schemaValidator,
+		parser:          services.NewConfigParser(),
 		versionManager:  versionManager,
 	}
 }
@@ -63,36 +74,42 @@ func NewUpdateConfigUseCase(
 func (uc *UpdateConfigUseCase) Execute(ctx context.Context, req UpdateConfigRequest) (*UpdateConfigResponse, error) {
 	// Validate input
 	if req.ProjectID == "" {
-		return nil, fmt.Errorf("project ID is required")
+		return nil, apperrors.BadRequest("project ID is required")
 	}
 	if req.Key == "" {
-		return nil, fmt.Errorf("config key is required")
+		return nil, apperrors.BadRequest("config key is required")
 	}
 	if req.ExpectedVersion < 1 {
-		return nil, fmt.Errorf("expected version must be >= 1")
+		return nil, apperrors.BadRequest("expected version must be >= 1")
 	}
 	if len(req.Content) == 0 {
-		return nil, fmt.Errorf("config content is required")
+		return nil, apperrors.BadRequest("config content is required")
 	}
 	if req.UpdatedByUserID == "" {
-		return nil, fmt.Errorf("user ID is required")
+		return nil, apperrors.BadRequest("user ID is required")
+	}
+	
+	// Convert content written in another format to canonical JSON
+	content, err := uc.parser.ParseContent(req.Content, req.Format)
+	if err != nil {
+		return nil, apperrors.Invalid(err)
 	}
 	
 	// Get current config
 	currentConfig, err := uc.configRepo.Get(ctx, req.ProjectID, req.Key)
 	if err != nil {
-		return nil, fmt.Errorf("config not found: %w", err)
+		return nil, apperrors.Internal(err, "failed to get config")
 	}
 	
 	// Create version value objects for validation
 	expectedVersion, err := valueobjects.NewVersion(req.ExpectedVersion)
 	if err != nil {
-		return nil, fmt.Errorf("invalid expected version: %w", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeBadRequest, fmt.Sprintf("invalid expected version: %v", err))
 	}
 	
 	currentVersion, err := valueobjects.NewVersion(currentConfig.Version)
 	if err != nil {
-		return nil, fmt.Errorf("invalid current version: %w", err)
+		return nil, apperrors.Internal(err, "invalid current version")
 	}
 	
 	// Validate optimistic lock - this is the key to preventing concurrent modifications!
@@ -100,14 +117,22 @@ func (uc *UpdateConfigUseCase) Execute(ctx context.Context, req UpdateConfigRequ
 		return nil, err // Returns VersionConflictError
 	}
 	
-	// Get schema for validation
-	schema, err := uc.schemaRepo.GetByID(ctx, currentConfig.SchemaID)
+	// Get the schema version the config is pinned to
+	schemaContent, err := pinnedSchemaContent(ctx, uc.schemaRepo, currentConfig)
 	if err != nil {
-		return nil, fmt.Errorf("schema not found: %w", err)
+		return nil, err
+	}
+	
+	// Fill in defaults before validation, so they are validated and stored
+	if req.ApplyDefaults {
+		content, err = applySchemaDefaults(ctx, uc.schemaValidator, currentConfig.SchemaID, currentConfig.SchemaVersion, schemaContent, content)
+		if err != nil {
+			return nil, err
+		}
 	}
 	
 	// Validate new content against schema
-	if err := uc.schemaValidator.ValidateOrError(schema.SchemaContent, req.Content); err != nil {
+	if err := uc.schemaValidator.ValidateOrError(ctx, currentConfig.SchemaID, currentConfig.SchemaVersion, schemaContent, content); err != nil {
 		return nil, fmt.Errorf("content validation failed: %w", err)
 	}
 	
@@ -116,11 +141,11 @@ func (uc *UpdateConfigUseCase) Execute(ctx context.Context, req UpdateConfigRequ
 		ProjectID:       req.ProjectID,
 		Key:             req.Key,
 		ExpectedVersion: req.ExpectedVersion,
-		Content:         req.Content,
+		Content:         content,
 		UpdatedByUserID: req.UpdatedByUserID,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update config: %w", err)
+		return nil, apperrors.Internal(err, "failed to update config")
 	}
 	
 	// Create revision for audit log
@@ -148,22 +173,11 @@ func (uc *UpdateConfigUseCase) Execute(ctx context.Context, req UpdateConfigRequ
 		fmt.Printf("Warning: failed to create config revision: %v\n", err)
 	}
 	
-	// TODO: Publish ConfigUpdated event
-	_ = events.NewConfigUpdated(
-		uuid.New().String(),
-		updatedConfig.ProjectID,
-		updatedConfig.Key,
-		updatedConfig.SchemaID,
-		currentVersion,
-		newVersion,
-		updatedConfig.Content,
-		updatedConfig.UpdatedByUserID,
-	)
-	
 	return &UpdateConfigResponse{
 		ProjectID:       updatedConfig.ProjectID,
 		Key:             updatedConfig.Key,
 		SchemaID:        updatedConfig.SchemaID,
+		SchemaVersion:   updatedConfig.SchemaVersion,
 		Version:         updatedConfig.Version,
 		Content:         updatedConfig.Content,
 		UpdatedByUserID: updatedConfig.UpdatedByUserID,
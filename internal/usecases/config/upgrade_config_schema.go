@@ -0,0 +1,172 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/vlone310/cfguardian/internal/domain/entities"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// UpgradeConfigSchemaRequest holds the schema version to pin a config to.
+// SchemaVersion 0 upgrades to the latest version. A lower version than the
+// pinned one is allowed, to revert an upgrade, as long as the content
+// satisfies it.
+type UpgradeConfigSchemaRequest struct {
+	ProjectID       string `json:"project_id"`
+	Key             string `json:"key"`
+	ExpectedVersion int64  `json:"expected_version"` // For optimistic locking
+	SchemaVersion   int32  `json:"schema_version"`
+	UpdatedByUserID string `json:"updated_by_user_id"`
+}
+
+// UpgradeConfigSchemaResponse holds the upgraded config
+type UpgradeConfigSchemaResponse struct {
+	ProjectID             string `json:"project_id"`
+	Key                   string `json:"key"`
+	SchemaID              string `json:"schema_id"`
+	PreviousSchemaVersion int32  `json:"previous_schema_version"`
+	SchemaVersion         int32  `json:"schema_version"`
+	Version               int64  `json:"version"` // New config version after the upgrade
+	UpdatedByUserID       string `json:"updated_by_user_id"`
+	UpdatedAt             string `json:"updated_at"`
+}
+
+// UpgradeConfigSchemaUseCase pins a config to another version of its schema.
+// The content is re-validated against that version and is not changed.
+type UpgradeConfigSchemaUseCase struct {
+	configRepo      outbound.ConfigRepository
+	revisionRepo    outbound.ConfigRevisionRepository
+	schemaRepo      outbound.ConfigSchemaRepository
+	schemaValidator *services.SchemaValidator
+	versionManager  *services.VersionManager
+}
+
+// NewUpgradeConfigSchemaUseCase creates a new UpgradeConfigSchemaUseCase
+func NewUpgradeConfigSchemaUseCase(
+	configRepo outbound.ConfigRepository,
+	revisionRepo outbound.ConfigRevisionRepository,
+	schemaRepo outbound.ConfigSchemaRepository,
+	schemaValidator *services.SchemaValidator,
+	versionManager *services.VersionManager,
+) *UpgradeConfigSchemaUseCase {
+	return &UpgradeConfigSchemaUseCase{
+		configRepo:      configRepo,
+		revisionRepo:    revisionRepo,
+		schemaRepo:      schemaRepo,
+		schemaValidator: schemaValidator,
+		versionManager:  versionManager,
+	}
+}
+
+// Execute upgrades a config to a schema version with optimistic locking
+func (uc *UpgradeConfigSchemaUseCase) Execute(ctx context.Context, req UpgradeConfigSchemaRequest) (*UpgradeConfigSchemaResponse, error) {
+	// Validate input
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	if req.Key == "" {
+		return nil, apperrors.BadRequest("config key is required")
+	}
+	if req.ExpectedVersion < 1 {
+		return nil, apperrors.BadRequest("expected version must be >= 1")
+	}
+	if req.SchemaVersion < 0 {
+		return nil, apperrors.BadRequest("schema version must be >= 1, or 0 for the latest")
+	}
+	if req.UpdatedByUserID == "" {
+		return nil, apperrors.BadRequest("user ID is required")
+	}
+
+	currentConfig, err := uc.configRepo.Get(ctx, req.ProjectID, req.Key)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get config")
+	}
+
+	// Validate optimistic lock
+	expectedVersion, err := valueobjects.NewVersion(req.ExpectedVersion)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeBadRequest, fmt.Sprintf("invalid expected version: %v", err))
+	}
+	currentVersion, err := valueobjects.NewVersion(currentConfig.Version)
+	if err != nil {
+		return nil, apperrors.Internal(err, "invalid current version")
+	}
+	if err := uc.versionManager.ValidateUpdate(expectedVersion, currentVersion, req.Key); err != nil {
+		return nil, err
+	}
+
+	// Resolve the target version
+	targetVersion := req.SchemaVersion
+	if targetVersion == 0 {
+		schema, err := uc.schemaRepo.GetByID(ctx, currentConfig.SchemaID)
+		if err != nil {
+			return nil, apperrors.Internal(err, "failed to get schema")
+		}
+		targetVersion = schema.LatestVersion
+	}
+	if targetVersion == currentConfig.SchemaVersion {
+		return nil, apperrors.BadRequest(fmt.Sprintf("config %s already uses schema version %d", req.Key, targetVersion))
+	}
+
+	target, err := uc.schemaRepo.GetVersion(ctx, currentConfig.SchemaID, targetVersion)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema version")
+	}
+
+	// The current content must satisfy the target version
+	if err := uc.schemaValidator.ValidateOrError(ctx, target.SchemaID, target.Version, target.SchemaContent, currentConfig.Content); err != nil {
+		return nil, fmt.Errorf("content validation failed: %w", err)
+	}
+
+	// Pin the config to the target version (version will be incremented)
+	updatedConfig, err := uc.configRepo.ChangeSchema(ctx, outbound.ChangeSchemaParams{
+		ProjectID:       req.ProjectID,
+		Key:             req.Key,
+		ExpectedVersion: req.ExpectedVersion,
+		SchemaID:        currentConfig.SchemaID,
+		SchemaVersion:   target.Version,
+		UpdatedByUserID: req.UpdatedByUserID,
+	})
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to upgrade config schema")
+	}
+
+	// Record the new config version so history has no gaps
+	newVersion, _ := valueobjects.NewVersion(updatedConfig.Version)
+	revisionEntity := entities.NewConfigRevision(
+		uuid.New().String(),
+		updatedConfig.ProjectID,
+		updatedConfig.Key,
+		newVersion,
+		updatedConfig.Content,
+		updatedConfig.UpdatedByUserID,
+	)
+
+	_, err = uc.revisionRepo.Create(ctx, outbound.CreateConfigRevisionParams{
+		ID:              revisionEntity.ID(),
+		ProjectID:       revisionEntity.ProjectID(),
+		ConfigKey:       revisionEntity.ConfigKey(),
+		Version:         revisionEntity.Version().Value(),
+		Content:         revisionEntity.Content(),
+		CreatedByUserID: revisionEntity.CreatedByUserID(),
+	})
+	if err != nil {
+		fmt.Printf("Warning: failed to create config revision: %v\n", err)
+	}
+
+	return &UpgradeConfigSchemaResponse{
+		ProjectID:             updatedConfig.ProjectID,
+		Key:                   updatedConfig.Key,
+		SchemaID:              updatedConfig.SchemaID,
+		PreviousSchemaVersion: currentConfig.SchemaVersion,
+		SchemaVersion:         updatedConfig.SchemaVersion,
+		Version:               updatedConfig.Version,
+		UpdatedByUserID:       updatedConfig.UpdatedByUserID,
+		UpdatedAt:             updatedConfig.UpdatedAt,
+	}, nil
+}
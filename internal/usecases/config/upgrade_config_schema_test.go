@@ -0,0 +1,175 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+func (m *MockConfigRepository) ChangeSchema(ctx context.Context, params outbound.ChangeSchemaParams) (*outbound.Config, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.Config), args.Error(1)
+}
+
+// Version 2 adds a required "host"; version 3 only tightens "port"
+const (
+	upgradeTestSchemaV2 = `{
+	"type": "object",
+	"properties": {"port": {"type": "integer"}, "host": {"type": "string"}},
+	"required": ["port", "host"]
+}`
+	upgradeTestSchemaV3 = `{
+	"type": "object",
+	"properties": {"port": {"type": "integer", "minimum": 1}},
+	"required": ["port"]
+}`
+)
+
+// upgradeTestConfig is at version 4, pinned to version 1 of schema-1,
+// whose latest version is 3
+var upgradeTestConfig = &outbound.Config{
+	ProjectID:     "proj-1",
+	Key:           "api",
+	SchemaID:      "schema-1",
+	SchemaVersion: 1,
+	Version:       4,
+	Content:       json.RawMessage(`{"port": 8080}`),
+}
+
+func TestUpgradeConfigSchemaUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name              string
+		schemaVersion     int32
+		expectedVersion   int64
+		wantSchemaVersion int32
+		wantErrorCode     apperrors.ErrorCode
+		wantInvalid       bool
+		wantConflict      bool
+		arrange           func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository)
+	}{
+		{
+			name:              "latest version",
+			expectedVersion:   4,
+			wantSchemaVersion: 3,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository) {
+				configRepo.On("Get", ctx, "proj-1", "api").Return(upgradeTestConfig, nil)
+				schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", SchemaContent: upgradeTestSchemaV3, LatestVersion: 3}, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(3)).Return(&outbound.ConfigSchemaVersion{SchemaID: "schema-1", Version: 3, SchemaContent: upgradeTestSchemaV3}, nil)
+				configRepo.On("ChangeSchema", ctx, outbound.ChangeSchemaParams{
+					ProjectID:       "proj-1",
+					Key:             "api",
+					ExpectedVersion: 4,
+					SchemaID:        "schema-1",
+					SchemaVersion:   3,
+					UpdatedByUserID: "user-1",
+				}).Return(&outbound.Config{
+					ProjectID:     "proj-1",
+					Key:           "api",
+					SchemaID:      "schema-1",
+					SchemaVersion: 3,
+					Version:       5,
+					Content:       json.RawMessage(`{"port": 8080}`),
+				}, nil)
+				revisionRepo.On("Create", ctx, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:            "content fails the target version",
+			schemaVersion:   2,
+			expectedVersion: 4,
+			wantInvalid:     true,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository) {
+				configRepo.On("Get", ctx, "proj-1", "api").Return(upgradeTestConfig, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(2)).Return(&outbound.ConfigSchemaVersion{SchemaID: "schema-1", Version: 2, SchemaContent: upgradeTestSchemaV2}, nil)
+			},
+		},
+		{
+			name:            "already pinned",
+			schemaVersion:   1,
+			expectedVersion: 4,
+			wantErrorCode:   apperrors.ErrCodeBadRequest,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository) {
+				configRepo.On("Get", ctx, "proj-1", "api").Return(upgradeTestConfig, nil)
+			},
+		},
+		{
+			name:            "negative version",
+			schemaVersion:   -1,
+			expectedVersion: 4,
+			wantErrorCode:   apperrors.ErrCodeBadRequest,
+		},
+		{
+			name:            "unknown version",
+			schemaVersion:   9,
+			expectedVersion: 4,
+			wantErrorCode:   apperrors.ErrCodeSchemaVersionNotFound,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository) {
+				configRepo.On("Get", ctx, "proj-1", "api").Return(upgradeTestConfig, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(9)).Return(nil, apperrors.New(apperrors.ErrCodeSchemaVersionNotFound, "config schema version not found"))
+			},
+		},
+		{
+			name:            "stale expected version",
+			expectedVersion: 3,
+			wantConflict:    true,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository, revisionRepo *MockConfigRevisionRepository) {
+				configRepo.On("Get", ctx, "proj-1", "api").Return(upgradeTestConfig, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			configRepo := new(MockConfigRepository)
+			schemaRepo := new(MockConfigSchemaRepository)
+			revisionRepo := new(MockConfigRevisionRepository)
+			if tt.arrange != nil {
+				tt.arrange(ctx, configRepo, schemaRepo, revisionRepo)
+			}
+			useCase := NewUpgradeConfigSchemaUseCase(configRepo, revisionRepo, schemaRepo, services.NewSchemaValidator(), services.NewVersionManager())
+
+			// Act
+			resp, err := useCase.Execute(ctx, UpgradeConfigSchemaRequest{
+				ProjectID:       "proj-1",
+				Key:             "api",
+				ExpectedVersion: tt.expectedVersion,
+				SchemaVersion:   tt.schemaVersion,
+				UpdatedByUserID: "user-1",
+			})
+
+			// Assert
+			configRepo.AssertExpectations(t)
+			schemaRepo.AssertExpectations(t)
+			switch {
+			case tt.wantErrorCode != "":
+				require.Error(t, err)
+				assert.True(t, apperrors.HasCode(err, tt.wantErrorCode), "got %v", err)
+			case tt.wantInvalid:
+				validationErr, ok := services.AsContentValidationError(err)
+				require.True(t, ok, "got %v", err)
+				assert.Equal(t, "required", validationErr.Errors[0].Keyword)
+			case tt.wantConflict:
+				assert.True(t, services.IsVersionConflict(err), "got %v", err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, int32(1), resp.PreviousSchemaVersion)
+				assert.Equal(t, tt.wantSchemaVersion, resp.SchemaVersion)
+				assert.Equal(t, int64(5), resp.Version)
+				return
+			}
+			configRepo.AssertNotCalled(t, "ChangeSchema", mock.Anything, mock.Anything)
+		})
+	}
+}
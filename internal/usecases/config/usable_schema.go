@@ -0,0 +1,22 @@
+package config
+
+import (
+	"context"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// usableSchema retrieves a schema a config of the project may use: a global
+// schema or one owned by the project. Another project's schema is reported
+// as not found, so its existence is not disclosed.
+func usableSchema(ctx context.Context, schemaRepo outbound.ConfigSchemaRepository, projectID, schemaID string) (*outbound.ConfigSchema, error) {
+	schema, err := schemaRepo.GetByID(ctx, schemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema")
+	}
+	if schema.ProjectID != "" && schema.ProjectID != projectID {
+		return nil, apperrors.New(apperrors.ErrCodeSchemaNotFound, "schema not found")
+	}
+	return schema, nil
+}
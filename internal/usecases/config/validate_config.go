@@ -0,0 +1,165 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// ValidateConfigRequest holds a candidate config to check without writing it.
+// ExpectedVersion is checked against the current version when set.
+// SchemaID is only needed for a config that does not exist yet.
+type ValidateConfigRequest struct {
+	ProjectID       string                    `json:"project_id"`
+	Key             string                    `json:"key"`
+	SchemaID        string                    `json:"schema_id"`
+	ExpectedVersion int64                     `json:"expected_version"`
+	Content         json.RawMessage           `json:"content"`
+	Format          valueobjects.ConfigFormat `json:"format,omitempty"`
+}
+
+// ValidateConfigResponse reports whether a write of the candidate would be
+// accepted, and what it would change.
+// Valid is false when the content fails the schema or the version conflicts.
+type ValidateConfigResponse struct {
+	Key             string                     `json:"key"`
+	SchemaID        string                     `json:"schema_id"`
+	SchemaVersion   int32                      `json:"schema_version"`
+	Valid           bool                       `json:"valid"`
+	Errors          []services.ValidationError `json:"errors"`
+	Exists          bool                       `json:"exists"`
+	CurrentVersion  int64                      `json:"current_version"`
+	VersionConflict string                     `json:"version_conflict,omitempty"`
+	Diff            []services.ConfigChange    `json:"diff"`
+}
+
+// ValidateConfigUseCase runs the checks of a config write as a dry run
+type ValidateConfigUseCase struct {
+	configRepo      outbound.ConfigRepository
+	schemaRepo      outbound.ConfigSchemaRepository
+	schemaValidator *services.SchemaValidator
+	versionManager  *services.VersionManager
+	parser          *services.ConfigParser
+	differ          *services.ConfigDiffer
+}
+
+// NewValidateConfigUseCase creates a new ValidateConfigUseCase
+func NewValidateConfigUseCase(
+	configRepo outbound.ConfigRepository,
+	schemaRepo outbound.ConfigSchemaRepository,
+	schemaValidator *services.SchemaValidator,
+	versionManager *services.VersionManager,
+) *ValidateConfigUseCase {
+	return &ValidateConfigUseCase{
+		configRepo:      configRepo,
+		schemaRepo:      schemaRepo,
+		schemaValidator: schemaValidator,
+		versionManager:  versionManager,
+		parser:          services.NewConfigParser(),
+		differ:          services.NewConfigDiffer(),
+	}
+}
+
+// Execute validates a candidate config against its schema, checks the
+// optimistic lock and diffs the candidate against the current content.
+// Nothing is written.
+func (uc *ValidateConfigUseCase) Execute(ctx context.Context, req ValidateConfigRequest) (*ValidateConfigResponse, error) {
+	// Validate input
+	if req.ProjectID == "" {
+		return nil, apperrors.BadRequest("project ID is required")
+	}
+	if req.Key == "" {
+		return nil, apperrors.BadRequest("config key is required")
+	}
+	if len(req.Content) == 0 {
+		return nil, apperrors.BadRequest("config content is required")
+	}
+	if req.ExpectedVersion < 0 {
+		return nil, apperrors.BadRequest("expected version must not be negative")
+	}
+
+	// Convert content written in another format to canonical JSON
+	content, err := uc.parser.ParseContent(req.Content, req.Format)
+	if err != nil {
+		return nil, apperrors.Invalid(err)
+	}
+	if err := uc.schemaValidator.ValidateContent(content); err != nil {
+		return nil, apperrors.Invalid(err)
+	}
+
+	resp := &ValidateConfigResponse{Key: req.Key}
+
+	// An existing config keeps its schema and is diffed against its content
+	exists, err := uc.configRepo.Exists(ctx, req.ProjectID, req.Key)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to check if config exists")
+	}
+	var currentContent json.RawMessage
+	var schemaContent string
+	if exists {
+		current, err := uc.configRepo.Get(ctx, req.ProjectID, req.Key)
+		if err != nil {
+			return nil, apperrors.Internal(err, "failed to get config")
+		}
+		if req.SchemaID != "" && req.SchemaID != current.SchemaID {
+			return nil, apperrors.BadRequest(fmt.Sprintf("config %s uses schema %s, not %s", req.Key, current.SchemaID, req.SchemaID))
+		}
+		resp.Exists = true
+		resp.SchemaID = current.SchemaID
+		resp.SchemaVersion = current.SchemaVersion
+		resp.CurrentVersion = current.Version
+		currentContent = current.Content
+
+		// An update is validated against the pinned schema version
+		schemaContent, err = pinnedSchemaContent(ctx, uc.schemaRepo, current)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if req.SchemaID == "" {
+			return nil, apperrors.BadRequest("schema ID is required for a config that does not exist")
+		}
+
+		// A new config would pin the latest schema version
+		schema, err := usableSchema(ctx, uc.schemaRepo, req.ProjectID, req.SchemaID)
+		if err != nil {
+			return nil, err
+		}
+		resp.SchemaID = schema.ID
+		resp.SchemaVersion = schema.LatestVersion
+		schemaContent = schema.SchemaContent
+	}
+
+	// Check the optimistic lock as an update would
+	if req.ExpectedVersion > 0 {
+		if !exists {
+			resp.VersionConflict = fmt.Sprintf("config '%s' does not exist, so expected version %d cannot match", req.Key, req.ExpectedVersion)
+		} else if err := uc.versionManager.ValidateUpdate(
+			valueobjects.MustNewVersion(req.ExpectedVersion),
+			valueobjects.MustNewVersion(resp.CurrentVersion),
+			req.Key,
+		); err != nil {
+			resp.VersionConflict = err.Error()
+		}
+	}
+
+	// Validate content against schema
+	result, err := uc.schemaValidator.Validate(ctx, resp.SchemaID, resp.SchemaVersion, schemaContent, content)
+	if err != nil {
+		return nil, err
+	}
+	resp.Errors = result.Errors
+	resp.Valid = result.Valid && resp.VersionConflict == ""
+
+	resp.Diff, err = uc.differ.Diff(currentContent, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
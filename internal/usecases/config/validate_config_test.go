@@ -0,0 +1,160 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// validateTestConfig is the stored config the update cases validate against
+var validateTestConfig = &outbound.Config{
+	Key:           "api",
+	SchemaID:      "schema-1",
+	SchemaVersion: 1,
+	Version:       4,
+	Content:       json.RawMessage(`{"port": 8080, "debug": true}`),
+}
+
+func TestValidateConfigUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              ValidateConfigRequest
+		wantValid        bool
+		wantErrors       int
+		wantConflict     bool
+		wantDiff         string
+		wantErrorContain string
+		wantErrorCode    apperrors.ErrorCode
+		arrange          func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository)
+	}{
+		{
+			name:      "valid update at the current version",
+			req:       ValidateConfigRequest{Key: "api", ExpectedVersion: 4, Content: json.RawMessage(`{"port": 9090, "debug": true}`)},
+			wantValid: true,
+			wantDiff:  `[{"op": "replace", "path": "/port", "old_value": 8080, "value": 9090}]`,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository) {
+				configRepo.On("Exists", ctx, "proj-1", "api").Return(true, nil)
+				configRepo.On("Get", ctx, "proj-1", "api").Return(validateTestConfig, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(1)).Return(&outbound.ConfigSchemaVersion{SchemaID: "schema-1", Version: 1, SchemaContent: importTestSchema}, nil)
+			},
+		},
+		{
+			name:         "stale expected version",
+			req:          ValidateConfigRequest{Key: "api", ExpectedVersion: 3, Content: json.RawMessage(`{"port": 8080}`)},
+			wantConflict: true,
+			wantDiff:     `[{"op": "remove", "path": "/debug", "old_value": true}]`,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository) {
+				configRepo.On("Exists", ctx, "proj-1", "api").Return(true, nil)
+				configRepo.On("Get", ctx, "proj-1", "api").Return(validateTestConfig, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(1)).Return(&outbound.ConfigSchemaVersion{SchemaID: "schema-1", Version: 1, SchemaContent: importTestSchema}, nil)
+			},
+		},
+		{
+			name:       "schema errors are listed",
+			req:        ValidateConfigRequest{Key: "api", Content: json.RawMessage(`"debug: yes\n"`), Format: "yaml"},
+			wantErrors: 2,
+			wantDiff:   `[{"op": "replace", "path": "/debug", "old_value": true, "value": "yes"}, {"op": "remove", "path": "/port", "old_value": 8080}]`,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository) {
+				configRepo.On("Exists", ctx, "proj-1", "api").Return(true, nil)
+				configRepo.On("Get", ctx, "proj-1", "api").Return(validateTestConfig, nil)
+				schemaRepo.On("GetVersion", ctx, "schema-1", int32(1)).Return(&outbound.ConfigSchemaVersion{SchemaID: "schema-1", Version: 1, SchemaContent: importTestSchema}, nil)
+			},
+		},
+		{
+			name:      "new config",
+			req:       ValidateConfigRequest{Key: "web", SchemaID: "schema-1", Content: json.RawMessage(`{"port": 80}`)},
+			wantValid: true,
+			wantDiff:  `[{"op": "add", "path": "", "value": {"port": 80}}]`,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository) {
+				configRepo.On("Exists", ctx, "proj-1", "web").Return(false, nil)
+				schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", SchemaContent: importTestSchema, LatestVersion: 1}, nil)
+			},
+		},
+		{
+			name:             "new config needs a schema",
+			req:              ValidateConfigRequest{Key: "web", Content: json.RawMessage(`{"port": 80}`)},
+			wantErrorContain: "schema ID is required",
+			wantErrorCode:    apperrors.ErrCodeBadRequest,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository) {
+				configRepo.On("Exists", ctx, "proj-1", "web").Return(false, nil)
+			},
+		},
+		{
+			name:             "unknown schema",
+			req:              ValidateConfigRequest{Key: "web", SchemaID: "missing", Content: json.RawMessage(`{"port": 80}`)},
+			wantErrorContain: "schema not found",
+			wantErrorCode:    apperrors.ErrCodeSchemaNotFound,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository) {
+				configRepo.On("Exists", ctx, "proj-1", "web").Return(false, nil)
+				schemaRepo.On("GetByID", ctx, "missing").Return(nil, apperrors.New(apperrors.ErrCodeSchemaNotFound, "config schema not found"))
+			},
+		},
+		{
+			name:      "new config with a schema of the project",
+			req:       ValidateConfigRequest{Key: "web", SchemaID: "own", Content: json.RawMessage(`{"port": 80}`)},
+			wantValid: true,
+			wantDiff:  `[{"op": "add", "path": "", "value": {"port": 80}}]`,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository) {
+				configRepo.On("Exists", ctx, "proj-1", "web").Return(false, nil)
+				schemaRepo.On("GetByID", ctx, "own").Return(&outbound.ConfigSchema{ID: "own", SchemaContent: importTestSchema, LatestVersion: 1, ProjectID: "proj-1"}, nil)
+			},
+		},
+		{
+			name:             "schema of another project",
+			req:              ValidateConfigRequest{Key: "web", SchemaID: "foreign", Content: json.RawMessage(`{"port": 80}`)},
+			wantErrorContain: "schema not found",
+			wantErrorCode:    apperrors.ErrCodeSchemaNotFound,
+			arrange: func(ctx context.Context, configRepo *MockConfigRepository, schemaRepo *MockConfigSchemaRepository) {
+				configRepo.On("Exists", ctx, "proj-1", "web").Return(false, nil)
+				schemaRepo.On("GetByID", ctx, "foreign").Return(&outbound.ConfigSchema{ID: "foreign", SchemaContent: importTestSchema, LatestVersion: 1, ProjectID: "proj-2"}, nil)
+			},
+		},
+		{
+			name:             "unparsable content",
+			req:              ValidateConfigRequest{Key: "api", Content: json.RawMessage(`"port: [\n"`), Format: "yaml"},
+			wantErrorContain: "invalid yaml",
+			wantErrorCode:    apperrors.ErrCodeBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			configRepo := new(MockConfigRepository)
+			schemaRepo := new(MockConfigSchemaRepository)
+			if tt.arrange != nil {
+				tt.arrange(ctx, configRepo, schemaRepo)
+			}
+			useCase := NewValidateConfigUseCase(configRepo, schemaRepo, services.NewSchemaValidator(), services.NewVersionManager())
+			tt.req.ProjectID = "proj-1"
+
+			// Act
+			resp, err := useCase.Execute(ctx, tt.req)
+
+			// Assert
+			configRepo.AssertExpectations(t)
+			schemaRepo.AssertExpectations(t)
+			if tt.wantErrorContain != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrorContain)
+				assert.True(t, apperrors.HasCode(err, tt.wantErrorCode), "error code of %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValid, resp.Valid)
+			assert.Len(t, resp.Errors, tt.wantErrors)
+			assert.Equal(t, tt.wantConflict, resp.VersionConflict != "")
+
+			diff, err := json.Marshal(resp.Diff)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantDiff, string(diff))
+		})
+	}
+}
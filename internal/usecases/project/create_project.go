@@ -8,16 +8,21 @@ import (
 	"github.com/vlone310/cfguardian/internal/domain/entities"
 	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
+// DefaultAPIKeyName is the name of the API key issued with a new project
+const DefaultAPIKeyName = "default"
+
 // CreateProjectRequest holds project creation data
 type CreateProjectRequest struct {
 	Name        string `json:"name"`
 	OwnerUserID string `json:"owner_user_id"`
 }
 
-// CreateProjectResponse holds created project data
+// CreateProjectResponse holds created project data.
+// APIKey is the project's default key; it is only ever returned here.
 type CreateProjectResponse struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
@@ -29,23 +34,29 @@ type CreateProjectResponse struct {
 // CreateProjectUseCase handles project creation
 type CreateProjectUseCase struct {
 	projectRepo     outbound.ProjectRepository
+	apiKeyRepo      outbound.APIKeyRepository
 	userRepo        outbound.UserRepository
 	roleRepo        outbound.RoleRepository
 	apiKeyGenerator *services.APIKeyGenerator
+	apiKeyHasher    *services.APIKeyHasher
 }
 
 // NewCreateProjectUseCase creates a new CreateProjectUseCase
 func NewCreateProjectUseCase(
 	projectRepo outbound.ProjectRepository,
+	apiKeyRepo outbound.APIKeyRepository,
 	userRepo outbound.UserRepository,
 	roleRepo outbound.RoleRepository,
 	apiKeyGenerator *services.APIKeyGenerator,
+	apiKeyHasher *services.APIKeyHasher,
 ) *CreateProjectUseCase {
 	return &CreateProjectUseCase{
 		projectRepo:     projectRepo,
+		apiKeyRepo:      apiKeyRepo,
 		userRepo:        userRepo,
 		roleRepo:        roleRepo,
 		apiKeyGenerator: apiKeyGenerator,
+		apiKeyHasher:    apiKeyHasher,
 	}
 }
 
@@ -53,49 +64,69 @@ func NewCreateProjectUseCase(
 func (uc *CreateProjectUseCase) Execute(ctx context.Context, req CreateProjectRequest) (*CreateProjectResponse, error) {
 	// Validate input
 	if req.Name == "" {
-		return nil, fmt.Errorf("project name is required")
+		return nil, apperrors.BadRequest("project name is required")
 	}
 	if req.OwnerUserID == "" {
-		return nil, fmt.Errorf("owner user ID is required")
+		return nil, apperrors.BadRequest("owner user ID is required")
 	}
 	
 	// Verify owner exists
 	ownerExists, err := uc.userRepo.Exists(ctx, req.OwnerUserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify owner exists: %w", err)
+		return nil, apperrors.Internal(err, "failed to verify owner exists")
 	}
 	if !ownerExists {
-		return nil, fmt.Errorf("owner user not found")
+		return nil, apperrors.New(apperrors.ErrCodeUserNotFound, "owner user not found")
 	}
 	
 	// Check if project name already exists
 	nameExists, err := uc.projectRepo.ExistsByName(ctx, req.Name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if project name exists: %w", err)
+		return nil, apperrors.Internal(err, "failed to check if project name exists")
 	}
 	if nameExists {
-		return nil, fmt.Errorf("project with name '%s' already exists", req.Name)
+		return nil, apperrors.New(apperrors.ErrCodeProjectExists, fmt.Sprintf("project with name '%s' already exists", req.Name))
 	}
 	
-	// Generate API key
+	// Generate API key; only its hash is stored
 	apiKey, err := uc.apiKeyGenerator.Generate()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate API key: %w", err)
+		return nil, apperrors.Internal(err, "failed to generate API key")
+	}
+	hashedKey, err := uc.apiKeyHasher.Hash(apiKey)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to hash API key")
 	}
 	
 	// Create domain entity
 	projectID := uuid.New().String()
-	projectEntity := entities.NewProject(projectID, req.Name, apiKey, req.OwnerUserID)
+	projectEntity := entities.NewProject(projectID, req.Name, req.OwnerUserID)
 	
 	// Persist to repository
 	project, err := uc.projectRepo.Create(ctx, outbound.CreateProjectParams{
 		ID:          projectEntity.ID(),
 		Name:        projectEntity.Name(),
-		APIKey:      projectEntity.APIKey().Value(),
 		OwnerUserID: projectEntity.OwnerUserID(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create project: %w", err)
+		return nil, apperrors.Internal(err, "failed to create project")
+	}
+	
+	// Register the generated key as the project's first, unrestricted API key
+	_, err = uc.apiKeyRepo.Create(ctx, outbound.CreateAPIKeyParams{
+		ID:              uuid.New().String(),
+		ProjectID:       project.ID,
+		Name:            DefaultAPIKeyName,
+		LookupPrefix:    hashedKey.LookupPrefix(),
+		KeyHash:         hashedKey.Hash(),
+		KeySalt:         hashedKey.Salt(),
+		KeyHint:         hashedKey.Hint(),
+		HashVersion:     hashedKey.Version(),
+		KeyPrefixes:     []string{},
+		CreatedByUserID: req.OwnerUserID,
+	})
+	if err != nil {
+		return nil, apperrors.Internal(err, "project created but failed to register API key")
 	}
 	
 	// Automatically assign admin role to owner
@@ -108,13 +139,13 @@ func (uc *CreateProjectUseCase) Execute(ctx context.Context, req CreateProjectRe
 		// Log error but don't fail project creation
 		// The project is created, but owner doesn't have explicit admin role yet
 		// This can be fixed manually
-		return nil, fmt.Errorf("project created but failed to assign admin role: %w", err)
+		return nil, apperrors.Internal(err, "project created but failed to assign admin role")
 	}
 	
 	return &CreateProjectResponse{
 		ID:          project.ID,
 		Name:        project.Name,
-		APIKey:      project.APIKey,
+		APIKey:      apiKey.Value(),
 		OwnerUserID: project.OwnerUserID,
 		CreatedAt:   project.CreatedAt,
 	}, nil
@@ -3,11 +3,13 @@ package project
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -32,14 +34,6 @@ func (m *MockProjectRepository) GetByID(ctx context.Context, id string) (*outbou
 	return args.Get(0).(*outbound.Project), args.Error(1)
 }
 
-func (m *MockProjectRepository) GetByAPIKey(ctx context.Context, apiKey string) (*outbound.Project, error) {
-	args := m.Called(ctx, apiKey)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*outbound.Project), args.Error(1)
-}
-
 func (m *MockProjectRepository) Update(ctx context.Context, params outbound.UpdateProjectParams) (*outbound.Project, error) {
 	args := m.Called(ctx, params)
 	if args.Get(0) == nil {
@@ -79,11 +73,6 @@ func (m *MockProjectRepository) ExistsByName(ctx context.Context, name string) (
 	return args.Get(0).(bool), args.Error(1)
 }
 
-func (m *MockProjectRepository) ExistsByAPIKey(ctx context.Context, apiKey string) (bool, error) {
-	args := m.Called(ctx, apiKey)
-	return args.Get(0).(bool), args.Error(1)
-}
-
 func (m *MockProjectRepository) Count(ctx context.Context) (int64, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(int64), args.Error(1)
@@ -266,15 +255,96 @@ func (m *MockRoleRepository) HasMinimumRole(ctx context.Context, userID, project
 	return args.Get(0).(bool), args.Error(1)
 }
 
+// MockAPIKeyRepository for the project's default API key
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, params outbound.CreateAPIKeyParams) (*outbound.APIKey, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, projectID, id string) (*outbound.APIKey, error) {
+	args := m.Called(ctx, projectID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByLookupPrefix(ctx context.Context, lookupPrefix string) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx, lookupPrefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListActive(ctx context.Context) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByProject(ctx context.Context, projectID string) ([]*outbound.APIKey, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, params outbound.UpdateAPIKeyParams) (*outbound.APIKey, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, projectID, id string) (*outbound.APIKey, error) {
+	args := m.Called(ctx, projectID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) SetExpiry(ctx context.Context, projectID, id string, expiresAt time.Time) (*outbound.APIKey, error) {
+	args := m.Called(ctx, projectID, id, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) UpdateHash(ctx context.Context, id string, keyHash []byte, hashVersion int) error {
+	args := m.Called(ctx, id, keyHash, hashVersion)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
 func TestCreateProjectUseCase_Execute_Success(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
 	mockProjectRepo := new(MockProjectRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockRoleRepo := new(MockRoleRepository)
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
 	apiKeyGen := services.NewAPIKeyGenerator()
+	apiKeyHasher := services.NewAPIKeyHasher("test-pepper")
 	
-	useCase := NewCreateProjectUseCase(mockProjectRepo, mockUserRepo, mockRoleRepo, apiKeyGen)
+	useCase := NewCreateProjectUseCase(mockProjectRepo, mockAPIKeyRepo, mockUserRepo, mockRoleRepo, apiKeyGen, apiKeyHasher)
 	
 	// Mock expectations
 	mockUserRepo.On("Exists", ctx, "user-123").Return(true, nil)
@@ -282,9 +352,16 @@ func TestCreateProjectUseCase_Execute_Success(t *testing.T) {
 	mockProjectRepo.On("Create", ctx, mock.AnythingOfType("outbound.CreateProjectParams")).Return(&outbound.Project{
 		ID:          "proj-123",
 		Name:        "My Project",
-		APIKey:      "cfg_abcd1234567890abcdef1234567890ab",
 		OwnerUserID: "user-123",
 	}, nil)
+	var keyParams outbound.CreateAPIKeyParams
+	mockAPIKeyRepo.On("Create", ctx, mock.MatchedBy(func(params outbound.CreateAPIKeyParams) bool {
+		return params.ProjectID == "proj-123" && params.Name == DefaultAPIKeyName
+	})).
+		Run(func(args mock.Arguments) {
+			keyParams = args.Get(1).(outbound.CreateAPIKeyParams)
+		}).
+		Return(&outbound.APIKey{ID: "key-123", ProjectID: "proj-123"}, nil)
 	mockRoleRepo.On("Assign", ctx, mock.AnythingOfType("outbound.AssignRoleParams")).Return(&outbound.Role{
 		UserID:    "user-123",
 		ProjectID: "proj-123",
@@ -311,7 +388,14 @@ func TestCreateProjectUseCase_Execute_Success(t *testing.T) {
 	assert.Contains(t, response.APIKey, "cfg_")
 	assert.Equal(t, 36, len(response.APIKey))
 	
+	// Only the key's hash is stored
+	hashedKey, err := valueobjects.NewHashedAPIKey(keyParams.LookupPrefix, keyParams.KeySalt, keyParams.KeyHash, keyParams.KeyHint, keyParams.HashVersion)
+	require.NoError(t, err)
+	assert.True(t, apiKeyHasher.Verify(valueobjects.MustNewAPIKey(response.APIKey), hashedKey))
+	assert.NotContains(t, string(keyParams.KeyHash), response.APIKey)
+	
 	mockProjectRepo.AssertExpectations(t)
+	mockAPIKeyRepo.AssertExpectations(t)
 	mockRoleRepo.AssertExpectations(t)
 }
 
@@ -321,9 +405,11 @@ func TestCreateProjectUseCase_Execute_EmptyName(t *testing.T) {
 	mockProjectRepo := new(MockProjectRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockRoleRepo := new(MockRoleRepository)
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
 	apiKeyGen := services.NewAPIKeyGenerator()
+	apiKeyHasher := services.NewAPIKeyHasher("test-pepper")
 	
-	useCase := NewCreateProjectUseCase(mockProjectRepo, mockUserRepo, mockRoleRepo, apiKeyGen)
+	useCase := NewCreateProjectUseCase(mockProjectRepo, mockAPIKeyRepo, mockUserRepo, mockRoleRepo, apiKeyGen, apiKeyHasher)
 	
 	// Act
 	request := CreateProjectRequest{
@@ -349,9 +435,11 @@ func TestCreateProjectUseCase_Execute_EmptyOwnerID(t *testing.T) {
 	mockProjectRepo := new(MockProjectRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockRoleRepo := new(MockRoleRepository)
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
 	apiKeyGen := services.NewAPIKeyGenerator()
+	apiKeyHasher := services.NewAPIKeyHasher("test-pepper")
 	
-	useCase := NewCreateProjectUseCase(mockProjectRepo, mockUserRepo, mockRoleRepo, apiKeyGen)
+	useCase := NewCreateProjectUseCase(mockProjectRepo, mockAPIKeyRepo, mockUserRepo, mockRoleRepo, apiKeyGen, apiKeyHasher)
 	
 	// Act
 	request := CreateProjectRequest{
@@ -377,9 +465,11 @@ func TestCreateProjectUseCase_Execute_DuplicateName(t *testing.T) {
 	mockProjectRepo := new(MockProjectRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockRoleRepo := new(MockRoleRepository)
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
 	apiKeyGen := services.NewAPIKeyGenerator()
+	apiKeyHasher := services.NewAPIKeyHasher("test-pepper")
 	
-	useCase := NewCreateProjectUseCase(mockProjectRepo, mockUserRepo, mockRoleRepo, apiKeyGen)
+	useCase := NewCreateProjectUseCase(mockProjectRepo, mockAPIKeyRepo, mockUserRepo, mockRoleRepo, apiKeyGen, apiKeyHasher)
 	
 	// Mock expectations - owner exists
 	mockUserRepo.On("Exists", ctx, "user-123").Return(true, nil)
@@ -411,9 +501,11 @@ func TestCreateProjectUseCase_Execute_AutoAssignsAdminRole(t *testing.T) {
 	mockProjectRepo := new(MockProjectRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockRoleRepo := new(MockRoleRepository)
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
 	apiKeyGen := services.NewAPIKeyGenerator()
+	apiKeyHasher := services.NewAPIKeyHasher("test-pepper")
 	
-	useCase := NewCreateProjectUseCase(mockProjectRepo, mockUserRepo, mockRoleRepo, apiKeyGen)
+	useCase := NewCreateProjectUseCase(mockProjectRepo, mockAPIKeyRepo, mockUserRepo, mockRoleRepo, apiKeyGen, apiKeyHasher)
 	
 	// Mock expectations
 	mockUserRepo.On("Exists", ctx, "user-123").Return(true, nil)
@@ -421,9 +513,9 @@ func TestCreateProjectUseCase_Execute_AutoAssignsAdminRole(t *testing.T) {
 	mockProjectRepo.On("Create", ctx, mock.AnythingOfType("outbound.CreateProjectParams")).Return(&outbound.Project{
 		ID:          "proj-123",
 		Name:        "New Project",
-		APIKey:      "cfg_abcd1234567890abcdef1234567890ab",
 		OwnerUserID: "user-123",
 	}, nil)
+	mockAPIKeyRepo.On("Create", ctx, mock.AnythingOfType("outbound.CreateAPIKeyParams")).Return(&outbound.APIKey{ID: "key-123"}, nil)
 	
 	// Capture the role assignment params
 	var assignedRoleParams outbound.AssignRoleParams
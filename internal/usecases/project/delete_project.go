@@ -2,8 +2,8 @@ package project
 
 import (
 	"context"
-	"fmt"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -27,16 +27,16 @@ func NewDeleteProjectUseCase(projectRepo outbound.ProjectRepository) *DeleteProj
 // Execute deletes a project
 func (uc *DeleteProjectUseCase) Execute(ctx context.Context, req DeleteProjectRequest) error {
 	if req.ProjectID == "" {
-		return fmt.Errorf("project ID is required")
+		return apperrors.BadRequest("project ID is required")
 	}
 	
 	// Check if project exists
 	exists, err := uc.projectRepo.Exists(ctx, req.ProjectID)
 	if err != nil {
-		return fmt.Errorf("failed to check if project exists: %w", err)
+		return apperrors.Internal(err, "failed to check if project exists")
 	}
 	if !exists {
-		return fmt.Errorf("project not found")
+		return apperrors.New(apperrors.ErrCodeProjectNotFound, "project not found")
 	}
 	
 	// Note: Foreign key CASCADE will automatically delete:
@@ -46,7 +46,7 @@ func (uc *DeleteProjectUseCase) Execute(ctx context.Context, req DeleteProjectRe
 	
 	// Delete project (cascading deletes handled by database)
 	if err := uc.projectRepo.Delete(ctx, req.ProjectID); err != nil {
-		return fmt.Errorf("failed to delete project: %w", err)
+		return apperrors.Internal(err, "failed to delete project")
 	}
 	
 	return nil
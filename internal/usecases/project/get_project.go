@@ -2,8 +2,8 @@ package project
 
 import (
 	"context"
-	"fmt"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -16,7 +16,6 @@ type GetProjectRequest struct {
 type GetProjectResponse struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
-	APIKey      string `json:"api_key"`
 	OwnerUserID string `json:"owner_user_id"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
@@ -37,19 +36,18 @@ func NewGetProjectUseCase(projectRepo outbound.ProjectRepository) *GetProjectUse
 // Execute retrieves a project by ID
 func (uc *GetProjectUseCase) Execute(ctx context.Context, req GetProjectRequest) (*GetProjectResponse, error) {
 	if req.ProjectID == "" {
-		return nil, fmt.Errorf("project ID is required")
+		return nil, apperrors.BadRequest("project ID is required")
 	}
 	
 	// Get project from repository
 	project, err := uc.projectRepo.GetByID(ctx, req.ProjectID)
 	if err != nil {
-		return nil, fmt.Errorf("project not found: %w", err)
+		return nil, apperrors.Internal(err, "failed to get project")
 	}
 	
 	return &GetProjectResponse{
 		ID:          project.ID,
 		Name:        project.Name,
-		APIKey:      project.APIKey,
 		OwnerUserID: project.OwnerUserID,
 		CreatedAt:   project.CreatedAt,
 		UpdatedAt:   project.UpdatedAt,
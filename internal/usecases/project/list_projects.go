@@ -2,8 +2,8 @@ package project
 
 import (
 	"context"
-	"fmt"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -11,7 +11,6 @@ import (
 type ProjectListItem struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
-	APIKey      string `json:"api_key"`
 	OwnerUserID string `json:"owner_user_id"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
@@ -51,23 +50,23 @@ func (uc *ListProjectsUseCase) Execute(ctx context.Context, req ListProjectsRequ
 	if req.OwnerUserID != nil && *req.OwnerUserID != "" {
 		projects, err = uc.projectRepo.ListByOwner(ctx, *req.OwnerUserID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list projects by owner: %w", err)
+			return nil, apperrors.Internal(err, "failed to list projects by owner")
 		}
 		
 		count, err = uc.projectRepo.CountByOwner(ctx, *req.OwnerUserID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to count projects by owner: %w", err)
+			return nil, apperrors.Internal(err, "failed to count projects by owner")
 		}
 	} else {
 		// List all projects
 		projects, err = uc.projectRepo.List(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list projects: %w", err)
+			return nil, apperrors.Internal(err, "failed to list projects")
 		}
 		
 		count, err = uc.projectRepo.Count(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to count projects: %w", err)
+			return nil, apperrors.Internal(err, "failed to count projects")
 		}
 	}
 	
@@ -77,7 +76,6 @@ func (uc *ListProjectsUseCase) Execute(ctx context.Context, req ListProjectsRequ
 		items[i] = &ProjectListItem{
 			ID:          project.ID,
 			Name:        project.Name,
-			APIKey:      project.APIKey,
 			OwnerUserID: project.OwnerUserID,
 			CreatedAt:   project.CreatedAt,
 			UpdatedAt:   project.UpdatedAt,
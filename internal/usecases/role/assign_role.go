@@ -6,6 +6,7 @@ import (
 
 	"github.com/vlone310/cfguardian/internal/domain/entities"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -49,37 +50,37 @@ func NewAssignRoleUseCase(
 func (uc *AssignRoleUseCase) Execute(ctx context.Context, req AssignRoleRequest) (*AssignRoleResponse, error) {
 	// Validate input
 	if req.UserID == "" {
-		return nil, fmt.Errorf("user ID is required")
+		return nil, apperrors.BadRequest("user ID is required")
 	}
 	if req.ProjectID == "" {
-		return nil, fmt.Errorf("project ID is required")
+		return nil, apperrors.BadRequest("project ID is required")
 	}
 	if req.RoleLevel == "" {
-		return nil, fmt.Errorf("role level is required")
+		return nil, apperrors.BadRequest("role level is required")
 	}
 	
 	// Validate role level
 	roleLevel, err := valueobjects.NewRoleLevel(req.RoleLevel)
 	if err != nil {
-		return nil, fmt.Errorf("invalid role level: %w", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeBadRequest, fmt.Sprintf("invalid role level: %v", err))
 	}
 	
 	// Verify user exists
 	userExists, err := uc.userRepo.Exists(ctx, req.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify user exists: %w", err)
+		return nil, apperrors.Internal(err, "failed to verify user exists")
 	}
 	if !userExists {
-		return nil, fmt.Errorf("user not found")
+		return nil, apperrors.New(apperrors.ErrCodeUserNotFound, "user not found")
 	}
 	
 	// Verify project exists
 	projectExists, err := uc.projectRepo.Exists(ctx, req.ProjectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify project exists: %w", err)
+		return nil, apperrors.Internal(err, "failed to verify project exists")
 	}
 	if !projectExists {
-		return nil, fmt.Errorf("project not found")
+		return nil, apperrors.New(apperrors.ErrCodeProjectNotFound, "project not found")
 	}
 	
 	// Create domain entity
@@ -92,7 +93,7 @@ func (uc *AssignRoleUseCase) Execute(ctx context.Context, req AssignRoleRequest)
 		RoleLevel: outbound.RoleLevel(roleEntity.Level()),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to assign role: %w", err)
+		return nil, apperrors.Internal(err, "failed to assign role")
 	}
 	
 	return &AssignRoleResponse{
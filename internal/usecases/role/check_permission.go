@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -37,19 +38,19 @@ func NewCheckPermissionUseCase(roleRepo outbound.RoleRepository) *CheckPermissio
 func (uc *CheckPermissionUseCase) Execute(ctx context.Context, req CheckPermissionRequest) (*CheckPermissionResponse, error) {
 	// Validate input
 	if req.UserID == "" {
-		return nil, fmt.Errorf("user ID is required")
+		return nil, apperrors.BadRequest("user ID is required")
 	}
 	if req.ProjectID == "" {
-		return nil, fmt.Errorf("project ID is required")
+		return nil, apperrors.BadRequest("project ID is required")
 	}
 	if req.RequiredRoleLevel == "" {
-		return nil, fmt.Errorf("required role level is required")
+		return nil, apperrors.BadRequest("required role level is required")
 	}
 	
 	// Validate required role level
 	requiredLevel, err := valueobjects.NewRoleLevel(req.RequiredRoleLevel)
 	if err != nil {
-		return nil, fmt.Errorf("invalid required role level: %w", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeBadRequest, fmt.Sprintf("invalid required role level: %v", err))
 	}
 	
 	// Get user's role in the project
@@ -65,7 +66,7 @@ func (uc *CheckPermissionUseCase) Execute(ctx context.Context, req CheckPermissi
 	// Convert repository role level to domain value object
 	userLevel, err := valueobjects.NewRoleLevel(string(userRoleLevel))
 	if err != nil {
-		return nil, fmt.Errorf("invalid user role level from database: %w", err)
+		return nil, apperrors.Internal(err, "invalid user role level from database")
 	}
 	
 	// Check if user's role includes the required level
@@ -89,7 +90,7 @@ func (uc *CheckPermissionUseCase) RequirePermission(ctx context.Context, userID,
 	}
 	
 	if !resp.Allowed {
-		return fmt.Errorf("permission denied: requires %s role", requiredLevel)
+		return apperrors.New(apperrors.ErrCodeInsufficientRole, fmt.Sprintf("permission denied: requires %s role", requiredLevel))
 	}
 	
 	return nil
@@ -2,8 +2,8 @@ package role
 
 import (
 	"context"
-	"fmt"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -29,24 +29,24 @@ func NewRevokeRoleUseCase(roleRepo outbound.RoleRepository) *RevokeRoleUseCase {
 func (uc *RevokeRoleUseCase) Execute(ctx context.Context, req RevokeRoleRequest) error {
 	// Validate input
 	if req.UserID == "" {
-		return fmt.Errorf("user ID is required")
+		return apperrors.BadRequest("user ID is required")
 	}
 	if req.ProjectID == "" {
-		return fmt.Errorf("project ID is required")
+		return apperrors.BadRequest("project ID is required")
 	}
 	
 	// Check if role exists
 	exists, err := uc.roleRepo.Exists(ctx, req.UserID, req.ProjectID)
 	if err != nil {
-		return fmt.Errorf("failed to check if role exists: %w", err)
+		return apperrors.Internal(err, "failed to check if role exists")
 	}
 	if !exists {
-		return fmt.Errorf("role not found")
+		return apperrors.New(apperrors.ErrCodeRoleNotFound, "role not found")
 	}
 	
 	// Revoke role
 	if err := uc.roleRepo.Revoke(ctx, req.UserID, req.ProjectID); err != nil {
-		return fmt.Errorf("failed to revoke role: %w", err)
+		return apperrors.Internal(err, "failed to revoke role")
 	}
 	
 	return nil
@@ -7,13 +7,16 @@ import (
 	"github.com/google/uuid"
 	"github.com/vlone310/cfguardian/internal/domain/entities"
 	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
-// CreateSchemaRequest holds schema creation data
+// CreateSchemaRequest holds schema creation data. An empty ProjectID
+// creates a global schema.
 type CreateSchemaRequest struct {
 	Name          string `json:"name"`
 	SchemaContent string `json:"schema_content"`
+	ProjectID     string `json:"project_id,omitempty"`
 	CreatedByUserID string `json:"created_by_user_id"`
 }
 
@@ -22,6 +25,8 @@ type CreateSchemaResponse struct {
 	ID              string `json:"id"`
 	Name            string `json:"name"`
 	SchemaContent   string `json:"schema_content"`
+	LatestVersion   int32  `json:"latest_version"`
+	ProjectID       string `json:"project_id,omitempty"`
 	CreatedByUserID string `json:"created_by_user_id"`
 	CreatedAt       string `json:"created_at"`
 }
@@ -47,27 +52,28 @@ func NewCreateSchemaUseCase(
 func (uc *CreateSchemaUseCase) Execute(ctx context.Context, req CreateSchemaRequest) (*CreateSchemaResponse, error) {
 	// Validate input
 	if req.Name == "" {
-		return nil, fmt.Errorf("schema name is required")
+		return nil, apperrors.BadRequest("schema name is required")
 	}
 	if req.SchemaContent == "" {
-		return nil, fmt.Errorf("schema content is required")
+		return nil, apperrors.BadRequest("schema content is required")
 	}
 	if req.CreatedByUserID == "" {
-		return nil, fmt.Errorf("creator user ID is required")
+		return nil, apperrors.BadRequest("creator user ID is required")
 	}
 	
 	// Validate that the schema content is a valid JSON Schema
-	if err := uc.schemaValidator.ValidateSchema(req.SchemaContent); err != nil {
-		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	references, err := validateSchemaContent(ctx, uc.schemaValidator, req.ProjectID, req.Name, req.SchemaContent)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Check if schema name already exists
-	nameExists, err := uc.schemaRepo.ExistsByName(ctx, req.Name)
+	// Check if the name is taken among the schemas the project sees
+	nameExists, err := uc.schemaRepo.ExistsByName(ctx, req.ProjectID, req.Name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if schema name exists: %w", err)
+		return nil, apperrors.Internal(err, "failed to check if schema name exists")
 	}
 	if nameExists {
-		return nil, fmt.Errorf("schema with name '%s' already exists", req.Name)
+		return nil, apperrors.New(apperrors.ErrCodeSchemaExists, fmt.Sprintf("schema with name '%s' already exists", req.Name))
 	}
 	
 	// Create domain entity
@@ -79,7 +85,7 @@ func (uc *CreateSchemaUseCase) Execute(ctx context.Context, req CreateSchemaRequ
 		req.CreatedByUserID,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create schema entity: %w", err)
+		return nil, apperrors.Invalid(err)
 	}
 	
 	// Persist to repository
@@ -87,16 +93,20 @@ func (uc *CreateSchemaUseCase) Execute(ctx context.Context, req CreateSchemaRequ
 		ID:              schemaEntity.ID(),
 		Name:            schemaEntity.Name(),
 		SchemaContent:   schemaEntity.SchemaContent(),
+		ProjectID:       req.ProjectID,
+		References:      references,
 		CreatedByUserID: schemaEntity.CreatedByUserID(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create schema: %w", err)
+		return nil, apperrors.Internal(err, "failed to create schema")
 	}
 	
 	return &CreateSchemaResponse{
 		ID:              schema.ID,
 		Name:            schema.Name,
 		SchemaContent:   schema.SchemaContent,
+		LatestVersion:   schema.LatestVersion,
+		ProjectID:       schema.ProjectID,
 		CreatedByUserID: schema.CreatedByUserID,
 		CreatedAt:       schema.CreatedAt,
 	}, nil
@@ -2,8 +2,9 @@ package schema
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -14,13 +15,24 @@ type DeleteSchemaRequest struct {
 
 // DeleteSchemaUseCase handles config schema deletion
 type DeleteSchemaUseCase struct {
-	schemaRepo outbound.ConfigSchemaRepository
+	schemaRepo      outbound.ConfigSchemaRepository
+	configRepo      outbound.ConfigRepository
+	schemaValidator *services.SchemaValidator
+	usageUseCase    *GetSchemaUsageUseCase
 }
 
 // NewDeleteSchemaUseCase creates a new DeleteSchemaUseCase
-func NewDeleteSchemaUseCase(schemaRepo outbound.ConfigSchemaRepository) *DeleteSchemaUseCase {
+func NewDeleteSchemaUseCase(
+	schemaRepo outbound.ConfigSchemaRepository,
+	configRepo outbound.ConfigRepository,
+	schemaValidator *services.SchemaValidator,
+	usageUseCase *GetSchemaUsageUseCase,
+) *DeleteSchemaUseCase {
 	return &DeleteSchemaUseCase{
-		schemaRepo: schemaRepo,
+		schemaRepo:      schemaRepo,
+		configRepo:      configRepo,
+		schemaValidator: schemaValidator,
+		usageUseCase:    usageUseCase,
 	}
 }
 
@@ -28,32 +40,41 @@ func NewDeleteSchemaUseCase(schemaRepo outbound.ConfigSchemaRepository) *DeleteS
 func (uc *DeleteSchemaUseCase) Execute(ctx context.Context, req DeleteSchemaRequest) error {
 	// Validate input
 	if req.SchemaID == "" {
-		return fmt.Errorf("schema ID is required")
+		return apperrors.BadRequest("schema ID is required")
 	}
 	
 	// Check if schema exists
-	exists, err := uc.schemaRepo.Exists(ctx, req.SchemaID)
+	schema, err := uc.schemaRepo.GetByID(ctx, req.SchemaID)
 	if err != nil {
-		return fmt.Errorf("failed to check if schema exists: %w", err)
-	}
-	if !exists {
-		return fmt.Errorf("schema not found")
+		return apperrors.Internal(err, "failed to get schema")
 	}
 	
-	// Check if any configs are using this schema
-	configsUsing, err := uc.schemaRepo.CountConfigsUsing(ctx, req.SchemaID)
+	// Check if any configs are using this schema; the refusal carries the
+	// usage report so the caller knows what to move first
+	configsUsing, err := uc.configRepo.CountBySchema(ctx, req.SchemaID)
 	if err != nil {
-		return fmt.Errorf("failed to check configs using schema: %w", err)
+		return apperrors.Internal(err, "failed to check configs using schema")
 	}
 	if configsUsing > 0 {
-		return fmt.Errorf("cannot delete schema: %d config(s) are still using it", configsUsing)
+		report, err := uc.usageUseCase.report(ctx, schema)
+		if err != nil {
+			return err
+		}
+		return usageConflict("delete", report)
+	}
+	
+	// Check if other schemas reference this one
+	if err := checkNotReferenced(ctx, uc.schemaRepo, req.SchemaID, "delete"); err != nil {
+		return err
 	}
 	
 	// Delete schema
-	// Note: Foreign key RESTRICT on configs.schema_id will prevent deletion if configs exist
+	// Note: the foreign key on config_schema_references.referenced_schema_id
+	// will prevent deletion if referencing schemas exist
 	if err := uc.schemaRepo.Delete(ctx, req.SchemaID); err != nil {
-		return fmt.Errorf("failed to delete schema: %w", err)
+		return apperrors.Internal(err, "failed to delete schema")
 	}
+	uc.schemaValidator.Invalidate(req.SchemaID)
 	
 	return nil
 }
@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"context"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// SchemaScope tells who governs a schema: the roles of its project, or
+// platform admins when ProjectID is empty and the schema is global
+type SchemaScope struct {
+	SchemaID  string `json:"schema_id"`
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+// IsGlobal reports whether the schema belongs to no project
+func (s *SchemaScope) IsGlobal() bool {
+	return s.ProjectID == ""
+}
+
+// GetSchemaScopeUseCase resolves the scope of a schema for authorization
+type GetSchemaScopeUseCase struct {
+	schemaRepo outbound.ConfigSchemaRepository
+}
+
+// NewGetSchemaScopeUseCase creates a new GetSchemaScopeUseCase
+func NewGetSchemaScopeUseCase(schemaRepo outbound.ConfigSchemaRepository) *GetSchemaScopeUseCase {
+	return &GetSchemaScopeUseCase{
+		schemaRepo: schemaRepo,
+	}
+}
+
+// Execute retrieves the scope of a schema
+func (uc *GetSchemaScopeUseCase) Execute(ctx context.Context, schemaID string) (*SchemaScope, error) {
+	if schemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+
+	schema, err := uc.schemaRepo.GetByID(ctx, schemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema")
+	}
+
+	return &SchemaScope{
+		SchemaID:  schema.ID,
+		ProjectID: schema.ProjectID,
+	}, nil
+}
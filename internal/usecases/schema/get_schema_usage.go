@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// maxUsageKeysInMessage bounds how many configs a refused deletion names in
+// its message; the full report is attached to the error
+const maxUsageKeysInMessage = 10
+
+// SchemaUsageReport lists the configs of every project that use a schema,
+// whatever version they pin, and whether each would pass the latest version
+// after the migrations that take it there
+type SchemaUsageReport struct {
+	SchemaID      string             `json:"schema_id"`
+	SchemaName    string             `json:"schema_name"`
+	LatestVersion int32              `json:"latest_version"`
+	Summary       SchemaUsageSummary `json:"summary"`
+	Configs       []SchemaUsageEntry `json:"configs"`
+}
+
+// SchemaUsageSummary counts the configs of a usage report
+type SchemaUsageSummary struct {
+	Configs         int           `json:"configs"`
+	Projects        int           `json:"projects"`
+	OnLatestVersion int           `json:"on_latest_version"`
+	Valid           int           `json:"valid"`
+	Invalid         int           `json:"invalid"`
+	ByVersion       map[int32]int `json:"by_version"`
+}
+
+// SchemaUsageEntry is a config using a schema. Revision is the Raft log
+// index of its last change, made by UpdatedByUserID.
+type SchemaUsageEntry struct {
+	ProjectID       string                     `json:"project_id"`
+	Key             string                     `json:"key"`
+	SchemaVersion   int32                      `json:"schema_version"`
+	Version         int64                      `json:"version"`
+	Revision        int64                      `json:"revision"`
+	UpdatedByUserID string                     `json:"updated_by_user_id"`
+	UpdatedByEmail  string                     `json:"updated_by_email,omitempty"`
+	Valid           bool                       `json:"valid"`
+	Errors          []services.ValidationError `json:"errors,omitempty"`
+}
+
+// GetSchemaUsageUseCase reports the configs using a schema
+type GetSchemaUsageUseCase struct {
+	schemaRepo      outbound.ConfigSchemaRepository
+	configRepo      outbound.ConfigRepository
+	userRepo        outbound.UserRepository
+	schemaValidator *services.SchemaValidator
+	migrator        *services.ConfigMigrator
+}
+
+// NewGetSchemaUsageUseCase creates a new GetSchemaUsageUseCase
+func NewGetSchemaUsageUseCase(
+	schemaRepo outbound.ConfigSchemaRepository,
+	configRepo outbound.ConfigRepository,
+	userRepo outbound.UserRepository,
+	schemaValidator *services.SchemaValidator,
+	migrator *services.ConfigMigrator,
+) *GetSchemaUsageUseCase {
+	return &GetSchemaUsageUseCase{
+		schemaRepo:      schemaRepo,
+		configRepo:      configRepo,
+		userRepo:        userRepo,
+		schemaValidator: schemaValidator,
+		migrator:        migrator,
+	}
+}
+
+// Execute builds the usage report of a schema
+func (uc *GetSchemaUsageUseCase) Execute(ctx context.Context, schemaID string) (*SchemaUsageReport, error) {
+	if schemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+
+	schema, err := uc.schemaRepo.GetByID(ctx, schemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema")
+	}
+
+	return uc.report(ctx, schema)
+}
+
+// report builds the usage report of a schema that exists
+func (uc *GetSchemaUsageUseCase) report(ctx context.Context, schema *outbound.ConfigSchema) (*SchemaUsageReport, error) {
+	configs, err := uc.configRepo.ListBySchema(ctx, schema.ID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list configs using schema")
+	}
+	invalid, err := findInvalidConfigs(ctx, uc.schemaRepo, uc.schemaValidator, uc.migrator, schema, configs, schemaChange{content: schema.SchemaContent})
+	if err != nil {
+		return nil, err
+	}
+	errorsByConfig := make(map[string][]services.ValidationError, len(invalid))
+	for _, config := range invalid {
+		errorsByConfig[config.ProjectID+"/"+config.Key] = config.Errors
+	}
+
+	report := &SchemaUsageReport{
+		SchemaID:      schema.ID,
+		SchemaName:    schema.Name,
+		LatestVersion: schema.LatestVersion,
+		Summary:       SchemaUsageSummary{ByVersion: make(map[int32]int)},
+		Configs:       make([]SchemaUsageEntry, 0, len(configs)),
+	}
+	projects := make(map[string]bool)
+	emails := make(map[string]string)
+	for _, config := range configs {
+		errs, isInvalid := errorsByConfig[config.ProjectID+"/"+config.Key]
+		report.Configs = append(report.Configs, SchemaUsageEntry{
+			ProjectID:       config.ProjectID,
+			Key:             config.Key,
+			SchemaVersion:   config.SchemaVersion,
+			Version:         config.Version,
+			Revision:        config.Revision,
+			UpdatedByUserID: config.UpdatedByUserID,
+			UpdatedByEmail:  uc.email(ctx, emails, config.UpdatedByUserID),
+			Valid:           !isInvalid,
+			Errors:          errs,
+		})
+
+		projects[config.ProjectID] = true
+		report.Summary.ByVersion[config.SchemaVersion]++
+		if config.SchemaVersion == schema.LatestVersion {
+			report.Summary.OnLatestVersion++
+		}
+		if isInvalid {
+			report.Summary.Invalid++
+		} else {
+			report.Summary.Valid++
+		}
+	}
+	report.Summary.Configs = len(configs)
+	report.Summary.Projects = len(projects)
+
+	return report, nil
+}
+
+// email returns the email of a user, or an empty string for a user that
+// no longer exists; lookups are remembered in emails
+func (uc *GetSchemaUsageUseCase) email(ctx context.Context, emails map[string]string, userID string) string {
+	if userID == "" {
+		return ""
+	}
+	if email, ok := emails[userID]; ok {
+		return email
+	}
+
+	email := ""
+	if user, err := uc.userRepo.GetByID(ctx, userID); err == nil {
+		email = user.Email
+	}
+	emails[userID] = email
+	return email
+}
+
+// usageConflict refuses a change to a schema that configs use, naming them
+// in the message and attaching the usage report
+func usageConflict(action string, report *SchemaUsageReport) error {
+	keys := make([]string, 0, maxUsageKeysInMessage)
+	for i, config := range report.Configs {
+		if i == maxUsageKeysInMessage {
+			keys = append(keys, fmt.Sprintf("and %d more", len(report.Configs)-i))
+			break
+		}
+		keys = append(keys, config.ProjectID+"/"+config.Key)
+	}
+
+	return apperrors.Conflict(fmt.Sprintf(
+		"cannot %s schema: used by %d config(s) in %d project(s), %d invalid against version %d: %s",
+		action, report.Summary.Configs, report.Summary.Projects, report.Summary.Invalid, report.LatestVersion, strings.Join(keys, ", "),
+	)).WithExtension("usage", report)
+}
@@ -0,0 +1,212 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// MockConfigSchemaRepository mocks schema lookups
+type MockConfigSchemaRepository struct {
+	mock.Mock
+	outbound.ConfigSchemaRepository
+}
+
+func (m *MockConfigSchemaRepository) GetByID(ctx context.Context, id string) (*outbound.ConfigSchema, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.ConfigSchema), args.Error(1)
+}
+
+func (m *MockConfigSchemaRepository) ListVersions(ctx context.Context, schemaID string) ([]*outbound.ConfigSchemaVersion, error) {
+	args := m.Called(ctx, schemaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.ConfigSchemaVersion), args.Error(1)
+}
+
+func (m *MockConfigSchemaRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockConfigRepository mocks the config reads by schema
+type MockConfigRepository struct {
+	mock.Mock
+	outbound.ConfigRepository
+}
+
+func (m *MockConfigRepository) ListBySchema(ctx context.Context, schemaID string) ([]*outbound.Config, error) {
+	args := m.Called(ctx, schemaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*outbound.Config), args.Error(1)
+}
+
+func (m *MockConfigRepository) CountBySchema(ctx context.Context, schemaID string) (int64, error) {
+	args := m.Called(ctx, schemaID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockUserRepository mocks user lookups
+type MockUserRepository struct {
+	mock.Mock
+	outbound.UserRepository
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*outbound.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.User), args.Error(1)
+}
+
+// Version 2 renames "port" to "listen_port" and requires it
+const (
+	usageTestSchemaV1 = `{"type": "object", "properties": {"port": {"type": "integer"}}}`
+	usageTestSchemaV2 = `{
+	"type": "object",
+	"properties": {"listen_port": {"type": "integer"}},
+	"required": ["listen_port"]
+}`
+	usageTestMigration = `{"operations": [{"op": "rename", "path": "/port", "to": "listen_port"}]}`
+)
+
+// usageTestSchema is at version 2 and used by configs of two projects:
+// "api" and "web" migrate to a valid version 2 config, "worker" has no port
+// at all. user-2, who last updated "worker", has been deleted.
+var (
+	usageTestSchema   = &outbound.ConfigSchema{ID: "schema-1", Name: "service", SchemaContent: usageTestSchemaV2, LatestVersion: 2}
+	usageTestVersions = []*outbound.ConfigSchemaVersion{
+		{SchemaID: "schema-1", Version: 2, SchemaContent: usageTestSchemaV2, Migration: json.RawMessage(usageTestMigration)},
+		{SchemaID: "schema-1", Version: 1, SchemaContent: usageTestSchemaV1},
+	}
+	usageTestConfigs = []*outbound.Config{
+		{ProjectID: "proj-1", Key: "api", SchemaID: "schema-1", SchemaVersion: 1, Version: 3, Revision: 10, UpdatedByUserID: "user-1", Content: json.RawMessage(`{"port": 8080}`)},
+		{ProjectID: "proj-1", Key: "worker", SchemaID: "schema-1", SchemaVersion: 1, Version: 1, Revision: 11, UpdatedByUserID: "user-2", Content: json.RawMessage(`{}`)},
+		{ProjectID: "proj-2", Key: "web", SchemaID: "schema-1", SchemaVersion: 2, Version: 2, Revision: 12, UpdatedByUserID: "user-1", Content: json.RawMessage(`{"listen_port": 80}`)},
+	}
+)
+
+func TestGetSchemaUsageUseCase_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	schemaRepo := new(MockConfigSchemaRepository)
+	configRepo := new(MockConfigRepository)
+	userRepo := new(MockUserRepository)
+	schemaRepo.On("GetByID", ctx, "schema-1").Return(usageTestSchema, nil)
+	schemaRepo.On("ListVersions", ctx, "schema-1").Return(usageTestVersions, nil)
+	configRepo.On("ListBySchema", ctx, "schema-1").Return(usageTestConfigs, nil)
+	userRepo.On("GetByID", ctx, "user-1").Return(&outbound.User{ID: "user-1", Email: "alice@example.com"}, nil)
+	userRepo.On("GetByID", ctx, "user-2").Return(nil, apperrors.New(apperrors.ErrCodeUserNotFound, "user not found"))
+	useCase := NewGetSchemaUsageUseCase(schemaRepo, configRepo, userRepo, services.NewSchemaValidator(), services.NewConfigMigrator())
+
+	// Act
+	report, err := useCase.Execute(ctx, "schema-1")
+
+	// Assert
+	schemaRepo.AssertExpectations(t)
+	configRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	assert.Equal(t, "service", report.SchemaName)
+	assert.Equal(t, int32(2), report.LatestVersion)
+	assert.Equal(t, SchemaUsageSummary{
+		Configs:         3,
+		Projects:        2,
+		OnLatestVersion: 1,
+		Valid:           2,
+		Invalid:         1,
+		ByVersion:       map[int32]int{1: 2, 2: 1},
+	}, report.Summary)
+
+	require.Len(t, report.Configs, 3)
+	api, worker := report.Configs[0], report.Configs[1]
+	assert.True(t, api.Valid)
+	assert.Empty(t, api.Errors)
+	assert.Equal(t, int64(10), api.Revision)
+	assert.Equal(t, "alice@example.com", api.UpdatedByEmail)
+	assert.False(t, worker.Valid)
+	require.NotEmpty(t, worker.Errors)
+	assert.Equal(t, "required", worker.Errors[0].Keyword)
+	assert.Empty(t, worker.UpdatedByEmail, "a deleted user has no email")
+}
+
+func TestGetSchemaUsageUseCase_Execute_NotFound(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	schemaRepo := new(MockConfigSchemaRepository)
+	configRepo := new(MockConfigRepository)
+	schemaRepo.On("GetByID", ctx, "missing").Return(nil, apperrors.New(apperrors.ErrCodeSchemaNotFound, "config schema not found"))
+	useCase := NewGetSchemaUsageUseCase(schemaRepo, configRepo, new(MockUserRepository), services.NewSchemaValidator(), services.NewConfigMigrator())
+
+	// Act
+	_, err := useCase.Execute(ctx, "missing")
+
+	// Assert
+	schemaRepo.AssertExpectations(t)
+	require.Error(t, err)
+	assert.True(t, apperrors.HasCode(err, apperrors.ErrCodeSchemaNotFound), "got %v", err)
+	configRepo.AssertNotCalled(t, "ListBySchema", mock.Anything, mock.Anything)
+}
+
+func TestGetSchemaUsageUseCase_Execute_RepositoryFailure(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	schemaRepo := new(MockConfigSchemaRepository)
+	schemaRepo.On("GetByID", ctx, "schema-2").Return(nil, errors.New("connection refused"))
+	useCase := NewGetSchemaUsageUseCase(schemaRepo, new(MockConfigRepository), new(MockUserRepository), services.NewSchemaValidator(), services.NewConfigMigrator())
+
+	// Act
+	_, err := useCase.Execute(ctx, "schema-2")
+
+	// Assert
+	schemaRepo.AssertExpectations(t)
+	require.Error(t, err)
+	assert.True(t, apperrors.HasCode(err, apperrors.ErrCodeInternal), "got %v", err)
+}
+
+func TestDeleteSchemaUseCase_Execute_InUse(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	schemaRepo := new(MockConfigSchemaRepository)
+	configRepo := new(MockConfigRepository)
+	userRepo := new(MockUserRepository)
+	schemaRepo.On("GetByID", ctx, "schema-1").Return(usageTestSchema, nil)
+	schemaRepo.On("ListVersions", ctx, "schema-1").Return(usageTestVersions, nil)
+	configRepo.On("CountBySchema", ctx, "schema-1").Return(int64(3), nil)
+	configRepo.On("ListBySchema", ctx, "schema-1").Return(usageTestConfigs, nil)
+	userRepo.On("GetByID", ctx, "user-1").Return(&outbound.User{ID: "user-1", Email: "alice@example.com"}, nil)
+	userRepo.On("GetByID", ctx, "user-2").Return(nil, apperrors.New(apperrors.ErrCodeUserNotFound, "user not found"))
+	usageUseCase := NewGetSchemaUsageUseCase(schemaRepo, configRepo, userRepo, services.NewSchemaValidator(), services.NewConfigMigrator())
+	useCase := NewDeleteSchemaUseCase(schemaRepo, configRepo, services.NewSchemaValidator(), usageUseCase)
+
+	// Act
+	err := useCase.Execute(ctx, DeleteSchemaRequest{SchemaID: "schema-1"})
+
+	// Assert
+	schemaRepo.AssertExpectations(t)
+	configRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	appErr, ok := apperrors.GetAppError(err)
+	require.True(t, ok, "got %v", err)
+	assert.Equal(t, apperrors.ErrCodeConflict, appErr.Code)
+	assert.Equal(t, "cannot delete schema: used by 3 config(s) in 2 project(s), 1 invalid against version 2: proj-1/api, proj-1/worker, proj-2/web", appErr.Message)
+	report, ok := appErr.Extensions["usage"].(*SchemaUsageReport)
+	require.True(t, ok)
+	assert.Equal(t, 3, report.Summary.Configs)
+	schemaRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
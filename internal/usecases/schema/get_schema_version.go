@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"context"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// GetSchemaVersionRequest identifies one version of a schema
+type GetSchemaVersionRequest struct {
+	SchemaID string `json:"schema_id"`
+	Version  int32  `json:"version"`
+}
+
+// GetSchemaVersionUseCase handles retrieving one version of a schema
+type GetSchemaVersionUseCase struct {
+	schemaRepo outbound.ConfigSchemaRepository
+}
+
+// NewGetSchemaVersionUseCase creates a new GetSchemaVersionUseCase
+func NewGetSchemaVersionUseCase(schemaRepo outbound.ConfigSchemaRepository) *GetSchemaVersionUseCase {
+	return &GetSchemaVersionUseCase{
+		schemaRepo: schemaRepo,
+	}
+}
+
+// Execute retrieves a schema version
+func (uc *GetSchemaVersionUseCase) Execute(ctx context.Context, req GetSchemaVersionRequest) (*SchemaVersionResponse, error) {
+	if req.SchemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+	if req.Version < 1 {
+		return nil, apperrors.BadRequest("schema version must be >= 1")
+	}
+
+	version, err := uc.schemaRepo.GetVersion(ctx, req.SchemaID, req.Version)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema version")
+	}
+
+	return toSchemaVersionResponse(version), nil
+}
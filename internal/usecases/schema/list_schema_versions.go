@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"context"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// ListSchemaVersionsResponse holds every version of a schema, newest first
+type ListSchemaVersionsResponse struct {
+	SchemaID      string                   `json:"schema_id"`
+	LatestVersion int32                    `json:"latest_version"`
+	Versions      []*SchemaVersionResponse `json:"versions"`
+}
+
+// ListSchemaVersionsUseCase handles listing the versions of a schema
+type ListSchemaVersionsUseCase struct {
+	schemaRepo outbound.ConfigSchemaRepository
+}
+
+// NewListSchemaVersionsUseCase creates a new ListSchemaVersionsUseCase
+func NewListSchemaVersionsUseCase(schemaRepo outbound.ConfigSchemaRepository) *ListSchemaVersionsUseCase {
+	return &ListSchemaVersionsUseCase{
+		schemaRepo: schemaRepo,
+	}
+}
+
+// Execute retrieves the versions of a schema
+func (uc *ListSchemaVersionsUseCase) Execute(ctx context.Context, schemaID string) (*ListSchemaVersionsResponse, error) {
+	if schemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+
+	schema, err := uc.schemaRepo.GetByID(ctx, schemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema")
+	}
+
+	versions, err := uc.schemaRepo.ListVersions(ctx, schemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list schema versions")
+	}
+
+	resp := &ListSchemaVersionsResponse{
+		SchemaID:      schema.ID,
+		LatestVersion: schema.LatestVersion,
+		Versions:      make([]*SchemaVersionResponse, len(versions)),
+	}
+	for i, version := range versions {
+		resp.Versions[i] = toSchemaVersionResponse(version)
+	}
+
+	return resp, nil
+}
@@ -2,8 +2,8 @@ package schema
 
 import (
 	"context"
-	"fmt"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -11,12 +11,24 @@ import (
 type SchemaListItem struct {
 	ID              string `json:"id"`
 	Name            string `json:"name"`
+	LatestVersion   int32  `json:"latest_version"`
+	ProjectID       string `json:"project_id,omitempty"`
 	CreatedByUserID string `json:"created_by_user_id"`
 	CreatedAt       string `json:"created_at"`
 	UpdatedAt       string `json:"updated_at"`
 	ConfigsUsing    int64  `json:"configs_using"` // Number of configs using this schema
 }
 
+// ListSchemasRequest selects the schemas usable by a caller. With ProjectID
+// set, the global schemas and that project's schemas are listed. Otherwise a
+// platform admin sees every schema and other users see the global schemas and
+// those of the projects they have a role in.
+type ListSchemasRequest struct {
+	UserID        string `json:"user_id"`
+	ProjectID     string `json:"project_id,omitempty"`
+	PlatformAdmin bool   `json:"platform_admin"`
+}
+
 // ListSchemasResponse holds the list of schemas
 type ListSchemasResponse struct {
 	Schemas []*SchemaListItem `json:"schemas"`
@@ -26,34 +38,35 @@ type ListSchemasResponse struct {
 // ListSchemasUseCase handles listing config schemas
 type ListSchemasUseCase struct {
 	schemaRepo outbound.ConfigSchemaRepository
+	configRepo outbound.ConfigRepository
+	roleRepo   outbound.RoleRepository
 }
 
 // NewListSchemasUseCase creates a new ListSchemasUseCase
-func NewListSchemasUseCase(schemaRepo outbound.ConfigSchemaRepository) *ListSchemasUseCase {
+func NewListSchemasUseCase(schemaRepo outbound.ConfigSchemaRepository, configRepo outbound.ConfigRepository, roleRepo outbound.RoleRepository) *ListSchemasUseCase {
 	return &ListSchemasUseCase{
 		schemaRepo: schemaRepo,
+		configRepo: configRepo,
+		roleRepo:   roleRepo,
 	}
 }
 
-// Execute retrieves all config schemas
-func (uc *ListSchemasUseCase) Execute(ctx context.Context) (*ListSchemasResponse, error) {
-	// Get all schemas
-	schemas, err := uc.schemaRepo.List(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list schemas: %w", err)
+// Execute retrieves the config schemas usable by the caller
+func (uc *ListSchemasUseCase) Execute(ctx context.Context, req ListSchemasRequest) (*ListSchemasResponse, error) {
+	if req.UserID == "" {
+		return nil, apperrors.BadRequest("user ID is required")
 	}
 	
-	// Get total count
-	count, err := uc.schemaRepo.Count(ctx)
+	schemas, err := uc.listUsable(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count schemas: %w", err)
+		return nil, apperrors.Internal(err, "failed to list schemas")
 	}
 	
 	// Convert to response format (include usage count for each)
 	items := make([]*SchemaListItem, len(schemas))
 	for i, schema := range schemas {
 		// Get count of configs using this schema
-		configsUsing, err := uc.schemaRepo.CountConfigsUsing(ctx, schema.ID)
+		configsUsing, err := uc.configRepo.CountBySchema(ctx, schema.ID)
 		if err != nil {
 			// Don't fail the entire request, just set to 0
 			configsUsing = 0
@@ -62,6 +75,8 @@ func (uc *ListSchemasUseCase) Execute(ctx context.Context) (*ListSchemasResponse
 		items[i] = &SchemaListItem{
 			ID:              schema.ID,
 			Name:            schema.Name,
+			LatestVersion:   schema.LatestVersion,
+			ProjectID:       schema.ProjectID,
 			CreatedByUserID: schema.CreatedByUserID,
 			CreatedAt:       schema.CreatedAt,
 			UpdatedAt:       schema.UpdatedAt,
@@ -71,7 +86,27 @@ func (uc *ListSchemasUseCase) Execute(ctx context.Context) (*ListSchemasResponse
 	
 	return &ListSchemasResponse{
 		Schemas: items,
-		Total:   count,
+		Total:   int64(len(items)),
 	}, nil
 }
 
+// listUsable retrieves the schemas the request selects
+func (uc *ListSchemasUseCase) listUsable(ctx context.Context, req ListSchemasRequest) ([]*outbound.ConfigSchema, error) {
+	if req.ProjectID != "" {
+		return uc.schemaRepo.ListByProject(ctx, req.ProjectID)
+	}
+	if req.PlatformAdmin {
+		return uc.schemaRepo.List(ctx)
+	}
+	
+	roles, err := uc.roleRepo.ListUserRoles(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := make([]string, len(roles))
+	for i, role := range roles {
+		projectIDs[i] = role.ProjectID
+	}
+	return uc.schemaRepo.ListForProjects(ctx, projectIDs)
+}
+
@@ -0,0 +1,262 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// PreviewSchemaChangeRequest holds candidate content for a schema and the
+// migration that would be published with it, if any
+type PreviewSchemaChangeRequest struct {
+	SchemaID      string          `json:"schema_id"`
+	SchemaContent string          `json:"schema_content"`
+	Migration     json.RawMessage `json:"migration,omitempty"`
+}
+
+// SchemaImpactReport describes what publishing new schema content would do:
+// how it compares to the latest version and which configs would fail it,
+// whatever version they pin. Configs using the schema are checked as they
+// would be after migration to the candidate content. Schemas referencing
+// this one pin the version they were published with, so the configs using
+// them are not affected.
+type SchemaImpactReport struct {
+	SchemaID       string                  `json:"schema_id"`
+	LatestVersion  int32                   `json:"latest_version"`
+	Classification string                  `json:"classification"`
+	Changes        []services.SchemaChange `json:"changes"`
+	ConfigsChecked int                     `json:"configs_checked"`
+	InvalidConfigs []InvalidConfig         `json:"invalid_configs"`
+}
+
+// InvalidConfig is a config that fails candidate schema content
+type InvalidConfig struct {
+	ProjectID     string                     `json:"project_id"`
+	Key           string                     `json:"key"`
+	SchemaVersion int32                      `json:"schema_version"`
+	Errors        []services.ValidationError `json:"errors"`
+}
+
+// PreviewSchemaChangeUseCase reports the impact of new schema content
+// without publishing it
+type PreviewSchemaChangeUseCase struct {
+	schemaRepo           outbound.ConfigSchemaRepository
+	configRepo           outbound.ConfigRepository
+	schemaValidator      *services.SchemaValidator
+	compatibilityChecker *services.SchemaCompatibilityChecker
+	migrator             *services.ConfigMigrator
+}
+
+// NewPreviewSchemaChangeUseCase creates a new PreviewSchemaChangeUseCase
+func NewPreviewSchemaChangeUseCase(
+	schemaRepo outbound.ConfigSchemaRepository,
+	configRepo outbound.ConfigRepository,
+	schemaValidator *services.SchemaValidator,
+	compatibilityChecker *services.SchemaCompatibilityChecker,
+	migrator *services.ConfigMigrator,
+) *PreviewSchemaChangeUseCase {
+	return &PreviewSchemaChangeUseCase{
+		schemaRepo:           schemaRepo,
+		configRepo:           configRepo,
+		schemaValidator:      schemaValidator,
+		compatibilityChecker: compatibilityChecker,
+		migrator:             migrator,
+	}
+}
+
+// Execute builds the impact report for candidate schema content
+func (uc *PreviewSchemaChangeUseCase) Execute(ctx context.Context, req PreviewSchemaChangeRequest) (*SchemaImpactReport, error) {
+	// Validate input
+	if req.SchemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+	if req.SchemaContent == "" {
+		return nil, apperrors.BadRequest("schema content is required")
+	}
+
+	current, err := uc.schemaRepo.GetByID(ctx, req.SchemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema")
+	}
+
+	if _, err := validateSchemaContent(ctx, uc.schemaValidator, current.ProjectID, current.Name, req.SchemaContent); err != nil {
+		return nil, err
+	}
+	migration, err := parseMigration(uc.migrator, req.Migration)
+	if err != nil {
+		return nil, err
+	}
+
+	change := schemaChange{content: req.SchemaContent, migration: migration}
+	return assessSchemaChange(ctx, uc.schemaRepo, uc.configRepo, uc.schemaValidator, uc.compatibilityChecker, uc.migrator, current, change)
+}
+
+// schemaChange is candidate content for the next version of a schema and
+// the migration published with it, if any
+type schemaChange struct {
+	content   string
+	migration *services.SchemaMigration
+}
+
+// parseMigration validates an optional migration
+func parseMigration(migrator *services.ConfigMigrator, data json.RawMessage) (*services.SchemaMigration, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	migration, err := migrator.Parse(data)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeBadRequest, err.Error())
+	}
+	return migration, nil
+}
+
+// pendingMigrations returns, for each version of a schema, the migrations
+// that take content pinned to it up to the latest version
+func pendingMigrations(migrator *services.ConfigMigrator, versions []*outbound.ConfigSchemaVersion) (map[int32][]*services.SchemaMigration, error) {
+	parsed := make(map[int32]*services.SchemaMigration, len(versions))
+	for _, version := range versions {
+		if len(version.Migration) == 0 {
+			continue
+		}
+		migration, err := migrator.Parse(version.Migration)
+		if err != nil {
+			return nil, fmt.Errorf("schema version %d: %w", version.Version, err)
+		}
+		parsed[version.Version] = migration
+	}
+
+	pending := make(map[int32][]*services.SchemaMigration, len(versions))
+	for _, from := range versions {
+		var chain []*services.SchemaMigration
+		for _, to := range versions {
+			if migration, ok := parsed[to.Version]; ok && to.Version > from.Version {
+				chain = append(chain, migration)
+			}
+		}
+		pending[from.Version] = chain
+	}
+	return pending, nil
+}
+
+// assessSchemaChange compares candidate content with the latest version of
+// a schema and re-validates every config using the schema against it, after
+// the migrations that would take the config to the candidate version
+func assessSchemaChange(
+	ctx context.Context,
+	schemaRepo outbound.ConfigSchemaRepository,
+	configRepo outbound.ConfigRepository,
+	schemaValidator *services.SchemaValidator,
+	compatibilityChecker *services.SchemaCompatibilityChecker,
+	migrator *services.ConfigMigrator,
+	current *outbound.ConfigSchema,
+	candidate schemaChange,
+) (*SchemaImpactReport, error) {
+	compatibility, err := compatibilityChecker.Compare(current.SchemaContent, candidate.content)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeSchemaInvalid, fmt.Sprintf("invalid JSON Schema: %v", err))
+	}
+
+	configs, err := configRepo.ListBySchema(ctx, current.ID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list configs using schema")
+	}
+	invalid, err := findInvalidConfigs(ctx, schemaRepo, schemaValidator, migrator, current, configs, candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SchemaImpactReport{
+		SchemaID:       current.ID,
+		LatestVersion:  current.LatestVersion,
+		Classification: compatibility.Classification,
+		Changes:        compatibility.Changes,
+		ConfigsChecked: len(configs),
+		InvalidConfigs: invalid,
+	}, nil
+}
+
+// findInvalidConfigs re-validates configs using a schema against candidate
+// content, after the migrations that would take each config to it
+func findInvalidConfigs(
+	ctx context.Context,
+	schemaRepo outbound.ConfigSchemaRepository,
+	schemaValidator *services.SchemaValidator,
+	migrator *services.ConfigMigrator,
+	current *outbound.ConfigSchema,
+	configs []*outbound.Config,
+	candidate schemaChange,
+) ([]InvalidConfig, error) {
+	versions, err := schemaRepo.ListVersions(ctx, current.ID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list schema versions")
+	}
+	pending, err := pendingMigrations(migrator, versions)
+	if err != nil {
+		return nil, apperrors.Internal(err, "invalid stored migration")
+	}
+
+	invalid := make([]InvalidConfig, 0)
+	for _, config := range configs {
+		chain := pending[config.SchemaVersion]
+		if candidate.migration != nil {
+			chain = append(chain[:len(chain):len(chain)], candidate.migration)
+		}
+		content, err := migrator.Apply(config.Content, chain...)
+		if err != nil {
+			invalid = append(invalid, InvalidConfig{
+				ProjectID:     config.ProjectID,
+				Key:           config.Key,
+				SchemaVersion: config.SchemaVersion,
+				Errors:        []services.ValidationError{{Keyword: "migration", Message: err.Error()}},
+			})
+			continue
+		}
+
+		result, err := schemaValidator.ValidateDraft(ctx, current.ProjectID, candidate.content, content)
+		if err != nil {
+			return nil, apperrors.Internal(err, fmt.Sprintf("failed to validate config %s", config.Key))
+		}
+		if !result.Valid {
+			invalid = append(invalid, InvalidConfig{
+				ProjectID:     config.ProjectID,
+				Key:           config.Key,
+				SchemaVersion: config.SchemaVersion,
+				Errors:        result.Errors,
+			})
+		}
+	}
+
+	return invalid, nil
+}
+
+// checkSchemaChange assesses candidate content before it is published and
+// refuses it when a config would fail it, unless force is set
+func checkSchemaChange(
+	ctx context.Context,
+	schemaRepo outbound.ConfigSchemaRepository,
+	configRepo outbound.ConfigRepository,
+	schemaValidator *services.SchemaValidator,
+	compatibilityChecker *services.SchemaCompatibilityChecker,
+	migrator *services.ConfigMigrator,
+	current *outbound.ConfigSchema,
+	candidate schemaChange,
+	force bool,
+) (*SchemaImpactReport, error) {
+	report, err := assessSchemaChange(ctx, schemaRepo, configRepo, schemaValidator, compatibilityChecker, migrator, current, candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(report.InvalidConfigs) > 0 && !force {
+		return nil, apperrors.New(
+			apperrors.ErrCodeSchemaIncompatible,
+			fmt.Sprintf("%d of %d config(s) using the schema would fail the new content; set force to publish anyway", len(report.InvalidConfigs), report.ConfigsChecked),
+		).WithExtension("impact", report)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,209 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+func (m *MockConfigSchemaRepository) PublishVersion(ctx context.Context, params outbound.PublishConfigSchemaVersionParams) (*outbound.ConfigSchemaVersion, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.ConfigSchemaVersion), args.Error(1)
+}
+
+func (m *MockConfigSchemaRepository) GetVersion(ctx context.Context, schemaID string, version int32) (*outbound.ConfigSchemaVersion, error) {
+	args := m.Called(ctx, schemaID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.ConfigSchemaVersion), args.Error(1)
+}
+
+func (m *MockConfigSchemaRepository) GetByName(ctx context.Context, projectID, name string) (*outbound.ConfigSchema, error) {
+	args := m.Called(ctx, projectID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*outbound.ConfigSchema), args.Error(1)
+}
+
+// Version 1 has an optional "port"; the candidate requires it
+const (
+	changeTestSchemaV1  = `{"type": "object", "properties": {"port": {"type": "integer"}}}`
+	changeTestCandidate = `{
+	"type": "object",
+	"properties": {"port": {"type": "integer"}},
+	"required": ["port"]
+}`
+)
+
+// changeTestSchema is at version 1, used by "api", which has a port, and
+// "worker", which has none
+var (
+	changeTestSchema   = &outbound.ConfigSchema{ID: "schema-1", Name: "service", SchemaContent: changeTestSchemaV1, LatestVersion: 1}
+	changeTestVersions = []*outbound.ConfigSchemaVersion{
+		{SchemaID: "schema-1", Version: 1, SchemaContent: changeTestSchemaV1},
+	}
+	changeTestConfigs = []*outbound.Config{
+		{ProjectID: "proj-1", Key: "api", SchemaID: "schema-1", SchemaVersion: 1, Content: json.RawMessage(`{"port": 8080}`)},
+		{ProjectID: "proj-1", Key: "worker", SchemaID: "schema-1", SchemaVersion: 1, Content: json.RawMessage(`{}`)},
+	}
+)
+
+func TestPreviewSchemaChangeUseCase_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	schemaRepo := new(MockConfigSchemaRepository)
+	configRepo := new(MockConfigRepository)
+	schemaRepo.On("GetByID", ctx, "schema-1").Return(changeTestSchema, nil)
+	schemaRepo.On("ListVersions", ctx, "schema-1").Return(changeTestVersions, nil)
+	configRepo.On("ListBySchema", ctx, "schema-1").Return(changeTestConfigs, nil)
+	useCase := NewPreviewSchemaChangeUseCase(schemaRepo, configRepo, services.NewSchemaValidator(), services.NewSchemaCompatibilityChecker(), services.NewConfigMigrator())
+
+	// Act
+	report, err := useCase.Execute(ctx, PreviewSchemaChangeRequest{SchemaID: "schema-1", SchemaContent: changeTestCandidate})
+
+	// Assert
+	schemaRepo.AssertExpectations(t)
+	configRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	assert.Equal(t, services.SchemaBreaking, report.Classification)
+	assert.Equal(t, 2, report.ConfigsChecked)
+	require.Len(t, report.InvalidConfigs, 1)
+	assert.Equal(t, "worker", report.InvalidConfigs[0].Key)
+	assert.Equal(t, "required", report.InvalidConfigs[0].Errors[0].Keyword)
+	schemaRepo.AssertNotCalled(t, "PublishVersion", mock.Anything, mock.Anything)
+}
+
+func TestPublishSchemaVersionUseCase_Execute_Impact(t *testing.T) {
+	tests := []struct {
+		name          string
+		force         bool
+		wantPublished bool
+	}{
+		{
+			name: "refused when a config would fail",
+		},
+		{
+			name:          "published with force",
+			force:         true,
+			wantPublished: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			schemaRepo := new(MockConfigSchemaRepository)
+			configRepo := new(MockConfigRepository)
+			schemaRepo.On("GetByID", ctx, "schema-1").Return(changeTestSchema, nil)
+			schemaRepo.On("ListVersions", ctx, "schema-1").Return(changeTestVersions, nil)
+			configRepo.On("ListBySchema", ctx, "schema-1").Return(changeTestConfigs, nil)
+			if tt.wantPublished {
+				schemaRepo.On("PublishVersion", ctx, mock.Anything).Return(&outbound.ConfigSchemaVersion{SchemaID: "schema-1", Version: 2, SchemaContent: changeTestCandidate}, nil)
+			}
+			useCase := NewPublishSchemaVersionUseCase(schemaRepo, configRepo, services.NewSchemaValidator(), services.NewSchemaCompatibilityChecker(), services.NewConfigMigrator())
+
+			// Act
+			resp, err := useCase.Execute(ctx, PublishSchemaVersionRequest{
+				SchemaID:        "schema-1",
+				SchemaContent:   changeTestCandidate,
+				Force:           tt.force,
+				CreatedByUserID: "user-1",
+			})
+
+			// Assert
+			schemaRepo.AssertExpectations(t)
+			configRepo.AssertExpectations(t)
+			if !tt.wantPublished {
+				appErr, ok := apperrors.GetAppError(err)
+				require.True(t, ok, "got %v", err)
+				assert.Equal(t, apperrors.ErrCodeSchemaIncompatible, appErr.Code)
+				impact, ok := appErr.Extensions["impact"].(*SchemaImpactReport)
+				require.True(t, ok)
+				require.Len(t, impact.InvalidConfigs, 1)
+				assert.Equal(t, "worker", impact.InvalidConfigs[0].Key)
+				schemaRepo.AssertNotCalled(t, "PublishVersion", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int32(2), resp.Version)
+			require.NotNil(t, resp.Impact)
+			assert.Len(t, resp.Impact.InvalidConfigs, 1)
+			schemaRepo.AssertCalled(t, "PublishVersion", ctx, mock.Anything)
+		})
+	}
+}
+
+func TestPublishSchemaVersionUseCase_Execute_PinsReferences(t *testing.T) {
+	// Arrange: the candidate references gateway, which references tls
+	ctx := context.Background()
+	schemaRepo := new(MockConfigSchemaRepository)
+	configRepo := new(MockConfigRepository)
+	schemaRepo.On("GetByID", ctx, "schema-1").Return(changeTestSchema, nil)
+	schemaRepo.On("ListVersions", ctx, "schema-1").Return(changeTestVersions, nil)
+	configRepo.On("ListBySchema", ctx, "schema-1").Return(changeTestConfigs, nil)
+	gateway := `{"properties": {"tls": {"$ref": "cfguardian://schemas/tls"}}}`
+	candidate := `{"properties": {"port": {"type": "integer"}, "upstream": {"$ref": "cfguardian://schemas/gateway"}}}`
+	schemaRepo.On("GetByName", ctx, "", "gateway").Return(&outbound.ConfigSchema{ID: "schema-2", Name: "gateway", SchemaContent: gateway, LatestVersion: 4}, nil)
+	schemaRepo.On("GetByName", ctx, "", "tls").Return(&outbound.ConfigSchema{ID: "schema-3", Name: "tls", SchemaContent: `{"type": "object"}`, LatestVersion: 2}, nil)
+	schemaRepo.On("PublishVersion", ctx, mock.Anything).Return(&outbound.ConfigSchemaVersion{SchemaID: "schema-1", Version: 2, SchemaContent: candidate}, nil)
+	validator := services.NewSchemaValidatorWithResolver(NewRegistrySchemaResolver(schemaRepo), services.DefaultSchemaCacheSize)
+	useCase := NewPublishSchemaVersionUseCase(schemaRepo, configRepo, validator, services.NewSchemaCompatibilityChecker(), services.NewConfigMigrator())
+
+	// Act
+	_, err := useCase.Execute(ctx, PublishSchemaVersionRequest{
+		SchemaID:        "schema-1",
+		SchemaContent:   candidate,
+		CreatedByUserID: "user-1",
+	})
+
+	// Assert
+	schemaRepo.AssertExpectations(t)
+	configRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	schemaRepo.AssertCalled(t, "PublishVersion", ctx, outbound.PublishConfigSchemaVersionParams{
+		SchemaID:      "schema-1",
+		SchemaContent: candidate,
+		References: []outbound.ConfigSchemaReference{
+			{Name: "gateway", SchemaID: "schema-2", Version: 4},
+			{Name: "tls", SchemaID: "schema-3", Version: 2},
+		},
+		CreatedByUserID: "user-1",
+	})
+}
+
+func TestPublishSchemaVersionUseCase_Execute_ReferenceOutsideProject(t *testing.T) {
+	// Arrange: tls belongs to another project, so proj-1 does not see it
+	ctx := context.Background()
+	schemaRepo := new(MockConfigSchemaRepository)
+	schemaRepo.On("GetByID", ctx, "schema-1").Return(&outbound.ConfigSchema{ID: "schema-1", Name: "service", SchemaContent: changeTestSchemaV1, LatestVersion: 1, ProjectID: "proj-1"}, nil)
+	schemaRepo.On("GetByName", ctx, "proj-1", "tls").Return(nil, apperrors.New(apperrors.ErrCodeSchemaNotFound, "config schema not found"))
+	validator := services.NewSchemaValidatorWithResolver(NewRegistrySchemaResolver(schemaRepo), services.DefaultSchemaCacheSize)
+	useCase := NewPublishSchemaVersionUseCase(schemaRepo, new(MockConfigRepository), validator, services.NewSchemaCompatibilityChecker(), services.NewConfigMigrator())
+
+	// Act
+	_, err := useCase.Execute(ctx, PublishSchemaVersionRequest{
+		SchemaID:        "schema-1",
+		SchemaContent:   `{"properties": {"tls": {"$ref": "cfguardian://schemas/tls"}}}`,
+		CreatedByUserID: "user-1",
+	})
+
+	// Assert
+	schemaRepo.AssertExpectations(t)
+	assert.True(t, apperrors.HasCode(err, apperrors.ErrCodeSchemaInvalid), "got %v", err)
+	assert.Contains(t, err.Error(), "not found")
+	schemaRepo.AssertNotCalled(t, "PublishVersion", mock.Anything, mock.Anything)
+}
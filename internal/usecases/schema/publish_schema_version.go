@@ -0,0 +1,134 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// PublishSchemaVersionRequest holds the content of a new schema version.
+// Migration optionally rewrites content of the previous version to fit it
+// (see services.SchemaMigration); configs are migrated by a separate run.
+// Force publishes it even when configs using the schema would fail it.
+type PublishSchemaVersionRequest struct {
+	SchemaID        string          `json:"schema_id"`
+	SchemaContent   string          `json:"schema_content"`
+	Migration       json.RawMessage `json:"migration,omitempty"`
+	Force           bool            `json:"force,omitempty"`
+	CreatedByUserID string          `json:"created_by_user_id"`
+}
+
+// SchemaVersionResponse holds one version of a schema
+type SchemaVersionResponse struct {
+	SchemaID        string          `json:"schema_id"`
+	Version         int32           `json:"version"`
+	SchemaContent   string          `json:"schema_content"`
+	Migration       json.RawMessage `json:"migration,omitempty"`
+	CreatedByUserID string          `json:"created_by_user_id"`
+	CreatedAt       string          `json:"created_at"`
+
+	// Impact is set on the version just published
+	Impact *SchemaImpactReport `json:"impact,omitempty"`
+}
+
+// PublishSchemaVersionUseCase appends a new immutable version to a schema.
+// Configs keep the version they pin until they are upgraded.
+type PublishSchemaVersionUseCase struct {
+	schemaRepo           outbound.ConfigSchemaRepository
+	configRepo           outbound.ConfigRepository
+	schemaValidator      *services.SchemaValidator
+	compatibilityChecker *services.SchemaCompatibilityChecker
+	migrator             *services.ConfigMigrator
+}
+
+// NewPublishSchemaVersionUseCase creates a new PublishSchemaVersionUseCase
+func NewPublishSchemaVersionUseCase(
+	schemaRepo outbound.ConfigSchemaRepository,
+	configRepo outbound.ConfigRepository,
+	schemaValidator *services.SchemaValidator,
+	compatibilityChecker *services.SchemaCompatibilityChecker,
+	migrator *services.ConfigMigrator,
+) *PublishSchemaVersionUseCase {
+	return &PublishSchemaVersionUseCase{
+		schemaRepo:           schemaRepo,
+		configRepo:           configRepo,
+		schemaValidator:      schemaValidator,
+		compatibilityChecker: compatibilityChecker,
+		migrator:             migrator,
+	}
+}
+
+// Execute publishes the next version of a schema. It is refused when a
+// config using the schema would fail the new content, unless Force is set.
+func (uc *PublishSchemaVersionUseCase) Execute(ctx context.Context, req PublishSchemaVersionRequest) (*SchemaVersionResponse, error) {
+	// Validate input
+	if req.SchemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+	if req.SchemaContent == "" {
+		return nil, apperrors.BadRequest("schema content is required")
+	}
+	if req.CreatedByUserID == "" {
+		return nil, apperrors.BadRequest("creator user ID is required")
+	}
+
+	current, err := uc.schemaRepo.GetByID(ctx, req.SchemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema")
+	}
+
+	references, err := validateSchemaContent(ctx, uc.schemaValidator, current.ProjectID, current.Name, req.SchemaContent)
+	if err != nil {
+		return nil, err
+	}
+	migration, err := parseMigration(uc.migrator, req.Migration)
+	if err != nil {
+		return nil, err
+	}
+
+	change := schemaChange{content: req.SchemaContent, migration: migration}
+	impact, err := checkSchemaChange(ctx, uc.schemaRepo, uc.configRepo, uc.schemaValidator, uc.compatibilityChecker, uc.migrator, current, change, req.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := uc.schemaRepo.PublishVersion(ctx, outbound.PublishConfigSchemaVersionParams{
+		SchemaID:        req.SchemaID,
+		SchemaContent:   req.SchemaContent,
+		Migration:       migrationContent(migration),
+		References:      references,
+		CreatedByUserID: req.CreatedByUserID,
+	})
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to publish schema version")
+	}
+	uc.schemaValidator.Invalidate(req.SchemaID)
+
+	resp := toSchemaVersionResponse(version)
+	resp.Impact = impact
+	return resp, nil
+}
+
+// toSchemaVersionResponse converts a stored schema version to its response
+func toSchemaVersionResponse(version *outbound.ConfigSchemaVersion) *SchemaVersionResponse {
+	return &SchemaVersionResponse{
+		SchemaID:        version.SchemaID,
+		Version:         version.Version,
+		SchemaContent:   version.SchemaContent,
+		Migration:       version.Migration,
+		CreatedByUserID: version.CreatedByUserID,
+		CreatedAt:       version.CreatedAt,
+	}
+}
+
+// migrationContent encodes a validated migration for storage
+func migrationContent(migration *services.SchemaMigration) json.RawMessage {
+	if migration == nil {
+		return nil
+	}
+	data, _ := json.Marshal(migration)
+	return data
+}
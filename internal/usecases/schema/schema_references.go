@@ -0,0 +1,102 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// RegistrySchemaResolver resolves cfguardian://schemas/<name> references
+// against the schema registry
+type RegistrySchemaResolver struct {
+	schemaRepo outbound.ConfigSchemaRepository
+}
+
+// NewRegistrySchemaResolver creates a new RegistrySchemaResolver
+func NewRegistrySchemaResolver(schemaRepo outbound.ConfigSchemaRepository) *RegistrySchemaResolver {
+	return &RegistrySchemaResolver{
+		schemaRepo: schemaRepo,
+	}
+}
+
+// LatestSchema implements services.SchemaResolver. A global schema may
+// only reference global schemas; a project schema may also reference the
+// schemas of its own project. Another project's schema is not found.
+func (r *RegistrySchemaResolver) LatestSchema(ctx context.Context, projectID, name string) (*services.ResolvedSchema, error) {
+	schema, err := r.schemaRepo.GetByName(ctx, projectID, name)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get referenced schema")
+	}
+	return &services.ResolvedSchema{
+		Name:     name,
+		SchemaID: schema.ID,
+		Version:  schema.LatestVersion,
+		Content:  schema.SchemaContent,
+	}, nil
+}
+
+// PinnedSchemas implements services.SchemaResolver
+func (r *RegistrySchemaResolver) PinnedSchemas(ctx context.Context, schemaID string, version int32) ([]services.ResolvedSchema, error) {
+	references, err := r.schemaRepo.ListVersionReferences(ctx, schemaID, version)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to list schema references")
+	}
+
+	pinned := make([]services.ResolvedSchema, len(references))
+	for i, reference := range references {
+		pinned[i] = services.ResolvedSchema{
+			Name:     reference.Name,
+			SchemaID: reference.SchemaID,
+			Version:  reference.Version,
+			Content:  reference.SchemaContent,
+		}
+	}
+	return pinned, nil
+}
+
+// validateSchemaContent checks that content is a valid JSON Schema whose
+// references resolve to schemas the project sees, and returns the schema
+// versions it reaches for a new version to pin. name is the schema's own
+// name, to detect references back to it.
+func validateSchemaContent(ctx context.Context, schemaValidator *services.SchemaValidator, projectID, name, content string) ([]outbound.ConfigSchemaReference, error) {
+	resolved, err := schemaValidator.ValidateSchema(ctx, projectID, name, content)
+	if err != nil {
+		if apperrors.HasCode(err, apperrors.ErrCodeInternal) {
+			return nil, apperrors.Internal(err, "failed to resolve schema references")
+		}
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeSchemaInvalid, fmt.Sprintf("invalid JSON Schema: %v", err))
+	}
+
+	references := make([]outbound.ConfigSchemaReference, len(resolved))
+	for i, referenced := range resolved {
+		references[i] = outbound.ConfigSchemaReference{
+			Name:     referenced.Name,
+			SchemaID: referenced.SchemaID,
+			Version:  referenced.Version,
+		}
+	}
+	return references, nil
+}
+
+// checkNotReferenced refuses a change to a schema that other schemas
+// reference, naming them in the error
+func checkNotReferenced(ctx context.Context, schemaRepo outbound.ConfigSchemaRepository, schemaID, action string) error {
+	referencing, err := schemaRepo.ListReferencing(ctx, schemaID)
+	if err != nil {
+		return apperrors.Internal(err, "failed to list schemas referencing schema")
+	}
+	if len(referencing) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(referencing))
+	for i, schema := range referencing {
+		names[i] = schema.Name
+	}
+	return apperrors.Conflict(fmt.Sprintf("cannot %s schema: referenced by %s", action, strings.Join(names, ", "))).
+		WithExtension("referenced_by", names)
+}
@@ -5,14 +5,19 @@ import (
 	"fmt"
 
 	"github.com/vlone310/cfguardian/internal/domain/services"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
-// UpdateSchemaRequest holds schema update data
+// UpdateSchemaRequest holds schema update data.
+// New content is published as the schema's next version; Force publishes
+// it even when configs using the schema would fail it.
 type UpdateSchemaRequest struct {
-	SchemaID      string  `json:"schema_id"`
-	Name          *string `json:"name,omitempty"`
-	SchemaContent *string `json:"schema_content,omitempty"`
+	SchemaID        string  `json:"schema_id"`
+	Name            *string `json:"name,omitempty"`
+	SchemaContent   *string `json:"schema_content,omitempty"`
+	Force           bool    `json:"force,omitempty"`
+	UpdatedByUserID string  `json:"updated_by_user_id"`
 }
 
 // UpdateSchemaResponse holds updated schema data
@@ -20,79 +25,123 @@ type UpdateSchemaResponse struct {
 	ID            string `json:"id"`
 	Name          string `json:"name"`
 	SchemaContent string `json:"schema_content"`
+	LatestVersion int32  `json:"latest_version"`
 	UpdatedAt     string `json:"updated_at"`
+	
+	// Impact is set when new content was published
+	Impact *SchemaImpactReport `json:"impact,omitempty"`
 }
 
 // UpdateSchemaUseCase handles config schema updates
 type UpdateSchemaUseCase struct {
-	schemaRepo      outbound.ConfigSchemaRepository
-	schemaValidator *services.SchemaValidator
+	schemaRepo           outbound.ConfigSchemaRepository
+	configRepo           outbound.ConfigRepository
+	schemaValidator      *services.SchemaValidator
+	compatibilityChecker *services.SchemaCompatibilityChecker
+	migrator             *services.ConfigMigrator
 }
 
 // NewUpdateSchemaUseCase creates a new UpdateSchemaUseCase
 func NewUpdateSchemaUseCase(
 	schemaRepo outbound.ConfigSchemaRepository,
+	configRepo outbound.ConfigRepository,
 	schemaValidator *services.SchemaValidator,
+	compatibilityChecker *services.SchemaCompatibilityChecker,
+	migrator *services.ConfigMigrator,
 ) *UpdateSchemaUseCase {
 	return &UpdateSchemaUseCase{
-		schemaRepo:      schemaRepo,
-		schemaValidator: schemaValidator,
+		schemaRepo:           schemaRepo,
+		configRepo:           configRepo,
+		schemaValidator:      schemaValidator,
+		compatibilityChecker: compatibilityChecker,
+		migrator:             migrator,
 	}
 }
 
-// Execute updates a config schema
+// Execute updates a config schema. Content is never rewritten: a change
+// publishes a new version and configs stay on the version they pin.
+// Every config using the schema is re-validated first, and the change is
+// refused if any would fail the new content unless Force is set.
 func (uc *UpdateSchemaUseCase) Execute(ctx context.Context, req UpdateSchemaRequest) (*UpdateSchemaResponse, error) {
 	// Validate input
 	if req.SchemaID == "" {
-		return nil, fmt.Errorf("schema ID is required")
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+	
+	if req.UpdatedByUserID == "" {
+		return nil, apperrors.BadRequest("user ID is required")
 	}
 	
 	// Check if schema exists
-	exists, err := uc.schemaRepo.Exists(ctx, req.SchemaID)
+	current, err := uc.schemaRepo.GetByID(ctx, req.SchemaID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if schema exists: %w", err)
-	}
-	if !exists {
-		return nil, fmt.Errorf("schema not found")
+		return nil, apperrors.Internal(err, "failed to get schema")
 	}
 	
 	// Validate schema content if provided
+	var references []outbound.ConfigSchemaReference
 	if req.SchemaContent != nil && *req.SchemaContent != "" {
-		if err := uc.schemaValidator.ValidateSchema(*req.SchemaContent); err != nil {
-			return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+		references, err = validateSchemaContent(ctx, uc.schemaValidator, current.ProjectID, current.Name, *req.SchemaContent)
+		if err != nil {
+			return nil, err
+		}
+	}
+	
+	// References are by name, so a referenced schema keeps its name
+	if req.Name != nil && *req.Name != "" && *req.Name != current.Name {
+		if err := checkNotReferenced(ctx, uc.schemaRepo, req.SchemaID, "rename"); err != nil {
+			return nil, err
 		}
 	}
 	
-	// Check if new name already exists (if name is being changed)
-	if req.Name != nil && *req.Name != "" {
-		nameExists, err := uc.schemaRepo.ExistsByName(ctx, *req.Name)
+	// Check if new name is taken among the schemas the project sees
+	if req.Name != nil && *req.Name != "" && *req.Name != current.Name {
+		nameExists, err := uc.schemaRepo.ExistsByName(ctx, current.ProjectID, *req.Name)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check if schema name exists: %w", err)
+			return nil, apperrors.Internal(err, "failed to check if schema name exists")
 		}
 		if nameExists {
-			// Check if it's the same schema (allowed to keep same name)
-			existing, _ := uc.schemaRepo.GetByName(ctx, *req.Name)
-			if existing != nil && existing.ID != req.SchemaID {
-				return nil, fmt.Errorf("schema with name '%s' already exists", *req.Name)
-			}
+			return nil, apperrors.New(apperrors.ErrCodeSchemaExists, fmt.Sprintf("schema with name '%s' already exists", *req.Name))
+		}
+	}
+	
+	// Publish changed content as the next version
+	var impact *SchemaImpactReport
+	if req.SchemaContent != nil && *req.SchemaContent != "" && *req.SchemaContent != current.SchemaContent {
+		change := schemaChange{content: *req.SchemaContent}
+		impact, err = checkSchemaChange(ctx, uc.schemaRepo, uc.configRepo, uc.schemaValidator, uc.compatibilityChecker, uc.migrator, current, change, req.Force)
+		if err != nil {
+			return nil, err
+		}
+		
+		_, err = uc.schemaRepo.PublishVersion(ctx, outbound.PublishConfigSchemaVersionParams{
+			SchemaID:        req.SchemaID,
+			SchemaContent:   *req.SchemaContent,
+			References:      references,
+			CreatedByUserID: req.UpdatedByUserID,
+		})
+		if err != nil {
+			return nil, apperrors.Internal(err, "failed to publish schema version")
 		}
+		uc.schemaValidator.Invalidate(req.SchemaID)
 	}
 	
-	// Update schema
+	// Update metadata
 	schema, err := uc.schemaRepo.Update(ctx, outbound.UpdateConfigSchemaParams{
-		ID:            req.SchemaID,
-		Name:          req.Name,
-		SchemaContent: req.SchemaContent,
+		ID:   req.SchemaID,
+		Name: req.Name,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update schema: %w", err)
+		return nil, apperrors.Internal(err, "failed to update schema")
 	}
 	
 	return &UpdateSchemaResponse{
 		ID:            schema.ID,
 		Name:          schema.Name,
 		SchemaContent: schema.SchemaContent,
+		LatestVersion: schema.LatestVersion,
 		UpdatedAt:     schema.UpdatedAt,
+		Impact:        impact,
 	}, nil
 }
 
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+)
+
+// ValidateContentRequest holds candidate config content to check against a schema.
+// Version selects a schema version; 0 uses the latest.
+type ValidateContentRequest struct {
+	SchemaID string                    `json:"schema_id"`
+	Version  int32                     `json:"version,omitempty"`
+	Content  json.RawMessage           `json:"content"`
+	Format   valueobjects.ConfigFormat `json:"format,omitempty"`
+}
+
+// ValidateContentResponse holds the result of validating content against a schema
+type ValidateContentResponse struct {
+	SchemaID string                     `json:"schema_id"`
+	Version  int32                      `json:"version"`
+	Valid    bool                       `json:"valid"`
+	Errors   []services.ValidationError `json:"errors"`
+}
+
+// ValidateContentUseCase validates candidate config content against a schema
+// without storing anything
+type ValidateContentUseCase struct {
+	schemaRepo      outbound.ConfigSchemaRepository
+	schemaValidator *services.SchemaValidator
+	parser          *services.ConfigParser
+}
+
+// NewValidateContentUseCase creates a new ValidateContentUseCase
+func NewValidateContentUseCase(
+	schemaRepo outbound.ConfigSchemaRepository,
+	schemaValidator *services.SchemaValidator,
+) *ValidateContentUseCase {
+	return &ValidateContentUseCase{
+		schemaRepo:      schemaRepo,
+		schemaValidator: schemaValidator,
+		parser:          services.NewConfigParser(),
+	}
+}
+
+// Execute validates content against a schema
+func (uc *ValidateContentUseCase) Execute(ctx context.Context, req ValidateContentRequest) (*ValidateContentResponse, error) {
+	// Validate input
+	if req.SchemaID == "" {
+		return nil, apperrors.BadRequest("schema ID is required")
+	}
+	if len(req.Content) == 0 {
+		return nil, apperrors.BadRequest("config content is required")
+	}
+
+	// Convert content written in another format to canonical JSON
+	content, err := uc.parser.ParseContent(req.Content, req.Format)
+	if err != nil {
+		return nil, apperrors.Invalid(err)
+	}
+	if err := uc.schemaValidator.ValidateContent(content); err != nil {
+		return nil, apperrors.Invalid(err)
+	}
+
+	if req.Version < 0 {
+		return nil, apperrors.BadRequest("schema version must be >= 1, or 0 for the latest")
+	}
+
+	schema, err := uc.schemaRepo.GetByID(ctx, req.SchemaID)
+	if err != nil {
+		return nil, apperrors.Internal(err, "failed to get schema")
+	}
+
+	version, schemaContent := schema.LatestVersion, schema.SchemaContent
+	if req.Version != 0 && req.Version != schema.LatestVersion {
+		schemaVersion, err := uc.schemaRepo.GetVersion(ctx, req.SchemaID, req.Version)
+		if err != nil {
+			return nil, apperrors.Internal(err, "failed to get schema version")
+		}
+		version, schemaContent = schemaVersion.Version, schemaVersion.SchemaContent
+	}
+
+	result, err := uc.schemaValidator.Validate(ctx, schema.ID, version, schemaContent, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidateContentResponse{
+		SchemaID: schema.ID,
+		Version:  version,
+		Valid:    result.Valid,
+		Errors:   result.Errors,
+	}, nil
+}
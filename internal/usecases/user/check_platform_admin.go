@@ -0,0 +1,39 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+)
+
+// CheckPlatformAdminUseCase decides whether a user is a platform admin.
+// Platform admins are configured by user ID and manage global schemas. IDs
+// are assigned at registration, so unlike an unverified email they cannot be
+// claimed by registering first.
+type CheckPlatformAdminUseCase struct {
+	userIDs map[string]struct{}
+}
+
+// NewCheckPlatformAdminUseCase creates a new CheckPlatformAdminUseCase
+func NewCheckPlatformAdminUseCase(userIDs []string) *CheckPlatformAdminUseCase {
+	set := make(map[string]struct{}, len(userIDs))
+	for _, userID := range userIDs {
+		if userID = strings.TrimSpace(userID); userID != "" {
+			set[userID] = struct{}{}
+		}
+	}
+	return &CheckPlatformAdminUseCase{
+		userIDs: set,
+	}
+}
+
+// Execute reports whether the user is a platform admin
+func (uc *CheckPlatformAdminUseCase) Execute(ctx context.Context, userID string) (bool, error) {
+	if userID == "" {
+		return false, apperrors.BadRequest("user ID is required")
+	}
+
+	_, ok := uc.userIDs[userID]
+	return ok, nil
+}
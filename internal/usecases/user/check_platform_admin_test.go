@@ -0,0 +1,33 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPlatformAdminUseCase_Execute(t *testing.T) {
+	useCase := NewCheckPlatformAdminUseCase([]string{" admin-1 ", ""})
+
+	tests := []struct {
+		name   string
+		userID string
+		want   bool
+	}{
+		{name: "configured user", userID: "admin-1", want: true},
+		{name: "other user", userID: "user-2", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			admin, err := useCase.Execute(context.Background(), tt.userID)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, admin)
+		})
+	}
+}
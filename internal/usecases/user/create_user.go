@@ -8,6 +8,7 @@ import (
 	"github.com/vlone310/cfguardian/internal/domain/entities"
 	"github.com/vlone310/cfguardian/internal/domain/services"
 	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -46,22 +47,22 @@ func (uc *CreateUserUseCase) Execute(ctx context.Context, req CreateUserRequest)
 	// Validate email
 	email, err := valueobjects.NewEmail(req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("invalid email: %w", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrCodeInvalidEmail, fmt.Sprintf("invalid email: %v", err))
 	}
 	
 	// Check if user already exists
 	exists, err := uc.userRepo.ExistsByEmail(ctx, email.Value())
 	if err != nil {
-		return nil, fmt.Errorf("failed to check if user exists: %w", err)
+		return nil, apperrors.Internal(err, "failed to check if user exists")
 	}
 	if exists {
-		return nil, fmt.Errorf("user with email %s already exists", email.Value())
+		return nil, apperrors.New(apperrors.ErrCodeUserExists, fmt.Sprintf("user with email %s already exists", email.Value()))
 	}
 	
 	// Hash password
 	passwordHash, err := uc.passwordHasher.Hash(req.Password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return nil, apperrors.Internal(err, "failed to hash password")
 	}
 	
 	// Create domain entity
@@ -75,7 +76,7 @@ func (uc *CreateUserUseCase) Execute(ctx context.Context, req CreateUserRequest)
 		PasswordHash: userEntity.PasswordHash(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+		return nil, apperrors.Internal(err, "failed to create user")
 	}
 	
 	return &CreateUserResponse{
@@ -2,8 +2,8 @@ package user
 
 import (
 	"context"
-	"fmt"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -32,16 +32,16 @@ func NewDeleteUserUseCase(
 // Execute deletes a user
 func (uc *DeleteUserUseCase) Execute(ctx context.Context, req DeleteUserRequest) error {
 	if req.UserID == "" {
-		return fmt.Errorf("user ID is required")
+		return apperrors.BadRequest("user ID is required")
 	}
 	
 	// Check if user exists
 	exists, err := uc.userRepo.Exists(ctx, req.UserID)
 	if err != nil {
-		return fmt.Errorf("failed to check if user exists: %w", err)
+		return apperrors.Internal(err, "failed to check if user exists")
 	}
 	if !exists {
-		return fmt.Errorf("user not found")
+		return apperrors.New(apperrors.ErrCodeUserNotFound, "user not found")
 	}
 	
 	// Note: Foreign key CASCADE will automatically delete:
@@ -51,7 +51,7 @@ func (uc *DeleteUserUseCase) Execute(ctx context.Context, req DeleteUserRequest)
 	
 	// Delete user (cascading deletes handled by database)
 	if err := uc.userRepo.Delete(ctx, req.UserID); err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+		return apperrors.Internal(err, "failed to delete user")
 	}
 	
 	return nil
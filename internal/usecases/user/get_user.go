@@ -2,8 +2,8 @@ package user
 
 import (
 	"context"
-	"fmt"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -35,13 +35,13 @@ func NewGetUserUseCase(userRepo outbound.UserRepository) *GetUserUseCase {
 // Execute retrieves a user by ID
 func (uc *GetUserUseCase) Execute(ctx context.Context, req GetUserRequest) (*GetUserResponse, error) {
 	if req.UserID == "" {
-		return nil, fmt.Errorf("user ID is required")
+		return nil, apperrors.BadRequest("user ID is required")
 	}
 	
 	// Get user from repository
 	user, err := uc.userRepo.GetByID(ctx, req.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, apperrors.Internal(err, "failed to get user")
 	}
 	
 	return &GetUserResponse{
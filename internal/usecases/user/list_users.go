@@ -2,8 +2,8 @@ package user
 
 import (
 	"context"
-	"fmt"
 
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
 	"github.com/vlone310/cfguardian/internal/ports/outbound"
 )
 
@@ -38,13 +38,13 @@ func (uc *ListUsersUseCase) Execute(ctx context.Context) (*ListUsersResponse, er
 	// Get all users
 	users, err := uc.userRepo.List(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list users: %w", err)
+		return nil, apperrors.Internal(err, "failed to list users")
 	}
 	
 	// Get total count
 	count, err := uc.userRepo.Count(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count users: %w", err)
+		return nil, apperrors.Internal(err, "failed to count users")
 	}
 	
 	// Convert to response format
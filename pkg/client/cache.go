@@ -0,0 +1,176 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// cacheEntry is a cached config
+type cacheEntry struct {
+	Version   int64           `json:"version"`
+	Content   json.RawMessage `json:"content"`
+	ETag      string          `json:"etag,omitempty"`
+	FetchedAt time.Time       `json:"fetched_at"`
+}
+
+func (e cacheEntry) config(key string, stale bool) *Config {
+	return &Config{
+		Key:     key,
+		Version: e.Version,
+		Content: e.Content,
+		Stale:   stale,
+	}
+}
+
+// cacheFile is the on-disk last-known-good format
+type cacheFile struct {
+	Configs map[string]cacheEntry `json:"configs"`
+	SavedAt time.Time             `json:"saved_at"`
+}
+
+// cache holds configs in memory and optionally mirrors them to a file
+type cache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+	path    string
+	fileMu  sync.Mutex
+}
+
+func newCache() *cache {
+	return &cache{
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *cache) get(key string) (cacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.entries[key]
+	return e, ok
+}
+
+func (c *cache) set(key string, version int64, content json.RawMessage, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{
+		Version:   version,
+		Content:   content,
+		ETag:      etag,
+		FetchedAt: time.Now(),
+	}
+}
+
+// touch marks an entry as freshly validated
+func (c *cache) touch(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.FetchedAt = time.Now()
+		c.entries[key] = e
+	}
+}
+
+// delete removes an entry and reports whether it existed
+func (c *cache) delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.entries[key]
+	delete(c.entries, key)
+	return ok
+}
+
+// expire forces every entry to be revalidated on its next read
+func (c *cache) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, e := range c.entries {
+		e.FetchedAt = time.Time{}
+		c.entries[key] = e
+	}
+}
+
+// load reads the last-known-good file, if configured and present.
+// Loaded entries are marked for revalidation.
+func (c *cache) load() error {
+	if c.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, e := range file.Configs {
+		e.FetchedAt = time.Time{}
+		c.entries[key] = e
+	}
+
+	return nil
+}
+
+// save atomically writes the cache to the last-known-good file
+func (c *cache) save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.mu.RLock()
+	file := cacheFile{
+		Configs: make(map[string]cacheEntry, len(c.entries)),
+		SavedAt: time.Now().UTC(),
+	}
+	for key, e := range c.entries {
+		file.Configs[key] = e
+	}
+	c.mu.RUnlock()
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	c.fileMu.Lock()
+	defer c.fileMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -0,0 +1,312 @@
+// Package client is the Go SDK for the GoConfig Guardian read API.
+//
+// It reads configs by key, one at a time or in batches, and decodes them into
+// structs, keeps an in-memory cache revalidated with ETags, follows changes
+// over the Server-Sent Events stream, and can persist the last-known-good
+// configs to a local file so applications can start while the cluster is
+// unreachable.
+//
+//	c, err := client.New("https://cfguardian.internal", apiKey,
+//		client.WithCacheFile("/var/lib/myapp/cfguardian.json"),
+//	)
+//	var cfg AppConfig
+//	if err := c.GetInto(ctx, "app-config", &cfg); err != nil { ... }
+//
+//	go c.Watch(ctx, func(e client.Event) { ... })
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// apiKeyHeader carries the project API key so it never appears in URLs
+const apiKeyHeader = "X-API-Key"
+
+var (
+	// ErrNotFound is returned when the requested config does not exist
+	ErrNotFound = errors.New("cfguardian: config not found")
+
+	// ErrUnauthorized is returned when the API key is rejected
+	ErrUnauthorized = errors.New("cfguardian: invalid API key")
+)
+
+// Config is a config as returned by the read API
+type Config struct {
+	Key     string          `json:"key"`
+	Version int64           `json:"version"`
+	Content json.RawMessage `json:"content"`
+
+	// Stale is set when the server could not be reached and the config was
+	// served from the in-memory cache or the last-known-good file
+	Stale bool `json:"-"`
+}
+
+// Client reads configs from a GoConfig Guardian server
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	cache      *cache
+	maxAge     time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	onError    func(error)
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+// Watch streams are long-lived, so the client should not set a Timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithCacheFile persists last-known-good configs to path and loads them on startup
+func WithCacheFile(path string) Option {
+	return func(c *Client) {
+		c.cache.path = path
+	}
+}
+
+// WithMaxAge serves cached configs younger than d without contacting the server.
+// Combined with Watch, which keeps the cache current, reads become local.
+// The default of 0 revalidates every read.
+func WithMaxAge(d time.Duration) Option {
+	return func(c *Client) {
+		c.maxAge = d
+	}
+}
+
+// WithReconnectBackoff sets the minimum and maximum delay between Watch reconnects
+func WithReconnectBackoff(min, max time.Duration) Option {
+	return func(c *Client) {
+		c.minBackoff = min
+		c.maxBackoff = max
+	}
+}
+
+// WithErrorHandler receives errors that do not fail a call, such as
+// cache file write failures and Watch disconnects
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *Client) {
+		c.onError = fn
+	}
+}
+
+// New creates a Client for the server at baseURL using a project API key
+func New(baseURL, apiKey string, opts ...Option) (*Client, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("cfguardian: base URL is required")
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("cfguardian: API key is required")
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{},
+		cache:      newCache(),
+		minBackoff: 500 * time.Millisecond,
+		maxBackoff: 30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := c.cache.load(); err != nil {
+		return nil, fmt.Errorf("cfguardian: failed to load cache file: %w", err)
+	}
+
+	return c, nil
+}
+
+// Get reads a config. A cached copy is revalidated with If-None-Match; if the
+// server is unreachable or failing, the cached or last-known-good copy is
+// returned with Stale set.
+func (c *Client) Get(ctx context.Context, key string) (*Config, error) {
+	if key == "" {
+		return nil, fmt.Errorf("cfguardian: config key is required")
+	}
+
+	cached, hasCached := c.cache.get(key)
+	if hasCached && c.maxAge > 0 && time.Since(cached.FetchedAt) < c.maxAge {
+		return cached.config(key, false), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.readURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("cfguardian: failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(apiKeyHeader, c.apiKey)
+	if hasCached && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if hasCached {
+			return cached.config(key, true), nil
+		}
+		return nil, fmt.Errorf("cfguardian: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && hasCached:
+		c.cache.touch(key)
+		return cached.config(key, false), nil
+
+	case resp.StatusCode == http.StatusOK:
+		var cfg Config
+		if err := json.NewDecoder(resp.Body).Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("cfguardian: failed to decode response: %w", err)
+		}
+		c.store(key, cfg.Version, cfg.Content, resp.Header.Get("ETag"))
+		return &cfg, nil
+
+	case resp.StatusCode == http.StatusNotFound:
+		c.remove(key)
+		return nil, ErrNotFound
+
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+
+	default:
+		if hasCached {
+			return cached.config(key, true), nil
+		}
+		return nil, fmt.Errorf("cfguardian: unexpected status %d", resp.StatusCode)
+	}
+}
+
+// GetInto reads a config and unmarshals its content into v
+func (c *Client) GetInto(ctx context.Context, key string, v interface{}) error {
+	cfg, err := c.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(cfg.Content, v); err != nil {
+		return fmt.Errorf("cfguardian: failed to unmarshal config %q: %w", key, err)
+	}
+
+	return nil
+}
+
+// GetMany reads several configs in one request, for example at startup.
+// Keys that do not exist or are outside the API key's scope are left out of
+// the result. If the server is unreachable or failing, the cached copies of
+// the keys are returned with Stale set.
+func (c *Client) GetMany(ctx context.Context, keys ...string) (map[string]*Config, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("cfguardian: at least one config key is required")
+	}
+
+	body, err := json.Marshal(map[string][]string{"keys": keys})
+	if err != nil {
+		return nil, fmt.Errorf("cfguardian: failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/read/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("cfguardian: failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(apiKeyHeader, c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return c.cachedMany(keys, fmt.Errorf("cfguardian: request failed: %w", err))
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		var batch struct {
+			Configs map[string]struct {
+				Config
+				ETag string `json:"etag"`
+			} `json:"configs"`
+			Missing []string `json:"missing"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+			return nil, fmt.Errorf("cfguardian: failed to decode response: %w", err)
+		}
+
+		result := make(map[string]*Config, len(batch.Configs))
+		for key, cfg := range batch.Configs {
+			c.cache.set(key, cfg.Version, cfg.Content, cfg.ETag)
+			config := cfg.Config
+			result[key] = &config
+		}
+		for _, key := range batch.Missing {
+			c.cache.delete(key)
+		}
+		c.persist()
+		return result, nil
+
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+
+	default:
+		return c.cachedMany(keys, fmt.Errorf("cfguardian: unexpected status %d", resp.StatusCode))
+	}
+}
+
+// cachedMany returns the cached copies of keys marked Stale, or err if none is cached
+func (c *Client) cachedMany(keys []string, err error) (map[string]*Config, error) {
+	result := make(map[string]*Config)
+	for _, key := range keys {
+		if cached, ok := c.cache.get(key); ok {
+			result[key] = cached.config(key, true)
+		}
+	}
+	if len(result) == 0 {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readURL builds the read API URL for a config key
+func (c *Client) readURL(key string) string {
+	return fmt.Sprintf("%s/api/v1/read/%s", c.baseURL, url.PathEscape(key))
+}
+
+// store caches a config and persists the cache file
+func (c *Client) store(key string, version int64, content json.RawMessage, etag string) {
+	c.cache.set(key, version, content, etag)
+	c.persist()
+}
+
+// remove drops a config from the cache and persists the cache file
+func (c *Client) remove(key string) {
+	if c.cache.delete(key) {
+		c.persist()
+	}
+}
+
+func (c *Client) persist() {
+	if err := c.cache.save(); err != nil {
+		c.reportError(fmt.Errorf("cfguardian: failed to write cache file: %w", err))
+	}
+}
+
+func (c *Client) reportError(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
@@ -0,0 +1,420 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	httpAdapter "github.com/vlone310/cfguardian/internal/adapters/inbound/http"
+	"github.com/vlone310/cfguardian/internal/adapters/inbound/http/handlers"
+	"github.com/vlone310/cfguardian/internal/adapters/outbound/eventbus"
+	"github.com/vlone310/cfguardian/internal/domain/events"
+	"github.com/vlone310/cfguardian/internal/domain/services"
+	"github.com/vlone310/cfguardian/internal/domain/valueobjects"
+	apperrors "github.com/vlone310/cfguardian/internal/infrastructure/errors"
+	"github.com/vlone310/cfguardian/internal/ports/outbound"
+	"github.com/vlone310/cfguardian/internal/usecases/auth"
+	"github.com/vlone310/cfguardian/internal/usecases/config"
+	"github.com/vlone310/cfguardian/pkg/client"
+)
+
+const (
+	testAPIKey    = "cfg_abcdefghijklmnopqrstuvwxyz012345"
+	testProjectID = "project-1"
+)
+
+// testAPIKeyHasher hashes and verifies the test API key
+var testAPIKeyHasher = services.NewAPIKeyHasher("test-pepper")
+
+// fakeAPIKeyRepository stores the hash of the single test API key
+type fakeAPIKeyRepository struct {
+	outbound.APIKeyRepository
+}
+
+func (f *fakeAPIKeyRepository) ListByLookupPrefix(ctx context.Context, lookupPrefix string) ([]*outbound.APIKey, error) {
+	key := valueobjects.MustNewAPIKey(testAPIKey)
+	if lookupPrefix != key.LookupPrefix() {
+		return nil, nil
+	}
+
+	hashed, err := testAPIKeyHasher.Hash(key)
+	if err != nil {
+		return nil, err
+	}
+	return []*outbound.APIKey{{
+		ID:           "key-1",
+		ProjectID:    testProjectID,
+		LookupPrefix: hashed.LookupPrefix(),
+		KeyHash:      hashed.Hash(),
+		KeySalt:      hashed.Salt(),
+		KeyHint:      hashed.Hint(),
+		HashVersion:  hashed.Version(),
+	}}, nil
+}
+
+func (f *fakeAPIKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	return nil
+}
+
+// fakeProjectRepository knows the single test project
+type fakeProjectRepository struct {
+	outbound.ProjectRepository
+}
+
+func (f *fakeProjectRepository) Exists(ctx context.Context, id string) (bool, error) {
+	return id == testProjectID, nil
+}
+
+// fakeConfigRepository is an in-memory config store
+type fakeConfigRepository struct {
+	outbound.ConfigRepository
+	mu      sync.Mutex
+	configs map[string]*outbound.Config
+}
+
+func (f *fakeConfigRepository) Get(ctx context.Context, projectID, key string) (*outbound.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cfg, ok := f.configs[key]
+	if !ok {
+		return nil, apperrors.New(apperrors.ErrCodeConfigNotFound, "config not found")
+	}
+	return cfg, nil
+}
+
+func (f *fakeConfigRepository) ListByProject(ctx context.Context, projectID string) ([]*outbound.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	configs := make([]*outbound.Config, 0, len(f.configs))
+	for _, cfg := range f.configs {
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+func (f *fakeConfigRepository) put(key string, version int64, content string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.configs[key] = &outbound.Config{ProjectID: testProjectID, Key: key, Version: version, Content: json.RawMessage(content)}
+}
+
+type testServer struct {
+	*httptest.Server
+	configs *fakeConfigRepository
+	broker  *eventbus.Broker
+
+	mu       sync.Mutex
+	statuses []int
+	streams  int
+}
+
+func (s *testServer) recordedStatuses() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]int(nil), s.statuses...)
+}
+
+// streamsOpened counts change streams that have been subscribed and answered
+func (s *testServer) streamsOpened() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.streams
+}
+
+// newTestServer serves the real router backed by in-memory repositories
+func newTestServer(t *testing.T) *testServer {
+	projects := &fakeProjectRepository{}
+	configs := &fakeConfigRepository{configs: make(map[string]*outbound.Config)}
+	broker := eventbus.NewBroker(eventbus.BrokerConfig{})
+
+	router := httpAdapter.NewRouter(httpAdapter.RouterConfig{
+		JWTSecret:           "test-secret",
+		APIKeyAuthenticator: auth.NewValidateAPIKeyUseCase(&fakeAPIKeyRepository{}, projects, testAPIKeyHasher),
+		ReadHandler:         handlers.NewReadHandler(config.NewReadConfigByAPIKeyUseCase(projects, configs, nil, nil)),
+		StreamHandler:       handlers.NewStreamHandler(config.NewStreamConfigChangesUseCase(projects, configs, broker), time.Second),
+	})
+
+	ts := &testServer{configs: configs, broker: broker}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stream") {
+			router.ServeHTTP(&statusRecorder{ResponseWriter: w, onHeader: func(int) {
+				ts.mu.Lock()
+				ts.streams++
+				ts.mu.Unlock()
+			}}, r)
+			return
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		router.ServeHTTP(rec, r)
+		ts.mu.Lock()
+		ts.statuses = append(ts.statuses, rec.status)
+		ts.mu.Unlock()
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status   int
+	onHeader func(int)
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+	if r.onHeader != nil {
+		r.onHeader(status)
+	}
+}
+
+func (r *statusRecorder) Flush() {
+	r.ResponseWriter.(http.Flusher).Flush()
+}
+
+type appConfig struct {
+	Debug   bool   `json:"debug"`
+	Timeout string `json:"timeout"`
+}
+
+func TestClient_GetInto(t *testing.T) {
+	t.Run("decodes config content into a struct", func(t *testing.T) {
+		// Arrange
+		ts := newTestServer(t)
+		ts.configs.put("app", 1, `{"debug":true,"timeout":"5s"}`)
+		c, err := client.New(ts.URL, testAPIKey)
+		require.NoError(t, err)
+
+		// Act
+		var cfg appConfig
+		err = c.GetInto(context.Background(), "app", &cfg)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, appConfig{Debug: true, Timeout: "5s"}, cfg)
+	})
+
+	t.Run("returns ErrNotFound for unknown keys", func(t *testing.T) {
+		ts := newTestServer(t)
+		c, err := client.New(ts.URL, testAPIKey)
+		require.NoError(t, err)
+
+		var cfg appConfig
+		err = c.GetInto(context.Background(), "missing", &cfg)
+
+		assert.ErrorIs(t, err, client.ErrNotFound)
+	})
+
+	t.Run("returns ErrUnauthorized for rejected keys", func(t *testing.T) {
+		ts := newTestServer(t)
+		c, err := client.New(ts.URL, "cfg_00000000000000000000000000000000")
+		require.NoError(t, err)
+
+		var cfg appConfig
+		err = c.GetInto(context.Background(), "app", &cfg)
+
+		assert.ErrorIs(t, err, client.ErrUnauthorized)
+	})
+}
+
+func TestClient_Get(t *testing.T) {
+	t.Run("revalidates cached configs with ETags", func(t *testing.T) {
+		// Arrange
+		ts := newTestServer(t)
+		ts.configs.put("app", 1, `{"debug":false}`)
+		c, err := client.New(ts.URL, testAPIKey)
+		require.NoError(t, err)
+		ctx := context.Background()
+
+		// Act
+		first, err := c.Get(ctx, "app")
+		require.NoError(t, err)
+		second, err := c.Get(ctx, "app")
+		require.NoError(t, err)
+		ts.configs.put("app", 2, `{"debug":true}`)
+		third, err := c.Get(ctx, "app")
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, []int{http.StatusOK, http.StatusNotModified, http.StatusOK}, ts.recordedStatuses())
+		assert.Equal(t, first.Content, second.Content)
+		assert.Equal(t, int64(2), third.Version)
+		assert.JSONEq(t, `{"debug":true}`, string(third.Content))
+	})
+
+	t.Run("serves fresh cache entries without a request", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.configs.put("app", 1, `{}`)
+		c, err := client.New(ts.URL, testAPIKey, client.WithMaxAge(time.Minute))
+		require.NoError(t, err)
+
+		_, err = c.Get(context.Background(), "app")
+		require.NoError(t, err)
+		_, err = c.Get(context.Background(), "app")
+		require.NoError(t, err)
+
+		assert.Len(t, ts.recordedStatuses(), 1)
+	})
+
+	t.Run("falls back to the last-known-good file when the server is down", func(t *testing.T) {
+		// Arrange
+		ts := newTestServer(t)
+		ts.configs.put("app", 3, `{"debug":true,"timeout":"1s"}`)
+		cacheFile := filepath.Join(t.TempDir(), "cfguardian", "configs.json")
+		online, err := client.New(ts.URL, testAPIKey, client.WithCacheFile(cacheFile))
+		require.NoError(t, err)
+		_, err = online.Get(context.Background(), "app")
+		require.NoError(t, err)
+		ts.Close()
+
+		// Act
+		offline, err := client.New(ts.URL, testAPIKey, client.WithCacheFile(cacheFile))
+		require.NoError(t, err)
+		cfg, err := offline.Get(context.Background(), "app")
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, cfg.Stale)
+		assert.Equal(t, int64(3), cfg.Version)
+		assert.JSONEq(t, `{"debug":true,"timeout":"1s"}`, string(cfg.Content))
+	})
+
+	t.Run("fails without a cached copy when the server is down", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.Close()
+		c, err := client.New(ts.URL, testAPIKey)
+		require.NoError(t, err)
+
+		_, err = c.Get(context.Background(), "app")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_GetMany(t *testing.T) {
+	t.Run("reads several configs in one request", func(t *testing.T) {
+		// Arrange
+		ts := newTestServer(t)
+		ts.configs.put("app", 1, `{"debug":true}`)
+		ts.configs.put("db", 3, `{"pool":10}`)
+		c, err := client.New(ts.URL, testAPIKey)
+		require.NoError(t, err)
+		ctx := context.Background()
+
+		// Act
+		configs, err := c.GetMany(ctx, "app", "db", "missing")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, configs, 2)
+		assert.Equal(t, int64(3), configs["db"].Version)
+		assert.JSONEq(t, `{"debug":true}`, string(configs["app"].Content))
+
+		// Configs read in a batch are revalidated with their ETag
+		_, err = c.Get(ctx, "app")
+		require.NoError(t, err)
+		assert.Equal(t, []int{http.StatusOK, http.StatusNotModified}, ts.recordedStatuses())
+	})
+
+	t.Run("serves cached configs when the server is down", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.configs.put("app", 1, `{}`)
+		c, err := client.New(ts.URL, testAPIKey)
+		require.NoError(t, err)
+		_, err = c.GetMany(context.Background(), "app")
+		require.NoError(t, err)
+
+		ts.Close()
+		configs, err := c.GetMany(context.Background(), "app", "db")
+
+		require.NoError(t, err)
+		require.Len(t, configs, 1)
+		assert.True(t, configs["app"].Stale)
+	})
+}
+
+func TestClient_Watch(t *testing.T) {
+	t.Run("delivers changes and resumes after reconnecting", func(t *testing.T) {
+		// Arrange
+		ts := newTestServer(t)
+		c, err := client.New(ts.URL, testAPIKey,
+			client.WithMaxAge(time.Minute),
+			client.WithReconnectBackoff(10*time.Millisecond, 50*time.Millisecond),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		received := make(chan client.Event, 10)
+		done := make(chan error, 1)
+		go func() {
+			done <- c.Watch(ctx, func(e client.Event) { received <- e })
+		}()
+		t.Cleanup(func() {
+			cancel()
+			<-done
+		})
+
+		publish := func(version int64, content string) {
+			var e events.ProjectEvent
+			if version == 1 {
+				e = events.NewConfigCreated("evt", testProjectID, "app", "schema-1", valueobjects.MustNewVersion(1), json.RawMessage(content), "user-1")
+			} else {
+				e = events.NewConfigUpdated("evt", testProjectID, "app", "schema-1", valueobjects.MustNewVersion(version-1), valueobjects.MustNewVersion(version), json.RawMessage(content), "user-1")
+			}
+			require.NoError(t, ts.broker.Publish(context.Background(), uint64(version), e))
+		}
+		waitForStreams(t, ts, 1)
+
+		// Act
+		publish(1, `{"debug":false}`)
+		first := nextEvent(t, received)
+
+		ts.CloseClientConnections()
+		publish(2, `{"debug":true}`)
+		waitForStreams(t, ts, 2)
+		second := nextEvent(t, received)
+
+		// Assert
+		assert.Equal(t, client.EventCreated, first.Type)
+		assert.Equal(t, uint64(1), first.ID)
+		assert.Equal(t, client.EventUpdated, second.Type)
+		assert.Equal(t, uint64(2), second.ID)
+		assert.Equal(t, int64(2), second.Version)
+
+		var cfg appConfig
+		require.NoError(t, c.GetInto(context.Background(), "app", &cfg))
+		assert.True(t, cfg.Debug, "watch should keep the cache current")
+	})
+}
+
+// waitForStreams waits until n change streams have been opened
+func waitForStreams(t *testing.T, ts *testServer, n int) {
+	require.Eventually(t, func() bool {
+		return ts.streamsOpened() >= n
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func nextEvent(t *testing.T, ch <-chan client.Event) client.Event {
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return client.Event{}
+	}
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event types delivered by Watch
+const (
+	EventCreated    = "config.created"
+	EventUpdated    = "config.updated"
+	EventDeleted    = "config.deleted"
+	EventRolledBack = "config.rolledback"
+
+	// EventResync means the server no longer has the events needed to resume;
+	// cached configs may be stale and should be re-read
+	EventResync = "resync"
+)
+
+// Event is a config change received from the stream
+type Event struct {
+	ID      uint64 // Stream position; 0 on all but the last event of a write that changed several configs
+	Type    string
+	Key     string
+	Version int64           // Version after the change (last version for deletes)
+	Content json.RawMessage // Content after the change (empty for deletes)
+}
+
+// eventPayload holds the fields shared by the server's config events
+type eventPayload struct {
+	ConfigKey   string          `json:"config_key"`
+	Version     int64           `json:"version"`
+	NewVersion  int64           `json:"new_version"`
+	LastVersion int64           `json:"last_version"`
+	Content     json.RawMessage `json:"content"`
+}
+
+// Watch follows the project's change stream, keeping the cache current and
+// calling fn for every event. It reconnects with exponential backoff and
+// resumes from the last received event. Watch blocks until ctx is cancelled
+// (returning ctx.Err()) or the API key is rejected (returning ErrUnauthorized).
+func (c *Client) Watch(ctx context.Context, fn func(Event)) error {
+	var lastEventID uint64
+	backoff := c.minBackoff
+
+	for {
+		connected, err := c.watchOnce(ctx, &lastEventID, fn)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, ErrUnauthorized) {
+			return err
+		}
+		if err != nil {
+			c.reportError(fmt.Errorf("cfguardian: watch disconnected: %w", err))
+		}
+
+		if connected {
+			backoff = c.minBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+// watchOnce runs a single stream connection until it ends.
+// It reports whether the connection was established.
+func (c *Client) watchOnce(ctx context.Context, lastEventID *uint64, fn func(Event)) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/read/stream", nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set(apiKeyHeader, c.apiKey)
+	if *lastEventID > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(*lastEventID, 10))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return false, ErrUnauthorized
+	default:
+		return false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	err = readEvents(resp.Body, func(id, eventType, data string) {
+		if id != "" {
+			if parsed, err := strconv.ParseUint(id, 10, 64); err == nil {
+				*lastEventID = parsed
+			}
+		}
+		c.handleEvent(id, eventType, data, fn)
+	})
+
+	return true, err
+}
+
+// handleEvent applies a stream event to the cache and forwards it to fn
+func (c *Client) handleEvent(id, eventType, data string, fn func(Event)) {
+	switch eventType {
+	case EventResync:
+		c.cache.expire()
+		fn(Event{Type: EventResync})
+		return
+	case EventCreated, EventUpdated, EventRolledBack, EventDeleted:
+	default:
+		// overflow and unknown events; the server closes the stream after overflow
+		return
+	}
+
+	var payload eventPayload
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		c.reportError(fmt.Errorf("cfguardian: invalid %s event: %w", eventType, err))
+		return
+	}
+
+	event := Event{
+		Type:    eventType,
+		Key:     payload.ConfigKey,
+		Content: payload.Content,
+	}
+	event.ID, _ = strconv.ParseUint(id, 10, 64)
+
+	switch eventType {
+	case EventCreated:
+		event.Version = payload.Version
+	case EventUpdated, EventRolledBack:
+		event.Version = payload.NewVersion
+	case EventDeleted:
+		event.Version = payload.LastVersion
+	}
+
+	if eventType == EventDeleted {
+		c.remove(event.Key)
+	} else {
+		c.store(event.Key, event.Version, event.Content, "")
+	}
+
+	fn(event)
+}
+
+// readEvents parses a text/event-stream body and calls dispatch per event
+func readEvents(body io.Reader, dispatch func(id, eventType, data string)) error {
+	reader := bufio.NewReader(body)
+
+	var id, eventType string
+	var data []string
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if len(data) > 0 || eventType != "" {
+				dispatch(id, eventType, strings.Join(data, "\n"))
+			}
+			id, eventType, data = "", "", nil
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue // comment / heartbeat
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "id":
+			id = value
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}